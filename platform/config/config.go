@@ -16,8 +16,9 @@ type Config struct {
 	HTTPServer HTTPServerConfig            `yaml:"http-server,omitempty" mapstructure:"http-server"`
 
 	Logger  Logger  `yaml:"logger"`
-	Jaeger  Jaeger  `yaml:"jaeger"`
+	Tracing Tracing `yaml:"tracing"`
 	Metrics Metrics `yaml:"metrics"`
+	Health  Health  `yaml:"health"`
 	Docs    Docs
 
 	KafkaConsumer KafkaConsumer `yaml:"kafkaConsumer"`
@@ -29,10 +30,18 @@ type Config struct {
 	MongoDB  MongoDB        `yaml:"mongodb,omitempty"`
 	AWS      AWS            `yaml:"aws,omitempty"`
 
+	FileStorage FileStorage `yaml:"fileStorage,omitempty"`
+	FileScan    FileScan    `yaml:"fileScan,omitempty"`
+	FileApi     FileApi     `yaml:"fileApi,omitempty"`
+
 	Cookie  Cookie  `yaml:"cookie,omitempty"`
 	Session Session `yaml:"session,omitempty"`
 
-	NotificationSmtp Smtp `yaml:"NotificationSmtp,omitempty"`
+	NotificationSmtp              Smtp                  `yaml:"NotificationSmtp,omitempty"`
+	NotificationWebhook           Webhook               `yaml:"NotificationWebhook,omitempty"`
+	NotificationSms               Sms                   `yaml:"NotificationSms,omitempty"`
+	NotificationEmailVerification EmailVerification     `yaml:"NotificationEmailVerification,omitempty"`
+	NotificationScheduler         NotificationScheduler `yaml:"NotificationScheduler,omitempty"`
 }
 
 // Swagger configuration
@@ -62,6 +71,7 @@ type AWS struct {
 	AccessKey     string
 	SecretKey     string
 	UseSSL        bool
+	Bucket        string
 	MinioEndpoint string `yaml:"minio-endpoint,omitempty"`
 }
 
@@ -0,0 +1,43 @@
+package config
+
+import "time"
+
+// File content storage, Backend is "s3" (default, uses AWS section) or "local"
+type FileStorage struct {
+	Backend   string
+	LocalPath string
+	// Download links of storages without presigning are signed by LinkSecret and point to LinkBaseUrl,
+	// LinkTTL and LinkMaxTTL are in seconds
+	LinkSecret  string
+	LinkBaseUrl string
+	LinkTTL     time.Duration
+	LinkMaxTTL  time.Duration
+}
+
+// Uploaded files checks. Policies are keyed by lower case business type, "default" one is used for the others,
+// no policies mean no restrictions.
+type FileScan struct {
+	Policies map[string]FileScanPolicy
+	// clamd socket, e.g. "tcp" and "localhost:3310" or "unix" and "/var/run/clamav/clamd.ctl",
+	// empty address disables malware scanning
+	ClamdNetwork string
+	ClamdAddress string
+	// Seconds
+	ClamdTimeout time.Duration
+	// Period of quarantined files rescan in seconds
+	RescanInterval int
+}
+
+type FileScanPolicy struct {
+	// Allowed content types detected by magic bytes, e.g. "application/pdf"
+	ContentTypes []string
+	// Bytes, zero means no limit
+	MaxSize int64
+}
+
+// file-api client of other services, Address is base url of file-api HTTP gateway, e.g. "http://localhost:8080"
+type FileApi struct {
+	Address string
+	// Seconds
+	Timeout time.Duration
+}
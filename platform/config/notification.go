@@ -0,0 +1,40 @@
+package config
+
+// Outgoing webhook notification channel
+type Webhook struct {
+	// Request timeout in seconds
+	Timeout int
+}
+
+// HTTP SMS gateway notification channel
+type Sms struct {
+	Url    string
+	ApiKey string
+	Sender string
+	// Request timeout in seconds
+	Timeout int
+}
+
+// Double opt-in of notification email
+type EmailVerification struct {
+	// HMAC-SHA256 key for verification token
+	Secret string
+	// Token live time in minutes
+	TokenTTL int
+	// Minimal time between verification emails in seconds
+	ResendInterval int
+	// Verification emails per user during 24 hours
+	MaxPerDay int
+	// Page that confirms email, token is added as query parameter
+	Url string
+}
+
+// Deferred delivery of digest and quiet hours notifications
+type NotificationScheduler struct {
+	// Period of pending notifications check in seconds
+	Interval int
+	// User's local hour when digest is sent
+	DigestHour int
+	// Day of weekly digest, 0 is Sunday
+	DigestWeekday int
+}
@@ -1,5 +1,12 @@
 package config
 
+// Smtp TLS modes
+const (
+	SmtpTLSImplicit = "implicit"
+	SmtpTLSStartTLS = "starttls"
+	SmtpTLSNone     = "none"
+)
+
 type Smtp struct {
 	Host     string
 	Port     string
@@ -7,4 +14,14 @@ type Smtp struct {
 	User     string
 	NickName string
 	Password string
+	// One of SmtpTLSImplicit, SmtpTLSStartTLS, SmtpTLSNone. Implicit TLS is used when empty
+	TLSMode string
+	// Skip server certificate verification, for self-signed development certificates only
+	InsecureSkipVerify bool
+	// Max open connections
+	PoolSize int
+	// Idle connection is closed after this time in seconds
+	IdleTimeout int
+	// Dial and command timeout in seconds
+	Timeout int
 }
@@ -4,13 +4,6 @@ import (
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/config"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"time"
-)
-
-const (
-	reconnectDelay = 5 * time.Second
-	reInitDelay    = 2 * time.Second
-	resendDelay    = 5 * time.Second
 )
 
 func NewAMQP(c *config.Config) (*amqp.Connection, error) {
@@ -35,17 +28,10 @@ func CreateChannel(conn *amqp.Connection) (*amqp.Channel, error) {
 	}
 	return ch, nil
 }
-func DeclareQueue(ch *amqp.Channel, cfg *config.Config) (*amqp.Queue, error) {
-	queue, err := ch.QueueDeclare(
-		cfg.RabbitMQ.Queue,
-		false,
-		false,
-		false,
-		false,
-		nil,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return &queue, nil
+
+// DeclareQueue declares durable queue with retry and dead-letter topology, see DeclareTopology.
+// Publisher and consumer must both use it, RabbitMQ rejects redeclaration with different arguments.
+// Messages are published to returned queue, its name is TopologyQueue of cfg.Queue.
+func DeclareQueue(ch *amqp.Channel, cfg config.RabbitMQConfig) (*amqp.Queue, error) {
+	return DeclareTopology(ch, cfg)
 }
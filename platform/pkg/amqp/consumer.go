@@ -0,0 +1,199 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	ErrDeliveriesClosed = errors.New("amqp deliveries channel is closed")
+	ErrPublishNacked    = errors.New("amqp broker nacked published message")
+	ErrPublishReturned  = errors.New("amqp broker returned unroutable message")
+)
+
+// Handler processes delivery. Returned error makes message retried, wrap it with Permanent to dead-letter at once.
+type Handler func(ctx context.Context, delivery amqp.Delivery) error
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks error which will not go away on retry
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent checks whether err was marked by Permanent
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// Consumer reads queue with manual acknowledgement.
+// Failed message is acked only after broker confirmed its copy published to retry exchange and routed it
+// to retry queue, otherwise it is requeued. So message is redelivered rather than lost in between.
+// When retries are exhausted or error is permanent message is nacked and dead-lettered.
+type Consumer struct {
+	ch      *amqp.Channel
+	cfg     config.RabbitMQConfig
+	logger  logger.Logger
+	returns chan amqp.Return
+
+	// Publishes message and waits until broker has taken responsibility for it
+	publish func(ctx context.Context, exchange string, key string, msg amqp.Publishing) error
+}
+
+// NewConsumer sets channel prefetch and puts channel into confirm mode, so channel must not be shared
+// with other publishers. Topology must be declared with DeclareQueue beforehand.
+func NewConsumer(ch *amqp.Channel, cfg config.RabbitMQConfig, logger logger.Logger) (*Consumer, error) {
+	prefetch := cfg.PrefetchCount
+	if prefetch <= 0 {
+		prefetch = defaultPrefetchCount
+	}
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		return nil, err
+	}
+
+	c := &Consumer{
+		ch:     ch,
+		cfg:    cfg,
+		logger: logger,
+		// Retries are published one at a time, so single return can be pending
+		returns: ch.NotifyReturn(make(chan amqp.Return, 1)),
+	}
+	c.publish = c.publishConfirmed
+	return c, nil
+}
+
+// Run consumes queue until ctx is done or channel is closed
+func (c *Consumer) Run(ctx context.Context, handler Handler) error {
+	deliveries, err := c.ch.ConsumeWithContext(ctx, TopologyQueue(c.cfg.Queue), "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return ErrDeliveriesClosed
+			}
+			c.handle(ctx, handler, delivery)
+		}
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, handler Handler, delivery amqp.Delivery) {
+	err := handler(ctx, delivery)
+	if err == nil {
+		if err = delivery.Ack(false); err != nil {
+			c.logger.Errorf("Ack message %s: %v", delivery.MessageId, err)
+		}
+		return
+	}
+
+	attempt := RetryCount(delivery) + 1
+	if IsPermanent(err) || attempt > maxRetries(c.cfg) {
+		c.logger.Errorf("Message %s dead-lettered after %d retries: %v", delivery.MessageId, attempt-1, err)
+		if err = delivery.Nack(false, false); err != nil {
+			c.logger.Errorf("Nack message %s: %v", delivery.MessageId, err)
+		}
+		return
+	}
+
+	c.logger.Warnf("Message %s retry %d in %s: %v", delivery.MessageId, attempt, RetryDelay(c.cfg, attempt), err)
+	if err = c.retry(ctx, delivery, attempt); err != nil {
+		// Broker redelivers message on requeue, retry delay is lost but message is kept
+		c.logger.Errorf("Publish retry of message %s: %v", delivery.MessageId, err)
+		if err = delivery.Nack(false, true); err != nil {
+			c.logger.Errorf("Nack message %s: %v", delivery.MessageId, err)
+		}
+		return
+	}
+	if err = delivery.Ack(false); err != nil {
+		c.logger.Errorf("Ack message %s: %v", delivery.MessageId, err)
+	}
+}
+
+func (c *Consumer) retry(ctx context.Context, delivery amqp.Delivery, attempt int) error {
+	headers := make(amqp.Table, len(delivery.Headers)+1)
+	for key, value := range delivery.Headers {
+		headers[key] = value
+	}
+	headers[HeaderRetryCount] = int32(attempt)
+
+	return c.publish(ctx,
+		RetryExchange(c.cfg.Queue),
+		RetryQueue(c.cfg.Queue, RetryDelay(c.cfg, attempt)),
+		amqp.Publishing{
+			Headers:         headers,
+			ContentType:     delivery.ContentType,
+			ContentEncoding: delivery.ContentEncoding,
+			DeliveryMode:    amqp.Persistent,
+			CorrelationId:   delivery.CorrelationId,
+			MessageId:       delivery.MessageId,
+			Timestamp:       delivery.Timestamp,
+			Type:            delivery.Type,
+			AppId:           delivery.AppId,
+			Body:            delivery.Body,
+		})
+}
+
+// publishConfirmed publishes mandatory message and waits for broker confirmation.
+// Broker sends basic.return of unroutable message before its ack, so return is already received when ack is.
+func (c *Consumer) publishConfirmed(ctx context.Context, exchange string, key string, msg amqp.Publishing) error {
+	confirmation, err := c.ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, true, false, msg)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case returned := <-c.returns:
+		return fmt.Errorf("%w: %s", ErrPublishReturned, returned.ReplyText)
+	default:
+	}
+
+	if !acked {
+		return ErrPublishNacked
+	}
+	return nil
+}
+
+// RetryCount of delivery read from HeaderRetryCount
+func RetryCount(delivery amqp.Delivery) int {
+	switch value := delivery.Headers[HeaderRetryCount].(type) {
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	}
+	return 0
+}
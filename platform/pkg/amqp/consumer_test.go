@@ -0,0 +1,190 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeAcknowledger records how delivery was settled
+type fakeAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked = true
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+type published struct {
+	exchange string
+	key      string
+	msg      amqp.Publishing
+}
+
+func newTestConsumer(cfg config.RabbitMQConfig, publishErr error) (*Consumer, *[]published) {
+	log := logger.NewServerLogger(&config.Config{Logger: config.Logger{Development: true, Level: "Debug"}})
+	log.InitLogger()
+
+	sent := make([]published, 0)
+	c := &Consumer{cfg: cfg, logger: log}
+	c.publish = func(ctx context.Context, exchange string, key string, msg amqp.Publishing) error {
+		sent = append(sent, published{exchange: exchange, key: key, msg: msg})
+		return publishErr
+	}
+	return c, &sent
+}
+
+func newDelivery(retries interface{}) (amqp.Delivery, *fakeAcknowledger) {
+	ack := &fakeAcknowledger{}
+	delivery := amqp.Delivery{
+		Acknowledger: ack,
+		MessageId:    "message",
+		Headers:      amqp.Table{"trace": "value"},
+		Body:         []byte("body"),
+	}
+	if retries != nil {
+		delivery.Headers[HeaderRetryCount] = retries
+	}
+	return delivery, ack
+}
+
+func TestRetryCount(t *testing.T) {
+	cases := map[string]struct {
+		header interface{}
+		want   int
+	}{
+		"no header": {nil, 0},
+		"int32":     {int32(2), 2},
+		"int64":     {int64(3), 3},
+		"int":       {4, 4},
+		"string":    {"5", 0},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			delivery, _ := newDelivery(test.header)
+			if got := RetryCount(delivery); got != test.want {
+				t.Fatalf("RetryCount() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	cfg := config.RabbitMQConfig{RetryDelay: 2}
+	for attempt, want := range map[int]time.Duration{1: 2 * time.Second, 2: 4 * time.Second, 4: 16 * time.Second} {
+		if got := RetryDelay(cfg, attempt); got != want {
+			t.Fatalf("RetryDelay(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+
+	if got := RetryDelay(config.RabbitMQConfig{}, 2); got != 2*defaultRetryDelay {
+		t.Fatalf("RetryDelay() with default delay = %s, want %s", got, 2*defaultRetryDelay)
+	}
+}
+
+func TestTopologyNames(t *testing.T) {
+	names := map[string]string{
+		TopologyQueue("notification"):              "notification.v2",
+		RetryExchange("notification"):              "notification.v2.retry",
+		RetryQueue("notification", 5*time.Second):  "notification.v2.retry.5s",
+		RetryQueue("notification", 80*time.Second): "notification.v2.retry.1m20s",
+		DeadLetterExchange("notification"):         "notification.v2.dlx",
+		DeadLetterQueue("notification"):            "notification.v2.dead",
+	}
+	for got, want := range names {
+		if got != want {
+			t.Errorf("name = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestConsumer_handle(t *testing.T) {
+	cfg := config.RabbitMQConfig{Queue: "notifications", MaxRetries: 2}
+	failed := errors.New("failed")
+
+	t.Run("Success", func(t *testing.T) {
+		c, sent := newTestConsumer(cfg, nil)
+		delivery, ack := newDelivery(nil)
+
+		c.handle(context.Background(), func(ctx context.Context, delivery amqp.Delivery) error { return nil }, delivery)
+
+		if !ack.acked || ack.nacked || len(*sent) != 0 {
+			t.Fatalf("message must be acked without retry, ack: %+v, published: %d", ack, len(*sent))
+		}
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		c, sent := newTestConsumer(cfg, nil)
+		delivery, ack := newDelivery(int32(1))
+
+		c.handle(context.Background(), func(ctx context.Context, delivery amqp.Delivery) error { return failed }, delivery)
+
+		if !ack.acked || ack.nacked {
+			t.Fatalf("message must be acked after retry is published, ack: %+v", ack)
+		}
+		if len(*sent) != 1 {
+			t.Fatalf("published %d retries, want 1", len(*sent))
+		}
+		retry := (*sent)[0]
+		if retry.exchange != RetryExchange(cfg.Queue) || retry.key != "notifications.v2.retry.10s" {
+			t.Fatalf("retry published to %s/%s", retry.exchange, retry.key)
+		}
+		if retry.msg.Headers[HeaderRetryCount] != int32(2) || retry.msg.Headers["trace"] != "value" {
+			t.Fatalf("retry headers %v", retry.msg.Headers)
+		}
+		if delivery.Headers[HeaderRetryCount] != int32(1) {
+			t.Fatalf("delivery headers must not be changed, got %v", delivery.Headers)
+		}
+	})
+
+	t.Run("Retry is not confirmed", func(t *testing.T) {
+		c, _ := newTestConsumer(cfg, ErrPublishReturned)
+		delivery, ack := newDelivery(nil)
+
+		c.handle(context.Background(), func(ctx context.Context, delivery amqp.Delivery) error { return failed }, delivery)
+
+		if ack.acked || !ack.nacked || !ack.requeue {
+			t.Fatalf("message must be requeued when retry isn't confirmed, ack: %+v", ack)
+		}
+	})
+
+	t.Run("Retries exhausted", func(t *testing.T) {
+		c, sent := newTestConsumer(cfg, nil)
+		delivery, ack := newDelivery(int32(2))
+
+		c.handle(context.Background(), func(ctx context.Context, delivery amqp.Delivery) error { return failed }, delivery)
+
+		if ack.acked || !ack.nacked || ack.requeue || len(*sent) != 0 {
+			t.Fatalf("message must be dead-lettered, ack: %+v, published: %d", ack, len(*sent))
+		}
+	})
+
+	t.Run("Permanent error", func(t *testing.T) {
+		c, sent := newTestConsumer(cfg, nil)
+		delivery, ack := newDelivery(nil)
+
+		c.handle(context.Background(), func(ctx context.Context, delivery amqp.Delivery) error { return Permanent(failed) }, delivery)
+
+		if ack.acked || !ack.nacked || ack.requeue || len(*sent) != 0 {
+			t.Fatalf("message must be dead-lettered, ack: %+v, published: %d", ack, len(*sent))
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package amqp
+
+import (
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultMaxRetries    = 5
+	defaultRetryDelay    = 5 * time.Second
+	defaultPrefetchCount = 10
+)
+
+// Header with number of retries already made for the message
+const HeaderRetryCount = "x-retry-count"
+
+// TopologyVersion is part of every queue name. RabbitMQ rejects redeclaring existing queue with other
+// arguments (PRECONDITION_FAILED), so version is changed together with queue arguments and
+// new topology is declared beside the old one. See upgrade steps in platform README.
+const TopologyVersion = "v2"
+
+// TopologyQueue name of queue cfg.Queue in current topology version. Messages are published there.
+func TopologyQueue(queue string) string {
+	return queue + "." + TopologyVersion
+}
+
+// RetryExchange name for queue. Messages are routed by retry queue name into retry queues.
+func RetryExchange(queue string) string {
+	return TopologyQueue(queue) + ".retry"
+}
+
+// RetryQueue name for delay. Message waits there for delay and returns to queue.
+// Delay is part of the name, so changed RetryDelay declares new queues instead of redeclaring old ones.
+func RetryQueue(queue string, delay time.Duration) string {
+	return TopologyQueue(queue) + ".retry." + delay.String()
+}
+
+// DeadLetterExchange name for queue. Rejected messages are routed there.
+func DeadLetterExchange(queue string) string {
+	return TopologyQueue(queue) + ".dlx"
+}
+
+// DeadLetterQueue name for queue. Keeps messages which failed permanently.
+func DeadLetterQueue(queue string) string {
+	return TopologyQueue(queue) + ".dead"
+}
+
+func maxRetries(cfg config.RabbitMQConfig) int {
+	if cfg.MaxRetries <= 0 {
+		return defaultMaxRetries
+	}
+	return cfg.MaxRetries
+}
+
+// RetryDelay for attempt starting from 1. Delay is doubled on every attempt.
+func RetryDelay(cfg config.RabbitMQConfig, attempt int) time.Duration {
+	delay := time.Duration(cfg.RetryDelay) * time.Second
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	return delay << (attempt - 1)
+}
+
+// DeclareTopology declares
+//   - durable TopologyQueue of cfg.Queue, rejected messages are dead-lettered to DeadLetterExchange;
+//   - DeadLetterExchange with DeadLetterQueue bound;
+//   - RetryExchange with RetryQueue for every attempt bound by its name. Retry queue has TTL of attempt delay
+//     and dead-letters expired messages back to TopologyQueue.
+//
+// Separate retry queue per attempt is used because RabbitMQ expires messages only from the queue head.
+func DeclareTopology(ch *amqp.Channel, cfg config.RabbitMQConfig) (*amqp.Queue, error) {
+	dlx := DeadLetterExchange(cfg.Queue)
+	if err := ch.ExchangeDeclare(dlx, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return nil, err
+	}
+	dead, err := ch.QueueDeclare(DeadLetterQueue(cfg.Queue), true, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = ch.QueueBind(dead.Name, "", dlx, false, nil); err != nil {
+		return nil, err
+	}
+
+	queue, err := ch.QueueDeclare(
+		TopologyQueue(cfg.Queue),
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{
+			"x-dead-letter-exchange": dlx,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	retryExchange := RetryExchange(cfg.Queue)
+	if err = ch.ExchangeDeclare(retryExchange, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+		return nil, err
+	}
+	for attempt := 1; attempt <= maxRetries(cfg); attempt++ {
+		delay := RetryDelay(cfg, attempt)
+		retry, err := ch.QueueDeclare(
+			RetryQueue(cfg.Queue, delay),
+			true,
+			false,
+			false,
+			false,
+			amqp.Table{
+				"x-message-ttl":             delay.Milliseconds(),
+				"x-dead-letter-exchange":    "",
+				"x-dead-letter-routing-key": queue.Name,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err = ch.QueueBind(retry.Name, retry.Name, retryExchange, false, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return &queue, nil
+}
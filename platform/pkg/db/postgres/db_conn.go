@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/jackc/pgx/stdlib" // pgx driver
 	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 
 	config "github.com/GCFactory/dbo-system/platform/config"
 )
@@ -27,10 +29,13 @@ func NewPsqlDB(c *config.Config) (*sqlx.DB, error) {
 		c.Postgres.PostgresqlPassword,
 	)
 
-	db, err := sqlx.Connect(c.Postgres.PgDriver, dataSourceName)
+	sqlDB, err := otelsql.Open(c.Postgres.PgDriver, dataSourceName,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
 	if err != nil {
 		return nil, err
 	}
+	db := sqlx.NewDb(sqlDB, c.Postgres.PgDriver)
 
 	db.SetMaxOpenConns(maxOpenConns)
 	db.SetConnMaxLifetime(connMaxLifetime * time.Second)
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/pkg/mail"
+	"github.com/IBM/sarama"
+	"github.com/jmoiron/sqlx"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrNoBrokers  = errors.New("no kafka brokers configured")
+	ErrAMQPClosed = errors.New("amqp channel is closed")
+)
+
+// PostgresChecker pings database
+func PostgresChecker(db *sqlx.DB) Checker {
+	return NewChecker("postgres", func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	})
+}
+
+// RedisChecker pings redis server
+func RedisChecker(client *redis.Client) Checker {
+	return NewChecker("redis", func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+}
+
+// KafkaChecker requests cluster metadata. Brokers are separated by ";" as in kafka config.
+// Check passes if any broker answers.
+func KafkaChecker(name string, brokers string) Checker {
+	return NewChecker(name, func(ctx context.Context) error {
+		if brokers == "" {
+			return ErrNoBrokers
+		}
+
+		cfg := sarama.NewConfig()
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout := time.Until(deadline)
+			cfg.Net.DialTimeout = timeout
+			cfg.Net.ReadTimeout = timeout
+			cfg.Net.WriteTimeout = timeout
+		}
+
+		var err error
+		for _, addr := range strings.Split(brokers, ";") {
+			if err = kafkaMetadata(addr, cfg); err == nil {
+				return nil
+			}
+		}
+		return err
+	})
+}
+
+func kafkaMetadata(addr string, cfg *sarama.Config) error {
+	broker := sarama.NewBroker(addr)
+	if err := broker.Open(cfg); err != nil {
+		return err
+	}
+	defer broker.Close()
+
+	_, err := broker.GetMetadata(&sarama.MetadataRequest{})
+	return err
+}
+
+// AMQPChecker verifies that channel is still open
+func AMQPChecker(ch *amqp.Channel) Checker {
+	return NewChecker("amqp", func(ctx context.Context) error {
+		if ch == nil || ch.IsClosed() {
+			return ErrAMQPClosed
+		}
+		return nil
+	})
+}
+
+// SmtpChecker opens new connection to smtp server and sends NOOP
+func SmtpChecker(sender mail.Sender) Checker {
+	return NewChecker("smtp", sender.Check)
+}
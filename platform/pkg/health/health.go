@@ -0,0 +1,149 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+// Response statuses
+const (
+	StatusOK   = "OK"
+	StatusFail = "FAIL"
+)
+
+// Checker verifies a single dependency of the service
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (c checkerFunc) Name() string {
+	return c.name
+}
+
+func (c checkerFunc) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// NewChecker creates checker from function
+func NewChecker(name string, check func(ctx context.Context) error) Checker {
+	return checkerFunc{name: name, check: check}
+}
+
+// Result of readiness check
+type Result struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Health runs registered checkers and caches readiness result
+type Health struct {
+	checkers []Checker
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	lock      sync.Mutex
+	checkedAt time.Time
+	result    Result
+}
+
+// NewHealth creates Health with timeout and cache ttl from cfg. Zero values are replaced with defaults.
+func NewHealth(cfg config.Health, checkers ...Checker) *Health {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	cacheTTL := time.Duration(cfg.CacheTTL) * time.Second
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+
+	return &Health{
+		checkers: checkers,
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Ready runs all checkers concurrently. Result is reused until cache ttl expires.
+func (h *Health) Ready(ctx context.Context) Result {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if !h.checkedAt.IsZero() && time.Since(h.checkedAt) < h.cacheTTL {
+		return h.result
+	}
+
+	// Cached result must not depend on cancellation of the request which triggered the check
+	ctxWithTimeout, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.timeout)
+	defer cancel()
+
+	errs := make([]error, len(h.checkers))
+	var wg sync.WaitGroup
+	for i, checker := range h.checkers {
+		wg.Add(1)
+		go func(i int, checker Checker) {
+			defer wg.Done()
+			errs[i] = check(ctxWithTimeout, checker)
+		}(i, checker)
+	}
+	wg.Wait()
+
+	result := Result{Status: StatusOK, Checks: make(map[string]string, len(h.checkers))}
+	for i, checker := range h.checkers {
+		if errs[i] != nil {
+			result.Status = StatusFail
+			result.Checks[checker.Name()] = errs[i].Error()
+		} else {
+			result.Checks[checker.Name()] = StatusOK
+		}
+	}
+
+	h.result = result
+	h.checkedAt = time.Now()
+	return result
+}
+
+// check stops waiting for checker when ctx is done. Not every client supports context cancellation.
+func check(ctx context.Context, checker Checker) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- checker.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// MapRoutes registers /live and /ready handlers in group
+func (h *Health) MapRoutes(group *echo.Group) {
+	group.GET("/live", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, Result{Status: StatusOK})
+	})
+	group.GET("/ready", func(c echo.Context) error {
+		result := h.Ready(c.Request().Context())
+		if result.Status != StatusOK {
+			return c.JSON(http.StatusServiceUnavailable, result)
+		}
+		return c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,165 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/labstack/echo/v4"
+)
+
+// countingChecker counts calls and returns err
+type countingChecker struct {
+	name  string
+	err   error
+	calls atomic.Int32
+}
+
+func (c *countingChecker) Name() string {
+	return c.name
+}
+
+func (c *countingChecker) Check(ctx context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+func TestNewHealth(t *testing.T) {
+	h := NewHealth(config.Health{})
+	if h.timeout != defaultTimeout || h.cacheTTL != defaultCacheTTL {
+		t.Fatalf("timeout = %s, cache ttl = %s", h.timeout, h.cacheTTL)
+	}
+
+	h = NewHealth(config.Health{Timeout: 1, CacheTTL: 10})
+	if h.timeout != time.Second || h.cacheTTL != 10*time.Second {
+		t.Fatalf("timeout = %s, cache ttl = %s", h.timeout, h.cacheTTL)
+	}
+}
+
+func TestHealth_ReadyCache(t *testing.T) {
+	checker := &countingChecker{name: "postgres"}
+	h := NewHealth(config.Health{CacheTTL: 60}, checker)
+
+	for i := 0; i < 3; i++ {
+		if result := h.Ready(context.Background()); result.Status != StatusOK {
+			t.Fatalf("status = %s", result.Status)
+		}
+	}
+	if calls := checker.calls.Load(); calls != 1 {
+		t.Fatalf("calls within cache ttl = %d", calls)
+	}
+
+	// Failure appears only after cached result expires
+	checker.err = errors.New("connection refused")
+	if result := h.Ready(context.Background()); result.Status != StatusOK {
+		t.Fatalf("cached status = %s", result.Status)
+	}
+
+	h.checkedAt = time.Now().Add(-h.cacheTTL)
+	result := h.Ready(context.Background())
+	if result.Status != StatusFail || result.Checks["postgres"] != "connection refused" {
+		t.Fatalf("result after cache ttl = %+v", result)
+	}
+	if calls := checker.calls.Load(); calls != 2 {
+		t.Fatalf("calls after cache ttl = %d", calls)
+	}
+}
+
+func TestHealth_ReadyTimeout(t *testing.T) {
+	// Slow checker ignores context like clients without cancellation support
+	release := make(chan struct{})
+	defer close(release)
+	slow := NewChecker("kafka", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	fast := &countingChecker{name: "postgres"}
+
+	h := NewHealth(config.Health{}, slow, fast)
+	h.timeout = 50 * time.Millisecond
+
+	started := time.Now()
+	result := h.Ready(context.Background())
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Ready waited for slow checker: %s", elapsed)
+	}
+
+	if result.Status != StatusFail {
+		t.Fatalf("status = %s", result.Status)
+	}
+	if result.Checks["kafka"] != context.DeadlineExceeded.Error() {
+		t.Fatalf("slow checker result = %s", result.Checks["kafka"])
+	}
+	if result.Checks["postgres"] != StatusOK {
+		t.Fatalf("fast checker result = %s", result.Checks["postgres"])
+	}
+}
+
+func TestHealth_ReadyRequestCancelled(t *testing.T) {
+	checker := NewChecker("postgres", func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	h := NewHealth(config.Health{}, checker)
+
+	// Cancelled request must not put failure into cache
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result := h.Ready(ctx); result.Status != StatusOK {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestHealth_MapRoutes(t *testing.T) {
+	cases := map[string]struct {
+		checkers   []Checker
+		wantCode   int
+		wantStatus string
+	}{
+		"all ok": {
+			checkers:   []Checker{&countingChecker{name: "postgres"}, &countingChecker{name: "kafka"}},
+			wantCode:   http.StatusOK,
+			wantStatus: StatusOK,
+		},
+		"one failed": {
+			checkers:   []Checker{&countingChecker{name: "postgres"}, &countingChecker{name: "kafka", err: ErrNoBrokers}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: StatusFail,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			NewHealth(config.Health{}, test.checkers...).MapRoutes(e.Group("/health"))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			if rec.Code != test.wantCode {
+				t.Fatalf("code = %d, want %d", rec.Code, test.wantCode)
+			}
+
+			var result Result
+			if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != test.wantStatus || len(result.Checks) != len(test.checkers) {
+				t.Fatalf("result = %+v", result)
+			}
+			if test.wantStatus == StatusFail && result.Checks["kafka"] != ErrNoBrokers.Error() {
+				t.Fatalf("failed check = %s", result.Checks["kafka"])
+			}
+
+			// Liveness doesn't depend on checkers
+			rec = httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("live code = %d", rec.Code)
+			}
+		})
+	}
+}
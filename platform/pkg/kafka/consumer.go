@@ -0,0 +1,297 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/codes"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts    = 3
+	defaultRetryDelay     = time.Second
+	deadLetterTopicSuffix = "_dlq"
+
+	// Headers of dead-lettered message, original headers are kept as well
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+)
+
+var ErrNoHandlers = errors.New("no kafka handlers registered")
+
+// HandlerFunc processes a single message. Returned error makes message handled again,
+// after MaxAttempts failures it is sent to dead-letter topic.
+type HandlerFunc func(ctx context.Context, message *sarama.ConsumerMessage) error
+
+// ProtoMessage constrains T to a pointer to a generated protobuf message.
+type ProtoMessage[T any] interface {
+	*T
+	proto.Message
+}
+
+// ConsumerGroup routes messages of subscribed topics to registered handlers.
+type ConsumerGroup struct {
+	group   sarama.ConsumerGroup
+	logger  logger.Logger
+	metrics metric.KafkaMetrics
+
+	handlersLock sync.RWMutex
+	handlers     map[string]HandlerFunc
+
+	maxAttempts     int
+	retryDelay      time.Duration
+	deadLetterTopic string
+	// Publishes dead-lettered message, message is redelivered if it fails
+	deadLetter func(ctx context.Context, record *sarama.ProducerMessage) error
+
+	done chan struct{}
+}
+
+// NewKafkaConsumer joins consumer group cfg.GroupID on cfg.Brokers.
+func NewKafkaConsumer(cfg config.KafkaConsumer, logger logger.Logger) (*ConsumerGroup, error) {
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{sarama.NewBalanceStrategyRoundRobin()}
+	saramaCfg.Consumer.Return.Errors = false
+
+	cg, err := sarama.NewConsumerGroup(strings.Split(cfg.Brokers, ";"), cfg.GroupID, saramaCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+
+	return &ConsumerGroup{
+		group:           cg,
+		logger:          logger,
+		handlers:        make(map[string]HandlerFunc),
+		maxAttempts:     maxAttempts,
+		retryDelay:      defaultRetryDelay,
+		deadLetterTopic: cfg.DeadLetterTopic,
+		done:            make(chan struct{}),
+	}, nil
+}
+
+// Handle registers handler for topic. Must be called before Run.
+func (cg *ConsumerGroup) Handle(topic string, handler HandlerFunc) {
+	cg.handlersLock.Lock()
+	defer cg.handlersLock.Unlock()
+
+	cg.handlers[topic] = handler
+}
+
+// SetMetrics enables consume error and lag metrics. Must be called before Run.
+func (cg *ConsumerGroup) SetMetrics(metrics metric.KafkaMetrics) {
+	cg.metrics = metrics
+}
+
+// SetDeadLetterProducer enables publishing of failed messages to dead-letter topic.
+// Without it failed message is logged and skipped. Must be called before Run.
+func (cg *ConsumerGroup) SetDeadLetterProducer(producer *ProducerProvider) {
+	cg.deadLetter = producer.ProduceMessage
+}
+
+// HandleProto registers handler for topic which receives message value decoded into PT.
+// Messages which can't be decoded are logged and skipped.
+func HandleProto[T any, PT ProtoMessage[T]](cg *ConsumerGroup, topic string, handler func(ctx context.Context, data PT) error) {
+	cg.Handle(topic, func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		data := PT(new(T))
+		if err := proto.Unmarshal(message.Value, data); err != nil {
+			cg.logger.Errorf("Consumer: unable to decode message from <%s>: %v", message.Topic, err)
+			return nil
+		}
+		return handler(ctx, data)
+	})
+}
+
+// Topics returns list of topics with registered handlers.
+func (cg *ConsumerGroup) Topics() []string {
+	cg.handlersLock.RLock()
+	defer cg.handlersLock.RUnlock()
+
+	topics := make([]string, 0, len(cg.handlers))
+	for topic := range cg.handlers {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Run consumes registered topics until ctx is cancelled or the group is closed.
+// Session errors are logged and consumption is restarted.
+func (cg *ConsumerGroup) Run(ctx context.Context) error {
+	defer close(cg.done)
+
+	topics := cg.Topics()
+	if len(topics) == 0 {
+		return ErrNoHandlers
+	}
+
+	for {
+		if err := cg.group.Consume(ctx, topics, cg); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			cg.logger.Errorf("Error from consumer: %v", err)
+		}
+		// check if context was cancelled, signaling that the consumer should stop
+		if ctx.Err() != nil {
+			cg.logger.Infof("Stopping kafka consumer: context close: %v", ctx.Err())
+			return nil
+		}
+	}
+}
+
+// Close leaves the group. In-flight handlers are allowed to finish.
+func (cg *ConsumerGroup) Close() error {
+	cg.group.PauseAll()
+	return cg.group.Close()
+}
+
+// Done is closed when Run returns.
+func (cg *ConsumerGroup) Done() <-chan struct{} {
+	return cg.done
+}
+
+// Setup is run at the beginning of a new session, before ConsumeClaim
+func (cg *ConsumerGroup) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
+func (cg *ConsumerGroup) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
+// Once the Messages() channel is closed, the Handler must finish its processing
+// loop and exit.
+func (cg *ConsumerGroup) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	// NOTE:
+	// Do not move the code below to a goroutine.
+	// The `ConsumeClaim` itself is called within a goroutine, see:
+	// https://github.com/IBM/sarama/blob/main/consumer_group.go#L27-L29
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				cg.logger.Info("Consumer: message channel was closed")
+				return nil
+			}
+
+			// Message which is neither handled nor dead-lettered is redelivered in next session
+			if err := cg.handle(session.Context(), message); err != nil {
+				return err
+			}
+
+			// Mark message as consumed
+			session.MarkMessage(message, "")
+
+			if cg.metrics != nil {
+				// High water mark is offset of the next message to be produced into partition
+				cg.metrics.SetKafkaConsumerLag(message.Topic, message.Partition, claim.HighWaterMarkOffset()-message.Offset-1)
+			}
+
+		// Should return when `session.Context()` is done.
+		// If not, will raise `ErrRebalanceInProgress` or `read tcp <ip>:<port>: i/o timeout` when kafka rebalance. see:
+		// https://github.com/IBM/sarama/issues/1192
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// handle dispatches message up to maxAttempts times, then sends it to dead-letter topic.
+// Error is returned if session ended between attempts or dead-letter topic is unavailable.
+func (cg *ConsumerGroup) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	for attempt := 1; ; attempt++ {
+		// Handler must be able to finish in-flight work on shutdown or rebalance
+		err := cg.dispatch(context.WithoutCancel(ctx), message)
+		if err == nil {
+			return nil
+		}
+
+		cg.logger.Errorf("Consumer: error on handle message from <%s>, attempt %d: %v", message.Topic, attempt, err)
+		if cg.metrics != nil {
+			cg.metrics.IncKafkaConsumeErrors(message.Topic)
+		}
+		if attempt >= cg.maxAttempts {
+			return cg.sendToDeadLetter(ctx, message, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(cg.retryDelay * time.Duration(attempt)):
+		}
+	}
+}
+
+func (cg *ConsumerGroup) sendToDeadLetter(ctx context.Context, message *sarama.ConsumerMessage, handleErr error) error {
+	if cg.deadLetter == nil {
+		cg.logger.Errorf("Consumer: message from <%s> at offset %d is skipped: %v", message.Topic, message.Offset, handleErr)
+		return nil
+	}
+
+	topic := cg.deadLetterTopic
+	if topic == "" {
+		topic = message.Topic + deadLetterTopicSuffix
+	}
+
+	record := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.ByteEncoder(message.Key),
+		Value: sarama.ByteEncoder(message.Value),
+	}
+	for _, header := range message.Headers {
+		if header != nil {
+			record.Headers = append(record.Headers, *header)
+		}
+	}
+	record.Headers = append(record.Headers,
+		sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(HeaderError), Value: []byte(handleErr.Error())},
+	)
+
+	if err := cg.deadLetter(context.WithoutCancel(ctx), record); err != nil {
+		cg.logger.Errorf("Consumer: unable to send message from <%s> to <%s>: %v", message.Topic, topic, err)
+		return err
+	}
+	cg.logger.Warnf("Consumer: message from <%s> at offset %d is sent to <%s>", message.Topic, message.Offset, topic)
+	return nil
+}
+
+func (cg *ConsumerGroup) dispatch(ctx context.Context, message *sarama.ConsumerMessage) error {
+	cg.handlersLock.RLock()
+	handler, ok := cg.handlers[message.Topic]
+	cg.handlersLock.RUnlock()
+
+	if !ok {
+		cg.logger.Warnf("Consumer: no handler for topic <%s>", message.Topic)
+		return nil
+	}
+
+	ctxWithTrace, span := extractSpanContext(ctx, message)
+	defer span.End()
+
+	if err := handler(ctxWithTrace, message); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("topic %s: %w", message.Topic, err)
+	}
+	return nil
+}
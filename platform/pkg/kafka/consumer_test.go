@@ -0,0 +1,270 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeSession records marked messages
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	marked []*sarama.ConsumerMessage
+}
+
+func (s *fakeSession) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeSession) MarkMessage(message *sarama.ConsumerMessage, metadata string) {
+	s.marked = append(s.marked, message)
+}
+
+// fakeClaim yields given messages and closes its channel
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func newFakeClaim(messages ...*sarama.ConsumerMessage) *fakeClaim {
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	close(claim.messages)
+	return claim
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+func newTestConsumerGroup(maxAttempts int) *ConsumerGroup {
+	log := logger.NewServerLogger(&config.Config{Logger: config.Logger{Development: true, Level: "Debug"}})
+	log.InitLogger()
+
+	return &ConsumerGroup{
+		logger:      log,
+		handlers:    make(map[string]HandlerFunc),
+		maxAttempts: maxAttempts,
+		done:        make(chan struct{}),
+	}
+}
+
+func newMessage(topic string, value []byte) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     topic,
+		Partition: 1,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     value,
+		Headers:   []*sarama.RecordHeader{{Key: []byte("trace"), Value: []byte("value")}},
+	}
+}
+
+func header(record *sarama.ProducerMessage, key string) string {
+	return producerHeaders{message: record}.Get(key)
+}
+
+func TestConsumerGroup_Handle(t *testing.T) {
+	cg := newTestConsumerGroup(1)
+
+	var handled []string
+	cg.Handle("first", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		handled = append(handled, "first:"+string(message.Value))
+		return nil
+	})
+	cg.Handle("second", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		handled = append(handled, "second:"+string(message.Value))
+		return nil
+	})
+
+	for _, message := range []*sarama.ConsumerMessage{
+		newMessage("second", []byte("a")),
+		newMessage("unknown", []byte("b")),
+		newMessage("first", []byte("c")),
+	} {
+		if err := cg.dispatch(context.Background(), message); err != nil {
+			t.Fatalf("dispatch %s: %v", message.Topic, err)
+		}
+	}
+
+	if len(handled) != 2 || handled[0] != "second:a" || handled[1] != "first:c" {
+		t.Fatalf("handled = %v", handled)
+	}
+	if topics := cg.Topics(); len(topics) != 2 {
+		t.Fatalf("topics = %v", topics)
+	}
+}
+
+func TestHandleProto(t *testing.T) {
+	cg := newTestConsumerGroup(1)
+
+	var received []string
+	handlerErr := errors.New("handler error")
+	HandleProto(cg, "proto", func(ctx context.Context, data *wrapperspb.StringValue) error {
+		received = append(received, data.GetValue())
+		if data.GetValue() == "fail" {
+			return handlerErr
+		}
+		return nil
+	})
+
+	encode := func(value string) []byte {
+		data, err := proto.Marshal(wrapperspb.String(value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	if err := cg.dispatch(context.Background(), newMessage("proto", encode("hello"))); err != nil {
+		t.Fatalf("dispatch decoded message: %v", err)
+	}
+
+	// Message which can't be decoded is skipped without calling handler
+	if err := cg.dispatch(context.Background(), newMessage("proto", []byte{0xff, 0xff})); err != nil {
+		t.Fatalf("dispatch broken message: %v", err)
+	}
+
+	if err := cg.dispatch(context.Background(), newMessage("proto", encode("fail"))); !errors.Is(err, handlerErr) {
+		t.Fatalf("dispatch error = %v, want %v", err, handlerErr)
+	}
+
+	if len(received) != 2 || received[0] != "hello" || received[1] != "fail" {
+		t.Fatalf("received = %v", received)
+	}
+}
+
+func TestConsumerGroup_ConsumeClaimRetry(t *testing.T) {
+	cg := newTestConsumerGroup(3)
+
+	attempts := 0
+	cg.Handle("topic", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+	var deadLettered []*sarama.ProducerMessage
+	cg.deadLetter = func(ctx context.Context, record *sarama.ProducerMessage) error {
+		deadLettered = append(deadLettered, record)
+		return nil
+	}
+
+	session := &fakeSession{}
+	if err := cg.ConsumeClaim(session, newFakeClaim(newMessage("topic", []byte("value")))); err != nil {
+		t.Fatal(err)
+	}
+
+	if attempts != 2 || len(session.marked) != 1 || len(deadLettered) != 0 {
+		t.Fatalf("attempts = %d, marked = %d, dead-lettered = %d", attempts, len(session.marked), len(deadLettered))
+	}
+}
+
+func TestConsumerGroup_ConsumeClaimDeadLetter(t *testing.T) {
+	handlerErr := errors.New("handler error")
+
+	cases := map[string]struct {
+		deadLetterTopic string
+		wantTopic       string
+	}{
+		"default topic":    {"", "topic_dlq"},
+		"configured topic": {"errors", "errors"},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			cg := newTestConsumerGroup(3)
+			cg.deadLetterTopic = test.deadLetterTopic
+
+			attempts := 0
+			cg.Handle("topic", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+				attempts++
+				return handlerErr
+			})
+			var deadLettered []*sarama.ProducerMessage
+			cg.deadLetter = func(ctx context.Context, record *sarama.ProducerMessage) error {
+				deadLettered = append(deadLettered, record)
+				return nil
+			}
+
+			message := newMessage("topic", []byte("value"))
+			session := &fakeSession{}
+			if err := cg.ConsumeClaim(session, newFakeClaim(message)); err != nil {
+				t.Fatal(err)
+			}
+
+			if attempts != 3 || len(session.marked) != 1 || len(deadLettered) != 1 {
+				t.Fatalf("attempts = %d, marked = %d, dead-lettered = %d", attempts, len(session.marked), len(deadLettered))
+			}
+
+			record := deadLettered[0]
+			if record.Topic != test.wantTopic {
+				t.Fatalf("dead-letter topic = %s, want %s", record.Topic, test.wantTopic)
+			}
+			if value, _ := record.Value.Encode(); string(value) != "value" {
+				t.Fatalf("dead-letter value = %s", value)
+			}
+			if key, _ := record.Key.Encode(); string(key) != "key" {
+				t.Fatalf("dead-letter key = %s", key)
+			}
+			for key, want := range map[string]string{
+				"trace":                 "value",
+				HeaderOriginalTopic:     "topic",
+				HeaderOriginalPartition: "1",
+				HeaderOriginalOffset:    "42",
+				HeaderError:             "topic topic: handler error",
+			} {
+				if got := header(record, key); got != want {
+					t.Errorf("header %s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConsumerGroup_ConsumeClaimDeadLetterError(t *testing.T) {
+	cg := newTestConsumerGroup(1)
+
+	cg.Handle("topic", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		return errors.New("handler error")
+	})
+	produceErr := errors.New("kafka is down")
+	cg.deadLetter = func(ctx context.Context, record *sarama.ProducerMessage) error {
+		return produceErr
+	}
+
+	// Message is redelivered in next session rather than lost
+	session := &fakeSession{}
+	if err := cg.ConsumeClaim(session, newFakeClaim(newMessage("topic", nil))); !errors.Is(err, produceErr) {
+		t.Fatalf("ConsumeClaim error = %v, want %v", err, produceErr)
+	}
+	if len(session.marked) != 0 {
+		t.Fatalf("marked = %d", len(session.marked))
+	}
+}
+
+func TestConsumerGroup_ConsumeClaimWithoutDeadLetter(t *testing.T) {
+	cg := newTestConsumerGroup(2)
+
+	attempts := 0
+	cg.Handle("topic", func(ctx context.Context, message *sarama.ConsumerMessage) error {
+		attempts++
+		return errors.New("handler error")
+	})
+
+	session := &fakeSession{}
+	if err := cg.ConsumeClaim(session, newFakeClaim(newMessage("topic", nil), newMessage("topic", nil))); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 4 || len(session.marked) != 2 {
+		t.Fatalf("attempts = %d, marked = %d", attempts, len(session.marked))
+	}
+}
@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/IBM/sarama"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTransactionID = "txn_producer"
+	createRetryDelay     = time.Second
+)
+
+var (
+	ErrProducerClosed = errors.New("kafka producer is closed")
+	ErrProducerFatal  = errors.New("kafka producer is in a fatal state")
+	ErrTxnAborted     = errors.New("kafka transaction aborted")
+)
+
+// ProducerProvider is a pool of transactional producers that ensures transactional-id is unique.
+type ProducerProvider struct {
+	transactionIdGenerator int32
+
+	producersLock sync.Mutex
+	producers     []sarama.AsyncProducer
+	closed        bool
+
+	producerProvider func() (sarama.AsyncProducer, error)
+
+	logger  logger.Logger
+	metrics metric.KafkaMetrics
+}
+
+// NewKafkaProducer creates producer pool. Transaction id prefix is taken from cfg.TransactionID.
+func NewKafkaProducer(cfg config.KafkaProducer, logger logger.Logger) *ProducerProvider {
+	brokers := strings.Split(cfg.Brokers, ";")
+	transactionID := cfg.TransactionID
+	if transactionID == "" {
+		transactionID = defaultTransactionID
+	}
+
+	provider := &ProducerProvider{
+		logger: logger,
+	}
+	provider.producerProvider = func() (sarama.AsyncProducer, error) {
+		saramaCfg := sarama.NewConfig()
+		saramaCfg.Version = sarama.DefaultVersion
+		saramaCfg.Producer.Idempotent = true
+		saramaCfg.Producer.Return.Errors = false
+		saramaCfg.Producer.RequiredAcks = sarama.WaitForAll
+		saramaCfg.Producer.Partitioner = sarama.NewRoundRobinPartitioner
+		saramaCfg.Producer.Transaction.Retry.Backoff = 10
+		saramaCfg.Net.MaxOpenRequests = 1
+		// Append transactionIdGenerator to transaction id prefix to ensure transaction-id uniqueness.
+		suffix := provider.transactionIdGenerator
+		provider.transactionIdGenerator++
+		saramaCfg.Producer.Transaction.ID = transactionID + "-" + fmt.Sprint(suffix)
+		return sarama.NewAsyncProducer(brokers, saramaCfg)
+	}
+	return provider
+}
+
+// SetMetrics enables produce error metrics. Must be called before first ProduceRecord.
+func (p *ProducerProvider) SetMetrics(metrics metric.KafkaMetrics) {
+	p.metrics = metrics
+}
+
+func (p *ProducerProvider) borrow(ctx context.Context) (sarama.AsyncProducer, error) {
+	p.producersLock.Lock()
+	defer p.producersLock.Unlock()
+
+	if p.closed {
+		return nil, ErrProducerClosed
+	}
+
+	if len(p.producers) == 0 {
+		for {
+			producer, err := p.producerProvider()
+			if err == nil {
+				return producer, nil
+			}
+			p.logger.Warnf("Producer: unable to create producer: %v", err)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(createRetryDelay):
+			}
+		}
+	}
+
+	index := len(p.producers) - 1
+	producer := p.producers[index]
+	p.producers = p.producers[:index]
+	return producer, nil
+}
+
+func (p *ProducerProvider) release(producer sarama.AsyncProducer) {
+	p.producersLock.Lock()
+	defer p.producersLock.Unlock()
+
+	// If released producer is erroneous or pool is closed - close it and don't return it to the producer pool.
+	if p.closed || producer.TxnStatus()&sarama.ProducerTxnFlagInError != 0 {
+		_ = producer.Close()
+		return
+	}
+	p.producers = append(p.producers, producer)
+}
+
+// Close closes every idle producer and rejects any further ProduceRecord call.
+func (p *ProducerProvider) Close() error {
+	p.producersLock.Lock()
+	defer p.producersLock.Unlock()
+
+	p.closed = true
+	var errs []error
+	for _, producer := range p.producers {
+		if err := producer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.producers = p.producers[:0]
+	return errors.Join(errs...)
+}
+
+// ProduceRecord sends message to topic within a kafka transaction.
+func (p *ProducerProvider) ProduceRecord(ctx context.Context, topic string, message []byte) error {
+	return p.ProduceMessage(ctx, &sarama.ProducerMessage{Topic: topic, Key: nil, Value: sarama.ByteEncoder(message)})
+}
+
+// ProduceMessage sends record with its key and headers within a kafka transaction.
+func (p *ProducerProvider) ProduceMessage(ctx context.Context, record *sarama.ProducerMessage) error {
+	err := p.produceMessage(ctx, record)
+	if err != nil && p.metrics != nil {
+		p.metrics.IncKafkaProduceErrors(record.Topic)
+	}
+	return err
+}
+
+func (p *ProducerProvider) produceMessage(ctx context.Context, record *sarama.ProducerMessage) error {
+	producer, err := p.borrow(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.release(producer)
+
+	// Start kafka transaction
+	err = producer.BeginTxn()
+	if err != nil {
+		return err
+	}
+
+	span := injectSpanContext(ctx, record)
+	defer span.End()
+
+	producer.Input() <- record
+
+	// commit transaction
+	err = producer.CommitTxn()
+	if err == nil {
+		return nil
+	}
+
+	p.logger.Warnf("Producer: unable to commit txn %s", err)
+	for {
+		if producer.TxnStatus()&sarama.ProducerTxnFlagFatalError != 0 {
+			// fatal error. producer will be dropped on release.
+			p.logger.Errorf("Producer: producer is in a fatal state, need to recreate it")
+			return ErrProducerFatal
+		}
+		// If producer is in abortable state, try to abort current transaction.
+		if producer.TxnStatus()&sarama.ProducerTxnFlagAbortableError != 0 {
+			err = producer.AbortTxn()
+			if err != nil {
+				// If an error occured just retry it.
+				p.logger.Errorf("Producer: unable to abort transaction: %+v", err)
+				continue
+			}
+			return ErrTxnAborted
+		}
+		// if not you can retry
+		err = producer.CommitTxn()
+		if err != nil {
+			p.logger.Warnf("Producer: unable to commit txn %s", err)
+			continue
+		}
+		return nil
+	}
+}
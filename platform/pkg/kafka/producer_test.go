@@ -0,0 +1,211 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/IBM/sarama"
+)
+
+// fakeProducer keeps produced records, CommitTxn fails with commitErr setting commitStatus
+type fakeProducer struct {
+	sarama.AsyncProducer
+	input  chan *sarama.ProducerMessage
+	status sarama.ProducerTxnStatusFlag
+
+	commitErr    error
+	commitStatus sarama.ProducerTxnStatusFlag
+
+	commits int
+	aborts  int
+	closed  bool
+}
+
+func newFakeProducer() *fakeProducer {
+	return &fakeProducer{input: make(chan *sarama.ProducerMessage, 10), status: sarama.ProducerTxnFlagReady}
+}
+
+func (p *fakeProducer) Input() chan<- *sarama.ProducerMessage {
+	return p.input
+}
+
+func (p *fakeProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return p.status
+}
+
+func (p *fakeProducer) BeginTxn() error {
+	p.status = sarama.ProducerTxnFlagInTransaction
+	return nil
+}
+
+func (p *fakeProducer) CommitTxn() error {
+	p.commits++
+	if p.commitErr != nil {
+		p.status = p.commitStatus
+		return p.commitErr
+	}
+	p.status = sarama.ProducerTxnFlagReady
+	return nil
+}
+
+func (p *fakeProducer) AbortTxn() error {
+	p.aborts++
+	p.status = sarama.ProducerTxnFlagReady
+	return nil
+}
+
+func (p *fakeProducer) Close() error {
+	p.closed = true
+	return nil
+}
+
+type testKafkaMetrics struct {
+	produceErrors map[string]int
+}
+
+func (m *testKafkaMetrics) IncKafkaConsumeErrors(topic string) {}
+
+func (m *testKafkaMetrics) IncKafkaProduceErrors(topic string) {
+	m.produceErrors[topic]++
+}
+
+func (m *testKafkaMetrics) SetKafkaConsumerLag(topic string, partition int32, lag int64) {}
+
+// newTestProducerProvider creates pool which takes producers from given ones
+func newTestProducerProvider(producers ...*fakeProducer) (*ProducerProvider, *testKafkaMetrics) {
+	log := logger.NewServerLogger(&config.Config{Logger: config.Logger{Development: true, Level: "Debug"}})
+	log.InitLogger()
+
+	metrics := &testKafkaMetrics{produceErrors: map[string]int{}}
+	provider := &ProducerProvider{logger: log, metrics: metrics}
+	provider.producerProvider = func() (sarama.AsyncProducer, error) {
+		if len(producers) == 0 {
+			return nil, errors.New("no producers left")
+		}
+		producer := producers[0]
+		producers = producers[1:]
+		return producer, nil
+	}
+	return provider, metrics
+}
+
+func TestProducerProvider_BorrowRelease(t *testing.T) {
+	first, second := newFakeProducer(), newFakeProducer()
+	provider, _ := newTestProducerProvider(first, second)
+
+	// Idle producer is reused
+	for i := 0; i < 2; i++ {
+		if err := provider.ProduceRecord(context.Background(), "topic", []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if first.commits != 2 || len(first.input) != 2 || len(provider.producers) != 1 {
+		t.Fatalf("commits = %d, produced = %d, idle = %d", first.commits, len(first.input), len(provider.producers))
+	}
+
+	// Concurrent transactions get different producers
+	borrowed, err := provider.borrow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := provider.borrow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if borrowed != first || created != second {
+		t.Fatal("borrowed producers are not distinct")
+	}
+	provider.release(borrowed)
+	provider.release(created)
+	if len(provider.producers) != 2 {
+		t.Fatalf("idle = %d", len(provider.producers))
+	}
+
+	if err = provider.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !first.closed || !second.closed || len(provider.producers) != 0 {
+		t.Fatalf("closed = %v/%v, idle = %d", first.closed, second.closed, len(provider.producers))
+	}
+	if err = provider.ProduceRecord(context.Background(), "topic", nil); !errors.Is(err, ErrProducerClosed) {
+		t.Fatalf("ProduceRecord after close error = %v", err)
+	}
+}
+
+func TestProducerProvider_BorrowCancelled(t *testing.T) {
+	provider, _ := newTestProducerProvider()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := provider.borrow(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("borrow error = %v", err)
+	}
+}
+
+func TestProducerProvider_ProduceErrors(t *testing.T) {
+	cases := map[string]struct {
+		commitStatus sarama.ProducerTxnStatusFlag
+		wantErr      error
+		wantAborts   int
+		wantIdle     int
+	}{
+		"abortable": {
+			commitStatus: sarama.ProducerTxnFlagInError | sarama.ProducerTxnFlagAbortableError,
+			wantErr:      ErrTxnAborted,
+			wantAborts:   1,
+			wantIdle:     1,
+		},
+		"fatal": {
+			commitStatus: sarama.ProducerTxnFlagInError | sarama.ProducerTxnFlagFatalError,
+			wantErr:      ErrProducerFatal,
+			wantAborts:   0,
+			wantIdle:     0,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			producer := newFakeProducer()
+			producer.commitErr = errors.New("commit failed")
+			producer.commitStatus = test.commitStatus
+			provider, metrics := newTestProducerProvider(producer)
+
+			err := provider.ProduceRecord(context.Background(), "topic", []byte("value"))
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("ProduceRecord error = %v, want %v", err, test.wantErr)
+			}
+			if producer.aborts != test.wantAborts {
+				t.Fatalf("aborts = %d, want %d", producer.aborts, test.wantAborts)
+			}
+			// Producer in fatal state is closed instead of returning to pool
+			if len(provider.producers) != test.wantIdle || producer.closed == (test.wantIdle == 1) {
+				t.Fatalf("idle = %d, closed = %v", len(provider.producers), producer.closed)
+			}
+			if metrics.produceErrors["topic"] != 1 {
+				t.Fatalf("produce errors = %v", metrics.produceErrors)
+			}
+		})
+	}
+}
+
+func TestProducerProvider_ProduceMessage(t *testing.T) {
+	producer := newFakeProducer()
+	provider, _ := newTestProducerProvider(producer)
+
+	record := &sarama.ProducerMessage{
+		Topic:   "topic_dlq",
+		Key:     sarama.StringEncoder("key"),
+		Value:   sarama.StringEncoder("value"),
+		Headers: []sarama.RecordHeader{{Key: []byte(HeaderOriginalTopic), Value: []byte("topic")}},
+	}
+	if err := provider.ProduceMessage(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+
+	produced := <-producer.input
+	if produced.Topic != "topic_dlq" || header(produced, HeaderOriginalTopic) != "topic" {
+		t.Fatalf("produced = %+v", produced)
+	}
+}
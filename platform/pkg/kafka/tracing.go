@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+)
+
+// Header carriers for otel text map propagator
+type producerHeaders struct {
+	message *sarama.ProducerMessage
+}
+
+func (h producerHeaders) Get(key string) string {
+	for _, header := range h.message.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set replaces header copied from consumed message, e.g. trace of dead-lettered one
+func (h producerHeaders) Set(key, val string) {
+	for i, header := range h.message.Headers {
+		if string(header.Key) == key {
+			h.message.Headers[i].Value = []byte(val)
+			return
+		}
+	}
+	h.message.Headers = append(h.message.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(val)})
+}
+
+func (h producerHeaders) Keys() []string {
+	keys := make([]string, 0, len(h.message.Headers))
+	for _, header := range h.message.Headers {
+		keys = append(keys, string(header.Key))
+	}
+	return keys
+}
+
+type consumerHeaders struct {
+	message *sarama.ConsumerMessage
+}
+
+func (h consumerHeaders) Get(key string) string {
+	for _, header := range h.message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// Set is not used for consumed messages
+func (h consumerHeaders) Set(string, string) {}
+
+func (h consumerHeaders) Keys() []string {
+	keys := make([]string, 0, len(h.message.Headers))
+	for _, header := range h.message.Headers {
+		if header != nil {
+			keys = append(keys, string(header.Key))
+		}
+	}
+	return keys
+}
+
+// injectSpanContext writes span context of ctx into message headers.
+func injectSpanContext(ctx context.Context, message *sarama.ProducerMessage) trace.Span {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "kafka.produce "+message.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(message.Topic),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctxWithTrace, producerHeaders{message: message})
+	return span
+}
+
+// extractSpanContext continues trace from message headers. If message has no trace, new one is started.
+func extractSpanContext(ctx context.Context, message *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, consumerHeaders{message: message})
+	return tracing.StartSpan(ctx, "kafka.consume "+message.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(message.Topic),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(message.Partition))),
+		),
+	)
+}
@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemorySender keeps messages in memory instead of sending, for tests and local runs
+type MemorySender struct {
+	lock     sync.Mutex
+	messages []Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	msg.To = append([]string(nil), msg.To...)
+	msg.Data = append([]byte(nil), msg.Data...)
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *MemorySender) Check(ctx context.Context) error {
+	return nil
+}
+
+func (s *MemorySender) Close() error {
+	return nil
+}
+
+// Messages sent so far
+func (s *MemorySender) Messages() []Message {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
@@ -0,0 +1,107 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Content of multipart/alternative message
+type Content struct {
+	From     string
+	FromName string
+	To       []string
+	Subject  string
+	Text     string
+	HTML     string
+}
+
+// BuildMessage renders content as MIME multipart/alternative message with text and html parts.
+// Subject and sender name are encoded as UTF-8 when needed.
+func BuildMessage(content Content) (Message, error) {
+	if len(content.To) == 0 {
+		return Message{}, ErrNoRecipients
+	}
+
+	messageId, err := newMessageId(content.From)
+	if err != nil {
+		return Message{}, err
+	}
+
+	to := make([]string, 0, len(content.To))
+	for _, address := range content.To {
+		to = append(to, (&netmail.Address{Address: address}).String())
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err = writePart(writer, "text/plain", content.Text); err != nil {
+		return Message{}, err
+	}
+	if err = writePart(writer, "text/html", content.HTML); err != nil {
+		return Message{}, err
+	}
+	if err = writer.Close(); err != nil {
+		return Message{}, err
+	}
+
+	var data bytes.Buffer
+	headers := [][2]string{
+		{"From", (&netmail.Address{Name: content.FromName, Address: content.From}).String()},
+		{"To", strings.Join(to, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", content.Subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", messageId},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()})},
+	}
+	for _, header := range headers {
+		data.WriteString(header[0] + ": " + header[1] + "\r\n")
+	}
+	data.WriteString("\r\n")
+	data.Write(body.Bytes())
+
+	return Message{
+		From: content.From,
+		To:   content.To,
+		Data: data.Bytes(),
+	}, nil
+}
+
+func writePart(writer *multipart.Writer, contentType string, content string) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": "utf-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+	if _, err = encoder.Write([]byte(content)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// newMessageId generates unique id in domain of sender address
+func newMessageId(from string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return "<" + hex.EncodeToString(random) + "." + time.Now().Format("20060102150405") + "@" + domain + ">", nil
+}
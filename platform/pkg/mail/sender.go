@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+)
+
+var (
+	ErrSenderClosed   = errors.New("mail sender is closed")
+	ErrNoRecipients   = errors.New("mail message has no recipients")
+	ErrNoStartTLS     = errors.New("smtp server does not support STARTTLS")
+	ErrUnknownTLSMode = errors.New("unknown smtp tls mode")
+	ErrAuthWithoutTLS = errors.New("smtp authentication requires tls mode implicit or starttls for non-localhost server")
+)
+
+// Message is a ready RFC 5322 message with envelope addresses
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Sender delivers mail messages
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+	// Check verifies that mail server is reachable
+	Check(ctx context.Context) error
+	Close() error
+}
+
+// TLSConfig for smtp server from cfg. Certificate is verified unless InsecureSkipVerify is set.
+func TLSConfig(cfg config.Smtp) *tls.Config {
+	return &tls.Config{
+		ServerName:         cfg.Host,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,264 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+)
+
+const (
+	defaultPoolSize    = 4
+	defaultIdleTimeout = 30 * time.Second
+	defaultTimeout     = 10 * time.Second
+)
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func (c *smtpConn) close() {
+	_ = c.client.Close()
+}
+
+// SmtpSender keeps pool of authenticated smtp connections.
+// Each connection is used by one Send at a time, idle connections are checked with NOOP before reuse
+// and replaced by new ones when server has dropped them.
+type SmtpSender struct {
+	cfg         config.Smtp
+	addr        string
+	tlsConfig   *tls.Config
+	auth        smtp.Auth
+	idleTimeout time.Duration
+	timeout     time.Duration
+
+	slots chan struct{}
+	idle  chan *smtpConn
+
+	lock   sync.Mutex
+	closed bool
+}
+
+// NewSmtpSender creates sender. Connections are opened lazily.
+// Authentication over TLS mode none is allowed only for localhost server.
+func NewSmtpSender(cfg config.Smtp) (*SmtpSender, error) {
+	switch cfg.TLSMode {
+	case "":
+		cfg.TLSMode = config.SmtpTLSImplicit
+	case config.SmtpTLSImplicit, config.SmtpTLSStartTLS, config.SmtpTLSNone:
+	default:
+		return nil, ErrUnknownTLSMode
+	}
+
+	poolSize := cfg.PoolSize
+	if poolSize <= 0 {
+		poolSize = defaultPoolSize
+	}
+	idleTimeout := time.Duration(cfg.IdleTimeout) * time.Second
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	var auth smtp.Auth
+	if cfg.User != "" {
+		// smtp.PlainAuth refuses to send credentials over unencrypted connection to other hosts
+		if cfg.TLSMode == config.SmtpTLSNone && !isLocalhost(cfg.Host) {
+			return nil, ErrAuthWithoutTLS
+		}
+		auth = smtp.PlainAuth(cfg.NickName, cfg.User, cfg.Password, cfg.Host)
+	}
+
+	return &SmtpSender{
+		cfg:         cfg,
+		addr:        net.JoinHostPort(cfg.Host, cfg.Port),
+		tlsConfig:   TLSConfig(cfg),
+		auth:        auth,
+		idleTimeout: idleTimeout,
+		timeout:     timeout,
+		slots:       make(chan struct{}, poolSize),
+		idle:        make(chan *smtpConn, poolSize),
+	}, nil
+}
+
+func isLocalhost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// Send message over pooled connection. Connection is dropped on any error.
+func (s *SmtpSender) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.slots }()
+
+	c, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	_ = c.conn.SetDeadline(time.Now().Add(s.timeout))
+	if err = send(c.client, msg); err != nil {
+		c.close()
+		return err
+	}
+
+	c.lastUsed = time.Now()
+	s.put(c)
+	return nil
+}
+
+func send(client *smtp.Client, msg Message) error {
+	if err := client.Mail(msg.From); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(msg.Data); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// get returns alive idle connection or dials new one
+func (s *SmtpSender) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		select {
+		case c := <-s.idle:
+			if time.Since(c.lastUsed) < s.idleTimeout {
+				_ = c.conn.SetDeadline(time.Now().Add(s.timeout))
+				if err := c.client.Noop(); err == nil {
+					return c, nil
+				}
+			}
+			c.close()
+		default:
+			return s.dial(ctx)
+		}
+	}
+}
+
+func (s *SmtpSender) put(c *smtpConn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		_ = c.client.Quit()
+		return
+	}
+	select {
+	case s.idle <- c:
+	default:
+		_ = c.client.Quit()
+	}
+}
+
+// dial opens connection according to TLS mode and authenticates
+func (s *SmtpSender) dial(ctx context.Context) (*smtpConn, error) {
+	s.lock.Lock()
+	closed := s.closed
+	s.lock.Unlock()
+	if closed {
+		return nil, ErrSenderClosed
+	}
+
+	dialer := &net.Dialer{Timeout: s.timeout}
+	var conn net.Conn
+	var err error
+	if s.cfg.TLSMode == config.SmtpTLSImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", s.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.cfg.TLSMode == config.SmtpTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, ErrNoStartTLS
+		}
+		if err = client.StartTLS(s.tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	if s.auth != nil {
+		if err = client.Auth(s.auth); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client, lastUsed: time.Now()}, nil
+}
+
+// Check dials separate connection and sends NOOP
+func (s *SmtpSender) Check(ctx context.Context) error {
+	c, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+	}
+	if err = c.client.Noop(); err != nil {
+		return err
+	}
+	return c.client.Quit()
+}
+
+// Close quits idle connections. Connections in use are closed when returned.
+func (s *SmtpSender) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	for {
+		select {
+		case c := <-s.idle:
+			_ = c.client.Quit()
+		default:
+			return nil
+		}
+	}
+}
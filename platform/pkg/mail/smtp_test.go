@@ -0,0 +1,277 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+)
+
+// fakeSmtpServer accepts plain smtp connections and counts what it has received
+type fakeSmtpServer struct {
+	ln net.Listener
+
+	lock     sync.Mutex
+	conns    int
+	quits    int
+	closed   int
+	messages int
+	// NOOP is answered with 421 and connection is dropped
+	failNoop bool
+	// Answer on message data is delayed until channel is closed
+	holdData chan struct{}
+}
+
+func newFakeSmtpServer(t *testing.T) *fakeSmtpServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSmtpServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			server.update(func() { server.conns++ })
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSmtpServer) update(f func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f()
+}
+
+func (s *fakeSmtpServer) stats() (conns int, quits int, closed int, messages int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.conns, s.quits, s.closed, s.messages
+}
+
+func (s *fakeSmtpServer) serve(conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.update(func() { s.closed++ })
+	}()
+
+	reader := bufio.NewReader(conn)
+	write := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	write("220 localhost fake smtp")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+
+		switch command {
+		case "EHLO", "HELO":
+			write("250 localhost")
+		case "MAIL", "RCPT", "RSET":
+			write("250 OK")
+		case "NOOP":
+			s.lock.Lock()
+			fail := s.failNoop
+			s.lock.Unlock()
+			if fail {
+				write("421 closing connection")
+				return
+			}
+			write("250 OK")
+		case "DATA":
+			write("354 send data")
+			for {
+				data, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if data == ".\r\n" {
+					break
+				}
+			}
+			s.lock.Lock()
+			hold := s.holdData
+			s.lock.Unlock()
+			if hold != nil {
+				<-hold
+			}
+			s.update(func() { s.messages++ })
+			write("250 queued")
+		case "QUIT":
+			s.update(func() { s.quits++ })
+			write("221 bye")
+			return
+		default:
+			write("502 unknown command")
+		}
+	}
+}
+
+func newTestSmtpSender(t *testing.T, server *fakeSmtpServer) *SmtpSender {
+	host, port, _ := net.SplitHostPort(server.ln.Addr().String())
+	sender, err := NewSmtpSender(config.Smtp{
+		Host:     host,
+		Port:     port,
+		TLSMode:  config.SmtpTLSNone,
+		PoolSize: 1,
+		Timeout:  5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sender
+}
+
+// waitFor polls condition, server counts change asynchronously to client calls
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition is not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+var testMessage = Message{
+	From: "bank@example.com",
+	To:   []string{"user@example.com"},
+	Data: []byte("Subject: test\r\n\r\nbody\r\n"),
+}
+
+func TestNewSmtpSender(t *testing.T) {
+	if _, err := NewSmtpSender(config.Smtp{Host: "smtp.example.com", TLSMode: "ssl"}); !errors.Is(err, ErrUnknownTLSMode) {
+		t.Fatalf("unknown tls mode error = %v", err)
+	}
+
+	if _, err := NewSmtpSender(config.Smtp{Host: "smtp.example.com", TLSMode: config.SmtpTLSNone, User: "user"}); !errors.Is(err, ErrAuthWithoutTLS) {
+		t.Fatalf("auth without tls error = %v", err)
+	}
+
+	if _, err := NewSmtpSender(config.Smtp{Host: "localhost", TLSMode: config.SmtpTLSNone, User: "user"}); err != nil {
+		t.Fatalf("auth without tls to localhost error = %v", err)
+	}
+
+	sender, err := NewSmtpSender(config.Smtp{Host: "smtp.example.com", User: "user"})
+	if err != nil || sender.cfg.TLSMode != config.SmtpTLSImplicit {
+		t.Fatalf("default tls mode = %v, error = %v", sender, err)
+	}
+}
+
+func TestSmtpSender_PoolReuse(t *testing.T) {
+	server := newFakeSmtpServer(t)
+	sender := newTestSmtpSender(t, server)
+	defer sender.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := sender.Send(context.Background(), testMessage); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conns, _, _, messages := server.stats()
+	if conns != 1 || messages != 3 {
+		t.Fatalf("connections = %d, messages = %d, want 1 connection for 3 messages", conns, messages)
+	}
+}
+
+func TestSmtpSender_NoopFailureReconnect(t *testing.T) {
+	server := newFakeSmtpServer(t)
+	sender := newTestSmtpSender(t, server)
+	defer sender.Close()
+
+	if err := sender.Send(context.Background(), testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	server.update(func() { server.failNoop = true })
+	if err := sender.Send(context.Background(), testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	conns, _, _, messages := server.stats()
+	if conns != 2 || messages != 2 {
+		t.Fatalf("connections = %d, messages = %d, want dropped connection replaced", conns, messages)
+	}
+}
+
+func TestSmtpSender_IdleExpiry(t *testing.T) {
+	server := newFakeSmtpServer(t)
+	sender := newTestSmtpSender(t, server)
+	sender.idleTimeout = 10 * time.Millisecond
+	defer sender.Close()
+
+	if err := sender.Send(context.Background(), testMessage); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(2 * sender.idleTimeout)
+	if err := sender.Send(context.Background(), testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool {
+		_, _, closed, _ := server.stats()
+		return closed == 1
+	})
+	conns, _, _, _ := server.stats()
+	if conns != 2 {
+		t.Fatalf("connections = %d, expired connection must be replaced", conns)
+	}
+}
+
+func TestSmtpSender_CloseWithConnectionInUse(t *testing.T) {
+	server := newFakeSmtpServer(t)
+	hold := make(chan struct{})
+	server.update(func() { server.holdData = hold })
+	sender := newTestSmtpSender(t, server)
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- sender.Send(context.Background(), testMessage)
+	}()
+
+	// Connection is taken by Send, so Close has no idle connections to quit
+	waitFor(t, func() bool {
+		conns, _, _, _ := server.stats()
+		return conns == 1
+	})
+	if err := sender.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, quits, _, _ := server.stats(); quits != 0 {
+		t.Fatalf("connection in use must not be quit by Close")
+	}
+
+	close(hold)
+	if err := <-sent; err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool {
+		_, quits, _, _ := server.stats()
+		return quits == 1
+	})
+	if len(sender.idle) != 0 {
+		t.Fatalf("connection returned after Close must not be pooled")
+	}
+
+	if err := sender.Send(context.Background(), testMessage); !errors.Is(err, ErrSenderClosed) {
+		t.Fatalf("send after close error = %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package metric
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Terminal status label values
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Saga orchestration metrics
+type SagaMetrics interface {
+	IncSaga(sagaType, status string)
+	ObserveSagaDuration(sagaType, status string, seconds float64)
+	IncEvent(eventType, status string)
+	IncCompensation(sagaType, eventType string)
+}
+
+// Kafka client metrics
+type KafkaMetrics interface {
+	IncKafkaConsumeErrors(topic string)
+	IncKafkaProduceErrors(topic string)
+	SetKafkaConsumerLag(topic string, partition int32, lag int64)
+}
+
+// Notification delivery metrics
+type NotificationMetrics interface {
+	IncNotificationSent(channel string)
+	IncNotificationFailed(channel string)
+}
+
+// Business Metrics interface
+type BusinessMetrics interface {
+	SagaMetrics
+	KafkaMetrics
+	NotificationMetrics
+}
+
+// Prometheus Business Metrics struct
+type PrometheusBusinessMetrics struct {
+	Sagas               *prometheus.CounterVec
+	SagaDuration        *prometheus.HistogramVec
+	Events              *prometheus.CounterVec
+	Compensations       *prometheus.CounterVec
+	KafkaConsumeErrors  *prometheus.CounterVec
+	KafkaProduceErrors  *prometheus.CounterVec
+	KafkaConsumerLag    *prometheus.GaugeVec
+	NotificationsSent   *prometheus.CounterVec
+	NotificationsFailed *prometheus.CounterVec
+}
+
+// Create business metrics with name prefix. Metrics are exposed by the server started in CreateMetrics
+func CreateBusinessMetrics(name string) (BusinessMetrics, error) {
+	metr := NewBusinessMetrics(name)
+
+	collectors := []prometheus.Collector{
+		metr.Sagas,
+		metr.SagaDuration,
+		metr.Events,
+		metr.Compensations,
+		metr.KafkaConsumeErrors,
+		metr.KafkaProduceErrors,
+		metr.KafkaConsumerLag,
+		metr.NotificationsSent,
+		metr.NotificationsFailed,
+	}
+	for _, collector := range collectors {
+		if err := prometheus.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return metr, nil
+}
+
+// NewBusinessMetrics creates business metrics with name prefix without registering them
+func NewBusinessMetrics(name string) *PrometheusBusinessMetrics {
+	var metr PrometheusBusinessMetrics
+
+	metr.Sagas = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_sagas_total",
+			Help: "Finished sagas by type and terminal status",
+		},
+		[]string{"type", "status"},
+	)
+	metr.SagaDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    name + "_saga_duration_seconds",
+			Help:    "Time from operation start till saga terminal status",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+		},
+		[]string{"type", "status"},
+	)
+	metr.Events = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_events_total",
+			Help: "Processed events by type and terminal status",
+		},
+		[]string{"type", "status"},
+	)
+	metr.Compensations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_compensations_total",
+			Help: "Compensating events created by saga and reverted event type",
+		},
+		[]string{"saga_type", "event_type"},
+	)
+	metr.KafkaConsumeErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_kafka_consume_errors_total",
+			Help: "Errors on handling consumed kafka messages",
+		},
+		[]string{"topic"},
+	)
+	metr.KafkaProduceErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_kafka_produce_errors_total",
+			Help: "Errors on producing kafka messages",
+		},
+		[]string{"topic"},
+	)
+	metr.KafkaConsumerLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name + "_kafka_consumer_lag",
+			Help: "Messages left in partition after the last consumed one",
+		},
+		[]string{"topic", "partition"},
+	)
+	metr.NotificationsSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_notifications_sent_total",
+			Help: "Delivered notifications by channel",
+		},
+		[]string{"channel"},
+	)
+	metr.NotificationsFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name + "_notifications_failed_total",
+			Help: "Failed notifications by channel",
+		},
+		[]string{"channel"},
+	)
+
+	return &metr
+}
+
+// IncSaga
+func (metr *PrometheusBusinessMetrics) IncSaga(sagaType, status string) {
+	metr.Sagas.WithLabelValues(sagaType, status).Inc()
+}
+
+// Observe saga duration
+func (metr *PrometheusBusinessMetrics) ObserveSagaDuration(sagaType, status string, seconds float64) {
+	metr.SagaDuration.WithLabelValues(sagaType, status).Observe(seconds)
+}
+
+// IncEvent
+func (metr *PrometheusBusinessMetrics) IncEvent(eventType, status string) {
+	metr.Events.WithLabelValues(eventType, status).Inc()
+}
+
+// IncCompensation
+func (metr *PrometheusBusinessMetrics) IncCompensation(sagaType, eventType string) {
+	metr.Compensations.WithLabelValues(sagaType, eventType).Inc()
+}
+
+// IncKafkaConsumeErrors
+func (metr *PrometheusBusinessMetrics) IncKafkaConsumeErrors(topic string) {
+	metr.KafkaConsumeErrors.WithLabelValues(topic).Inc()
+}
+
+// IncKafkaProduceErrors
+func (metr *PrometheusBusinessMetrics) IncKafkaProduceErrors(topic string) {
+	metr.KafkaProduceErrors.WithLabelValues(topic).Inc()
+}
+
+// Set consumer lag of partition
+func (metr *PrometheusBusinessMetrics) SetKafkaConsumerLag(topic string, partition int32, lag int64) {
+	metr.KafkaConsumerLag.WithLabelValues(topic, strconv.Itoa(int(partition))).Set(float64(lag))
+}
+
+// IncNotificationSent
+func (metr *PrometheusBusinessMetrics) IncNotificationSent(channel string) {
+	metr.NotificationsSent.WithLabelValues(channel).Inc()
+}
+
+// IncNotificationFailed
+func (metr *PrometheusBusinessMetrics) IncNotificationFailed(channel string) {
+	metr.NotificationsFailed.WithLabelValues(channel).Inc()
+}
@@ -0,0 +1,87 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCreateBusinessMetrics(t *testing.T) {
+	if _, err := CreateBusinessMetrics("business_test"); err != nil {
+		t.Fatal(err)
+	}
+	// Metrics with the same prefix are already registered
+	if _, err := CreateBusinessMetrics("business_test"); err == nil {
+		t.Fatal("duplicate registration error is expected")
+	}
+}
+
+func TestPrometheusBusinessMetrics(t *testing.T) {
+	metr := NewBusinessMetrics("test")
+
+	metr.IncSaga("add_user", "completed")
+	metr.IncSaga("add_user", "completed")
+	metr.IncSaga("add_user", "error")
+	metr.IncEvent("create_user", "completed")
+	metr.IncCompensation("add_user", "create_user")
+	metr.IncKafkaConsumeErrors("users_res")
+	metr.IncKafkaProduceErrors("users_cons")
+	metr.SetKafkaConsumerLag("users_res", 2, 10)
+	metr.SetKafkaConsumerLag("users_res", 2, 4)
+	metr.IncNotificationSent("email")
+	metr.IncNotificationFailed("sms")
+
+	counters := map[string]struct {
+		collector prometheus.Collector
+		want      float64
+	}{
+		"saga completed":   {metr.Sagas.WithLabelValues("add_user", "completed"), 2},
+		"saga error":       {metr.Sagas.WithLabelValues("add_user", "error"), 1},
+		"event":            {metr.Events.WithLabelValues("create_user", "completed"), 1},
+		"compensation":     {metr.Compensations.WithLabelValues("add_user", "create_user"), 1},
+		"consume errors":   {metr.KafkaConsumeErrors.WithLabelValues("users_res"), 1},
+		"produce errors":   {metr.KafkaProduceErrors.WithLabelValues("users_cons"), 1},
+		"consumer lag":     {metr.KafkaConsumerLag.WithLabelValues("users_res", "2"), 4},
+		"notification":     {metr.NotificationsSent.WithLabelValues("email"), 1},
+		"notification err": {metr.NotificationsFailed.WithLabelValues("sms"), 1},
+	}
+	for name, test := range counters {
+		if got := testutil.ToFloat64(test.collector); got != test.want {
+			t.Errorf("%s = %v, want %v", name, got, test.want)
+		}
+	}
+}
+
+func TestPrometheusBusinessMetrics_SagaDuration(t *testing.T) {
+	metr := NewBusinessMetrics("test")
+
+	metr.ObserveSagaDuration("add_user", "completed", 0.3)
+	metr.ObserveSagaDuration("add_user", "completed", 7)
+	metr.ObserveSagaDuration("add_user", "error", 1)
+
+	if count := testutil.CollectAndCount(metr.SagaDuration); count != 2 {
+		t.Fatalf("duration series = %d", count)
+	}
+
+	var m dto.Metric
+	if err := metr.SagaDuration.WithLabelValues("add_user", "completed").(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	histogram := m.GetHistogram()
+	if histogram.GetSampleCount() != 2 || histogram.GetSampleSum() != 7.3 {
+		t.Fatalf("count = %d, sum = %v", histogram.GetSampleCount(), histogram.GetSampleSum())
+	}
+	for _, bucket := range histogram.GetBucket() {
+		want := uint64(0)
+		if bucket.GetUpperBound() >= 7 {
+			want = 2
+		} else if bucket.GetUpperBound() >= 0.3 {
+			want = 1
+		}
+		if bucket.GetCumulativeCount() != want {
+			t.Errorf("bucket %v = %d, want %d", bucket.GetUpperBound(), bucket.GetCumulativeCount(), want)
+		}
+	}
+}
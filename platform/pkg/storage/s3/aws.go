@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"context"
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
@@ -18,3 +19,15 @@ func NewAWSClient(endpoint string, accessKeyID string, secretAccessKey string, u
 
 	return minioClient, nil
 }
+
+// Create bucket if it doesn't exist yet
+func MakeBucket(ctx context.Context, client *minio.Client, bucket string) error {
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+}
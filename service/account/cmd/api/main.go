@@ -1,23 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/db/postgres"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/account/internal/server"
-	"github.com/GCFactory/dbo-system/service/account/pkg/kafka"
 	"github.com/golang-migrate/migrate/v4"
 	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/opentracing/opentracing-go"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
 	"log"
 	"os"
-	"strings"
 )
 
 //	@Title			Registration Service
@@ -79,47 +77,40 @@ func main() {
 		appLogger.Fatalf("Error on initiate migration: %s", err)
 	}
 
+	// Failed migration leaves database dirty, service must not work with partly updated schema
 	status := migration.Up()
-	if status != nil {
+	if status != nil && !errors.Is(status, migrate.ErrNoChange) {
+		appLogger.Fatalf("Migration error: %s", status)
+	} else if status != nil {
 		appLogger.Infof("Migration status: %s", status)
 	}
 	appLogger.Info("Migration completed")
 
-	jaegerCfgInstance := jaegercfg.Configuration{
-		ServiceName: cfg.Jaeger.ServiceName,
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans:           cfg.Jaeger.LogSpans,
-			LocalAgentHostPort: cfg.Jaeger.Host,
-		},
-	}
-
-	tracer, closer, err := jaegerCfgInstance.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
-	)
+	tp, err := tracing.NewTracerProvider(context.Background(), cfg)
 	if err != nil {
-		log.Fatal("cannot create tracer", err)
+		log.Fatal("cannot create tracer provider", err)
 	}
-	appLogger.Info("Jaeger connected")
-
-	opentracing.SetGlobalTracer(tracer)
-	defer closer.Close()
-	appLogger.Info("Opentracing connected")
+	defer tp.Shutdown(context.Background())
+	appLogger.Info("OpenTelemetry tracing connected")
 
-	kc, err := kafka.NewKafkaConsumer(strings.Split(cfg.KafkaConsumer.Brokers, ";"), cfg.KafkaConsumer.GroupID)
+	kc, err := kafka.NewKafkaConsumer(cfg.KafkaConsumer, appLogger)
 	if err != nil {
 		appLogger.Fatal(err)
 	}
 	appLogger.Infof("Kafka consumer with group '%s' connected", cfg.KafkaConsumer.GroupID)
 
-	kp := kafka.NewKafkaProducer(cfg, appLogger)
+	kp := kafka.NewKafkaProducer(cfg.KafkaProducer, appLogger)
+
+	businessMetrics, err := metric.CreateBusinessMetrics(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateBusinessMetrics Error: %s", err)
+	}
+	kc.SetMetrics(businessMetrics)
+	kp.SetMetrics(businessMetrics)
+	kc.SetDeadLetterProducer(kp)
 
 	//Run server
-	s := server.NewServer(cfg, kc, kp, psqlDB, appLogger)
+	s := server.NewServer(cfg, kc, kp, psqlDB, businessMetrics, appLogger)
 	if err = s.Run(); err != nil {
 		appLogger.Fatal(err)
 	}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v3.21.12
 // source: account/account.proto
 
@@ -27,6 +27,7 @@ type OperationDetails struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	AccUuid        string                 `protobuf:"bytes,1,opt,name=acc_uuid,json=accUuid,proto3" json:"acc_uuid,omitempty"`                        //  UUID счёта
 	AdditionalData float32                `protobuf:"fixed32,2,opt,name=additional_data,json=additionalData,proto3" json:"additional_data,omitempty"` //  Дополнительные данные
+	UserUuid       string                 `protobuf:"bytes,3,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`                     //  UUID пользователя, выполняющего снятие
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -75,6 +76,483 @@ func (x *OperationDetails) GetAdditionalData() float32 {
 	return 0
 }
 
+func (x *OperationDetails) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+// Фильтр истории движений по счёту, пустые поля не фильтруют
+type HistoryFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccUuid       string                 `protobuf:"bytes,1,opt,name=acc_uuid,json=accUuid,proto3" json:"acc_uuid,omitempty"`                   //  UUID счёта
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`                                        //  Начало периода, 02-01-2006
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`                                            //  Конец периода включительно, 02-01-2006
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`                              //  deposit или withdrawal
+	MinAmount     *float64               `protobuf:"fixed64,5,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`     //  Минимальная сумма движения по модулю
+	MaxAmount     *float64               `protobuf:"fixed64,6,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`     //  Максимальная сумма движения по модулю
+	OperationUuid string                 `protobuf:"bytes,7,opt,name=operation_uuid,json=operationUuid,proto3" json:"operation_uuid,omitempty"` //  UUID операции, изменившей счёт
+	Cursor        string                 `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`                                    //  Курсор следующей страницы
+	Limit         uint32                 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`                                     //  Размер страницы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryFilter) Reset() {
+	*x = HistoryFilter{}
+	mi := &file_account_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryFilter) ProtoMessage() {}
+
+func (x *HistoryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryFilter.ProtoReflect.Descriptor instead.
+func (*HistoryFilter) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HistoryFilter) GetAccUuid() string {
+	if x != nil {
+		return x.AccUuid
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetMinAmount() float64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *HistoryFilter) GetMaxAmount() float64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+func (x *HistoryFilter) GetOperationUuid() string {
+	if x != nil {
+		return x.OperationUuid
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *HistoryFilter) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Движение по счёту
+type Movement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MovementUuid  string                 `protobuf:"bytes,1,opt,name=movement_uuid,json=movementUuid,proto3" json:"movement_uuid,omitempty"`
+	MovementType  string                 `protobuf:"bytes,2,opt,name=movement_type,json=movementType,proto3" json:"movement_type,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`   //  Положительна для зачислений, отрицательна для списаний
+	Balance       float64                `protobuf:"fixed64,4,opt,name=balance,proto3" json:"balance,omitempty"` //  Остаток после движения
+	OperationUuid string                 `protobuf:"bytes,5,opt,name=operation_uuid,json=operationUuid,proto3" json:"operation_uuid,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` //  Unix time в секундах
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Movement) Reset() {
+	*x = Movement{}
+	mi := &file_account_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Movement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Movement) ProtoMessage() {}
+
+func (x *Movement) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Movement.ProtoReflect.Descriptor instead.
+func (*Movement) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Movement) GetMovementUuid() string {
+	if x != nil {
+		return x.MovementUuid
+	}
+	return ""
+}
+
+func (x *Movement) GetMovementType() string {
+	if x != nil {
+		return x.MovementType
+	}
+	return ""
+}
+
+func (x *Movement) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Movement) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Movement) GetOperationUuid() string {
+	if x != nil {
+		return x.OperationUuid
+	}
+	return ""
+}
+
+func (x *Movement) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// Страница истории движений, новые движения первые
+type AccountHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movements     []*Movement            `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` //  Пуст на последней странице
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountHistory) Reset() {
+	*x = AccountHistory{}
+	mi := &file_account_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountHistory) ProtoMessage() {}
+
+func (x *AccountHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountHistory.ProtoReflect.Descriptor instead.
+func (*AccountHistory) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AccountHistory) GetMovements() []*Movement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *AccountHistory) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Данные операции с холдом
+type HoldDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccUuid       string                 `protobuf:"bytes,1,opt,name=acc_uuid,json=accUuid,proto3" json:"acc_uuid,omitempty"`        //  UUID счёта
+	HoldUuid      string                 `protobuf:"bytes,2,opt,name=hold_uuid,json=holdUuid,proto3" json:"hold_uuid,omitempty"`     //  UUID холда, пуст при создании
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`                       //  Сумма холда или списания, 0 при списании всего холда
+	ExpiresIn     uint64                 `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` //  Время жизни холда в секундах, 0 - по умолчанию
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldDetails) Reset() {
+	*x = HoldDetails{}
+	mi := &file_account_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldDetails) ProtoMessage() {}
+
+func (x *HoldDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldDetails.ProtoReflect.Descriptor instead.
+func (*HoldDetails) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HoldDetails) GetAccUuid() string {
+	if x != nil {
+		return x.AccUuid
+	}
+	return ""
+}
+
+func (x *HoldDetails) GetHoldUuid() string {
+	if x != nil {
+		return x.HoldUuid
+	}
+	return ""
+}
+
+func (x *HoldDetails) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *HoldDetails) GetExpiresIn() uint64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// Холд средств на счёте
+type Hold struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	HoldUuid       string                 `protobuf:"bytes,1,opt,name=hold_uuid,json=holdUuid,proto3" json:"hold_uuid,omitempty"`
+	AccUuid        string                 `protobuf:"bytes,2,opt,name=acc_uuid,json=accUuid,proto3" json:"acc_uuid,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                         //  active, captured, released или expired
+	Amount         float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`                                       //  Удерживаемая сумма
+	CapturedAmount float64                `protobuf:"fixed64,5,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"` //  Списанная сумма
+	ExpiresAt      int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                 //  Unix time в секундах
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_account_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Hold) GetHoldUuid() string {
+	if x != nil {
+		return x.HoldUuid
+	}
+	return ""
+}
+
+func (x *Hold) GetAccUuid() string {
+	if x != nil {
+		return x.AccUuid
+	}
+	return ""
+}
+
+func (x *Hold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Hold) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Hold) GetCapturedAmount() float64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Hold) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Данные перевода между счетами, сумма зачисления пересчитывается по курсу при разных валютах счетов
+type TransferDetails struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccUuid   string                 `protobuf:"bytes,1,opt,name=from_acc_uuid,json=fromAccUuid,proto3" json:"from_acc_uuid,omitempty"` //  UUID счёта списания
+	ToAccUuid     string                 `protobuf:"bytes,2,opt,name=to_acc_uuid,json=toAccUuid,proto3" json:"to_acc_uuid,omitempty"`       //  UUID счёта зачисления
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`                              //  Сумма списания в валюте счёта списания
+	UserUuid      string                 `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`            //  UUID пользователя, выполняющего перевод
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferDetails) Reset() {
+	*x = TransferDetails{}
+	mi := &file_account_account_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferDetails) ProtoMessage() {}
+
+func (x *TransferDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_account_account_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferDetails.ProtoReflect.Descriptor instead.
+func (*TransferDetails) Descriptor() ([]byte, []int) {
+	return file_account_account_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TransferDetails) GetFromAccUuid() string {
+	if x != nil {
+		return x.FromAccUuid
+	}
+	return ""
+}
+
+func (x *TransferDetails) GetToAccUuid() string {
+	if x != nil {
+		return x.ToAccUuid
+	}
+	return ""
+}
+
+func (x *TransferDetails) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferDetails) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
 // Данные event-а
 type EventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -85,6 +563,9 @@ type EventData struct {
 	//
 	//	*EventData_AccountData
 	//	*EventData_AdditionalInfo
+	//	*EventData_HistoryFilter
+	//	*EventData_HoldDetails
+	//	*EventData_TransferDetails
 	Data          isEventData_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -92,7 +573,7 @@ type EventData struct {
 
 func (x *EventData) Reset() {
 	*x = EventData{}
-	mi := &file_account_account_proto_msgTypes[1]
+	mi := &file_account_account_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +585,7 @@ func (x *EventData) String() string {
 func (*EventData) ProtoMessage() {}
 
 func (x *EventData) ProtoReflect() protoreflect.Message {
-	mi := &file_account_account_proto_msgTypes[1]
+	mi := &file_account_account_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +598,7 @@ func (x *EventData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventData.ProtoReflect.Descriptor instead.
 func (*EventData) Descriptor() ([]byte, []int) {
-	return file_account_account_proto_rawDescGZIP(), []int{1}
+	return file_account_account_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *EventData) GetSagaUuid() string {
@@ -166,6 +647,33 @@ func (x *EventData) GetAdditionalInfo() *OperationDetails {
 	return nil
 }
 
+func (x *EventData) GetHistoryFilter() *HistoryFilter {
+	if x != nil {
+		if x, ok := x.Data.(*EventData_HistoryFilter); ok {
+			return x.HistoryFilter
+		}
+	}
+	return nil
+}
+
+func (x *EventData) GetHoldDetails() *HoldDetails {
+	if x != nil {
+		if x, ok := x.Data.(*EventData_HoldDetails); ok {
+			return x.HoldDetails
+		}
+	}
+	return nil
+}
+
+func (x *EventData) GetTransferDetails() *TransferDetails {
+	if x != nil {
+		if x, ok := x.Data.(*EventData_TransferDetails); ok {
+			return x.TransferDetails
+		}
+	}
+	return nil
+}
+
 type isEventData_Data interface {
 	isEventData_Data()
 }
@@ -178,10 +686,28 @@ type EventData_AdditionalInfo struct {
 	AdditionalInfo *OperationDetails `protobuf:"bytes,5,opt,name=additional_info,json=additionalInfo,proto3,oneof"` //  Дополнительная информация по операции
 }
 
+type EventData_HistoryFilter struct {
+	HistoryFilter *HistoryFilter `protobuf:"bytes,6,opt,name=history_filter,json=historyFilter,proto3,oneof"` //  Фильтр истории движений
+}
+
+type EventData_HoldDetails struct {
+	HoldDetails *HoldDetails `protobuf:"bytes,7,opt,name=hold_details,json=holdDetails,proto3,oneof"` //  Данные операции с холдом
+}
+
+type EventData_TransferDetails struct {
+	TransferDetails *TransferDetails `protobuf:"bytes,8,opt,name=transfer_details,json=transferDetails,proto3,oneof"` //  Данные перевода между счетами
+}
+
 func (*EventData_AccountData) isEventData_Data() {}
 
 func (*EventData_AdditionalInfo) isEventData_Data() {}
 
+func (*EventData_HistoryFilter) isEventData_Data() {}
+
+func (*EventData_HoldDetails) isEventData_Data() {}
+
+func (*EventData_TransferDetails) isEventData_Data() {}
+
 // Результат event-а
 type EventStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -192,6 +718,8 @@ type EventStatus struct {
 	//
 	//	*EventStatus_Info
 	//	*EventStatus_AccData
+	//	*EventStatus_AccHistory
+	//	*EventStatus_Hold
 	Result        isEventStatus_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -199,7 +727,7 @@ type EventStatus struct {
 
 func (x *EventStatus) Reset() {
 	*x = EventStatus{}
-	mi := &file_account_account_proto_msgTypes[2]
+	mi := &file_account_account_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -211,7 +739,7 @@ func (x *EventStatus) String() string {
 func (*EventStatus) ProtoMessage() {}
 
 func (x *EventStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_account_account_proto_msgTypes[2]
+	mi := &file_account_account_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +752,7 @@ func (x *EventStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventStatus.ProtoReflect.Descriptor instead.
 func (*EventStatus) Descriptor() ([]byte, []int) {
-	return file_account_account_proto_rawDescGZIP(), []int{2}
+	return file_account_account_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *EventStatus) GetSagaUuid() string {
@@ -273,6 +801,24 @@ func (x *EventStatus) GetAccData() *platform.FullAccountData {
 	return nil
 }
 
+func (x *EventStatus) GetAccHistory() *AccountHistory {
+	if x != nil {
+		if x, ok := x.Result.(*EventStatus_AccHistory); ok {
+			return x.AccHistory
+		}
+	}
+	return nil
+}
+
+func (x *EventStatus) GetHold() *Hold {
+	if x != nil {
+		if x, ok := x.Result.(*EventStatus_Hold); ok {
+			return x.Hold
+		}
+	}
+	return nil
+}
+
 type isEventStatus_Result interface {
 	isEventStatus_Result()
 }
@@ -285,10 +831,22 @@ type EventStatus_AccData struct {
 	AccData *platform.FullAccountData `protobuf:"bytes,5,opt,name=acc_data,json=accData,proto3,oneof"` //  Данные счета
 }
 
+type EventStatus_AccHistory struct {
+	AccHistory *AccountHistory `protobuf:"bytes,6,opt,name=acc_history,json=accHistory,proto3,oneof"` //  История движений по счёту
+}
+
+type EventStatus_Hold struct {
+	Hold *Hold `protobuf:"bytes,7,opt,name=hold,proto3,oneof"` //  Созданный холд
+}
+
 func (*EventStatus_Info) isEventStatus_Result() {}
 
 func (*EventStatus_AccData) isEventStatus_Result() {}
 
+func (*EventStatus_AccHistory) isEventStatus_Result() {}
+
+func (*EventStatus_Hold) isEventStatus_Result() {}
+
 type EventError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SagaUuid      string                 `protobuf:"bytes,1,opt,name=saga_uuid,json=sagaUuid,proto3" json:"saga_uuid,omitempty"`                //  UUID sag-и
@@ -302,7 +860,7 @@ type EventError struct {
 
 func (x *EventError) Reset() {
 	*x = EventError{}
-	mi := &file_account_account_proto_msgTypes[3]
+	mi := &file_account_account_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -314,7 +872,7 @@ func (x *EventError) String() string {
 func (*EventError) ProtoMessage() {}
 
 func (x *EventError) ProtoReflect() protoreflect.Message {
-	mi := &file_account_account_proto_msgTypes[3]
+	mi := &file_account_account_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +885,7 @@ func (x *EventError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EventError.ProtoReflect.Descriptor instead.
 func (*EventError) Descriptor() ([]byte, []int) {
-	return file_account_account_proto_rawDescGZIP(), []int{3}
+	return file_account_account_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *EventError) GetSagaUuid() string {
@@ -367,58 +925,88 @@ func (x *EventError) GetInfo() string {
 
 var File_account_account_proto protoreflect.FileDescriptor
 
-var file_account_account_proto_rawDesc = string([]byte{
-	0x0a, 0x15, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x1a, 0x17, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x56, 0x0a, 0x10, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x19, 0x0a,
-	0x08, 0x61, 0x63, 0x63, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x61, 0x63, 0x63, 0x55, 0x75, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x64, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x61, 0x74,
-	0x61, 0x22, 0xfb, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x1b, 0x0a, 0x09, 0x73, 0x61, 0x67, 0x61, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x73, 0x61, 0x67, 0x61, 0x55, 0x75, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x75, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x44, 0x0a, 0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
-	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0xc8, 0x01, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1b, 0x0a, 0x09, 0x73, 0x61, 0x67, 0x61, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x73, 0x61, 0x67, 0x61, 0x55, 0x75, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x75, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x36, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x6c, 0x61,
-	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x46, 0x75, 0x6c, 0x6c, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x07, 0x61, 0x63, 0x63, 0x44, 0x61, 0x74, 0x61,
-	0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x9b, 0x01, 0x0a, 0x0a, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x61, 0x67,
-	0x61, 0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x61,
-	0x67, 0x61, 0x55, 0x75, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
-	0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x55, 0x75, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x42, 0x15, 0x5a, 0x13, 0x2e, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_account_account_proto_rawDesc = "" +
+	"\n" +
+	"\x15account/account.proto\x12\aaccount\x1a\x17platform/platform.proto\"s\n" +
+	"\x10OperationDetails\x12\x19\n" +
+	"\bacc_uuid\x18\x01 \x01(\tR\aaccUuid\x12'\n" +
+	"\x0fadditional_data\x18\x02 \x01(\x02R\x0eadditionalData\x12\x1b\n" +
+	"\tuser_uuid\x18\x03 \x01(\tR\buserUuid\"\xa7\x02\n" +
+	"\rHistoryFilter\x12\x19\n" +
+	"\bacc_uuid\x18\x01 \x01(\tR\aaccUuid\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\"\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\x01H\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\x01H\x01R\tmaxAmount\x88\x01\x01\x12%\n" +
+	"\x0eoperation_uuid\x18\a \x01(\tR\roperationUuid\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\t \x01(\rR\x05limitB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amount\"\xcc\x01\n" +
+	"\bMovement\x12#\n" +
+	"\rmovement_uuid\x18\x01 \x01(\tR\fmovementUuid\x12#\n" +
+	"\rmovement_type\x18\x02 \x01(\tR\fmovementType\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x01R\abalance\x12%\n" +
+	"\x0eoperation_uuid\x18\x05 \x01(\tR\roperationUuid\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"b\n" +
+	"\x0eAccountHistory\x12/\n" +
+	"\tmovements\x18\x01 \x03(\v2\x11.account.MovementR\tmovements\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"|\n" +
+	"\vHoldDetails\x12\x19\n" +
+	"\bacc_uuid\x18\x01 \x01(\tR\aaccUuid\x12\x1b\n" +
+	"\thold_uuid\x18\x02 \x01(\tR\bholdUuid\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x04R\texpiresIn\"\xb6\x01\n" +
+	"\x04Hold\x12\x1b\n" +
+	"\thold_uuid\x18\x01 \x01(\tR\bholdUuid\x12\x19\n" +
+	"\bacc_uuid\x18\x02 \x01(\tR\aaccUuid\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12'\n" +
+	"\x0fcaptured_amount\x18\x05 \x01(\x01R\x0ecapturedAmount\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\"\x8a\x01\n" +
+	"\x0fTransferDetails\x12\"\n" +
+	"\rfrom_acc_uuid\x18\x01 \x01(\tR\vfromAccUuid\x12\x1e\n" +
+	"\vto_acc_uuid\x18\x02 \x01(\tR\ttoAccUuid\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\"\xbe\x03\n" +
+	"\tEventData\x12\x1b\n" +
+	"\tsaga_uuid\x18\x01 \x01(\tR\bsagaUuid\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x02 \x01(\tR\teventUuid\x12%\n" +
+	"\x0eoperation_name\x18\x03 \x01(\tR\roperationName\x12=\n" +
+	"\faccount_data\x18\x04 \x01(\v2\x18.platform.AccountDetailsH\x00R\vaccountData\x12D\n" +
+	"\x0fadditional_info\x18\x05 \x01(\v2\x19.account.OperationDetailsH\x00R\x0eadditionalInfo\x12?\n" +
+	"\x0ehistory_filter\x18\x06 \x01(\v2\x16.account.HistoryFilterH\x00R\rhistoryFilter\x129\n" +
+	"\fhold_details\x18\a \x01(\v2\x14.account.HoldDetailsH\x00R\vholdDetails\x12E\n" +
+	"\x10transfer_details\x18\b \x01(\v2\x18.account.TransferDetailsH\x00R\x0ftransferDetailsB\x06\n" +
+	"\x04data\"\xa9\x02\n" +
+	"\vEventStatus\x12\x1b\n" +
+	"\tsaga_uuid\x18\x01 \x01(\tR\bsagaUuid\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x02 \x01(\tR\teventUuid\x12%\n" +
+	"\x0eoperation_name\x18\x03 \x01(\tR\roperationName\x12\x14\n" +
+	"\x04info\x18\x04 \x01(\tH\x00R\x04info\x126\n" +
+	"\bacc_data\x18\x05 \x01(\v2\x19.platform.FullAccountDataH\x00R\aaccData\x12:\n" +
+	"\vacc_history\x18\x06 \x01(\v2\x17.account.AccountHistoryH\x00R\n" +
+	"accHistory\x12#\n" +
+	"\x04hold\x18\a \x01(\v2\r.account.HoldH\x00R\x04holdB\b\n" +
+	"\x06result\"\x9b\x01\n" +
+	"\n" +
+	"EventError\x12\x1b\n" +
+	"\tsaga_uuid\x18\x01 \x01(\tR\bsagaUuid\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x02 \x01(\tR\teventUuid\x12%\n" +
+	"\x0eoperation_name\x18\x03 \x01(\tR\roperationName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\rR\x06status\x12\x12\n" +
+	"\x04info\x18\x05 \x01(\tR\x04infoB\x15Z\x13./proto/api/accountb\x06proto3"
 
 var (
 	file_account_account_proto_rawDescOnce sync.Once
@@ -432,24 +1020,36 @@ func file_account_account_proto_rawDescGZIP() []byte {
 	return file_account_account_proto_rawDescData
 }
 
-var file_account_account_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_account_account_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_account_account_proto_goTypes = []any{
 	(*OperationDetails)(nil),         // 0: account.OperationDetails
-	(*EventData)(nil),                // 1: account.EventData
-	(*EventStatus)(nil),              // 2: account.EventStatus
-	(*EventError)(nil),               // 3: account.EventError
-	(*platform.AccountDetails)(nil),  // 4: platform.AccountDetails
-	(*platform.FullAccountData)(nil), // 5: platform.FullAccountData
+	(*HistoryFilter)(nil),            // 1: account.HistoryFilter
+	(*Movement)(nil),                 // 2: account.Movement
+	(*AccountHistory)(nil),           // 3: account.AccountHistory
+	(*HoldDetails)(nil),              // 4: account.HoldDetails
+	(*Hold)(nil),                     // 5: account.Hold
+	(*TransferDetails)(nil),          // 6: account.TransferDetails
+	(*EventData)(nil),                // 7: account.EventData
+	(*EventStatus)(nil),              // 8: account.EventStatus
+	(*EventError)(nil),               // 9: account.EventError
+	(*platform.AccountDetails)(nil),  // 10: platform.AccountDetails
+	(*platform.FullAccountData)(nil), // 11: platform.FullAccountData
 }
 var file_account_account_proto_depIdxs = []int32{
-	4, // 0: account.EventData.account_data:type_name -> platform.AccountDetails
-	0, // 1: account.EventData.additional_info:type_name -> account.OperationDetails
-	5, // 2: account.EventStatus.acc_data:type_name -> platform.FullAccountData
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2,  // 0: account.AccountHistory.movements:type_name -> account.Movement
+	10, // 1: account.EventData.account_data:type_name -> platform.AccountDetails
+	0,  // 2: account.EventData.additional_info:type_name -> account.OperationDetails
+	1,  // 3: account.EventData.history_filter:type_name -> account.HistoryFilter
+	4,  // 4: account.EventData.hold_details:type_name -> account.HoldDetails
+	6,  // 5: account.EventData.transfer_details:type_name -> account.TransferDetails
+	11, // 6: account.EventStatus.acc_data:type_name -> platform.FullAccountData
+	3,  // 7: account.EventStatus.acc_history:type_name -> account.AccountHistory
+	5,  // 8: account.EventStatus.hold:type_name -> account.Hold
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_account_account_proto_init() }
@@ -457,13 +1057,19 @@ func file_account_account_proto_init() {
 	if File_account_account_proto != nil {
 		return
 	}
-	file_account_account_proto_msgTypes[1].OneofWrappers = []any{
+	file_account_account_proto_msgTypes[1].OneofWrappers = []any{}
+	file_account_account_proto_msgTypes[7].OneofWrappers = []any{
 		(*EventData_AccountData)(nil),
 		(*EventData_AdditionalInfo)(nil),
+		(*EventData_HistoryFilter)(nil),
+		(*EventData_HoldDetails)(nil),
+		(*EventData_TransferDetails)(nil),
 	}
-	file_account_account_proto_msgTypes[2].OneofWrappers = []any{
+	file_account_account_proto_msgTypes[8].OneofWrappers = []any{
 		(*EventStatus_Info)(nil),
 		(*EventStatus_AccData)(nil),
+		(*EventStatus_AccHistory)(nil),
+		(*EventStatus_Hold)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -471,7 +1077,7 @@ func file_account_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_account_proto_rawDesc), len(file_account_account_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.5
+// 	protoc-gen-go v1.36.6
 // 	protoc        v3.21.12
 // source: platform/platform.proto
 
@@ -325,6 +325,7 @@ type AccountDetails struct {
 	Bic           string                 `protobuf:"bytes,4,opt,name=bic,proto3" json:"bic,omitempty"`                                          //  БИК
 	Cio           string                 `protobuf:"bytes,5,opt,name=cio,proto3" json:"cio,omitempty"`                                          //  КПП
 	ReserveReason string                 `protobuf:"bytes,6,opt,name=reserve_reason,json=reserveReason,proto3" json:"reserve_reason,omitempty"` //  Причина резервирования
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`                                //  Валюта ISO-4217, пуста - по расчётному номеру
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -401,14 +402,22 @@ func (x *AccountDetails) GetReserveReason() string {
 	return ""
 }
 
+func (x *AccountDetails) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type FullAccountData struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AccDetails     *AccountDetails        `protobuf:"bytes,1,opt,name=acc_details,json=accDetails,proto3" json:"acc_details,omitempty"`                 //  Реквизиты счёта
-	AccStatus      uint64                 `protobuf:"varint,2,opt,name=acc_status,json=accStatus,proto3" json:"acc_status,omitempty"`                   //  Статус счёта
-	AccMoneyValue  uint64                 `protobuf:"varint,3,opt,name=acc_money_value,json=accMoneyValue,proto3" json:"acc_money_value,omitempty"`     //  Денежная величина
-	AccMoneyAmount float32                `protobuf:"fixed32,4,opt,name=acc_money_amount,json=accMoneyAmount,proto3" json:"acc_money_amount,omitempty"` //  Кол-во денег на счету
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AccDetails         *AccountDetails        `protobuf:"bytes,1,opt,name=acc_details,json=accDetails,proto3" json:"acc_details,omitempty"`                             //  Реквизиты счёта
+	AccStatus          uint64                 `protobuf:"varint,2,opt,name=acc_status,json=accStatus,proto3" json:"acc_status,omitempty"`                               //  Статус счёта
+	AccMoneyAmount     float32                `protobuf:"fixed32,4,opt,name=acc_money_amount,json=accMoneyAmount,proto3" json:"acc_money_amount,omitempty"`             //  Кол-во денег на счету
+	AccAvailableAmount float32                `protobuf:"fixed32,5,opt,name=acc_available_amount,json=accAvailableAmount,proto3" json:"acc_available_amount,omitempty"` //  Кол-во денег на счету без холдов
+	AccCurrency        string                 `protobuf:"bytes,6,opt,name=acc_currency,json=accCurrency,proto3" json:"acc_currency,omitempty"`                          //  Валюта счёта ISO-4217
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *FullAccountData) Reset() {
@@ -455,99 +464,84 @@ func (x *FullAccountData) GetAccStatus() uint64 {
 	return 0
 }
 
-func (x *FullAccountData) GetAccMoneyValue() uint64 {
+func (x *FullAccountData) GetAccMoneyAmount() float32 {
 	if x != nil {
-		return x.AccMoneyValue
+		return x.AccMoneyAmount
 	}
 	return 0
 }
 
-func (x *FullAccountData) GetAccMoneyAmount() float32 {
+func (x *FullAccountData) GetAccAvailableAmount() float32 {
 	if x != nil {
-		return x.AccMoneyAmount
+		return x.AccAvailableAmount
 	}
 	return 0
 }
 
+func (x *FullAccountData) GetAccCurrency() string {
+	if x != nil {
+		return x.AccCurrency
+	}
+	return ""
+}
+
 var File_platform_platform_proto protoreflect.FileDescriptor
 
-var file_platform_platform_proto_rawDesc = string([]byte{
-	0x0a, 0x17, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
-	0x6f, 0x72, 0x6d, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x53, 0x0a, 0x03, 0x46, 0x43, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x73, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x74,
-	0x72, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70,
-	0x61, 0x74, 0x72, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x63, 0x22, 0xf3, 0x02, 0x0a, 0x08, 0x50, 0x61,
-	0x73, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x03, 0x66, 0x63, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x46,
-	0x43, 0x73, 0x52, 0x03, 0x66, 0x63, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x69, 0x72, 0x74, 0x68,
-	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x62, 0x69, 0x72, 0x74, 0x68, 0x44, 0x61,
-	0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x69, 0x72, 0x74, 0x68, 0x5f, 0x6c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x69, 0x72, 0x74,
-	0x68, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x69, 0x63,
-	0x6b, 0x5f, 0x75, 0x70, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x70, 0x69, 0x63, 0x6b, 0x55, 0x70, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c, 0x0a,
-	0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x41, 0x0a, 0x0e, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x2f,
-	0x0a, 0x13, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0xc0, 0x01, 0x0a, 0x0e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69,
-	0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x75, 0x6c, 0x63, 0x5f, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x75, 0x6c, 0x63,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x5f, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x72,
-	0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x63, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x6f,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x72,
-	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x61, 0x73,
-	0x6f, 0x6e, 0x22, 0xbd, 0x01, 0x0a, 0x0f, 0x46, 0x75, 0x6c, 0x6c, 0x41, 0x63, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x5f, 0x64, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x61, 0x63, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x26, 0x0a, 0x0f, 0x61, 0x63, 0x63, 0x5f, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x5f, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x4d, 0x6f,
-	0x6e, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x5f,
-	0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x02, 0x52, 0x0e, 0x61, 0x63, 0x63, 0x4d, 0x6f, 0x6e, 0x65, 0x79, 0x41, 0x6d, 0x6f, 0x75,
-	0x6e, 0x74, 0x2a, 0xa4, 0x01, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x41, 0x43,
-	0x54, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x47, 0x52, 0x49, 0x43,
-	0x55, 0x4c, 0x54, 0x55, 0x52, 0x45, 0x49, 0x4e, 0x44, 0x55, 0x53, 0x54, 0x52, 0x59, 0x10, 0x01,
-	0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x49, 0x4e, 0x44, 0x55, 0x53, 0x54,
-	0x52, 0x59, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4e, 0x53, 0x54, 0x52, 0x55, 0x43,
-	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x52, 0x41, 0x44, 0x49, 0x4e,
-	0x47, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x53, 0x45,
-	0x43, 0x54, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x4f, 0x43, 0x48, 0x49, 0x41,
-	0x4c, 0x53, 0x48, 0x50, 0x45, 0x52, 0x45, 0x10, 0x07, 0x2a, 0x51, 0x0a, 0x0c, 0x54, 0x61, 0x78,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x4e, 0x4b,
-	0x4e, 0x4f, 0x57, 0x4e, 0x54, 0x41, 0x58, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a,
-	0x04, 0x4f, 0x53, 0x4e, 0x4f, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x53, 0x48, 0x10, 0x02,
-	0x12, 0x07, 0x0a, 0x03, 0x50, 0x53, 0x4e, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x44,
-	0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x45, 0x53, 0x58, 0x48, 0x10, 0x05, 0x42, 0x12, 0x5a, 0x10,
-	0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-})
+const file_platform_platform_proto_rawDesc = "" +
+	"\n" +
+	"\x17platform/platform.proto\x12\bplatform\x1a\x1fgoogle/protobuf/timestamp.proto\"S\n" +
+	"\x03FCs\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\asurname\x18\x02 \x01(\tR\asurname\x12\x1e\n" +
+	"\n" +
+	"patronymic\x18\x03 \x01(\tR\n" +
+	"patronymic\"\xf3\x02\n" +
+	"\bPassport\x12\x16\n" +
+	"\x06series\x18\x01 \x01(\tR\x06series\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1f\n" +
+	"\x03fcs\x18\x03 \x01(\v2\r.platform.FCsR\x03fcs\x129\n" +
+	"\n" +
+	"birth_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tbirthDate\x12%\n" +
+	"\x0ebirth_location\x18\x05 \x01(\tR\rbirthLocation\x12\"\n" +
+	"\rpick_up_point\x18\x06 \x01(\tR\vpickUpPoint\x12\x1c\n" +
+	"\tauthority\x18\a \x01(\rR\tauthority\x12A\n" +
+	"\x0eauthority_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\rauthorityDate\x12/\n" +
+	"\x13registration_adress\x18\t \x01(\tR\x12registrationAdress\"\xdc\x01\n" +
+	"\x0eAccountDetails\x12!\n" +
+	"\faccount_name\x18\x01 \x01(\tR\vaccountName\x12\x1f\n" +
+	"\vculc_number\x18\x02 \x01(\tR\n" +
+	"culcNumber\x12\x1f\n" +
+	"\vcorr_number\x18\x03 \x01(\tR\n" +
+	"corrNumber\x12\x10\n" +
+	"\x03bic\x18\x04 \x01(\tR\x03bic\x12\x10\n" +
+	"\x03cio\x18\x05 \x01(\tR\x03cio\x12%\n" +
+	"\x0ereserve_reason\x18\x06 \x01(\tR\rreserveReason\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\"\xf0\x01\n" +
+	"\x0fFullAccountData\x129\n" +
+	"\vacc_details\x18\x01 \x01(\v2\x18.platform.AccountDetailsR\n" +
+	"accDetails\x12\x1d\n" +
+	"\n" +
+	"acc_status\x18\x02 \x01(\x04R\taccStatus\x12(\n" +
+	"\x10acc_money_amount\x18\x04 \x01(\x02R\x0eaccMoneyAmount\x120\n" +
+	"\x14acc_available_amount\x18\x05 \x01(\x02R\x12accAvailableAmount\x12!\n" +
+	"\facc_currency\x18\x06 \x01(\tR\vaccCurrencyJ\x04\b\x03\x10\x04*\xa4\x01\n" +
+	"\fActivityType\x12\x12\n" +
+	"\x0eUNKNOWNACTTYPE\x10\x00\x12\x17\n" +
+	"\x13AGRICULTUREINDUSTRY\x10\x01\x12\x12\n" +
+	"\x0eMININGINDUSTRY\x10\x02\x12\x0e\n" +
+	"\n" +
+	"PRODUCTION\x10\x03\x12\x10\n" +
+	"\fCONSTRUCTION\x10\x04\x12\v\n" +
+	"\aTRADING\x10\x05\x12\x11\n" +
+	"\rSERVICESECTOR\x10\x06\x12\x11\n" +
+	"\rSOCHIALSHPERE\x10\a*Q\n" +
+	"\fTaxationType\x12\x12\n" +
+	"\x0eUNKNOWNTAXTYPE\x10\x00\x12\b\n" +
+	"\x04OSNO\x10\x01\x12\a\n" +
+	"\x03USH\x10\x02\x12\a\n" +
+	"\x03PSN\x10\x03\x12\a\n" +
+	"\x03NPD\x10\x04\x12\b\n" +
+	"\x04ESXH\x10\x05B\x12Z\x10./proto/platformb\x06proto3"
 
 var (
 	file_platform_platform_proto_rawDescOnce sync.Once
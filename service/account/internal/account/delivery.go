@@ -4,4 +4,10 @@ import "github.com/labstack/echo/v4"
 
 type Handlers interface {
 	GetAccountData() echo.HandlerFunc
+	GetAccountHistory() echo.HandlerFunc
+	CreateStatement() echo.HandlerFunc
+	GetWithdrawalLimits() echo.HandlerFunc
+	SetWithdrawalLimits() echo.HandlerFunc
+	GetExchangeRates() echo.HandlerFunc
+	SetExchangeRate() echo.HandlerFunc
 }
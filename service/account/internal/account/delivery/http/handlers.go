@@ -8,6 +8,8 @@ import (
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	"github.com/GCFactory/dbo-system/service/account/internal/account/statement"
+	"github.com/GCFactory/dbo-system/service/account/internal/account/usecase"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -15,6 +17,7 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 type httpError struct {
@@ -68,6 +71,252 @@ func (h accHandlers) GetAccountData() echo.HandlerFunc {
 	}
 }
 
+func (h accHandlers) GetAccountHistory() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.GetAccountHistoryRequest{}
+		err := h.safeReadQueryParamsRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		accId, _ := uuid.Parse(operationInfo.AccId)
+		filter := &models.HistoryFilter{
+			Direction: operationInfo.Direction,
+			Cursor:    operationInfo.Cursor,
+			Limit:     operationInfo.Limit,
+		}
+		if operationInfo.From != "" {
+			filter.From, _ = time.Parse(usecase.HistoryDateFormat, operationInfo.From)
+		}
+		if operationInfo.To != "" {
+			// Last day of period is included
+			to, _ := time.Parse(usecase.HistoryDateFormat, operationInfo.To)
+			filter.To = to.AddDate(0, 0, 1)
+		}
+		if operationInfo.MinAmount != "" {
+			minAmount, _ := strconv.ParseFloat(operationInfo.MinAmount, 64)
+			filter.MinAmount = &minAmount
+		}
+		if operationInfo.MaxAmount != "" {
+			maxAmount, _ := strconv.ParseFloat(operationInfo.MaxAmount, 64)
+			filter.MaxAmount = &maxAmount
+		}
+		if operationInfo.OperationId != "" {
+			filter.Operation_uuid, _ = uuid.Parse(operationInfo.OperationId)
+		}
+
+		history, err := h.accUC.GetAccHistory(context.Background(), accId, filter)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			switch err {
+			case usecase.ErrorNoFoundAcc:
+				result.Status = http.StatusNotFound
+			case usecase.ErrorWrongHistoryFilter, usecase.ErrorWrongHistoryCursor:
+				result.Status = http.StatusBadRequest
+			default:
+				result.Status = http.StatusInternalServerError
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		return c.JSON(http.StatusOK, history)
+	}
+}
+
+func (h accHandlers) CreateStatement() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.CreateStatementRequest{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		accId, _ := uuid.Parse(operationInfo.AccId)
+		ownerId, _ := uuid.Parse(operationInfo.OwnerId)
+		from, _ := time.Parse(statement.DateFormat, operationInfo.From)
+		to, _ := time.Parse(statement.DateFormat, operationInfo.To)
+
+		// Last day of period is included
+		fileId, err := h.accUC.CreateStatement(context.Background(), accId, ownerId, from, to.AddDate(0, 0, 1), operationInfo.Format)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			switch err {
+			case usecase.ErrorNoFoundAcc:
+				result.Status = http.StatusNotFound
+			case usecase.ErrorWrongStatementFormat, usecase.ErrorWrongStatementPeriod:
+				result.Status = http.StatusBadRequest
+			default:
+				result.Status = http.StatusInternalServerError
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		return c.JSON(http.StatusOK, &models.CreateStatementResponse{
+			FileId: fileId.String(),
+		})
+	}
+}
+
+func (h accHandlers) GetWithdrawalLimits() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.GetWithdrawalLimitsRequest{}
+		err := h.safeReadQueryParamsRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		subjectId, _ := uuid.Parse(operationInfo.SubjectId)
+
+		limits, err := h.accUC.GetWithdrawalLimits(context.Background(), operationInfo.SubjectType, subjectId)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			switch err {
+			case usecase.ErrorNoFoundAcc:
+				result.Status = http.StatusNotFound
+			case usecase.ErrorWrongLimitSubject:
+				result.Status = http.StatusBadRequest
+			default:
+				result.Status = http.StatusInternalServerError
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		return c.JSON(http.StatusOK, limits)
+	}
+}
+
+func (h accHandlers) SetWithdrawalLimits() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.SetWithdrawalLimitsRequest{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		subjectId, _ := uuid.Parse(operationInfo.SubjectId)
+
+		err = h.accUC.SetWithdrawalLimits(context.Background(), &models.WithdrawalLimits{
+			Subject_type:   operationInfo.SubjectType,
+			Subject_uuid:   subjectId,
+			Per_operation:  operationInfo.PerOperation,
+			Daily_amount:   operationInfo.DailyAmount,
+			Monthly_amount: operationInfo.MonthlyAmount,
+			Daily_count:    operationInfo.DailyCount,
+			Monthly_count:  operationInfo.MonthlyCount,
+		})
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			switch err {
+			case usecase.ErrorNoFoundAcc:
+				result.Status = http.StatusNotFound
+			case usecase.ErrorWrongLimitSubject, usecase.ErrorWrongLimitValue:
+				result.Status = http.StatusBadRequest
+			default:
+				result.Status = http.StatusInternalServerError
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		result.Info = "Success"
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+func (h accHandlers) GetExchangeRates() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		rates, err := h.accUC.GetExchangeRates(context.Background())
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusInternalServerError
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		return c.JSON(http.StatusOK, rates)
+	}
+}
+
+func (h accHandlers) SetExchangeRate() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.SetExchangeRateRequest{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		err = h.accUC.SetExchangeRate(context.Background(), operationInfo.BaseCurrency, operationInfo.QuoteCurrency, operationInfo.Rate)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			switch err {
+			case usecase.ErrorWrongCurrencyValue, usecase.ErrorWrongExchangeRate:
+				result.Status = http.StatusBadRequest
+			default:
+				result.Status = http.StatusInternalServerError
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		result.Info = "Success"
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
 func (h accHandlers) safeReadBodyRequest(c echo.Context, v interface{}) error {
 	var err error = nil
 	func() {
@@ -8,4 +8,10 @@ import (
 
 func MapACCRoutes(accountGroup *echo.Group, h account.Handlers, mw *middleware.MiddlewareManager) {
 	accountGroup.GET("/get_account_data", h.GetAccountData())
+	accountGroup.GET("/get_account_history", h.GetAccountHistory())
+	accountGroup.POST("/create_statement", h.CreateStatement())
+	accountGroup.GET("/get_withdrawal_limits", h.GetWithdrawalLimits())
+	accountGroup.POST("/set_withdrawal_limits", h.SetWithdrawalLimits())
+	accountGroup.GET("/get_exchange_rates", h.GetExchangeRates())
+	accountGroup.POST("/set_exchange_rate", h.SetExchangeRate())
 }
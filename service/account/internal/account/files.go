@@ -0,0 +1,14 @@
+//go:generate mockgen -source files.go -destination mock/files_mock.go -package mock
+
+package account
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"github.com/google/uuid"
+)
+
+// Storage of generated documents (file-api)
+type Files interface {
+	PutFile(ctx context.Context, file *models.File) (uuid.UUID, error)
+}
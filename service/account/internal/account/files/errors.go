@@ -0,0 +1,8 @@
+package files
+
+import "errors"
+
+var (
+	ErrorPutFile         = errors.New("Error putting file")
+	ErrorFileApiDisabled = errors.New("file-api isn't configured")
+)
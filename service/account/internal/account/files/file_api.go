@@ -0,0 +1,90 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	fileApiPutFile   = "/putfile"
+	maxErrorBodySize = 1 << 10
+)
+
+// File message of file-api, names are proto ones
+type fileApiFile struct {
+	UUID         string `json:"UUID,omitempty"`
+	BusinessType string `json:"BusinessType,omitempty"`
+	OwnerUUID    string `json:"OwnerUUID,omitempty"`
+	FileName     string `json:"FileName,omitempty"`
+	Hash         string `json:"Hash,omitempty"`
+	Size         uint64 `json:"Size,omitempty"`
+	Content      []byte `json:"Content,omitempty"`
+}
+
+// Client of file-api HTTP gateway
+type fileApiClient struct {
+	client  *http.Client
+	address string
+}
+
+func (c fileApiClient) PutFile(ctx context.Context, file *models.File) (uuid.UUID, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileApiClient.PutFile")
+	defer span.End()
+
+	hash := sha256.Sum256(file.Content)
+	body := fileApiFile{
+		BusinessType: file.BusinessType,
+		FileName:     file.FileName,
+		Hash:         hex.EncodeToString(hash[:]),
+		Size:         uint64(len(file.Content)),
+		Content:      file.Content,
+	}
+	if file.OwnerUUID != uuid.Nil {
+		body.OwnerUUID = file.OwnerUUID.String()
+	}
+
+	data, err := json.Marshal(&body)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctxWithTrace, http.MethodPost, c.address+fileApiPutFile, bytes.NewReader(data))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return uuid.Nil, fmt.Errorf("%w %s: %s %s", ErrorPutFile, file.FileName, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	var result fileApiFile
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(result.UUID)
+}
+
+func NewFileApiClient(address string, timeout time.Duration) account.Files {
+	return fileApiClient{client: &http.Client{Timeout: timeout}, address: strings.TrimRight(address, "/")}
+}
@@ -0,0 +1,19 @@
+package files
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"github.com/google/uuid"
+)
+
+// Used when file-api isn't configured, documents can't be stored
+type noopFiles struct{}
+
+func (noopFiles) PutFile(ctx context.Context, file *models.File) (uuid.UUID, error) {
+	return uuid.Nil, ErrorFileApiDisabled
+}
+
+func NewNoopFiles() account.Files {
+	return noopFiles{}
+}
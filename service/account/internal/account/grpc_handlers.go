@@ -1,9 +1,9 @@
 package account
 
 import (
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	acc_proto_api "github.com/GCFactory/dbo-system/service/account/gen_proto/proto/api/account"
 	acc_proto_platform "github.com/GCFactory/dbo-system/service/account/gen_proto/proto/platform"
-	"github.com/GCFactory/dbo-system/service/account/pkg/kafka"
 	"golang.org/x/net/context"
 )
 
@@ -11,6 +11,9 @@ type GRPCHandlers interface {
 	ReserveAccount(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_platform.AccountDetails, kProducer *kafka.ProducerProvider) error
 	ChangeAccountStatus(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error
 	GetAccountData(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error
+	GetAccountHistory(ctx context.Context, saga_uuid string, event_uuid string, filter_data *acc_proto_api.HistoryFilter, kProducer *kafka.ProducerProvider) error
 	RemoveAccount(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error
 	OperationWithAccAmount(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error
+	TransferAccount(ctx context.Context, saga_uuid string, event_uuid string, transfer_data *acc_proto_api.TransferDetails, kProducer *kafka.ProducerProvider) error
+	OperationWithHold(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, hold_data *acc_proto_api.HoldDetails, kProducer *kafka.ProducerProvider) error
 }
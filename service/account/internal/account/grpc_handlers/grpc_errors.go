@@ -41,6 +41,7 @@ var (
 
 		usecase.ErrorWrongCurrencyLen:   170,
 		usecase.ErrorWrongCurrencyValue: 171,
+		usecase.ErrorCurrencyMismatch:   172,
 
 		usecase.ErrorWrongPaymentSystemLen: 180,
 		usecase.ErrorWrongPaymentSystem:    181,
@@ -73,6 +74,37 @@ var (
 		usecase.ErrorCreateAcc:         1022,
 
 		ErrorInvalidInputData: 1030,
+
+		usecase.ErrorWrongStatementFormat: 1040,
+		usecase.ErrorWrongStatementPeriod: 1041,
+		usecase.ErrorGetMovements:         1042,
+		usecase.ErrorSaveStatement:        1043,
+
+		usecase.ErrorWrongHistoryFilter: 1050,
+		usecase.ErrorWrongHistoryCursor: 1051,
+		usecase.ErrorGetHistory:         1052,
+
+		usecase.ErrorWrongHoldAmount: 1060,
+		usecase.ErrorWrongHoldTTL:    1061,
+		usecase.ErrorNoFoundHold:     1062,
+		usecase.ErrorHoldIsNotActive: 1063,
+		usecase.ErrorGetHolds:        1064,
+		usecase.ErrorCreateHold:      1065,
+		usecase.ErrorUpdateHold:      1066,
+
+		usecase.ErrorOperationLimitExceeded: 1070,
+		usecase.ErrorDailyLimitExceeded:     1071,
+		usecase.ErrorMonthlyLimitExceeded:   1072,
+		usecase.ErrorDailyCountExceeded:     1073,
+		usecase.ErrorMonthlyCountExceeded:   1074,
+		usecase.ErrorGetWithdrawalLimits:    1075,
+
+		usecase.ErrorWrongExchangeRate:   1080,
+		usecase.ErrorNoExchangeRate:      1081,
+		usecase.ErrorGetExchangeRate:     1082,
+		usecase.ErrorWrongTransferAmount: 1083,
+		usecase.ErrorWrongTransferAcc:    1084,
+		usecase.ErrorTransferAmount:      1085,
 	}
 )
 
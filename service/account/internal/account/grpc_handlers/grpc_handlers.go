@@ -2,17 +2,19 @@ package grpc_handlers
 
 import (
 	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	acc_proto_api "github.com/GCFactory/dbo-system/service/account/gen_proto/proto/api/account"
 	acc_proto_platform "github.com/GCFactory/dbo-system/service/account/gen_proto/proto/platform"
 	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	"github.com/GCFactory/dbo-system/service/account/internal/account/usecase"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
-	"github.com/GCFactory/dbo-system/service/account/pkg/kafka"
-	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
+	"time"
 )
 
 type AccountGRPCHandlers struct {
@@ -20,12 +22,13 @@ type AccountGRPCHandlers struct {
 	kProducer *kafka.ProducerProvider
 	accUC     account.UseCase
 	accLog    logger.Logger
+	metrics   metric.SagaMetrics
 }
 
 func (accGRPCH AccountGRPCHandlers) ReserveAccount(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_platform.AccountDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accGRPCH.ReserveAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.ReserveAccount")
+	defer span.End()
 
 	acc_model := &models.FullAccountData{
 		Acc_uuid:        uuid.New(),
@@ -35,6 +38,7 @@ func (accGRPCH AccountGRPCHandlers) ReserveAccount(ctx context.Context, saga_uui
 		Acc_cio:         acc_data.GetCio(),
 		Acc_bic:         acc_data.GetBic(),
 		Reason:          acc_data.GetReserveReason(),
+		Acc_currency:    acc_data.GetCurrency(),
 	}
 
 	flag_error := false
@@ -67,8 +71,10 @@ func (accGRPCH AccountGRPCHandlers) ReserveAccount(ctx context.Context, saga_uui
 	var answer_data []byte
 
 	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -77,7 +83,7 @@ func (accGRPCH AccountGRPCHandlers) ReserveAccount(ctx context.Context, saga_uui
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		accGRPCH.accLog.Error(err)
 		return err
@@ -89,8 +95,8 @@ func (accGRPCH AccountGRPCHandlers) ReserveAccount(ctx context.Context, saga_uui
 
 func (accGRPCH AccountGRPCHandlers) ChangeAccountStatus(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accGRPCH.ChangeAccountStatus")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.ChangeAccountStatus")
+	defer span.End()
 
 	flag_error := false
 	var err error
@@ -132,7 +138,12 @@ func (accGRPCH AccountGRPCHandlers) ChangeAccountStatus(ctx context.Context, sag
 		}
 		if err == nil {
 			answer_topic = TopicResult
-			answer.Result = &acc_proto_api.EventStatus_Info{"Success"}
+			switch operation_type {
+			case CloseAccount, BlockAccount:
+				accGRPCH.setAccountState(ctxWithTrace, answer, account_uuid)
+			default:
+				answer.Result = &acc_proto_api.EventStatus_Info{"Success"}
+			}
 		} else {
 			flag_error = true
 			accGRPCH.accLog.Error(err)
@@ -143,8 +154,10 @@ func (accGRPCH AccountGRPCHandlers) ChangeAccountStatus(ctx context.Context, sag
 
 	var answer_data []byte
 	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(answer_error)
 	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 	if err != nil {
@@ -152,7 +165,7 @@ func (accGRPCH AccountGRPCHandlers) ChangeAccountStatus(ctx context.Context, sag
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		accGRPCH.accLog.Error(err)
 		return err
@@ -164,8 +177,8 @@ func (accGRPCH AccountGRPCHandlers) ChangeAccountStatus(ctx context.Context, sag
 
 func (accGRPCH AccountGRPCHandlers) GetAccountData(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accGRPCH.GetAccountData")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.GetAccountData")
+	defer span.End()
 
 	flag_error := false
 	var err error
@@ -206,28 +219,16 @@ func (accGRPCH AccountGRPCHandlers) GetAccountData(ctx context.Context, saga_uui
 			answer_error.Info = err.Error()
 		} else {
 			answer_topic = TopicResult
-			answer.Result = &acc_proto_api.EventStatus_AccData{
-				AccData: &acc_proto_platform.FullAccountData{
-					AccDetails: &acc_proto_platform.AccountDetails{
-						CulcNumber:    result.Acc_culc_number,
-						CorrNumber:    result.Acc_corr_number,
-						Bic:           result.Acc_bic,
-						Cio:           result.Acc_cio,
-						ReserveReason: result.Reason,
-						AccountName:   result.Acc_name,
-					},
-					AccStatus:      uint64(result.Acc_status),
-					AccMoneyValue:  uint64(result.Acc_money_value),
-					AccMoneyAmount: float32(result.Acc_money_amount),
-				},
-			}
+			answer.Result = accountDataResult(result)
 		}
 	}
 
 	var answer_data []byte
 	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(answer_error)
 	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 	if err != nil {
@@ -235,7 +236,7 @@ func (accGRPCH AccountGRPCHandlers) GetAccountData(ctx context.Context, saga_uui
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		accGRPCH.accLog.Error(err)
 		return err
@@ -247,8 +248,8 @@ func (accGRPCH AccountGRPCHandlers) GetAccountData(ctx context.Context, saga_uui
 
 func (accGRPCH AccountGRPCHandlers) OperationWithAccAmount(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accGRPCH.OperationWithAccAmount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.OperationWithAccAmount")
+	defer span.End()
 
 	flag_error := false
 	var err error
@@ -277,16 +278,188 @@ func (accGRPCH AccountGRPCHandlers) OperationWithAccAmount(ctx context.Context,
 	}
 
 	if !flag_error {
+		// Saga is operation which made movement, it is saved in account history
+		operation_uuid, _ := uuid.Parse(saga_uuid)
 		switch operation_type {
 		case AddingAcc:
-			err = accGRPCH.accUC.AddingAcc(ctxWithTrace, account_uuid, float64(acc_data.GetAdditionalData()))
+			err = accGRPCH.accUC.AddingAcc(ctxWithTrace, account_uuid, float64(acc_data.GetAdditionalData()), operation_uuid)
 		case WidthAcc:
-			err = accGRPCH.accUC.WidthAcc(ctxWithTrace, account_uuid, float64(acc_data.GetAdditionalData()))
+			// User is optional, without it only account limits are checked
+			var user_uuid uuid.UUID
+			if acc_data.GetUserUuid() != "" {
+				user_uuid, err = uuid.Parse(acc_data.GetUserUuid())
+				if err != nil {
+					err = ErrorInvalidInputData
+					break
+				}
+			}
+			err = accGRPCH.accUC.WidthAcc(ctxWithTrace, account_uuid, float64(acc_data.GetAdditionalData()), operation_uuid, user_uuid)
 		}
 
 		if err == nil {
 			answer_topic = TopicResult
-			answer.Result = &acc_proto_api.EventStatus_Info{"Success"}
+			accGRPCH.setAccountState(ctxWithTrace, answer, account_uuid)
+		} else {
+			flag_error = true
+			accGRPCH.accLog.Error(err)
+			answer_error.Status = GetErrorCode(err)
+			answer_error.Info = err.Error()
+		}
+	}
+
+	var answer_data []byte
+	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
+		answer_data, err = proto.Marshal(answer_error)
+	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
+		answer_data, err = proto.Marshal(answer)
+	}
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+	accGRPCH.accLog.Info("Success to send answer!")
+
+	return nil
+}
+
+func (accGRPCH AccountGRPCHandlers) OperationWithHold(ctx context.Context, saga_uuid string, event_uuid string, operation_type string, hold_data *acc_proto_api.HoldDetails, kProducer *kafka.ProducerProvider) error {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.OperationWithHold")
+	defer span.End()
+
+	flag_error := false
+	var err error
+	answer_topic := TopicError
+
+	answer := &acc_proto_api.EventStatus{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: operation_type,
+	}
+
+	answer_error := &acc_proto_api.EventError{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: operation_type,
+	}
+
+	var account_uuid, hold_uuid uuid.UUID
+	account_uuid, err = uuid.Parse(hold_data.GetAccUuid())
+	if err == nil && operation_type != CreateHold {
+		hold_uuid, err = uuid.Parse(hold_data.GetHoldUuid())
+	}
+	if err != nil {
+		answer_error.Info = err.Error()
+		answer_error.Status = GetErrorCode(ErrorInvalidInputData)
+
+		flag_error = true
+	}
+
+	if !flag_error {
+		// Saga is operation which made hold, it is saved with hold and captured movement
+		operation_uuid, _ := uuid.Parse(saga_uuid)
+		var hold *models.AccountHold
+		switch operation_type {
+		case CreateHold:
+			ttl := time.Duration(hold_data.GetExpiresIn()) * time.Second
+			hold, err = accGRPCH.accUC.CreateHold(ctxWithTrace, account_uuid, hold_data.GetAmount(), ttl, operation_uuid)
+		case CaptureHold:
+			err = accGRPCH.accUC.CaptureHold(ctxWithTrace, account_uuid, hold_uuid, hold_data.GetAmount(), operation_uuid)
+		case ReleaseHold:
+			err = accGRPCH.accUC.ReleaseHold(ctxWithTrace, account_uuid, hold_uuid)
+		}
+
+		if err == nil {
+			answer_topic = TopicResult
+			if hold != nil {
+				answer.Result = holdResult(hold)
+			} else {
+				accGRPCH.setAccountState(ctxWithTrace, answer, account_uuid)
+			}
+		} else {
+			flag_error = true
+			accGRPCH.accLog.Error(err)
+			answer_error.Status = GetErrorCode(err)
+			answer_error.Info = err.Error()
+		}
+	}
+
+	var answer_data []byte
+	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
+		answer_data, err = proto.Marshal(answer_error)
+	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
+		answer_data, err = proto.Marshal(answer)
+	}
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+	accGRPCH.accLog.Info("Success to send answer!")
+
+	return nil
+}
+
+func (accGRPCH AccountGRPCHandlers) TransferAccount(ctx context.Context, saga_uuid string, event_uuid string, transfer_data *acc_proto_api.TransferDetails, kProducer *kafka.ProducerProvider) error {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.TransferAccount")
+	defer span.End()
+
+	flag_error := false
+	var err error
+	answer_topic := TopicError
+
+	answer := &acc_proto_api.EventStatus{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: TransferAcc,
+	}
+
+	answer_error := &acc_proto_api.EventError{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: TransferAcc,
+	}
+
+	// User is optional, without it only source account limits are checked
+	var from_acc_uuid, to_acc_uuid, user_uuid uuid.UUID
+	from_acc_uuid, err = uuid.Parse(transfer_data.GetFromAccUuid())
+	if err == nil {
+		to_acc_uuid, err = uuid.Parse(transfer_data.GetToAccUuid())
+	}
+	if err == nil && transfer_data.GetUserUuid() != "" {
+		user_uuid, err = uuid.Parse(transfer_data.GetUserUuid())
+	}
+	if err != nil {
+		answer_error.Info = err.Error()
+		answer_error.Status = GetErrorCode(ErrorInvalidInputData)
+
+		flag_error = true
+	}
+
+	if !flag_error {
+		// Saga is operation which made movements, it is saved in history of both accounts
+		operation_uuid, _ := uuid.Parse(saga_uuid)
+		err = accGRPCH.accUC.TransferAcc(ctxWithTrace, from_acc_uuid, to_acc_uuid, transfer_data.GetAmount(), operation_uuid, user_uuid)
+
+		if err == nil {
+			answer_topic = TopicResult
+			accGRPCH.setAccountState(ctxWithTrace, answer, from_acc_uuid)
 		} else {
 			flag_error = true
 			accGRPCH.accLog.Error(err)
@@ -297,8 +470,10 @@ func (accGRPCH AccountGRPCHandlers) OperationWithAccAmount(ctx context.Context,
 
 	var answer_data []byte
 	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(answer_error)
 	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 	if err != nil {
@@ -306,7 +481,72 @@ func (accGRPCH AccountGRPCHandlers) OperationWithAccAmount(ctx context.Context,
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+	accGRPCH.accLog.Info("Success to send answer!")
+
+	return nil
+}
+
+func (accGRPCH AccountGRPCHandlers) GetAccountHistory(ctx context.Context, saga_uuid string, event_uuid string, filter_data *acc_proto_api.HistoryFilter, kProducer *kafka.ProducerProvider) error {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.GetAccountHistory")
+	defer span.End()
+
+	flag_error := false
+	var err error
+	answer_topic := TopicError
+
+	answer := &acc_proto_api.EventStatus{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: GetAccountHistory,
+	}
+
+	answer_error := &acc_proto_api.EventError{
+		SagaUuid:      saga_uuid,
+		EventUuid:     event_uuid,
+		OperationName: GetAccountHistory,
+	}
+
+	account_uuid, filter, err := historyFilter(filter_data)
+	if err != nil {
+		answer_error.Info = err.Error()
+		answer_error.Status = GetErrorCode(ErrorInvalidInputData)
+
+		flag_error = true
+	}
+
+	if !flag_error {
+		result, err := accGRPCH.accUC.GetAccHistory(ctxWithTrace, account_uuid, filter)
+		if err != nil {
+			accGRPCH.accLog.Error(err)
+			flag_error = true
+			answer_error.Status = GetErrorCode(err)
+			answer_error.Info = err.Error()
+		} else {
+			answer_topic = TopicResult
+			answer.Result = accountHistoryResult(result)
+		}
+	}
+
+	var answer_data []byte
+	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
+		answer_data, err = proto.Marshal(answer_error)
+	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
+		answer_data, err = proto.Marshal(answer)
+	}
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		return err
+	}
+
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		accGRPCH.accLog.Error(err)
 		return err
@@ -318,8 +558,8 @@ func (accGRPCH AccountGRPCHandlers) OperationWithAccAmount(ctx context.Context,
 
 func (accGRPCH AccountGRPCHandlers) RemoveAccount(ctx context.Context, saga_uuid string, event_uuid string, acc_data *acc_proto_api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accGRPCH.RemoveAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accGRPCH.RemoveAccount")
+	defer span.End()
 
 	flag_error := false
 	var err error
@@ -363,8 +603,10 @@ func (accGRPCH AccountGRPCHandlers) RemoveAccount(ctx context.Context, saga_uuid
 
 	var answer_data []byte
 	if flag_error {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(answer_error)
 	} else {
+		accGRPCH.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 	if err != nil {
@@ -372,7 +614,7 @@ func (accGRPCH AccountGRPCHandlers) RemoveAccount(ctx context.Context, saga_uuid
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		accGRPCH.accLog.Error(err)
 		return err
@@ -381,6 +623,113 @@ func (accGRPCH AccountGRPCHandlers) RemoveAccount(ctx context.Context, saga_uuid
 	return nil
 }
 
-func NewAccountGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, accUC account.UseCase, accLog logger.Logger) account.GRPCHandlers {
-	return &AccountGRPCHandlers{cfg: cfg, kProducer: kProducer, accUC: accUC, accLog: accLog}
+// setAccountState reports account state after successful operation, so registration can notify user
+// with account name and balance. Operation is already done, so "Success" is sent when state can't be read.
+func (accGRPCH AccountGRPCHandlers) setAccountState(ctx context.Context, answer *acc_proto_api.EventStatus, account_uuid uuid.UUID) {
+	result, err := accGRPCH.accUC.GetAccInfo(ctx, account_uuid)
+	if err != nil {
+		accGRPCH.accLog.Error(err)
+		answer.Result = &acc_proto_api.EventStatus_Info{Info: "Success"}
+		return
+	}
+	answer.Result = accountDataResult(result)
+}
+
+func accountDataResult(result *models.FullAccountData) *acc_proto_api.EventStatus_AccData {
+	return &acc_proto_api.EventStatus_AccData{
+		AccData: &acc_proto_platform.FullAccountData{
+			AccDetails: &acc_proto_platform.AccountDetails{
+				CulcNumber:    result.Acc_culc_number,
+				CorrNumber:    result.Acc_corr_number,
+				Bic:           result.Acc_bic,
+				Cio:           result.Acc_cio,
+				ReserveReason: result.Reason,
+				AccountName:   result.Acc_name,
+			},
+			AccStatus:          uint64(result.Acc_status),
+			AccMoneyAmount:     float32(result.Acc_money_amount),
+			AccAvailableAmount: float32(result.Acc_available_amount),
+			AccCurrency:        result.Acc_currency,
+		},
+	}
+}
+
+// historyFilter converts proto filter, period dates are in 02-01-2006 format and To day is included
+func historyFilter(filter_data *acc_proto_api.HistoryFilter) (uuid.UUID, *models.HistoryFilter, error) {
+	account_uuid, err := uuid.Parse(filter_data.GetAccUuid())
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	filter := &models.HistoryFilter{
+		Direction: filter_data.GetDirection(),
+		MinAmount: filter_data.MinAmount,
+		MaxAmount: filter_data.MaxAmount,
+		Cursor:    filter_data.GetCursor(),
+		Limit:     int(filter_data.GetLimit()),
+	}
+
+	if from := filter_data.GetFrom(); from != "" {
+		filter.From, err = time.Parse(usecase.HistoryDateFormat, from)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+	}
+	if to := filter_data.GetTo(); to != "" {
+		filter.To, err = time.Parse(usecase.HistoryDateFormat, to)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+	if operation_uuid := filter_data.GetOperationUuid(); operation_uuid != "" {
+		filter.Operation_uuid, err = uuid.Parse(operation_uuid)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+	}
+
+	return account_uuid, filter, nil
+}
+
+func accountHistoryResult(result *models.AccountHistory) *acc_proto_api.EventStatus_AccHistory {
+	history := &acc_proto_api.AccountHistory{
+		Movements:  make([]*acc_proto_api.Movement, 0, len(result.Movements)),
+		NextCursor: result.NextCursor,
+	}
+
+	for _, movement := range result.Movements {
+		history_movement := &acc_proto_api.Movement{
+			MovementUuid: movement.Movement_uuid.String(),
+			MovementType: movement.Movement_type,
+			Amount:       movement.Amount,
+			Balance:      movement.Balance,
+			CreatedAt:    movement.Created_at.Unix(),
+		}
+		if movement.Operation_uuid.Valid {
+			history_movement.OperationUuid = movement.Operation_uuid.UUID.String()
+		}
+		history.Movements = append(history.Movements, history_movement)
+	}
+
+	return &acc_proto_api.EventStatus_AccHistory{
+		AccHistory: history,
+	}
+}
+
+func holdResult(hold *models.AccountHold) *acc_proto_api.EventStatus_Hold {
+	return &acc_proto_api.EventStatus_Hold{
+		Hold: &acc_proto_api.Hold{
+			HoldUuid:       hold.Hold_uuid.String(),
+			AccUuid:        hold.Acc_uuid.String(),
+			Status:         hold.Status,
+			Amount:         hold.Amount,
+			CapturedAmount: hold.Captured_amount,
+			ExpiresAt:      hold.Expires_at.Unix(),
+		},
+	}
+}
+
+func NewAccountGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, accUC account.UseCase, accLog logger.Logger, metrics metric.SagaMetrics) account.GRPCHandlers {
+	return &AccountGRPCHandlers{cfg: cfg, kProducer: kProducer, accUC: accUC, accLog: accLog, metrics: metrics}
 }
@@ -1,13 +1,18 @@
 package grpc_handlers
 
 const (
-	ReserveAccount = "reserve_acc"
-	CreateAccount  = "create_acc"
-	OpenAccount    = "open_acc"
-	CloseAccount   = "close_acc"
-	BlockAccount   = "block_acc"
-	GetAccountData = "get_acc_data"
-	AddingAcc      = "adding_acc"
-	WidthAcc       = "width_acc"
-	RemoveAcc      = "remove_acc"
+	ReserveAccount    = "reserve_acc"
+	CreateAccount     = "create_acc"
+	OpenAccount       = "open_acc"
+	CloseAccount      = "close_acc"
+	BlockAccount      = "block_acc"
+	GetAccountData    = "get_acc_data"
+	GetAccountHistory = "get_acc_history"
+	AddingAcc         = "adding_acc"
+	WidthAcc          = "width_acc"
+	RemoveAcc         = "remove_acc"
+	CreateHold        = "create_hold"
+	CaptureHold       = "capture_hold"
+	ReleaseHold       = "release_hold"
+	TransferAcc       = "transfer_acc"
 )
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/GCFactory/dbo-system/service/account/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +37,20 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CaptureHold mocks base method.
+func (m *MockRepository) CaptureHold(ctx context.Context, hold_uuid uuid.UUID, movement *models.AccountMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, hold_uuid, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockRepositoryMockRecorder) CaptureHold(ctx, hold_uuid, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockRepository)(nil).CaptureHold), ctx, hold_uuid, movement)
+}
+
 // CreateAccount mocks base method.
 func (m *MockRepository) CreateAccount(ctx context.Context, account *models.Account, reason *models.ReserverReason) error {
 	m.ctrl.T.Helper()
@@ -50,6 +65,21 @@ func (mr *MockRepositoryMockRecorder) CreateAccount(ctx, account, reason interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockRepository)(nil).CreateAccount), ctx, account, reason)
 }
 
+// CreateHold mocks base method.
+func (m *MockRepository) CreateHold(ctx context.Context, hold *models.AccountHold) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, hold)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockRepositoryMockRecorder) CreateHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockRepository)(nil).CreateHold), ctx, hold)
+}
+
 // DeleteAccount mocks base method.
 func (m *MockRepository) DeleteAccount(ctx context.Context, acc_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -78,6 +108,21 @@ func (mr *MockRepositoryMockRecorder) DeleteReserveReason(ctx, acc_uuid interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReserveReason", reflect.TypeOf((*MockRepository)(nil).DeleteReserveReason), ctx, acc_uuid)
 }
 
+// ExpireHolds mocks base method.
+func (m *MockRepository) ExpireHolds(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockRepositoryMockRecorder) ExpireHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockRepository)(nil).ExpireHolds), ctx)
+}
+
 // GetAccountAmount mocks base method.
 func (m *MockRepository) GetAccountAmount(ctx context.Context, acc_uuid uuid.UUID) (float64, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +153,66 @@ func (mr *MockRepositoryMockRecorder) GetAccountData(ctx, acc_uuid interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountData", reflect.TypeOf((*MockRepository)(nil).GetAccountData), ctx, acc_uuid)
 }
 
+// GetAccountHistory mocks base method.
+func (m *MockRepository) GetAccountHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter, after *models.HistoryCursor, limit int) ([]*models.AccountMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHistory", ctx, acc_uuid, filter, after, limit)
+	ret0, _ := ret[0].([]*models.AccountMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHistory indicates an expected call of GetAccountHistory.
+func (mr *MockRepositoryMockRecorder) GetAccountHistory(ctx, acc_uuid, filter, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHistory", reflect.TypeOf((*MockRepository)(nil).GetAccountHistory), ctx, acc_uuid, filter, after, limit)
+}
+
+// GetAccountHoldsAmount mocks base method.
+func (m *MockRepository) GetAccountHoldsAmount(ctx context.Context, acc_uuid uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountHoldsAmount", ctx, acc_uuid)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountHoldsAmount indicates an expected call of GetAccountHoldsAmount.
+func (mr *MockRepositoryMockRecorder) GetAccountHoldsAmount(ctx, acc_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountHoldsAmount", reflect.TypeOf((*MockRepository)(nil).GetAccountHoldsAmount), ctx, acc_uuid)
+}
+
+// GetAccountMovements mocks base method.
+func (m *MockRepository) GetAccountMovements(ctx context.Context, acc_uuid uuid.UUID, from, to time.Time) ([]*models.AccountMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMovements", ctx, acc_uuid, from, to)
+	ret0, _ := ret[0].([]*models.AccountMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMovements indicates an expected call of GetAccountMovements.
+func (mr *MockRepositoryMockRecorder) GetAccountMovements(ctx, acc_uuid, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMovements", reflect.TypeOf((*MockRepository)(nil).GetAccountMovements), ctx, acc_uuid, from, to)
+}
+
+// GetAccountMovementsSum mocks base method.
+func (m *MockRepository) GetAccountMovementsSum(ctx context.Context, acc_uuid uuid.UUID, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountMovementsSum", ctx, acc_uuid, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountMovementsSum indicates an expected call of GetAccountMovementsSum.
+func (mr *MockRepositoryMockRecorder) GetAccountMovementsSum(ctx, acc_uuid, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountMovementsSum", reflect.TypeOf((*MockRepository)(nil).GetAccountMovementsSum), ctx, acc_uuid, since)
+}
+
 // GetAccountStatus mocks base method.
 func (m *MockRepository) GetAccountStatus(ctx context.Context, acc_uuid uuid.UUID) (uint8, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +228,51 @@ func (mr *MockRepositoryMockRecorder) GetAccountStatus(ctx, acc_uuid interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatus", reflect.TypeOf((*MockRepository)(nil).GetAccountStatus), ctx, acc_uuid)
 }
 
+// GetExchangeRate mocks base method.
+func (m *MockRepository) GetExchangeRate(ctx context.Context, base_currency, quote_currency string) (*models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", ctx, base_currency, quote_currency)
+	ret0, _ := ret[0].(*models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockRepositoryMockRecorder) GetExchangeRate(ctx, base_currency, quote_currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockRepository)(nil).GetExchangeRate), ctx, base_currency, quote_currency)
+}
+
+// GetExchangeRates mocks base method.
+func (m *MockRepository) GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx)
+	ret0, _ := ret[0].([]*models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockRepositoryMockRecorder) GetExchangeRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockRepository)(nil).GetExchangeRates), ctx)
+}
+
+// GetHold mocks base method.
+func (m *MockRepository) GetHold(ctx context.Context, hold_uuid uuid.UUID) (*models.AccountHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, hold_uuid)
+	ret0, _ := ret[0].(*models.AccountHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockRepositoryMockRecorder) GetHold(ctx, hold_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockRepository)(nil).GetHold), ctx, hold_uuid)
+}
+
 // GetReserveReason mocks base method.
 func (m *MockRepository) GetReserveReason(ctx context.Context, acc_uuid uuid.UUID) (*models.ReserverReason, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +288,91 @@ func (mr *MockRepositoryMockRecorder) GetReserveReason(ctx, acc_uuid interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReserveReason", reflect.TypeOf((*MockRepository)(nil).GetReserveReason), ctx, acc_uuid)
 }
 
+// GetWithdrawalLimits mocks base method.
+func (m *MockRepository) GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalLimits", ctx, subject_type, subject_uuid)
+	ret0, _ := ret[0].(*models.WithdrawalLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalLimits indicates an expected call of GetWithdrawalLimits.
+func (mr *MockRepositoryMockRecorder) GetWithdrawalLimits(ctx, subject_type, subject_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalLimits", reflect.TypeOf((*MockRepository)(nil).GetWithdrawalLimits), ctx, subject_type, subject_uuid)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockRepository) ReleaseHold(ctx context.Context, hold_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, hold_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockRepositoryMockRecorder) ReleaseHold(ctx, hold_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockRepository)(nil).ReleaseHold), ctx, hold_uuid)
+}
+
+// RemoveAccount mocks base method.
+func (m *MockRepository) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAccount", ctx, acc_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAccount indicates an expected call of RemoveAccount.
+func (mr *MockRepositoryMockRecorder) RemoveAccount(ctx, acc_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccount", reflect.TypeOf((*MockRepository)(nil).RemoveAccount), ctx, acc_uuid)
+}
+
+// SetExchangeRate mocks base method.
+func (m *MockRepository) SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockRepositoryMockRecorder) SetExchangeRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockRepository)(nil).SetExchangeRate), ctx, rate)
+}
+
+// SetWithdrawalLimits mocks base method.
+func (m *MockRepository) SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithdrawalLimits", ctx, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithdrawalLimits indicates an expected call of SetWithdrawalLimits.
+func (mr *MockRepositoryMockRecorder) SetWithdrawalLimits(ctx, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithdrawalLimits", reflect.TypeOf((*MockRepository)(nil).SetWithdrawalLimits), ctx, limits)
+}
+
+// TransferAmount mocks base method.
+func (m *MockRepository) TransferAmount(ctx context.Context, from, to *models.AccountMovement, conversion *models.CurrencyConversion, check func(*models.WithdrawalState) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferAmount", ctx, from, to, conversion, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferAmount indicates an expected call of TransferAmount.
+func (mr *MockRepositoryMockRecorder) TransferAmount(ctx, from, to, conversion, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferAmount", reflect.TypeOf((*MockRepository)(nil).TransferAmount), ctx, from, to, conversion, check)
+}
+
 // UpdateAccountAmount mocks base method.
 func (m *MockRepository) UpdateAccountAmount(ctx context.Context, acc_uuid uuid.UUID, acc_new_amount float64) error {
 	m.ctrl.T.Helper()
@@ -152,6 +387,20 @@ func (mr *MockRepositoryMockRecorder) UpdateAccountAmount(ctx, acc_uuid, acc_new
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountAmount", reflect.TypeOf((*MockRepository)(nil).UpdateAccountAmount), ctx, acc_uuid, acc_new_amount)
 }
 
+// UpdateAccountAmountWithMovement mocks base method.
+func (m *MockRepository) UpdateAccountAmountWithMovement(ctx context.Context, movement *models.AccountMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountAmountWithMovement", ctx, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccountAmountWithMovement indicates an expected call of UpdateAccountAmountWithMovement.
+func (mr *MockRepositoryMockRecorder) UpdateAccountAmountWithMovement(ctx, movement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountAmountWithMovement", reflect.TypeOf((*MockRepository)(nil).UpdateAccountAmountWithMovement), ctx, movement)
+}
+
 // UpdateAccountStatus mocks base method.
 func (m *MockRepository) UpdateAccountStatus(ctx context.Context, acc_uuid uuid.UUID, new_status uint8) error {
 	m.ctrl.T.Helper()
@@ -165,3 +414,17 @@ func (mr *MockRepositoryMockRecorder) UpdateAccountStatus(ctx, acc_uuid, new_sta
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockRepository)(nil).UpdateAccountStatus), ctx, acc_uuid, new_status)
 }
+
+// WidthAccountAmount mocks base method.
+func (m *MockRepository) WidthAccountAmount(ctx context.Context, movement *models.AccountMovement, check func(*models.WithdrawalState) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WidthAccountAmount", ctx, movement, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WidthAccountAmount indicates an expected call of WidthAccountAmount.
+func (mr *MockRepositoryMockRecorder) WidthAccountAmount(ctx, movement, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WidthAccountAmount", reflect.TypeOf((*MockRepository)(nil).WidthAccountAmount), ctx, movement, check)
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/GCFactory/dbo-system/service/account/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -37,17 +38,17 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 }
 
 // AddingAcc mocks base method.
-func (m *MockUseCase) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64) error {
+func (m *MockUseCase) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64, operation_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddingAcc", ctx, acc_uuid, add_value)
+	ret := m.ctrl.Call(m, "AddingAcc", ctx, acc_uuid, add_value, operation_uuid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddingAcc indicates an expected call of AddingAcc.
-func (mr *MockUseCaseMockRecorder) AddingAcc(ctx, acc_uuid, add_value interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) AddingAcc(ctx, acc_uuid, add_value, operation_uuid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddingAcc", reflect.TypeOf((*MockUseCase)(nil).AddingAcc), ctx, acc_uuid, add_value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddingAcc", reflect.TypeOf((*MockUseCase)(nil).AddingAcc), ctx, acc_uuid, add_value, operation_uuid)
 }
 
 // BlockAcc mocks base method.
@@ -64,6 +65,20 @@ func (mr *MockUseCaseMockRecorder) BlockAcc(ctx, acc_uuid interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAcc", reflect.TypeOf((*MockUseCase)(nil).BlockAcc), ctx, acc_uuid)
 }
 
+// CaptureHold mocks base method.
+func (m *MockUseCase) CaptureHold(ctx context.Context, acc_uuid, hold_uuid uuid.UUID, amount float64, operation_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, acc_uuid, hold_uuid, amount, operation_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockUseCaseMockRecorder) CaptureHold(ctx, acc_uuid, hold_uuid, amount, operation_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockUseCase)(nil).CaptureHold), ctx, acc_uuid, hold_uuid, amount, operation_uuid)
+}
+
 // CloseAcc mocks base method.
 func (m *MockUseCase) CloseAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -92,6 +107,65 @@ func (mr *MockUseCaseMockRecorder) CreateAcc(ctx, acc_uuid interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAcc", reflect.TypeOf((*MockUseCase)(nil).CreateAcc), ctx, acc_uuid)
 }
 
+// CreateHold mocks base method.
+func (m *MockUseCase) CreateHold(ctx context.Context, acc_uuid uuid.UUID, amount float64, ttl time.Duration, operation_uuid uuid.UUID) (*models.AccountHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, acc_uuid, amount, ttl, operation_uuid)
+	ret0, _ := ret[0].(*models.AccountHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockUseCaseMockRecorder) CreateHold(ctx, acc_uuid, amount, ttl, operation_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockUseCase)(nil).CreateHold), ctx, acc_uuid, amount, ttl, operation_uuid)
+}
+
+// CreateStatement mocks base method.
+func (m *MockUseCase) CreateStatement(ctx context.Context, acc_uuid, owner_uuid uuid.UUID, from, to time.Time, format string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatement", ctx, acc_uuid, owner_uuid, from, to, format)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatement indicates an expected call of CreateStatement.
+func (mr *MockUseCaseMockRecorder) CreateStatement(ctx, acc_uuid, owner_uuid, from, to, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatement", reflect.TypeOf((*MockUseCase)(nil).CreateStatement), ctx, acc_uuid, owner_uuid, from, to, format)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockUseCase) ExpireHolds(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockUseCaseMockRecorder) ExpireHolds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockUseCase)(nil).ExpireHolds), ctx)
+}
+
+// GetAccHistory mocks base method.
+func (m *MockUseCase) GetAccHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter) (*models.AccountHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccHistory", ctx, acc_uuid, filter)
+	ret0, _ := ret[0].(*models.AccountHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccHistory indicates an expected call of GetAccHistory.
+func (mr *MockUseCaseMockRecorder) GetAccHistory(ctx, acc_uuid, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccHistory", reflect.TypeOf((*MockUseCase)(nil).GetAccHistory), ctx, acc_uuid, filter)
+}
+
 // GetAccInfo mocks base method.
 func (m *MockUseCase) GetAccInfo(ctx context.Context, acc_uuid uuid.UUID) (*models.FullAccountData, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +181,36 @@ func (mr *MockUseCaseMockRecorder) GetAccInfo(ctx, acc_uuid interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccInfo", reflect.TypeOf((*MockUseCase)(nil).GetAccInfo), ctx, acc_uuid)
 }
 
+// GetExchangeRates mocks base method.
+func (m *MockUseCase) GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRates", ctx)
+	ret0, _ := ret[0].([]*models.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRates indicates an expected call of GetExchangeRates.
+func (mr *MockUseCaseMockRecorder) GetExchangeRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRates", reflect.TypeOf((*MockUseCase)(nil).GetExchangeRates), ctx)
+}
+
+// GetWithdrawalLimits mocks base method.
+func (m *MockUseCase) GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithdrawalLimits", ctx, subject_type, subject_uuid)
+	ret0, _ := ret[0].(*models.WithdrawalLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithdrawalLimits indicates an expected call of GetWithdrawalLimits.
+func (mr *MockUseCaseMockRecorder) GetWithdrawalLimits(ctx, subject_type, subject_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithdrawalLimits", reflect.TypeOf((*MockUseCase)(nil).GetWithdrawalLimits), ctx, subject_type, subject_uuid)
+}
+
 // OpenAcc mocks base method.
 func (m *MockUseCase) OpenAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -121,6 +225,34 @@ func (mr *MockUseCaseMockRecorder) OpenAcc(ctx, acc_uuid interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAcc", reflect.TypeOf((*MockUseCase)(nil).OpenAcc), ctx, acc_uuid)
 }
 
+// ReleaseHold mocks base method.
+func (m *MockUseCase) ReleaseHold(ctx context.Context, acc_uuid, hold_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, acc_uuid, hold_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockUseCaseMockRecorder) ReleaseHold(ctx, acc_uuid, hold_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockUseCase)(nil).ReleaseHold), ctx, acc_uuid, hold_uuid)
+}
+
+// RemoveAccount mocks base method.
+func (m *MockUseCase) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAccount", ctx, acc_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAccount indicates an expected call of RemoveAccount.
+func (mr *MockUseCaseMockRecorder) RemoveAccount(ctx, acc_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAccount", reflect.TypeOf((*MockUseCase)(nil).RemoveAccount), ctx, acc_uuid)
+}
+
 // ReservAcc mocks base method.
 func (m *MockUseCase) ReservAcc(ctx context.Context, acc_data *models.FullAccountData) error {
 	m.ctrl.T.Helper()
@@ -135,6 +267,48 @@ func (mr *MockUseCaseMockRecorder) ReservAcc(ctx, acc_data interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservAcc", reflect.TypeOf((*MockUseCase)(nil).ReservAcc), ctx, acc_data)
 }
 
+// SetExchangeRate mocks base method.
+func (m *MockUseCase) SetExchangeRate(ctx context.Context, base_currency, quote_currency string, rate float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExchangeRate", ctx, base_currency, quote_currency, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExchangeRate indicates an expected call of SetExchangeRate.
+func (mr *MockUseCaseMockRecorder) SetExchangeRate(ctx, base_currency, quote_currency, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExchangeRate", reflect.TypeOf((*MockUseCase)(nil).SetExchangeRate), ctx, base_currency, quote_currency, rate)
+}
+
+// SetWithdrawalLimits mocks base method.
+func (m *MockUseCase) SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithdrawalLimits", ctx, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithdrawalLimits indicates an expected call of SetWithdrawalLimits.
+func (mr *MockUseCaseMockRecorder) SetWithdrawalLimits(ctx, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithdrawalLimits", reflect.TypeOf((*MockUseCase)(nil).SetWithdrawalLimits), ctx, limits)
+}
+
+// TransferAcc mocks base method.
+func (m *MockUseCase) TransferAcc(ctx context.Context, from_acc_uuid, to_acc_uuid uuid.UUID, amount float64, operation_uuid, user_uuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferAcc", ctx, from_acc_uuid, to_acc_uuid, amount, operation_uuid, user_uuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferAcc indicates an expected call of TransferAcc.
+func (mr *MockUseCaseMockRecorder) TransferAcc(ctx, from_acc_uuid, to_acc_uuid, amount, operation_uuid, user_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferAcc", reflect.TypeOf((*MockUseCase)(nil).TransferAcc), ctx, from_acc_uuid, to_acc_uuid, amount, operation_uuid, user_uuid)
+}
+
 // ValidateAccBankNumber mocks base method.
 func (m *MockUseCase) ValidateAccBankNumber(ctx context.Context, acc_bank_number string) error {
 	m.ctrl.T.Helper()
@@ -191,32 +365,32 @@ func (mr *MockUseCaseMockRecorder) ValidateAccCountryRegion(ctx, acc_country, ac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccCountryRegion", reflect.TypeOf((*MockUseCase)(nil).ValidateAccCountryRegion), ctx, acc_country, acc_country_region)
 }
 
-// ValidateAccMainOffice mocks base method.
-func (m *MockUseCase) ValidateAccMainOffice(ctx context.Context, acc_country, acc_country_region, acc_main_office string) error {
+// ValidateAccCurrency mocks base method.
+func (m *MockUseCase) ValidateAccCurrency(ctx context.Context, currency string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateAccMainOffice", ctx, acc_country, acc_country_region, acc_main_office)
+	ret := m.ctrl.Call(m, "ValidateAccCurrency", ctx, currency)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ValidateAccMainOffice indicates an expected call of ValidateAccMainOffice.
-func (mr *MockUseCaseMockRecorder) ValidateAccMainOffice(ctx, acc_country, acc_country_region, acc_main_office interface{}) *gomock.Call {
+// ValidateAccCurrency indicates an expected call of ValidateAccCurrency.
+func (mr *MockUseCaseMockRecorder) ValidateAccCurrency(ctx, currency interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccMainOffice", reflect.TypeOf((*MockUseCase)(nil).ValidateAccMainOffice), ctx, acc_country, acc_country_region, acc_main_office)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccCurrency", reflect.TypeOf((*MockUseCase)(nil).ValidateAccCurrency), ctx, currency)
 }
 
-// ValidateAccMoneyValue mocks base method.
-func (m *MockUseCase) ValidateAccMoneyValue(ctx context.Context, money_value uint8) error {
+// ValidateAccMainOffice mocks base method.
+func (m *MockUseCase) ValidateAccMainOffice(ctx context.Context, acc_country, acc_country_region, acc_main_office string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateAccMoneyValue", ctx, money_value)
+	ret := m.ctrl.Call(m, "ValidateAccMainOffice", ctx, acc_country, acc_country_region, acc_main_office)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ValidateAccMoneyValue indicates an expected call of ValidateAccMoneyValue.
-func (mr *MockUseCaseMockRecorder) ValidateAccMoneyValue(ctx, money_value interface{}) *gomock.Call {
+// ValidateAccMainOffice indicates an expected call of ValidateAccMainOffice.
+func (mr *MockUseCaseMockRecorder) ValidateAccMainOffice(ctx, acc_country, acc_country_region, acc_main_office interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccMoneyValue", reflect.TypeOf((*MockUseCase)(nil).ValidateAccMoneyValue), ctx, money_value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAccMainOffice", reflect.TypeOf((*MockUseCase)(nil).ValidateAccMainOffice), ctx, acc_country, acc_country_region, acc_main_office)
 }
 
 // ValidateAccStatus mocks base method.
@@ -290,10 +464,10 @@ func (mr *MockUseCaseMockRecorder) ValidateCulcNumber(ctx, culc_number interface
 }
 
 // ValidateCurrency mocks base method.
-func (m *MockUseCase) ValidateCurrency(ctx context.Context, currency string) (uint8, error) {
+func (m *MockUseCase) ValidateCurrency(ctx context.Context, currency string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateCurrency", ctx, currency)
-	ret0, _ := ret[0].(uint8)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -347,15 +521,15 @@ func (mr *MockUseCaseMockRecorder) ValidatePaymentSystem(ctx, payment_system int
 }
 
 // WidthAcc mocks base method.
-func (m *MockUseCase) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64) error {
+func (m *MockUseCase) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64, operation_uuid, user_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WidthAcc", ctx, acc_uuid, width_value)
+	ret := m.ctrl.Call(m, "WidthAcc", ctx, acc_uuid, width_value, operation_uuid, user_uuid)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WidthAcc indicates an expected call of WidthAcc.
-func (mr *MockUseCaseMockRecorder) WidthAcc(ctx, acc_uuid, width_value interface{}) *gomock.Call {
+func (mr *MockUseCaseMockRecorder) WidthAcc(ctx, acc_uuid, width_value, operation_uuid, user_uuid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WidthAcc", reflect.TypeOf((*MockUseCase)(nil).WidthAcc), ctx, acc_uuid, width_value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WidthAcc", reflect.TypeOf((*MockUseCase)(nil).WidthAcc), ctx, acc_uuid, width_value, operation_uuid, user_uuid)
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Repository interface {
@@ -18,4 +19,21 @@ type Repository interface {
 	DeleteReserveReason(ctx context.Context, acc_uuid uuid.UUID) error
 	GetReserveReason(ctx context.Context, acc_uuid uuid.UUID) (*models.ReserverReason, error)
 	RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error
+	UpdateAccountAmountWithMovement(ctx context.Context, movement *models.AccountMovement) error
+	GetAccountMovements(ctx context.Context, acc_uuid uuid.UUID, from time.Time, to time.Time) ([]*models.AccountMovement, error)
+	GetAccountMovementsSum(ctx context.Context, acc_uuid uuid.UUID, since time.Time) (float64, error)
+	GetAccountHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter, after *models.HistoryCursor, limit int) ([]*models.AccountMovement, error)
+	GetAccountHoldsAmount(ctx context.Context, acc_uuid uuid.UUID) (float64, error)
+	CreateHold(ctx context.Context, hold *models.AccountHold) (bool, error)
+	GetHold(ctx context.Context, hold_uuid uuid.UUID) (*models.AccountHold, error)
+	CaptureHold(ctx context.Context, hold_uuid uuid.UUID, movement *models.AccountMovement) error
+	ReleaseHold(ctx context.Context, hold_uuid uuid.UUID) error
+	ExpireHolds(ctx context.Context) (int64, error)
+	WidthAccountAmount(ctx context.Context, movement *models.AccountMovement, check func(state *models.WithdrawalState) error) error
+	GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error)
+	SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error
+	TransferAmount(ctx context.Context, from *models.AccountMovement, to *models.AccountMovement, conversion *models.CurrencyConversion, check func(state *models.WithdrawalState) error) error
+	GetExchangeRate(ctx context.Context, base_currency string, quote_currency string) (*models.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error)
+	SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) error
 }
@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	registration "github.com/GCFactory/dbo-system/service/account/internal/account"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/opentracing/opentracing-go"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type accountRepo struct {
@@ -15,8 +20,8 @@ type accountRepo struct {
 }
 
 func (repo accountRepo) CreateAccount(ctx context.Context, account *models.Account, reason *models.ReserverReason) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.CreateAccount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.CreateAccount")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(local_ctx, nil)
 	if err != nil {
@@ -36,7 +41,7 @@ func (repo accountRepo) CreateAccount(ctx context.Context, account *models.Accou
 		account.Acc_corr_number,
 		account.Acc_bic,
 		account.Acc_cio,
-		account.Acc_money_value,
+		account.Acc_currency,
 	); err != nil {
 		println("CreateAccount", err.Error())
 		return ErrorCreateAccount
@@ -59,8 +64,8 @@ func (repo accountRepo) CreateAccount(ctx context.Context, account *models.Accou
 }
 
 func (repo accountRepo) DeleteAccount(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.DeleteAccount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.DeleteAccount")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		DeleteAccount,
@@ -81,8 +86,8 @@ func (repo accountRepo) DeleteAccount(ctx context.Context, acc_uuid uuid.UUID) e
 }
 
 func (repo accountRepo) GetAccountData(ctx context.Context, acc_uuid uuid.UUID) (*models.Account, error) {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.GetAccountData")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountData")
+	defer span.End()
 
 	var result models.Account
 
@@ -97,8 +102,8 @@ func (repo accountRepo) GetAccountData(ctx context.Context, acc_uuid uuid.UUID)
 }
 
 func (repo accountRepo) UpdateAccountStatus(ctx context.Context, acc_uuid uuid.UUID, new_status uint8) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.UpdateAccountStatus")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.UpdateAccountStatus")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		UpdateAccountStatus,
@@ -119,8 +124,8 @@ func (repo accountRepo) UpdateAccountStatus(ctx context.Context, acc_uuid uuid.U
 }
 
 func (repo accountRepo) GetAccountStatus(ctx context.Context, acc_uuid uuid.UUID) (uint8, error) {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.GetAccountStatus")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountStatus")
+	defer span.End()
 
 	var result models.Account
 
@@ -135,8 +140,8 @@ func (repo accountRepo) GetAccountStatus(ctx context.Context, acc_uuid uuid.UUID
 }
 
 func (repo accountRepo) UpdateAccountAmount(ctx context.Context, acc_uuid uuid.UUID, acc_new_amount float64) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.UpdateAccountAmount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.UpdateAccountAmount")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		UpdateAccountAmount,
@@ -156,8 +161,8 @@ func (repo accountRepo) UpdateAccountAmount(ctx context.Context, acc_uuid uuid.U
 }
 
 func (repo accountRepo) GetAccountAmount(ctx context.Context, acc_uuid uuid.UUID) (float64, error) {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.GetAccountAmount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountAmount")
+	defer span.End()
 
 	var result models.Account
 
@@ -172,8 +177,8 @@ func (repo accountRepo) GetAccountAmount(ctx context.Context, acc_uuid uuid.UUID
 }
 
 func (repo accountRepo) DeleteReserveReason(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.DeleteReserveReason")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.DeleteReserveReason")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		DeleteReserveReason,
@@ -194,8 +199,8 @@ func (repo accountRepo) DeleteReserveReason(ctx context.Context, acc_uuid uuid.U
 }
 
 func (repo accountRepo) GetReserveReason(ctx context.Context, acc_uuid uuid.UUID) (*models.ReserverReason, error) {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.GetReserveReason")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetReserveReason")
+	defer span.End()
 
 	var result models.ReserverReason
 
@@ -210,8 +215,8 @@ func (repo accountRepo) GetReserveReason(ctx context.Context, acc_uuid uuid.UUID
 }
 
 func (repo accountRepo) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "accountRepo.RemoveAccount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.RemoveAccount")
+	defer span.End()
 
 	if _, err := repo.db.ExecContext(local_ctx,
 		RemoveAccount,
@@ -223,6 +228,649 @@ func (repo accountRepo) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) e
 	return nil
 }
 
+// UpdateAccountAmountWithMovement sets account amount to movement balance and saves movement in one transaction
+func (repo accountRepo) UpdateAccountAmountWithMovement(ctx context.Context, movement *models.AccountMovement) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.UpdateAccountAmountWithMovement")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(local_ctx,
+		UpdateAccountAmount,
+		movement.Acc_uuid,
+		movement.Balance,
+	)
+	if err != nil {
+		return ErrorUpdateAccountAmount
+	} else {
+		count, err := res.RowsAffected()
+		if err != nil || count == 0 {
+			return ErrorUpdateAccountAmount
+		}
+	}
+
+	if _, err = tx.ExecContext(local_ctx,
+		AddAccountMovement,
+		movement.Movement_uuid,
+		movement.Acc_uuid,
+		movement.Movement_type,
+		movement.Amount,
+		movement.Balance,
+		movement.Created_at,
+		movement.Operation_uuid,
+		movement.User_uuid,
+	); err != nil {
+		return ErrorAddAccountMovement
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetAccountMovements returns account movements in [from, to) ordered by time
+func (repo accountRepo) GetAccountMovements(ctx context.Context, acc_uuid uuid.UUID, from time.Time, to time.Time) ([]*models.AccountMovement, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountMovements")
+	defer span.End()
+
+	result := make([]*models.AccountMovement, 0)
+
+	if err := repo.db.SelectContext(local_ctx,
+		&result,
+		GetAccountMovements,
+		acc_uuid,
+		from,
+		to,
+	); err != nil {
+		return nil, ErrorGetAccountMovements
+	}
+
+	return result, nil
+}
+
+// GetAccountMovementsSum returns sum of account movements made since given time
+func (repo accountRepo) GetAccountMovementsSum(ctx context.Context, acc_uuid uuid.UUID, since time.Time) (float64, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountMovementsSum")
+	defer span.End()
+
+	var result float64
+
+	if err := repo.db.GetContext(local_ctx,
+		&result,
+		GetAccountMovementsSum,
+		acc_uuid,
+		since,
+	); err != nil {
+		return 0, ErrorGetMovementsSum
+	}
+
+	return result, nil
+}
+
+// GetAccountHistory returns up to limit account movements matching filter, newest first,
+// after cursor movement when it isn't nil
+func (repo accountRepo) GetAccountHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter, after *models.HistoryCursor, limit int) ([]*models.AccountMovement, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountHistory")
+	defer span.End()
+
+	var query strings.Builder
+	query.WriteString(GetAccountHistory)
+	args := []interface{}{acc_uuid}
+
+	addCondition := func(condition string, values ...interface{}) {
+		for _, value := range values {
+			args = append(args, value)
+			condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(args)), 1)
+		}
+		query.WriteString(" AND " + condition)
+	}
+
+	if !filter.From.IsZero() {
+		addCondition("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < ?", filter.To)
+	}
+	switch filter.Direction {
+	case models.MovementTypeDeposit:
+		addCondition("amount > 0")
+	case models.MovementTypeWithdrawal:
+		addCondition("amount < 0")
+	}
+	if filter.MinAmount != nil {
+		addCondition("ABS(amount) >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		addCondition("ABS(amount) <= ?", *filter.MaxAmount)
+	}
+	if filter.Operation_uuid != uuid.Nil {
+		addCondition("operation_uuid = ?", filter.Operation_uuid)
+	}
+	if after != nil {
+		addCondition("(created_at, movement_uuid) < (?, ?)", after.Created_at, after.Movement_uuid)
+	}
+
+	query.WriteString(GetAccountHistoryOrder + strconv.Itoa(limit))
+
+	result := make([]*models.AccountMovement, 0)
+
+	if err := repo.db.SelectContext(local_ctx,
+		&result,
+		query.String(),
+		args...,
+	); err != nil {
+		return nil, ErrorGetAccountHistory
+	}
+
+	return result, nil
+}
+
+// GetAccountHoldsAmount returns sum of active not expired account holds
+func (repo accountRepo) GetAccountHoldsAmount(ctx context.Context, acc_uuid uuid.UUID) (float64, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetAccountHoldsAmount")
+	defer span.End()
+
+	var result float64
+
+	if err := repo.db.GetContext(local_ctx,
+		&result,
+		GetAccountHoldsAmount,
+		acc_uuid,
+		models.HoldStatusActive,
+	); err != nil {
+		return 0, ErrorGetHoldsAmount
+	}
+
+	return result, nil
+}
+
+// CreateHold saves hold when account available amount covers it, otherwise hold isn't saved and false is returned.
+// Account row is locked while available amount is counted, so concurrent holds can't exceed account amount
+func (repo accountRepo) CreateHold(ctx context.Context, hold *models.AccountHold) (bool, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.CreateHold")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	var amount, holds_amount float64
+
+	if err = tx.GetContext(local_ctx,
+		&amount,
+		LockAccountAmount,
+		hold.Acc_uuid,
+	); err != nil {
+		return false, ErrorLockAccount
+	}
+
+	if err = tx.GetContext(local_ctx,
+		&holds_amount,
+		GetAccountHoldsAmount,
+		hold.Acc_uuid,
+		models.HoldStatusActive,
+	); err != nil {
+		return false, ErrorGetHoldsAmount
+	}
+
+	if amount-holds_amount < hold.Amount {
+		return false, nil
+	}
+
+	if _, err = tx.ExecContext(local_ctx,
+		CreateHold,
+		hold.Hold_uuid,
+		hold.Acc_uuid,
+		hold.Amount,
+		hold.Status,
+		hold.Operation_uuid,
+		hold.Created_at,
+		hold.Expires_at,
+	); err != nil {
+		return false, ErrorCreateHold
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo accountRepo) GetHold(ctx context.Context, hold_uuid uuid.UUID) (*models.AccountHold, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetHold")
+	defer span.End()
+
+	var result models.AccountHold
+
+	if err := repo.db.GetContext(local_ctx,
+		&result,
+		GetHold,
+		hold_uuid,
+	); err != nil {
+		return nil, ErrorGetHold
+	}
+
+	return &result, nil
+}
+
+// CaptureHold marks active hold captured by movement amount, withdraws it from account and saves movement
+// in one transaction. Movement balance is set to account amount after withdrawal
+func (repo accountRepo) CaptureHold(ctx context.Context, hold_uuid uuid.UUID, movement *models.AccountMovement) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.CaptureHold")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(local_ctx,
+		FinishHold,
+		hold_uuid,
+		models.HoldStatusCaptured,
+		-movement.Amount,
+		models.HoldStatusActive,
+	)
+	if err != nil {
+		return ErrorFinishHold
+	} else {
+		count, err := res.RowsAffected()
+		if err != nil || count == 0 {
+			return ErrorFinishHold
+		}
+	}
+
+	if err = applyMovement(local_ctx, tx, movement); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseHold marks active hold released, account amount isn't changed
+func (repo accountRepo) ReleaseHold(ctx context.Context, hold_uuid uuid.UUID) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.ReleaseHold")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(local_ctx,
+		FinishHold,
+		hold_uuid,
+		models.HoldStatusReleased,
+		0,
+		models.HoldStatusActive,
+	)
+	if err != nil {
+		return ErrorFinishHold
+	} else {
+		count, err := res.RowsAffected()
+		if err != nil || count == 0 {
+			return ErrorFinishHold
+		}
+	}
+
+	return nil
+}
+
+// ExpireHolds marks expired all active holds which expiry time has passed, returns count of expired holds
+func (repo accountRepo) ExpireHolds(ctx context.Context) (int64, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.ExpireHolds")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(local_ctx,
+		ExpireHolds,
+		models.HoldStatusExpired,
+		models.HoldStatusActive,
+	)
+	if err != nil {
+		return 0, ErrorExpireHolds
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrorExpireHolds
+	}
+
+	return count, nil
+}
+
+// WidthAccountAmount withdraws movement amount from account and saves movement in one transaction.
+// Account row and withdrawals of movement user are locked while check decides if withdrawal is allowed,
+// so concurrent withdrawals can't exceed account amount or limits. Error of check is returned as is.
+// Movement balance is set to account amount after withdrawal
+func (repo accountRepo) WidthAccountAmount(ctx context.Context, movement *models.AccountMovement, check func(state *models.WithdrawalState) error) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.WidthAccountAmount")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err = lockUserWithdrawals(local_ctx, tx, movement.User_uuid); err != nil {
+		return err
+	}
+
+	state, err := getWithdrawalState(local_ctx, tx, movement)
+	if err != nil {
+		return err
+	}
+
+	if err = check(state); err != nil {
+		return err
+	}
+
+	if err = applyMovement(local_ctx, tx, movement); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TransferAmount withdraws from movement from its account and deposits to movement to its account in one
+// transaction, conversion is saved when it isn't nil. Withdrawal is checked as in WidthAccountAmount,
+// both accounts are locked in same order by all transfers, so opposite transfers don't deadlock
+func (repo accountRepo) TransferAmount(ctx context.Context, from *models.AccountMovement, to *models.AccountMovement, conversion *models.CurrencyConversion, check func(state *models.WithdrawalState) error) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.TransferAmount")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	if err = lockUserWithdrawals(local_ctx, tx, from.User_uuid); err != nil {
+		return err
+	}
+
+	lockTo := func() error {
+		var amount float64
+		if err := tx.GetContext(local_ctx,
+			&amount,
+			LockAccountAmount,
+			to.Acc_uuid,
+		); err != nil {
+			return ErrorLockAccount
+		}
+		return nil
+	}
+
+	to_first := strings.Compare(to.Acc_uuid.String(), from.Acc_uuid.String()) < 0
+	if to_first {
+		if err = lockTo(); err != nil {
+			return err
+		}
+	}
+
+	state, err := getWithdrawalState(local_ctx, tx, from)
+	if err != nil {
+		return err
+	}
+
+	if !to_first {
+		if err = lockTo(); err != nil {
+			return err
+		}
+	}
+
+	if err = check(state); err != nil {
+		return err
+	}
+
+	if err = applyMovement(local_ctx, tx, from); err != nil {
+		return err
+	}
+
+	if err = applyMovement(local_ctx, tx, to); err != nil {
+		return err
+	}
+
+	if conversion != nil {
+		if _, err = tx.ExecContext(local_ctx,
+			AddConversion,
+			conversion.Conversion_uuid,
+			conversion.From_acc_uuid,
+			conversion.To_acc_uuid,
+			conversion.From_movement_uuid,
+			conversion.To_movement_uuid,
+			conversion.From_currency,
+			conversion.To_currency,
+			conversion.From_amount,
+			conversion.To_amount,
+			conversion.Rate,
+			conversion.Operation_uuid,
+			conversion.Created_at,
+		); err != nil {
+			return ErrorAddConversion
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Withdrawals of user are serialized till end of transaction, nothing is locked without user
+func lockUserWithdrawals(ctx context.Context, tx *sqlx.Tx, user_uuid uuid.NullUUID) error {
+	if !user_uuid.Valid {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		LockUserWithdrawals,
+		user_uuid.UUID.String(),
+	); err != nil {
+		return ErrorLockUser
+	}
+
+	return nil
+}
+
+// Locks movement account and returns its amount, holds and withdrawals made by account and movement user
+// in day and month of movement
+func getWithdrawalState(ctx context.Context, tx *sqlx.Tx, movement *models.AccountMovement) (*models.WithdrawalState, error) {
+	state := &models.WithdrawalState{}
+
+	if err := tx.GetContext(ctx,
+		&state.Amount,
+		LockAccountAmount,
+		movement.Acc_uuid,
+	); err != nil {
+		return nil, ErrorLockAccount
+	}
+
+	if err := tx.GetContext(ctx,
+		&state.Holds_amount,
+		GetAccountHoldsAmount,
+		movement.Acc_uuid,
+		models.HoldStatusActive,
+	); err != nil {
+		return nil, ErrorGetHoldsAmount
+	}
+
+	year, month, day := movement.Created_at.Date()
+	day_start := time.Date(year, month, day, 0, 0, 0, 0, movement.Created_at.Location())
+	month_start := time.Date(year, month, 1, 0, 0, 0, 0, movement.Created_at.Location())
+
+	if err := tx.GetContext(ctx,
+		&state.Account,
+		GetAccountWithdrawalTotals,
+		movement.Acc_uuid,
+		models.MovementTypeWithdrawal,
+		day_start,
+		month_start,
+	); err != nil {
+		return nil, ErrorGetWithdrawals
+	}
+
+	if movement.User_uuid.Valid {
+		if err := tx.SelectContext(ctx,
+			&state.User,
+			GetUserWithdrawalTotals,
+			movement.User_uuid,
+			models.MovementTypeWithdrawal,
+			day_start,
+			month_start,
+		); err != nil {
+			return nil, ErrorGetWithdrawals
+		}
+	}
+
+	return state, nil
+}
+
+// Changes account amount by movement amount and saves movement with account amount after change as balance
+func applyMovement(ctx context.Context, tx *sqlx.Tx, movement *models.AccountMovement) error {
+	if err := tx.GetContext(ctx,
+		&movement.Balance,
+		ChangeAccountAmount,
+		movement.Acc_uuid,
+		movement.Amount,
+	); err != nil {
+		return ErrorUpdateAccountAmount
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		AddAccountMovement,
+		movement.Movement_uuid,
+		movement.Acc_uuid,
+		movement.Movement_type,
+		movement.Amount,
+		movement.Balance,
+		movement.Created_at,
+		movement.Operation_uuid,
+		movement.User_uuid,
+	); err != nil {
+		return ErrorAddAccountMovement
+	}
+
+	return nil
+}
+
+// GetWithdrawalLimits returns limits saved for subject, nil is returned when subject has no limits
+func (repo accountRepo) GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetWithdrawalLimits")
+	defer span.End()
+
+	var result models.WithdrawalLimits
+
+	if err := repo.db.GetContext(local_ctx,
+		&result,
+		GetWithdrawalLimits,
+		subject_type,
+		subject_uuid,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, ErrorGetWithdrawalLimits
+	}
+
+	return &result, nil
+}
+
+// SetWithdrawalLimits saves limits of subject replacing previous ones
+func (repo accountRepo) SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.SetWithdrawalLimits")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		SetWithdrawalLimits,
+		limits.Subject_type,
+		limits.Subject_uuid,
+		limits.Per_operation,
+		limits.Daily_amount,
+		limits.Monthly_amount,
+		limits.Daily_count,
+		limits.Monthly_count,
+	); err != nil {
+		return ErrorSetWithdrawalLimits
+	}
+
+	return nil
+}
+
+// GetExchangeRate returns rate of base currency in quote one, nil is returned when rate isn't set
+func (repo accountRepo) GetExchangeRate(ctx context.Context, base_currency string, quote_currency string) (*models.ExchangeRate, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetExchangeRate")
+	defer span.End()
+
+	var result models.ExchangeRate
+
+	if err := repo.db.GetContext(local_ctx,
+		&result,
+		GetExchangeRate,
+		base_currency,
+		quote_currency,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, ErrorGetExchangeRate
+	}
+
+	return &result, nil
+}
+
+func (repo accountRepo) GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error) {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.GetExchangeRates")
+	defer span.End()
+
+	result := make([]*models.ExchangeRate, 0)
+
+	if err := repo.db.SelectContext(local_ctx,
+		&result,
+		GetExchangeRates,
+	); err != nil {
+		return nil, ErrorGetExchangeRates
+	}
+
+	return result, nil
+}
+
+// SetExchangeRate saves rate of currencies pair replacing previous one
+func (repo accountRepo) SetExchangeRate(ctx context.Context, rate *models.ExchangeRate) error {
+	local_ctx, span := tracing.StartSpan(ctx, "accountRepo.SetExchangeRate")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		SetExchangeRate,
+		rate.Base_currency,
+		rate.Quote_currency,
+		rate.Rate,
+	); err != nil {
+		return ErrorSetExchangeRate
+	}
+
+	return nil
+}
+
 func NewAccountRepository(db *sqlx.DB) registration.Repository {
 	return &accountRepo{db: db}
 }
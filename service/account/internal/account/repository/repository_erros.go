@@ -13,4 +13,22 @@ var (
 	ErrorUpdateAccountAmount = errors.New("accountRepo.UpdateAccountAmount.ExecContext")
 	ErrorDeleteAccount       = errors.New("accountRepo.DeleteAccount.ExecContext")
 	ErrorDeleteReserveReason = errors.New("accountRepo.DeleteReserveReason.ExecContext")
+	ErrorAddAccountMovement  = errors.New("accountRepo.AddAccountMovement.ExecContext")
+	ErrorGetAccountMovements = errors.New("accountRepo.GetAccountMovements.SelectContext")
+	ErrorGetMovementsSum     = errors.New("accountRepo.GetAccountMovementsSum.GetContext")
+	ErrorGetAccountHistory   = errors.New("accountRepo.GetAccountHistory.SelectContext")
+	ErrorLockAccount         = errors.New("accountRepo.LockAccountAmount.GetContext")
+	ErrorGetHoldsAmount      = errors.New("accountRepo.GetAccountHoldsAmount.GetContext")
+	ErrorCreateHold          = errors.New("accountRepo.CreateHold.ExecContext")
+	ErrorGetHold             = errors.New("accountRepo.GetHold.GetContext")
+	ErrorFinishHold          = errors.New("accountRepo.FinishHold.ExecContext")
+	ErrorExpireHolds         = errors.New("accountRepo.ExpireHolds.ExecContext")
+	ErrorLockUser            = errors.New("accountRepo.LockUserWithdrawals.ExecContext")
+	ErrorGetWithdrawals      = errors.New("accountRepo.GetWithdrawalTotals.GetContext")
+	ErrorGetWithdrawalLimits = errors.New("accountRepo.GetWithdrawalLimits.GetContext")
+	ErrorSetWithdrawalLimits = errors.New("accountRepo.SetWithdrawalLimits.ExecContext")
+	ErrorGetExchangeRate     = errors.New("accountRepo.GetExchangeRate.GetContext")
+	ErrorGetExchangeRates    = errors.New("accountRepo.GetExchangeRates.SelectContext")
+	ErrorSetExchangeRate     = errors.New("accountRepo.SetExchangeRate.ExecContext")
+	ErrorAddConversion       = errors.New("accountRepo.AddConversion.ExecContext")
 )
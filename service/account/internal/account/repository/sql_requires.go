@@ -9,7 +9,7 @@ const (
                       acc_corr_number, 
                       acc_bic, 
                       acc_cio, 
-                      acc_money_value)
+                      acc_currency)
 			VALUES($1, $2, $3, $4, $5, $6, $7, $8)`
 	InsertReserveReason = `INSERT INTO accounts_reserved (acc_uuid, reserve_reason)
 			VALUES($1, $2)`
@@ -22,4 +22,98 @@ const (
 	GetReserveReason    = `SELECT * FROM accounts_reserved WHERE acc_uuid = $1`
 	DeleteReserveReason = `DELETE FROM accounts_reserved WHERE acc_uuid = $1`
 	RemoveAccount       = `DELETE FROM accounts WHERE acc_uuid = $1`
+	AddAccountMovement  = `INSERT INTO accounts_movements (
+                      movement_uuid,
+                      acc_uuid,
+                      movement_type,
+                      amount,
+                      balance,
+                      created_at,
+                      operation_uuid,
+                      user_uuid)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8)`
+	GetAccountMovements = `SELECT * FROM accounts_movements
+			WHERE acc_uuid = $1 AND created_at >= $2 AND created_at < $3
+			ORDER BY created_at, movement_uuid`
+	GetAccountMovementsSum = `SELECT COALESCE(SUM(amount), 0) FROM accounts_movements WHERE acc_uuid = $1 AND created_at >= $2`
+	// Filters of GetAccountHistory are appended by accountRepo.GetAccountHistory
+	GetAccountHistory      = `SELECT * FROM accounts_movements WHERE acc_uuid = $1`
+	GetAccountHistoryOrder = ` ORDER BY created_at DESC, movement_uuid DESC LIMIT `
+	// Account row stays locked till end of transaction, so concurrent holds and captures see same balance
+	LockAccountAmount   = `SELECT acc_money_amount FROM ONLY accounts WHERE acc_uuid = $1 FOR UPDATE`
+	ChangeAccountAmount = `UPDATE ONLY accounts SET acc_money_amount = acc_money_amount + $2 WHERE acc_uuid = $1
+			RETURNING acc_money_amount`
+	GetAccountHoldsAmount = `SELECT COALESCE(SUM(amount), 0) FROM accounts_holds
+			WHERE acc_uuid = $1 AND status = $2 AND expires_at > now()`
+	CreateHold = `INSERT INTO accounts_holds (
+                      hold_uuid,
+                      acc_uuid,
+                      amount,
+                      status,
+                      operation_uuid,
+                      created_at,
+                      expires_at,
+                      updated_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $6)`
+	GetHold    = `SELECT * FROM accounts_holds WHERE hold_uuid = $1`
+	FinishHold = `UPDATE accounts_holds SET status = $2, captured_amount = $3, updated_at = now()
+			WHERE hold_uuid = $1 AND status = $4 AND expires_at > now()`
+	ExpireHolds = `UPDATE accounts_holds SET status = $1, updated_at = now() WHERE status = $2 AND expires_at <= now()`
+	// Withdrawals of one user are serialized by lock on user, so concurrent withdrawals from user accounts
+	// see same user totals
+	LockUserWithdrawals        = `SELECT pg_advisory_xact_lock(hashtext($1))`
+	GetAccountWithdrawalTotals = `SELECT
+			COALESCE(SUM(-amount) FILTER (WHERE created_at >= $3), 0) AS daily_amount,
+			COUNT(*) FILTER (WHERE created_at >= $3) AS daily_count,
+			COALESCE(SUM(-amount), 0) AS monthly_amount,
+			COUNT(*) AS monthly_count
+			FROM accounts_movements WHERE acc_uuid = $1 AND movement_type = $2 AND created_at >= $4`
+	GetUserWithdrawalTotals = `SELECT
+			accounts.acc_currency AS currency,
+			COALESCE(SUM(-movements.amount) FILTER (WHERE movements.created_at >= $3), 0) AS daily_amount,
+			COUNT(*) FILTER (WHERE movements.created_at >= $3) AS daily_count,
+			COALESCE(SUM(-movements.amount), 0) AS monthly_amount,
+			COUNT(*) AS monthly_count
+			FROM accounts_movements AS movements JOIN accounts ON accounts.acc_uuid = movements.acc_uuid
+			WHERE movements.user_uuid = $1 AND movements.movement_type = $2 AND movements.created_at >= $4
+			GROUP BY accounts.acc_currency`
+	GetWithdrawalLimits = `SELECT * FROM accounts_withdrawal_limits WHERE subject_type = $1 AND subject_uuid = $2`
+	SetWithdrawalLimits = `INSERT INTO accounts_withdrawal_limits (
+                      subject_type,
+                      subject_uuid,
+                      per_operation,
+                      daily_amount,
+                      monthly_amount,
+                      daily_count,
+                      monthly_count,
+                      updated_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7, now())
+			ON CONFLICT (subject_type, subject_uuid) DO UPDATE SET
+                      per_operation = EXCLUDED.per_operation,
+                      daily_amount = EXCLUDED.daily_amount,
+                      monthly_amount = EXCLUDED.monthly_amount,
+                      daily_count = EXCLUDED.daily_count,
+                      monthly_count = EXCLUDED.monthly_count,
+                      updated_at = EXCLUDED.updated_at`
+	GetExchangeRate  = `SELECT * FROM accounts_exchange_rates WHERE base_currency = $1 AND quote_currency = $2`
+	GetExchangeRates = `SELECT * FROM accounts_exchange_rates ORDER BY base_currency, quote_currency`
+	SetExchangeRate  = `INSERT INTO accounts_exchange_rates (base_currency, quote_currency, rate, updated_at)
+			VALUES($1, $2, $3, now())
+			ON CONFLICT (base_currency, quote_currency) DO UPDATE SET
+                      rate = EXCLUDED.rate,
+                      updated_at = EXCLUDED.updated_at`
+	AddConversion = `INSERT INTO accounts_conversions (
+                      conversion_uuid,
+                      from_acc_uuid,
+                      to_acc_uuid,
+                      from_movement_uuid,
+                      to_movement_uuid,
+                      from_currency,
+                      to_currency,
+                      from_amount,
+                      to_amount,
+                      rate,
+                      operation_uuid,
+                      created_at)
+			VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
 )
@@ -0,0 +1,50 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+)
+
+// RenderCSV writes requisites and balances as "name,value" rows followed by movements table
+func RenderCSV(st *models.Statement) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	deposits, withdrawals := totals(st)
+
+	rows := [][]string{
+		{"Account statement"},
+		{"Account name", st.Account.Acc_name},
+		{"Culc number", st.Account.Acc_culc_number},
+		{"Corr number", st.Account.Acc_corr_number},
+		{"BIC", st.Account.Acc_bic},
+		{"KPP", st.Account.Acc_cio},
+		{"Period from", st.From.Format(DateFormat)},
+		{"Period to", lastDay(st).Format(DateFormat)},
+		{"Opening balance", formatMoney(st.OpeningBalance)},
+		{},
+		{"Date", "Type", "Amount", "Balance"},
+	}
+	for _, movement := range st.Movements {
+		rows = append(rows, []string{
+			movement.Created_at.UTC().Format(DateTimeFormat),
+			movementTypeName(movement.Movement_type),
+			formatMoney(movement.Amount),
+			formatMoney(movement.Balance),
+		})
+	}
+	rows = append(rows,
+		[]string{},
+		[]string{"Deposits", formatMoney(deposits)},
+		[]string{"Withdrawals", formatMoney(withdrawals)},
+		[]string{"Closing balance", formatMoney(st.ClosingBalance)},
+		[]string{"Generated at", st.GeneratedAt.UTC().Format(DateTimeFormat)},
+	)
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,129 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"strings"
+)
+
+// A4 page with Courier text, monospace font keeps movements table aligned
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 40
+	pdfFontSize     = 9
+	pdfLeading      = 12
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// RenderPDF writes statement as text lines of PDF pages
+func RenderPDF(st *models.Statement) ([]byte, error) {
+	deposits, withdrawals := totals(st)
+
+	lines := []string{
+		"ACCOUNT STATEMENT",
+		"",
+		"Account name:    " + st.Account.Acc_name,
+		"Culc number:     " + st.Account.Acc_culc_number,
+		"Corr number:     " + st.Account.Acc_corr_number,
+		"BIC:             " + st.Account.Acc_bic,
+		"KPP:             " + st.Account.Acc_cio,
+		"Period:          " + st.From.Format(DateFormat) + " - " + lastDay(st).Format(DateFormat),
+		"",
+		"Opening balance: " + formatMoney(st.OpeningBalance),
+		"",
+		fmt.Sprintf("%-19s  %-10s  %20s  %20s", "Date", "Type", "Amount", "Balance"),
+		strings.Repeat("-", 75),
+	}
+	for _, movement := range st.Movements {
+		lines = append(lines, fmt.Sprintf("%-19s  %-10s  %20s  %20s",
+			movement.Created_at.UTC().Format(DateTimeFormat),
+			movementTypeName(movement.Movement_type),
+			formatMoney(movement.Amount),
+			formatMoney(movement.Balance),
+		))
+	}
+	if len(st.Movements) == 0 {
+		lines = append(lines, "No movements for period")
+	}
+	lines = append(lines,
+		strings.Repeat("-", 75),
+		"",
+		"Deposits:        "+formatMoney(deposits),
+		"Withdrawals:     "+formatMoney(withdrawals),
+		"Closing balance: "+formatMoney(st.ClosingBalance),
+		"",
+		"Generated at "+st.GeneratedAt.UTC().Format(DateTimeFormat)+" UTC",
+	)
+
+	return writePDF(lines), nil
+}
+
+// writePDF makes PDF 1.4 document, each page object is followed by its content stream
+func writePDF(lines []string) []byte {
+	pages := make([][]string, 0, len(lines)/pdfLinesPerPage+1)
+	for len(lines) > pdfLinesPerPage {
+		pages = append(pages, lines[:pdfLinesPerPage])
+		lines = lines[pdfLinesPerPage:]
+	}
+	pages = append(pages, lines)
+
+	var buffer bytes.Buffer
+	offsets := make([]int, 0, 3+2*len(pages))
+
+	writeObject := func(body string) {
+		offsets = append(offsets, buffer.Len())
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buffer.WriteString("%PDF-1.4\n")
+
+	// Objects 1-3 are catalog, pages and font, then page and its content for each page
+	kids := make([]string, 0, len(pages))
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 5+2*i))
+
+		var content bytes.Buffer
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, line := range page {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(line))
+		}
+		content.WriteString("ET")
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buffer.Bytes()
+}
+
+// pdfEscape escapes string literal, standard fonts have no cyrillic glyphs, so non ASCII runes are replaced by '?'
+func pdfEscape(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r < ' ' || r > '~':
+			builder.WriteRune('?')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
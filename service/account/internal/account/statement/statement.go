@@ -0,0 +1,84 @@
+package statement
+
+import (
+	"errors"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatPDF = "pdf"
+	FormatCSV = "csv"
+
+	DateFormat     = "02-01-2006"
+	DateTimeFormat = "02-01-2006 15:04:05"
+)
+
+var (
+	ErrorUnknownFormat = errors.New("Unknown statement format")
+
+	movementTypeNames = map[string]string{
+		models.MovementTypeDeposit:    "Deposit",
+		models.MovementTypeWithdrawal: "Withdrawal",
+	}
+)
+
+// Render returns statement content in given format and file name for it
+func Render(st *models.Statement, format string) ([]byte, string, error) {
+	var content []byte
+	var err error
+
+	switch format {
+	case FormatPDF:
+		content, err = RenderPDF(st)
+	case FormatCSV:
+		content, err = RenderCSV(st)
+	default:
+		return nil, "", ErrorUnknownFormat
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, FileName(st, format), nil
+}
+
+// FileName is like statement_40817810000000000001_01-01-2025_31-01-2025.pdf, To day is the last included one
+func FileName(st *models.Statement, format string) string {
+	return strings.Join([]string{
+		"statement",
+		st.Account.Acc_culc_number,
+		st.From.Format(DateFormat),
+		lastDay(st).Format(DateFormat),
+	}, "_") + "." + format
+}
+
+func formatMoney(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func movementTypeName(movementType string) string {
+	if name, ok := movementTypeNames[movementType]; ok {
+		return name
+	}
+	return movementType
+}
+
+func lastDay(st *models.Statement) time.Time {
+	return st.To.Add(-time.Nanosecond)
+}
+
+// totals returns sums of deposits and withdrawals, withdrawals sum is positive
+func totals(st *models.Statement) (float64, float64) {
+	var deposits, withdrawals float64
+	for _, movement := range st.Movements {
+		if movement.Amount >= 0 {
+			deposits += movement.Amount
+		} else {
+			withdrawals -= movement.Amount
+		}
+	}
+	return deposits, withdrawals
+}
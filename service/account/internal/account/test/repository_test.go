@@ -2,6 +2,8 @@ package test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/GCFactory/dbo-system/platform/config"
@@ -11,6 +13,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 var (
@@ -38,7 +41,7 @@ func TestAccountRepo_CreateAccount(t *testing.T) {
 	acc_corr_number := "01234567890123456789"
 	acc_bic := "123456789"
 	acc_cio := "123456789"
-	var acc_money_value uint8 = 0
+	acc_currency := "RUB"
 	acc_reason := &models.ReserverReason{
 		Acc_uuid: acc_uuid,
 		Reason:   "smth",
@@ -51,18 +54,19 @@ func TestAccountRepo_CreateAccount(t *testing.T) {
 			Acc_corr_number: acc_corr_number,
 			Acc_bic:         acc_bic,
 			Acc_cio:         acc_cio,
-			Acc_money_value: acc_money_value,
+			Acc_currency:    acc_currency,
 		}
 
 		mock.ExpectBegin()
 		mock.ExpectExec(repository.CreateAccount).WithArgs(
 			&account.Acc_uuid,
 			&account.Acc_status,
+			&account.Acc_name,
 			&account.Acc_culc_number,
 			&account.Acc_corr_number,
 			&account.Acc_bic,
 			&account.Acc_cio,
-			&account.Acc_money_value,
+			&account.Acc_currency,
 		).WillReturnResult(sqlmock.NewResult(0, 0))
 		mock.ExpectExec(repository.InsertReserveReason).WithArgs(
 			&acc_reason.Acc_uuid,
@@ -80,18 +84,19 @@ func TestAccountRepo_CreateAccount(t *testing.T) {
 			Acc_corr_number: acc_corr_number,
 			Acc_bic:         acc_bic,
 			Acc_cio:         acc_cio,
-			Acc_money_value: acc_money_value,
+			Acc_currency:    acc_currency,
 		}
 
 		mock.ExpectBegin()
 		mock.ExpectExec(repository.CreateAccount).WithArgs(
 			&account.Acc_uuid,
 			&account.Acc_status,
+			&account.Acc_name,
 			&account.Acc_culc_number,
 			&account.Acc_corr_number,
 			&account.Acc_bic,
 			&account.Acc_cio,
-			&account.Acc_money_value,
+			&account.Acc_currency,
 		).WillReturnError(err)
 		mock.ExpectRollback()
 
@@ -105,18 +110,19 @@ func TestAccountRepo_CreateAccount(t *testing.T) {
 			Acc_corr_number: acc_corr_number,
 			Acc_bic:         acc_bic,
 			Acc_cio:         acc_cio,
-			Acc_money_value: acc_money_value,
+			Acc_currency:    acc_currency,
 		}
 
 		mock.ExpectBegin()
 		mock.ExpectExec(repository.CreateAccount).WithArgs(
 			&account.Acc_uuid,
 			&account.Acc_status,
+			&account.Acc_name,
 			&account.Acc_culc_number,
 			&account.Acc_corr_number,
 			&account.Acc_bic,
 			&account.Acc_cio,
-			&account.Acc_money_value,
+			&account.Acc_currency,
 		).WillReturnResult(sqlmock.NewResult(0, 0))
 		mock.ExpectExec(repository.InsertReserveReason).WithArgs(
 			&acc_reason.Acc_uuid,
@@ -179,7 +185,7 @@ func TestAccountRepo_GetAccountData(t *testing.T) {
 	acc_corr_number := "01234567890123456789"
 	acc_bic := "123456789"
 	acc_cio := "123456789"
-	var acc_money_value uint8 = 0
+	acc_currency := "RUB"
 	var acc_money_amount float64 = 0.0
 	var acc_status uint8 = 0
 
@@ -190,18 +196,18 @@ func TestAccountRepo_GetAccountData(t *testing.T) {
 			Acc_corr_number:  acc_corr_number,
 			Acc_bic:          acc_bic,
 			Acc_cio:          acc_cio,
-			Acc_money_value:  acc_money_value,
+			Acc_currency:     acc_currency,
 			Acc_money_amount: acc_money_amount,
 			Acc_status:       acc_status,
 		}
 
-		row := sqlmock.NewRows([]string{"acc_uuid", "acc_culc_number", "acc_corr_number", "acc_bic", "acc_cio", "acc_money_value", "acc_money_amount", "acc_status"}).AddRow(
+		row := sqlmock.NewRows([]string{"acc_uuid", "acc_culc_number", "acc_corr_number", "acc_bic", "acc_cio", "acc_currency", "acc_money_amount", "acc_status"}).AddRow(
 			&acc_uuid,
 			&acc_culc_number,
 			&acc_corr_number,
 			&acc_bic,
 			&acc_cio,
-			&acc_money_value,
+			&acc_currency,
 			&acc_money_amount,
 			&acc_status,
 		)
@@ -433,3 +439,641 @@ func TestAccountRepo_GetReserveReason(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestAccountRepo_UpdateAccountAmountWithMovement(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	movement := &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      uuid.New(),
+		Movement_type: models.MovementTypeDeposit,
+		Amount:        10,
+		Balance:       15,
+		Created_at:    time.Now(),
+		Operation_uuid: uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdateAccountAmount).WithArgs(movement.Acc_uuid, movement.Balance).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.AddAccountMovement).WithArgs(
+			movement.Movement_uuid,
+			movement.Acc_uuid,
+			movement.Movement_type,
+			movement.Amount,
+			movement.Balance,
+			movement.Created_at,
+			movement.Operation_uuid,
+			movement.User_uuid,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err = accRepo.UpdateAccountAmountWithMovement(context.Background(), movement)
+		require.Nil(t, err)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error no data", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdateAccountAmount).WithArgs(movement.Acc_uuid, movement.Balance).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err = accRepo.UpdateAccountAmountWithMovement(context.Background(), movement)
+		require.Equal(t, err, repository.ErrorUpdateAccountAmount)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error add movement", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.UpdateAccountAmount).WithArgs(movement.Acc_uuid, movement.Balance).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.AddAccountMovement).WillReturnError(fmt.Errorf("error"))
+		mock.ExpectRollback()
+
+		err = accRepo.UpdateAccountAmountWithMovement(context.Background(), movement)
+		require.Equal(t, err, repository.ErrorAddAccountMovement)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_GetAccountMovements(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	acc_uuid := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	movement := &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      acc_uuid,
+		Movement_type: models.MovementTypeWithdrawal,
+		Amount:        -5,
+		Balance:       10,
+		Created_at:    from.Add(time.Hour),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		rows := mock.NewRows([]string{"movement_uuid", "acc_uuid", "movement_type", "amount", "balance", "created_at"}).AddRow(
+			movement.Movement_uuid,
+			movement.Acc_uuid,
+			movement.Movement_type,
+			movement.Amount,
+			movement.Balance,
+			movement.Created_at,
+		)
+		mock.ExpectQuery(repository.GetAccountMovements).WithArgs(acc_uuid, from, to).WillReturnRows(rows)
+
+		result, err := accRepo.GetAccountMovements(context.Background(), acc_uuid, from, to)
+		require.Nil(t, err)
+		require.Equal(t, result, []*models.AccountMovement{movement})
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetAccountMovements).WithArgs(acc_uuid, from, to).WillReturnError(fmt.Errorf("error"))
+
+		result, err := accRepo.GetAccountMovements(context.Background(), acc_uuid, from, to)
+		require.Equal(t, err, repository.ErrorGetAccountMovements)
+		require.Nil(t, result)
+	})
+
+	t.Run("Sum", func(t *testing.T) {
+		rows := mock.NewRows([]string{"sum"}).AddRow(float64(-5))
+		mock.ExpectQuery(repository.GetAccountMovementsSum).WithArgs(acc_uuid, from).WillReturnRows(rows)
+
+		result, err := accRepo.GetAccountMovementsSum(context.Background(), acc_uuid, from)
+		require.Nil(t, err)
+		require.Equal(t, result, float64(-5))
+	})
+
+	t.Run("Sum error", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetAccountMovementsSum).WithArgs(acc_uuid, from).WillReturnError(fmt.Errorf("error"))
+
+		_, err := accRepo.GetAccountMovementsSum(context.Background(), acc_uuid, from)
+		require.Equal(t, err, repository.ErrorGetMovementsSum)
+	})
+}
+
+func TestAccountRepo_GetAccountHistory(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	acc_uuid := uuid.New()
+	operation_uuid := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	min_amount := float64(1)
+	max_amount := float64(100)
+	movement := &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      acc_uuid,
+		Movement_type: models.MovementTypeDeposit,
+		Amount:        10,
+		Balance:       10,
+		Created_at:    from.Add(time.Hour),
+		Operation_uuid: uuid.NullUUID{
+			UUID:  operation_uuid,
+			Valid: true,
+		},
+	}
+	columns := []string{"movement_uuid", "acc_uuid", "movement_type", "amount", "balance", "created_at", "operation_uuid"}
+
+	t.Run("Success no filter", func(t *testing.T) {
+		rows := mock.NewRows(columns).AddRow(
+			movement.Movement_uuid,
+			movement.Acc_uuid,
+			movement.Movement_type,
+			movement.Amount,
+			movement.Balance,
+			movement.Created_at,
+			operation_uuid,
+		)
+		mock.ExpectQuery(repository.GetAccountHistory + repository.GetAccountHistoryOrder + "21").WithArgs(acc_uuid).WillReturnRows(rows)
+
+		result, err := accRepo.GetAccountHistory(context.Background(), acc_uuid, &models.HistoryFilter{}, nil, 21)
+		require.Nil(t, err)
+		require.Equal(t, result, []*models.AccountMovement{movement})
+	})
+
+	t.Run("Success all filters", func(t *testing.T) {
+		filter := &models.HistoryFilter{
+			From:           from,
+			To:             to,
+			Direction:      models.MovementTypeWithdrawal,
+			MinAmount:      &min_amount,
+			MaxAmount:      &max_amount,
+			Operation_uuid: operation_uuid,
+		}
+		after := &models.HistoryCursor{
+			Created_at:    to,
+			Movement_uuid: uuid.New(),
+		}
+		query := repository.GetAccountHistory +
+			" AND created_at >= $2 AND created_at < $3 AND amount < 0 AND ABS(amount) >= $4 AND ABS(amount) <= $5" +
+			" AND operation_uuid = $6 AND (created_at, movement_uuid) < ($7, $8)" +
+			repository.GetAccountHistoryOrder + "11"
+		mock.ExpectQuery(query).WithArgs(acc_uuid, from, to, min_amount, max_amount, operation_uuid, after.Created_at, after.Movement_uuid).WillReturnRows(mock.NewRows(columns))
+
+		result, err := accRepo.GetAccountHistory(context.Background(), acc_uuid, filter, after, 11)
+		require.Nil(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetAccountHistory + " AND amount > 0" + repository.GetAccountHistoryOrder + "21").WithArgs(acc_uuid).WillReturnError(fmt.Errorf("error"))
+
+		result, err := accRepo.GetAccountHistory(context.Background(), acc_uuid, &models.HistoryFilter{Direction: models.MovementTypeDeposit}, nil, 21)
+		require.Equal(t, err, repository.ErrorGetAccountHistory)
+		require.Nil(t, result)
+	})
+}
+
+func TestAccountRepo_CreateHold(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	now := time.Now()
+	hold := &models.AccountHold{
+		Hold_uuid:  uuid.New(),
+		Acc_uuid:   uuid.New(),
+		Amount:     40,
+		Status:     models.HoldStatusActive,
+		Created_at: now,
+		Expires_at: now.Add(time.Hour),
+		Updated_at: now,
+		Operation_uuid: uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(hold.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(100))
+		mock.ExpectQuery(repository.GetAccountHoldsAmount).WithArgs(hold.Acc_uuid, models.HoldStatusActive).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(60))
+		mock.ExpectExec(repository.CreateHold).WithArgs(
+			hold.Hold_uuid,
+			hold.Acc_uuid,
+			hold.Amount,
+			hold.Status,
+			hold.Operation_uuid,
+			hold.Created_at,
+			hold.Expires_at,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		created, err := accRepo.CreateHold(context.Background(), hold)
+		require.Nil(t, err)
+		require.True(t, created)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not enough available amount", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(hold.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(100))
+		mock.ExpectQuery(repository.GetAccountHoldsAmount).WithArgs(hold.Acc_uuid, models.HoldStatusActive).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(70))
+		mock.ExpectRollback()
+
+		created, err := accRepo.CreateHold(context.Background(), hold)
+		require.Nil(t, err)
+		require.False(t, created)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error no account", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(hold.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}))
+		mock.ExpectRollback()
+
+		_, err := accRepo.CreateHold(context.Background(), hold)
+		require.Equal(t, err, repository.ErrorLockAccount)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_CaptureHold(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	hold_uuid := uuid.New()
+	movement := &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      uuid.New(),
+		Movement_type: models.MovementTypeWithdrawal,
+		Amount:        -25,
+		Created_at:    time.Now(),
+		Operation_uuid: uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.FinishHold).WithArgs(hold_uuid, models.HoldStatusCaptured, float64(25), models.HoldStatusActive).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(movement.Acc_uuid, movement.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(75))
+		mock.ExpectExec(repository.AddAccountMovement).WithArgs(
+			movement.Movement_uuid,
+			movement.Acc_uuid,
+			movement.Movement_type,
+			movement.Amount,
+			float64(75),
+			movement.Created_at,
+			movement.Operation_uuid,
+			movement.User_uuid,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err = accRepo.CaptureHold(context.Background(), hold_uuid, movement)
+		require.Nil(t, err)
+		require.Equal(t, movement.Balance, float64(75))
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error hold isn't active", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.FinishHold).WithArgs(hold_uuid, models.HoldStatusCaptured, float64(25), models.HoldStatusActive).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err = accRepo.CaptureHold(context.Background(), hold_uuid, movement)
+		require.Equal(t, err, repository.ErrorFinishHold)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_WidthAccountAmount(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	user_uuid := uuid.New()
+	movement := &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      uuid.New(),
+		Movement_type: models.MovementTypeWithdrawal,
+		Amount:        -25,
+		Created_at:    time.Date(2024, 3, 15, 12, 30, 0, 0, time.UTC),
+		Operation_uuid: uuid.NullUUID{
+			UUID:  uuid.New(),
+			Valid: true,
+		},
+		User_uuid: uuid.NullUUID{
+			UUID:  user_uuid,
+			Valid: true,
+		},
+	}
+	day_start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	month_start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	totals_columns := []string{"daily_amount", "daily_count", "monthly_amount", "monthly_count"}
+	user_totals_columns := append([]string{"currency"}, totals_columns...)
+
+	expectState := func() {
+		mock.ExpectBegin()
+		mock.ExpectExec(repository.LockUserWithdrawals).WithArgs(user_uuid.String()).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(movement.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(100))
+		mock.ExpectQuery(repository.GetAccountHoldsAmount).WithArgs(movement.Acc_uuid, models.HoldStatusActive).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(30))
+		mock.ExpectQuery(repository.GetAccountWithdrawalTotals).WithArgs(movement.Acc_uuid, models.MovementTypeWithdrawal, day_start, month_start).WillReturnRows(sqlmock.NewRows(totals_columns).AddRow(10, 1, 40, 3))
+		mock.ExpectQuery(repository.GetUserWithdrawalTotals).WithArgs(movement.User_uuid, models.MovementTypeWithdrawal, day_start, month_start).WillReturnRows(sqlmock.NewRows(user_totals_columns).AddRow("RUB", 15, 2, 60, 5).AddRow("USD", 1, 1, 2, 2))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expectState()
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(movement.Acc_uuid, movement.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(75))
+		mock.ExpectExec(repository.AddAccountMovement).WithArgs(
+			movement.Movement_uuid,
+			movement.Acc_uuid,
+			movement.Movement_type,
+			movement.Amount,
+			float64(75),
+			movement.Created_at,
+			movement.Operation_uuid,
+			movement.User_uuid,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		var state *models.WithdrawalState
+		err = accRepo.WidthAccountAmount(context.Background(), movement, func(s *models.WithdrawalState) error {
+			state = s
+			return nil
+		})
+		require.Nil(t, err)
+		require.Equal(t, &models.WithdrawalState{
+			Amount:       100,
+			Holds_amount: 30,
+			Account:      models.WithdrawalTotals{Daily_amount: 10, Daily_count: 1, Monthly_amount: 40, Monthly_count: 3},
+			User: []models.CurrencyWithdrawalTotals{
+				{Currency: "RUB", WithdrawalTotals: models.WithdrawalTotals{Daily_amount: 15, Daily_count: 2, Monthly_amount: 60, Monthly_count: 5}},
+				{Currency: "USD", WithdrawalTotals: models.WithdrawalTotals{Daily_amount: 1, Daily_count: 1, Monthly_amount: 2, Monthly_count: 2}},
+			},
+		}, state)
+		require.Equal(t, movement.Balance, float64(75))
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error check", func(t *testing.T) {
+		expectState()
+		mock.ExpectRollback()
+
+		check_err := errors.New("limit exceeded")
+		err = accRepo.WidthAccountAmount(context.Background(), movement, func(s *models.WithdrawalState) error {
+			return check_err
+		})
+		require.Equal(t, err, check_err)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_GetWithdrawalLimits(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	user_uuid := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"subject_type", "subject_uuid", "per_operation", "daily_amount", "monthly_amount", "daily_count", "monthly_count", "updated_at"}).
+			AddRow(models.LimitSubjectUser, user_uuid, 100, nil, 1000, 5, nil, time.Now())
+		mock.ExpectQuery(repository.GetWithdrawalLimits).WithArgs(models.LimitSubjectUser, user_uuid).WillReturnRows(rows)
+
+		limits, err := accRepo.GetWithdrawalLimits(context.Background(), models.LimitSubjectUser, user_uuid)
+		require.Nil(t, err)
+		require.Equal(t, *limits.Per_operation, float64(100))
+		require.Nil(t, limits.Daily_amount)
+		require.Equal(t, *limits.Daily_count, int64(5))
+		require.Nil(t, limits.Monthly_count)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No limits", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetWithdrawalLimits).WithArgs(models.LimitSubjectUser, user_uuid).WillReturnError(sql.ErrNoRows)
+
+		limits, err := accRepo.GetWithdrawalLimits(context.Background(), models.LimitSubjectUser, user_uuid)
+		require.Nil(t, err)
+		require.Nil(t, limits)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetWithdrawalLimits).WithArgs(models.LimitSubjectUser, user_uuid).WillReturnError(fmt.Errorf("error"))
+
+		_, err := accRepo.GetWithdrawalLimits(context.Background(), models.LimitSubjectUser, user_uuid)
+		require.Equal(t, err, repository.ErrorGetWithdrawalLimits)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_TransferAmount(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	created_at := time.Date(2024, 3, 15, 12, 30, 0, 0, time.UTC)
+	operation_uuid := uuid.NullUUID{
+		UUID:  uuid.New(),
+		Valid: true,
+	}
+	// Source account uuid is less, so it is locked before destination one
+	from := &models.AccountMovement{
+		Movement_uuid:  uuid.New(),
+		Acc_uuid:       uuid.MustParse("10000000-0000-0000-0000-000000000000"),
+		Movement_type:  models.MovementTypeWithdrawal,
+		Amount:         -100,
+		Created_at:     created_at,
+		Operation_uuid: operation_uuid,
+	}
+	to := &models.AccountMovement{
+		Movement_uuid:  uuid.New(),
+		Acc_uuid:       uuid.MustParse("20000000-0000-0000-0000-000000000000"),
+		Movement_type:  models.MovementTypeDeposit,
+		Amount:         1.08,
+		Created_at:     created_at,
+		Operation_uuid: operation_uuid,
+	}
+	conversion := &models.CurrencyConversion{
+		Conversion_uuid:    uuid.New(),
+		From_acc_uuid:      from.Acc_uuid,
+		To_acc_uuid:        to.Acc_uuid,
+		From_movement_uuid: from.Movement_uuid,
+		To_movement_uuid:   to.Movement_uuid,
+		From_currency:      "RUB",
+		To_currency:        "USD",
+		From_amount:        100,
+		To_amount:          1.08,
+		Rate:               0.0108,
+		Operation_uuid:     operation_uuid,
+		Created_at:         created_at,
+	}
+	day_start := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	month_start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	totals_columns := []string{"daily_amount", "daily_count", "monthly_amount", "monthly_count"}
+
+	expectState := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(from.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(500))
+		mock.ExpectQuery(repository.GetAccountHoldsAmount).WithArgs(from.Acc_uuid, models.HoldStatusActive).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+		mock.ExpectQuery(repository.GetAccountWithdrawalTotals).WithArgs(from.Acc_uuid, models.MovementTypeWithdrawal, day_start, month_start).WillReturnRows(sqlmock.NewRows(totals_columns).AddRow(0, 0, 0, 0))
+		mock.ExpectQuery(repository.LockAccountAmount).WithArgs(to.Acc_uuid).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(10))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		expectState()
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(from.Acc_uuid, from.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(400))
+		mock.ExpectExec(repository.AddAccountMovement).WithArgs(
+			from.Movement_uuid,
+			from.Acc_uuid,
+			from.Movement_type,
+			from.Amount,
+			float64(400),
+			from.Created_at,
+			from.Operation_uuid,
+			from.User_uuid,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(to.Acc_uuid, to.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(11.08))
+		mock.ExpectExec(repository.AddAccountMovement).WithArgs(
+			to.Movement_uuid,
+			to.Acc_uuid,
+			to.Movement_type,
+			to.Amount,
+			float64(11.08),
+			to.Created_at,
+			to.Operation_uuid,
+			to.User_uuid,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.AddConversion).WithArgs(
+			conversion.Conversion_uuid,
+			conversion.From_acc_uuid,
+			conversion.To_acc_uuid,
+			conversion.From_movement_uuid,
+			conversion.To_movement_uuid,
+			conversion.From_currency,
+			conversion.To_currency,
+			conversion.From_amount,
+			conversion.To_amount,
+			conversion.Rate,
+			conversion.Operation_uuid,
+			conversion.Created_at,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err = accRepo.TransferAmount(context.Background(), from, to, conversion, func(s *models.WithdrawalState) error {
+			return nil
+		})
+		require.Nil(t, err)
+		require.Equal(t, from.Balance, float64(400))
+		require.Equal(t, to.Balance, float64(11.08))
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error check", func(t *testing.T) {
+		expectState()
+		mock.ExpectRollback()
+
+		check_err := errors.New("not enough money")
+		err = accRepo.TransferAmount(context.Background(), from, to, conversion, func(s *models.WithdrawalState) error {
+			return check_err
+		})
+		require.Equal(t, err, check_err)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error add conversion", func(t *testing.T) {
+		expectState()
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(from.Acc_uuid, from.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(400))
+		mock.ExpectExec(repository.AddAccountMovement).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(repository.ChangeAccountAmount).WithArgs(to.Acc_uuid, to.Amount).WillReturnRows(sqlmock.NewRows([]string{"acc_money_amount"}).AddRow(11.08))
+		mock.ExpectExec(repository.AddAccountMovement).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(repository.AddConversion).WillReturnError(fmt.Errorf("error"))
+		mock.ExpectRollback()
+
+		err = accRepo.TransferAmount(context.Background(), from, to, conversion, func(s *models.WithdrawalState) error {
+			return nil
+		})
+		require.Equal(t, err, repository.ErrorAddConversion)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAccountRepo_GetExchangeRate(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	accRepo := repository.NewAccountRepository(sqlxDB)
+
+	t.Run("Success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"base_currency", "quote_currency", "rate", "updated_at"}).AddRow("USD", "RUB", 92.5, time.Now())
+		mock.ExpectQuery(repository.GetExchangeRate).WithArgs("USD", "RUB").WillReturnRows(rows)
+
+		rate, err := accRepo.GetExchangeRate(context.Background(), "USD", "RUB")
+		require.Nil(t, err)
+		require.Equal(t, rate.Rate, 92.5)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No rate", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetExchangeRate).WithArgs("RUB", "USD").WillReturnError(sql.ErrNoRows)
+
+		rate, err := accRepo.GetExchangeRate(context.Background(), "RUB", "USD")
+		require.Nil(t, err)
+		require.Nil(t, rate)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mock.ExpectQuery(repository.GetExchangeRate).WithArgs("USD", "RUB").WillReturnError(fmt.Errorf("error"))
+
+		_, err := accRepo.GetExchangeRate(context.Background(), "USD", "RUB")
+		require.Equal(t, err, repository.ErrorGetExchangeRate)
+		require.Nil(t, mock.ExpectationsWereMet())
+	})
+}
@@ -2,18 +2,22 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/mock"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/repository"
+	"github.com/GCFactory/dbo-system/service/account/internal/account/statement"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/usecase"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 	"math"
+	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -26,6 +30,26 @@ var (
 	}
 )
 
+// Matches movement by all fields except generated uuid and time
+type movementMatcher struct {
+	accUuid       uuid.UUID
+	movementType  string
+	amount        float64
+	balance       float64
+	operationUuid uuid.UUID
+}
+
+func (m movementMatcher) Matches(x interface{}) bool {
+	movement, ok := x.(*models.AccountMovement)
+	return ok && movement.Acc_uuid == m.accUuid && movement.Movement_type == m.movementType &&
+		movement.Amount == m.amount && movement.Balance == m.balance && movement.Movement_uuid != uuid.Nil &&
+		movement.Operation_uuid.Valid && movement.Operation_uuid.UUID == m.operationUuid
+}
+
+func (m movementMatcher) String() string {
+	return fmt.Sprintf("is %s movement of %s by %v to %v", m.movementType, m.accUuid, m.amount, m.balance)
+}
+
 func TestAccountUC_ValidateAccStatus(t *testing.T) {
 	t.Parallel()
 
@@ -36,7 +60,7 @@ func TestAccountUC_ValidateAccStatus(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateAccStatus(context.Background(), usecase.AccStatusReserved)
@@ -49,7 +73,7 @@ func TestAccountUC_ValidateAccStatus(t *testing.T) {
 	})
 }
 
-func TestAccountUC_ValidateAccMoneyValue(t *testing.T) {
+func TestAccountUC_ValidateAccCurrency(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -59,16 +83,16 @@ func TestAccountUC_ValidateAccMoneyValue(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Success", func(t *testing.T) {
-		err := accUC.ValidateAccMoneyValue(context.Background(), usecase.AccMoneyValueRub)
+		err := accUC.ValidateAccCurrency(context.Background(), usecase.CurrencyRub)
 		require.Nil(t, err)
 	})
 
 	t.Run("Error", func(t *testing.T) {
-		err := accUC.ValidateAccMoneyValue(context.Background(), 12)
-		require.Equal(t, err, usecase.ErrorWrongMoneyValue)
+		err := accUC.ValidateAccCurrency(context.Background(), "ABC")
+		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
 	})
 }
 
@@ -82,7 +106,7 @@ func TestAccountUC_ValidateOwner(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateOwner(context.Background(), "417")
@@ -110,7 +134,7 @@ func TestAccountUC_ValidateActivity(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateActivity(context.Background(), "407", "02")
@@ -148,12 +172,12 @@ func TestAccountUC_ValidateCurrency(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Success", func(t *testing.T) {
-		currency, ok := usecase.PossibleAccMoneyValueStr["810"]
+		currency, ok := usecase.PossibleAccCurrencyStr["810"]
 		require.Equal(t, ok, true)
-		require.Equal(t, currency, usecase.AccMoneyValueRub)
+		require.Equal(t, currency, usecase.CurrencyRub)
 
 		result, err := accUC.ValidateCurrency(context.Background(), "810")
 		require.Nil(t, err)
@@ -163,13 +187,13 @@ func TestAccountUC_ValidateCurrency(t *testing.T) {
 	t.Run("Wrong currency len", func(t *testing.T) {
 		result, err := accUC.ValidateCurrency(context.Background(), "")
 		require.Equal(t, err, usecase.ErrorWrongCurrencyLen)
-		require.Equal(t, result, usecase.AccMoneyValueError)
+		require.Equal(t, result, "")
 	})
 
 	t.Run("Wrong currency", func(t *testing.T) {
 		result, err := accUC.ValidateCurrency(context.Background(), "123")
 		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
-		require.Equal(t, result, usecase.AccMoneyValueUnknown)
+		require.Equal(t, result, "")
 	})
 }
 
@@ -183,22 +207,14 @@ func TestAccountUC_ValidateCulcNumber(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong culc number len", func(t *testing.T) {
 		err := accUC.ValidateCulcNumber(context.Background(), "")
 		require.Equal(t, err, usecase.ErrorWrongCulcNumberLen)
 	})
 
-	t.Run("Wrong owner", func(t *testing.T) {
-		err := accUC.ValidateCulcNumber(context.Background(), "01234567890123456789")
-		require.Equal(t, err, usecase.ErrorWrongOwner)
-	})
 
-	t.Run("Wrong activity", func(t *testing.T) {
-		err := accUC.ValidateCulcNumber(context.Background(), "40799999990123456789")
-		require.Equal(t, err, usecase.ErrorWrongActivity)
-	})
 
 	t.Run("Wrong currency", func(t *testing.T) {
 		err := accUC.ValidateCulcNumber(context.Background(), "40704123990123456789")
@@ -221,7 +237,7 @@ func TestAccountUC_ValidatePaymentSystem(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong payment system len", func(t *testing.T) {
 		err := accUC.ValidatePaymentSystem(context.Background(), "")
@@ -254,7 +270,7 @@ func TestAccountUC_ValidateAccCountry(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong country len", func(t *testing.T) {
 		err := accUC.ValidateAccCountry(context.Background(), "")
@@ -287,7 +303,7 @@ func TestAccountUC_ValidateAccCountryRegion(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong country region len", func(t *testing.T) {
 		err := accUC.ValidateAccCountryRegion(context.Background(), "", "")
@@ -330,7 +346,7 @@ func TestAccountUC_ValidateAccMainOffice(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong main office len", func(t *testing.T) {
 		err := accUC.ValidateAccMainOffice(context.Background(), "", "", "")
@@ -383,7 +399,7 @@ func TestAccountUC_ValidateAccBankNumber(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong bank number len", func(t *testing.T) {
 		err := accUC.ValidateAccBankNumber(context.Background(), "")
@@ -417,37 +433,17 @@ func TestAccountUC_ValidateBIC(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong BIC len", func(t *testing.T) {
 		err := accUC.ValidateBIC(context.Background(), "")
 		require.Equal(t, err, usecase.ErrorWrongBICLen)
 	})
 
-	t.Run("Wrong payment system", func(t *testing.T) {
-		err := accUC.ValidateBIC(context.Background(), "987654321")
-		require.Equal(t, err, usecase.ErrorWrongPaymentSystem)
-	})
 
-	t.Run("Wrong acc country", func(t *testing.T) {
-		err := accUC.ValidateBIC(context.Background(), "299999999")
-		require.Equal(t, err, usecase.ErrorWrongAccCountry)
-	})
 
-	t.Run("Wrong acc country region", func(t *testing.T) {
-		err := accUC.ValidateBIC(context.Background(), "249999999")
-		require.Equal(t, err, usecase.ErrorWrongAccCountryRegion)
-	})
 
-	t.Run("Wrong main office", func(t *testing.T) {
-		err := accUC.ValidateBIC(context.Background(), "245099999")
-		require.Equal(t, err, usecase.ErrorWrongAccMainOffice)
-	})
 
-	t.Run("Wrong bank number", func(t *testing.T) {
-		err := accUC.ValidateBIC(context.Background(), "245025999")
-		require.Equal(t, err, usecase.ErrorWrongAccBankNumber)
-	})
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateBIC(context.Background(), "245025025")
@@ -465,42 +461,18 @@ func TestAccountUC_ValidateCorrNumber(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong BIC len", func(t *testing.T) {
 		err := accUC.ValidateCorrNumber(context.Background(), "", "")
 		require.Equal(t, err, usecase.ErrorWrongBICLen)
 	})
 
-	t.Run("Wrong corr number len", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCorrNumberLen)
-	})
 
-	t.Run("Wrong first group number", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "01234567890123456789", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCorrFirstGroupNumber)
-	})
 
-	t.Run("Wrong owner", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "30199012340123456789", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCorrOwner)
-	})
 
-	t.Run("Wrong currency", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "30125012340123456789", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
-	})
 
-	t.Run("Wrong bank number", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "30125810010123456789", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCorrBankNumber)
-	})
 
-	t.Run("Wrong last group number", func(t *testing.T) {
-		err := accUC.ValidateCorrNumber(context.Background(), "30125810502501234567", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCorrNumber)
-	})
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateCorrNumber(context.Background(), "30125810502500000025", "245025025")
@@ -518,7 +490,7 @@ func TestAccountUC_ValidateAccCorrNumberOwner(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong corr number len", func(t *testing.T) {
 		err := accUC.ValidateAccCorrNumberOwner(context.Background(), "")
@@ -546,27 +518,15 @@ func TestAccountUC_ValidateKPP(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	t.Run("Wrong KPP len", func(t *testing.T) {
 		err := accUC.ValidateKPP(context.Background(), "", "")
 		require.Equal(t, err, usecase.ErrorWrongAccKPPLen)
 	})
 
-	t.Run("Wrong BIC", func(t *testing.T) {
-		err := accUC.ValidateKPP(context.Background(), "012345678", "")
-		require.Equal(t, err, usecase.ErrorWrongBICLen)
-	})
 
-	t.Run("Wrong country region", func(t *testing.T) {
-		err := accUC.ValidateKPP(context.Background(), "049999999", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccCountryRegion)
-	})
 
-	t.Run("Wrong reason", func(t *testing.T) {
-		err := accUC.ValidateKPP(context.Background(), "5099ab999", "245025025")
-		require.Equal(t, err, usecase.ErrorWrongAccKPP)
-	})
 
 	t.Run("Success", func(t *testing.T) {
 		err := accUC.ValidateKPP(context.Background(), "509910012", "245025025")
@@ -584,11 +544,11 @@ func TestAccountUC_ReservAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ReservAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ReservAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -607,6 +567,7 @@ func TestAccountUC_ReservAcc(t *testing.T) {
 		Acc_cio:          "509910012",
 		Acc_corr_number:  "30125810502500000025",
 		Acc_culc_number:  "40705810990123456789",
+		Acc_currency:     usecase.CurrencyRub,
 		Acc_money_amount: 0.0,
 		Acc_status:       usecase.AccStatusReserved,
 	}
@@ -667,16 +628,15 @@ func TestAccountUC_ReservAcc(t *testing.T) {
 		acc_data.Acc_bic = tmp
 	})
 
-	t.Run("Error validate corr number", func(t *testing.T) {
-		tmp := acc_data.Acc_corr_number
-		acc_data.Acc_corr_number = ""
+	t.Run("Error currency mismatch", func(t *testing.T) {
+		acc_data.Acc_currency = usecase.CurrencyUsd
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, err)
 		mockRepo.EXPECT().GetReserveReason(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, err)
 
 		err = accUC.ReservAcc(ctx, acc_data)
-		require.Equal(t, err, usecase.ErrorWrongAccCorrNumberLen)
-		acc_data.Acc_corr_number = tmp
+		require.Equal(t, err, usecase.ErrorCurrencyMismatch)
+		acc_data.Acc_currency = ""
 	})
 
 	t.Run("Error validate KPP", func(t *testing.T) {
@@ -726,11 +686,11 @@ func TestAccountUC_CreateAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.CreateAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CreateAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -825,11 +785,11 @@ func TestAccountUC_OpenAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.OpenAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.OpenAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -924,11 +884,11 @@ func TestAccountUC_CloseAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.CloseAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CloseAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -1023,11 +983,11 @@ func TestAccountUC_BlockAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.BlockAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.BlockAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -1122,11 +1082,11 @@ func TestAccountUC_GetAccInfo(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.GetAccInfo")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.GetAccInfo")
+	defer span.End()
 
 	acc_uuid := uuid.New()
 
@@ -1168,6 +1128,7 @@ func TestAccountUC_GetAccInfo(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 		mockRepo.EXPECT().GetReserveReason(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetReserveReason)
+		mockRepo.EXPECT().GetAccountHoldsAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(0.0, nil)
 
 		result, err := accUC.GetAccInfo(ctx, acc_uuid)
 		require.Nil(t, err)
@@ -1180,11 +1141,36 @@ func TestAccountUC_GetAccInfo(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 		mockRepo.EXPECT().GetReserveReason(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_reason, nil)
+		mockRepo.EXPECT().GetAccountHoldsAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(0.0, nil)
 
 		result, err := accUC.GetAccInfo(ctx, acc_uuid)
 		require.Nil(t, err)
 		require.Equal(t, result, acc_full)
 	})
+
+	t.Run("Success with holds", func(t *testing.T) {
+		acc_data.Acc_money_amount = 100
+		acc_full.Acc_money_amount = 100
+		acc_full.Acc_available_amount = 70
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetReserveReason(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_reason, nil)
+		mockRepo.EXPECT().GetAccountHoldsAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(30.0, nil)
+
+		result, err := accUC.GetAccInfo(ctx, acc_uuid)
+		require.Nil(t, err)
+		require.Equal(t, result, acc_full)
+	})
+
+	t.Run("Error holds amount", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetReserveReason(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_reason, nil)
+		mockRepo.EXPECT().GetAccountHoldsAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(0.0, repository.ErrorGetHoldsAmount)
+
+		result, err := accUC.GetAccInfo(ctx, acc_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, usecase.ErrorGetHolds)
+	})
 }
 
 func TestAccountUC_AddingAcc(t *testing.T) {
@@ -1197,13 +1183,14 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.AddingAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.AddingAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
+	operation_uuid := uuid.New()
 
 	acc_data := &models.Account{
 		Acc_uuid:         acc_uuid,
@@ -1218,7 +1205,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 	t.Run("Error no acc data", func(t *testing.T) {
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetAccountData)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, 0)
+		err = accUC.AddingAcc(ctx, acc_uuid, 0, operation_uuid)
 		require.Equal(t, err, usecase.ErrorNoFoundAcc)
 	})
 
@@ -1228,7 +1215,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccReservedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1240,7 +1227,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccReservedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1252,7 +1239,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccCreatedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1264,7 +1251,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccCloseStatus)
 
 		acc_data.Acc_status = tmp
@@ -1276,7 +1263,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccBlockStatus)
 
 		acc_data.Acc_status = tmp
@@ -1288,7 +1275,7 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorOverflowAmount)
 
 		acc_data.Acc_money_amount = tmp
@@ -1298,9 +1285,9 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 	t.Run("Update error", func(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
-		mockRepo.EXPECT().UpdateAccountAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid), gomock.Eq(acc_data.Acc_money_amount+add_value)).Return(repository.ErrorUpdateAccountAmount)
+		mockRepo.EXPECT().UpdateAccountAmountWithMovement(gomock.Eq(ctxWithTrace), movementMatcher{acc_uuid, models.MovementTypeDeposit, add_value, acc_data.Acc_money_amount + add_value, operation_uuid}).Return(repository.ErrorUpdateAccountAmount)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorUpdateAmountValue)
 
 	})
@@ -1308,9 +1295,9 @@ func TestAccountUC_AddingAcc(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
-		mockRepo.EXPECT().UpdateAccountAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid), gomock.Eq(acc_data.Acc_money_amount+add_value)).Return(nil)
+		mockRepo.EXPECT().UpdateAccountAmountWithMovement(gomock.Eq(ctxWithTrace), movementMatcher{acc_uuid, models.MovementTypeDeposit, add_value, acc_data.Acc_money_amount + add_value, operation_uuid}).Return(nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, add_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, add_value, operation_uuid)
 		require.Nil(t, err)
 
 	})
@@ -1327,17 +1314,20 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, apiLogger)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	ctx := context.Background()
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.WidthAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.WidthAcc")
+	defer span.End()
 
 	acc_uuid := uuid.New()
+	operation_uuid := uuid.New()
+	user_uuid := uuid.New()
 
 	acc_data := &models.Account{
 		Acc_uuid:         acc_uuid,
 		Acc_status:       usecase.AccStatusOpen,
+		Acc_currency:     usecase.CurrencyRub,
 		Acc_money_amount: float64(10),
 	}
 
@@ -1348,7 +1338,7 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 	t.Run("Error no acc data", func(t *testing.T) {
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetAccountData)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, 0)
+		err = accUC.WidthAcc(ctx, acc_uuid, 0, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorNoFoundAcc)
 	})
 
@@ -1358,7 +1348,7 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.AddingAcc(ctx, acc_uuid, width_value)
+		err = accUC.AddingAcc(ctx, acc_uuid, width_value, operation_uuid)
 		require.Equal(t, err, usecase.ErrorWrongAccReservedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1370,7 +1360,7 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorWrongAccReservedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1382,7 +1372,7 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorWrongAccCreatedStatus)
 
 		acc_data.Acc_status = tmp
@@ -1394,7 +1384,7 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorWrongAccCloseStatus)
 
 		acc_data.Acc_status = tmp
@@ -1406,31 +1396,201 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorWrongAccBlockStatus)
 
 		acc_data.Acc_status = tmp
 	})
 
+	// Repository runs withdrawal check against given locked account state
+	withState := func(state *models.WithdrawalState) func(context.Context, *models.AccountMovement, func(*models.WithdrawalState) error) error {
+		return func(_ context.Context, _ *models.AccountMovement, check func(*models.WithdrawalState) error) error {
+			return check(state)
+		}
+	}
+
+	t.Run("Error get limits", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetWithdrawalLimits)
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorGetWithdrawalLimits)
+
+	})
+
 	t.Run("Overflow error", func(t *testing.T) {
-		tmp := acc_data.Acc_money_amount
-		acc_data.Acc_money_amount = -10
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{Amount: -10}))
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorNotEnoughMoneyAmount)
 
-		acc_data.Acc_money_amount = tmp
+	})
+
+	t.Run("Error held amount", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{Amount: 10, Holds_amount: 5}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorNotEnoughMoneyAmount)
+
+	})
+
+	t.Run("Error per-operation limit", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(&models.WithdrawalLimits{Per_operation: &[]float64{5}[0]}, nil)
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorOperationLimitExceeded)
+
+	})
+
+	t.Run("Error default per-operation limit", func(t *testing.T) {
+		tmp := acc_data.Acc_culc_number
+		acc_data.Acc_culc_number = "40817810000000000001"
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+
+		err = accUC.WidthAcc(ctx, acc_uuid, *usecase.DefaultWithdrawalLimits["408"].Per_operation+1, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorOperationLimitExceeded)
+
+		acc_data.Acc_culc_number = tmp
+	})
+
+	t.Run("Error default per-operation limit in account currency", func(t *testing.T) {
+		usd_acc := &models.Account{
+			Acc_uuid:         acc_uuid,
+			Acc_status:       usecase.AccStatusOpen,
+			Acc_culc_number:  "40817840000000000001",
+			Acc_currency:     usecase.CurrencyUsd,
+			Acc_money_amount: float64(100000),
+		}
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(usd_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyUsd), gomock.Eq(usecase.CurrencyRub)).Return(&models.ExchangeRate{Rate: 100}, nil)
+
+		err = accUC.WidthAcc(ctx, acc_uuid, *usecase.DefaultWithdrawalLimits["408"].Per_operation/100+1, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorOperationLimitExceeded)
+	})
+
+	t.Run("Error default limits without exchange rate", func(t *testing.T) {
+		usd_acc := &models.Account{
+			Acc_uuid:        acc_uuid,
+			Acc_status:      usecase.AccStatusOpen,
+			Acc_culc_number: "40817840000000000001",
+			Acc_currency:    usecase.CurrencyUsd,
+		}
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(usd_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyUsd), gomock.Eq(usecase.CurrencyRub)).Return(nil, nil)
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorNoExchangeRate)
+	})
+
+	t.Run("Error daily limit", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(&models.WithdrawalLimits{Daily_amount: &[]float64{15}[0]}, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount:  100,
+			Account: models.WithdrawalTotals{Daily_amount: 10, Monthly_amount: 10},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorDailyLimitExceeded)
+
+	})
+
+	t.Run("Error monthly count limit", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(&models.WithdrawalLimits{Monthly_count: &[]int64{3}[0]}, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount:  100,
+			Account: models.WithdrawalTotals{Monthly_count: 3},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorMonthlyCountExceeded)
+
+	})
+
+	t.Run("Error user daily count limit", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectUser), gomock.Eq(user_uuid)).Return(&models.WithdrawalLimits{Daily_count: &[]int64{1}[0]}, nil)
+		mockRepo.EXPECT().GetExchangeRates(gomock.Eq(ctxWithTrace)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount: 100,
+			User: []models.CurrencyWithdrawalTotals{
+				{Currency: usecase.CurrencyRub, WithdrawalTotals: models.WithdrawalTotals{Daily_count: 1, Monthly_count: 1}},
+			},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, user_uuid)
+		require.Equal(t, err, usecase.ErrorDailyCountExceeded)
+
+	})
+
+	t.Run("Error user daily limit with withdrawals in other currency", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectUser), gomock.Eq(user_uuid)).Return(&models.WithdrawalLimits{Daily_amount: &[]float64{1000}[0]}, nil)
+		mockRepo.EXPECT().GetExchangeRates(gomock.Eq(ctxWithTrace)).Return([]*models.ExchangeRate{
+			{Base_currency: usecase.CurrencyUsd, Quote_currency: usecase.CurrencyRub, Rate: 90},
+		}, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount: 100,
+			User: []models.CurrencyWithdrawalTotals{
+				{Currency: usecase.CurrencyRub, WithdrawalTotals: models.WithdrawalTotals{Daily_amount: 500, Daily_count: 1}},
+				{Currency: usecase.CurrencyUsd, WithdrawalTotals: models.WithdrawalTotals{Daily_amount: 6, Daily_count: 1}},
+			},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, user_uuid)
+		require.Equal(t, err, usecase.ErrorDailyLimitExceeded)
+
+	})
+
+	t.Run("Error user limits without exchange rate", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectUser), gomock.Eq(user_uuid)).Return(&models.WithdrawalLimits{Daily_amount: &[]float64{1000}[0]}, nil)
+		mockRepo.EXPECT().GetExchangeRates(gomock.Eq(ctxWithTrace)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount: 100,
+			User: []models.CurrencyWithdrawalTotals{
+				{Currency: usecase.CurrencyJpy, WithdrawalTotals: models.WithdrawalTotals{Daily_amount: 100, Daily_count: 1}},
+			},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, user_uuid)
+		require.Equal(t, err, usecase.ErrorNoExchangeRate)
 
 	})
 
 	t.Run("Update error", func(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
-		mockRepo.EXPECT().UpdateAccountAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid), gomock.Eq(acc_data.Acc_money_amount-width_value)).Return(repository.ErrorUpdateAccountAmount)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), movementMatcher{acc_uuid, models.MovementTypeWithdrawal, -width_value, 0, operation_uuid}, gomock.Any()).Return(repository.ErrorUpdateAccountAmount)
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, uuid.Nil)
 		require.Equal(t, err, usecase.ErrorUpdateAmountValue)
 
 	})
@@ -1438,11 +1598,803 @@ func TestAccountUC_WidthAcc(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 
 		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(acc_data, nil)
-		mockRepo.EXPECT().UpdateAccountAmount(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid), gomock.Eq(acc_data.Acc_money_amount-width_value)).Return(nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(acc_uuid)).Return(&models.WithdrawalLimits{Daily_amount: &[]float64{20}[0]}, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectUser), gomock.Eq(user_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().WidthAccountAmount(gomock.Eq(ctxWithTrace), movementMatcher{acc_uuid, models.MovementTypeWithdrawal, -width_value, 0, operation_uuid}, gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{
+			Amount:  acc_data.Acc_money_amount,
+			Account: models.WithdrawalTotals{Daily_amount: 10, Daily_count: 1},
+		}))
+
+		err = accUC.WidthAcc(ctx, acc_uuid, width_value, operation_uuid, user_uuid)
+		require.Nil(t, err)
+
+	})
+
+}
+
+func TestAccountUC_CreateStatement(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockFiles := mock.NewMockFiles(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mockFiles, apiLogger)
+
+	ctx := context.Background()
+
+	acc_uuid := uuid.New()
+	owner_uuid := uuid.New()
+	file_uuid := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	acc_data := &models.Account{
+		Acc_uuid:         acc_uuid,
+		Acc_name:         "Main",
+		Acc_status:       usecase.AccStatusOpen,
+		Acc_culc_number:  "40817810000000000001",
+		Acc_corr_number:  "30101810400000000225",
+		Acc_bic:          "044525225",
+		Acc_cio:          "773601001",
+		Acc_money_amount: float64(100),
+	}
+	movements := []*models.AccountMovement{
+		{
+			Movement_uuid: uuid.New(),
+			Acc_uuid:      acc_uuid,
+			Movement_type: models.MovementTypeDeposit,
+			Amount:        50,
+			Balance:       70,
+			Created_at:    from.Add(time.Hour),
+		},
+		{
+			Movement_uuid: uuid.New(),
+			Acc_uuid:      acc_uuid,
+			Movement_type: models.MovementTypeWithdrawal,
+			Amount:        -30,
+			Balance:       40,
+			Created_at:    from.Add(2 * time.Hour),
+		},
+	}
+
+	t.Run("Wrong format", func(t *testing.T) {
+		_, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, to, "xlsx")
+		require.Equal(t, err, usecase.ErrorWrongStatementFormat)
+	})
+
+	t.Run("Wrong period", func(t *testing.T) {
+		_, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, to, from, statement.FormatCSV)
+		require.Equal(t, err, usecase.ErrorWrongStatementPeriod)
+
+		_, err = accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, from.AddDate(2, 0, 0), statement.FormatCSV)
+		require.Equal(t, err, usecase.ErrorWrongStatementPeriod)
+	})
+
+	t.Run("Error no acc data", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetAccountData)
+
+		_, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, to, statement.FormatCSV)
+		require.Equal(t, err, usecase.ErrorNoFoundAcc)
+	})
+
+	t.Run("Error get movements", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountMovementsSum(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(from)).Return(float64(0), repository.ErrorGetMovementsSum)
+
+		_, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, to, statement.FormatCSV)
+		require.Equal(t, err, usecase.ErrorGetMovements)
+	})
+
+	t.Run("Error save", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountMovementsSum(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(from)).Return(float64(80), nil)
+		mockRepo.EXPECT().GetAccountMovements(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(from), gomock.Eq(to)).Return(movements, nil)
+		mockFiles.EXPECT().PutFile(gomock.Any(), gomock.Any()).Return(uuid.Nil, fmt.Errorf("error"))
+
+		_, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, to, statement.FormatPDF)
+		require.Equal(t, err, usecase.ErrorSaveStatement)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		// 80 moved since period start, so period opens with 20 and closes with 40
+		mockRepo.EXPECT().GetAccountMovementsSum(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(from)).Return(float64(80), nil)
+		mockRepo.EXPECT().GetAccountMovements(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(from), gomock.Eq(to)).Return(movements, nil)
+		mockFiles.EXPECT().PutFile(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, file *models.File) (uuid.UUID, error) {
+			require.Equal(t, file.BusinessType, usecase.StatementBusinessType)
+			require.Equal(t, file.OwnerUUID, owner_uuid)
+			require.Equal(t, file.FileName, "statement_40817810000000000001_01-01-2025_31-01-2025.csv")
+
+			content := string(file.Content)
+			require.Contains(t, content, "KPP,773601001\n")
+			require.Contains(t, content, "Opening balance,20.00\n")
+			require.Contains(t, content, "01-01-2025 01:00:00,Deposit,50.00,70.00\n")
+			require.Contains(t, content, "01-01-2025 02:00:00,Withdrawal,-30.00,40.00\n")
+			require.Contains(t, content, "Closing balance,40.00\n")
+			return file_uuid, nil
+		})
+
+		result, err := accUC.CreateStatement(ctx, acc_uuid, owner_uuid, from, to, statement.FormatCSV)
+		require.Nil(t, err)
+		require.Equal(t, result, file_uuid)
+	})
+}
+
+func TestAccountUC_GetAccHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+
+	acc_uuid := uuid.New()
+	acc_data := &models.Account{
+		Acc_uuid:   acc_uuid,
+		Acc_status: usecase.AccStatusOpen,
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	movements := []*models.AccountMovement{
+		{
+			Movement_uuid: uuid.New(),
+			Acc_uuid:      acc_uuid,
+			Movement_type: models.MovementTypeDeposit,
+			Amount:        30,
+			Balance:       60,
+			Created_at:    from.Add(3 * time.Hour),
+		},
+		{
+			Movement_uuid: uuid.New(),
+			Acc_uuid:      acc_uuid,
+			Movement_type: models.MovementTypeWithdrawal,
+			Amount:        -20,
+			Balance:       30,
+			Created_at:    from.Add(2 * time.Hour),
+		},
+		{
+			Movement_uuid: uuid.New(),
+			Acc_uuid:      acc_uuid,
+			Movement_type: models.MovementTypeDeposit,
+			Amount:        50,
+			Balance:       50,
+			Created_at:    from.Add(time.Hour),
+		},
+	}
+
+	t.Run("Wrong filter", func(t *testing.T) {
+		min_amount := float64(10)
+		max_amount := float64(5)
+		negative_amount := float64(-1)
+
+		for _, filter := range []*models.HistoryFilter{
+			{Direction: "transfer"},
+			{From: from, To: from},
+			{MinAmount: &min_amount, MaxAmount: &max_amount},
+			{MinAmount: &negative_amount},
+			{Limit: usecase.HistoryMaxLimit + 1},
+			{Limit: -1},
+		} {
+			_, err := accUC.GetAccHistory(ctx, acc_uuid, filter)
+			require.Equal(t, err, usecase.ErrorWrongHistoryFilter)
+		}
+	})
+
+	t.Run("Wrong cursor", func(t *testing.T) {
+		_, err := accUC.GetAccHistory(ctx, acc_uuid, &models.HistoryFilter{Cursor: "not a cursor"})
+		require.Equal(t, err, usecase.ErrorWrongHistoryCursor)
+	})
+
+	t.Run("Error no acc data", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetAccountData)
+
+		_, err := accUC.GetAccHistory(ctx, acc_uuid, &models.HistoryFilter{})
+		require.Equal(t, err, usecase.ErrorNoFoundAcc)
+	})
+
+	t.Run("Error get history", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountHistory(gomock.Any(), gomock.Eq(acc_uuid), gomock.Any(), gomock.Nil(), gomock.Eq(usecase.HistoryDefaultLimit+1)).Return(nil, repository.ErrorGetAccountHistory)
+
+		_, err := accUC.GetAccHistory(ctx, acc_uuid, &models.HistoryFilter{})
+		require.Equal(t, err, usecase.ErrorGetHistory)
+	})
+
+	t.Run("Success last page", func(t *testing.T) {
+		filter := &models.HistoryFilter{}
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountHistory(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(filter), gomock.Nil(), gomock.Eq(usecase.HistoryDefaultLimit+1)).Return(movements, nil)
+
+		result, err := accUC.GetAccHistory(ctx, acc_uuid, filter)
+		require.Nil(t, err)
+		require.Equal(t, result.Movements, movements)
+		require.Equal(t, result.NextCursor, "")
+	})
+
+	t.Run("Success pages", func(t *testing.T) {
+		filter := &models.HistoryFilter{Limit: 2}
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountHistory(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(filter), gomock.Nil(), gomock.Eq(3)).Return(movements, nil)
+
+		first_page, err := accUC.GetAccHistory(ctx, acc_uuid, filter)
+		require.Nil(t, err)
+		require.Equal(t, first_page.Movements, movements[:2])
+		require.NotEmpty(t, first_page.NextCursor)
+
+		// Next page starts after last movement of first page
+		next_filter := &models.HistoryFilter{Limit: 2, Cursor: first_page.NextCursor}
+		after := &models.HistoryCursor{
+			Created_at:    movements[1].Created_at,
+			Movement_uuid: movements[1].Movement_uuid,
+		}
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().GetAccountHistory(gomock.Any(), gomock.Eq(acc_uuid), gomock.Eq(next_filter), gomock.Eq(after), gomock.Eq(3)).Return(movements[2:], nil)
+
+		second_page, err := accUC.GetAccHistory(ctx, acc_uuid, next_filter)
+		require.Nil(t, err)
+		require.Equal(t, second_page.Movements, movements[2:])
+		require.Equal(t, second_page.NextCursor, "")
+	})
+}
+
+func TestAccountUC_CreateHold(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+
+	acc_uuid := uuid.New()
+	operation_uuid := uuid.New()
+	acc_data := &models.Account{
+		Acc_uuid:         acc_uuid,
+		Acc_status:       usecase.AccStatusOpen,
+		Acc_money_amount: 100,
+	}
+
+	t.Run("Wrong amount", func(t *testing.T) {
+		result, err := accUC.CreateHold(ctx, acc_uuid, 0, 0, operation_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, usecase.ErrorWrongHoldAmount)
+	})
+
+	t.Run("Wrong ttl", func(t *testing.T) {
+		result, err := accUC.CreateHold(ctx, acc_uuid, 10, usecase.HoldMaxTTL+time.Second, operation_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, usecase.ErrorWrongHoldTTL)
+	})
+
+	t.Run("Error acc status (close)", func(t *testing.T) {
+		closed := *acc_data
+		closed.Acc_status = usecase.AccStatusClose
+
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(&closed, nil)
+
+		result, err := accUC.CreateHold(ctx, acc_uuid, 10, 0, operation_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, usecase.ErrorWrongAccCloseStatus)
+	})
+
+	t.Run("Not enough available amount", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(false, nil)
+
+		result, err := accUC.CreateHold(ctx, acc_uuid, 200, 0, operation_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, usecase.ErrorNotEnoughMoneyAmount)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().CreateHold(gomock.Any(), gomock.Any()).Return(true, nil)
+
+		result, err := accUC.CreateHold(ctx, acc_uuid, 40, 0, operation_uuid)
+		require.Nil(t, err)
+		require.NotEqual(t, result.Hold_uuid, uuid.Nil)
+		require.Equal(t, result.Acc_uuid, acc_uuid)
+		require.Equal(t, result.Amount, float64(40))
+		require.Equal(t, result.Status, models.HoldStatusActive)
+		require.Equal(t, result.Operation_uuid.UUID, operation_uuid)
+		require.Equal(t, result.Expires_at.Sub(result.Created_at), usecase.HoldDefaultTTL)
+	})
+}
+
+func TestAccountUC_CaptureHold(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+
+	acc_uuid := uuid.New()
+	operation_uuid := uuid.New()
+	acc_data := &models.Account{
+		Acc_uuid:         acc_uuid,
+		Acc_status:       usecase.AccStatusOpen,
+		Acc_money_amount: 100,
+	}
+	hold := &models.AccountHold{
+		Hold_uuid:  uuid.New(),
+		Acc_uuid:   acc_uuid,
+		Amount:     40,
+		Status:     models.HoldStatusActive,
+		Expires_at: time.Now().Add(time.Hour),
+	}
+
+	t.Run("Error no hold", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(nil, repository.ErrorGetHold)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 0, operation_uuid)
+		require.Equal(t, err, usecase.ErrorNoFoundHold)
+	})
+
+	t.Run("Error hold of other account", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+
+		err := accUC.CaptureHold(ctx, uuid.New(), hold.Hold_uuid, 0, operation_uuid)
+		require.Equal(t, err, usecase.ErrorNoFoundHold)
+	})
+
+	t.Run("Error hold expired", func(t *testing.T) {
+		expired := *hold
+		expired.Expires_at = time.Now().Add(-time.Second)
+
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(&expired, nil)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 0, operation_uuid)
+		require.Equal(t, err, usecase.ErrorHoldIsNotActive)
+	})
+
+	t.Run("Error hold released", func(t *testing.T) {
+		released := *hold
+		released.Status = models.HoldStatusReleased
+
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(&released, nil)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 0, operation_uuid)
+		require.Equal(t, err, usecase.ErrorHoldIsNotActive)
+	})
 
-		err = accUC.WidthAcc(ctx, acc_uuid, width_value)
+	t.Run("Error amount more than hold", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 50, operation_uuid)
+		require.Equal(t, err, usecase.ErrorWrongHoldAmount)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Eq(hold.Hold_uuid), gomock.Any()).Return(repository.ErrorFinishHold)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 0, operation_uuid)
+		require.Equal(t, err, usecase.ErrorUpdateHold)
+	})
+
+	t.Run("Success full", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Eq(hold.Hold_uuid), movementMatcher{acc_uuid, models.MovementTypeWithdrawal, -40, 60, operation_uuid}).Return(nil)
+
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 0, operation_uuid)
 		require.Nil(t, err)
+	})
+
+	t.Run("Success partial", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Any(), gomock.Eq(acc_uuid)).Return(acc_data, nil)
+		mockRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Eq(hold.Hold_uuid), movementMatcher{acc_uuid, models.MovementTypeWithdrawal, -25, 75, operation_uuid}).Return(nil)
 
+		err := accUC.CaptureHold(ctx, acc_uuid, hold.Hold_uuid, 25, operation_uuid)
+		require.Nil(t, err)
 	})
+}
+
+func TestAccountUC_ReleaseHold(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+
+	acc_uuid := uuid.New()
+	hold := &models.AccountHold{
+		Hold_uuid:  uuid.New(),
+		Acc_uuid:   acc_uuid,
+		Amount:     40,
+		Status:     models.HoldStatusActive,
+		Expires_at: time.Now().Add(time.Hour),
+	}
+
+	t.Run("Error hold captured", func(t *testing.T) {
+		captured := *hold
+		captured.Status = models.HoldStatusCaptured
+
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(&captured, nil)
+
+		err := accUC.ReleaseHold(ctx, acc_uuid, hold.Hold_uuid)
+		require.Equal(t, err, usecase.ErrorHoldIsNotActive)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+		mockRepo.EXPECT().ReleaseHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(repository.ErrorFinishHold)
+
+		err := accUC.ReleaseHold(ctx, acc_uuid, hold.Hold_uuid)
+		require.Equal(t, err, usecase.ErrorUpdateHold)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(hold, nil)
+		mockRepo.EXPECT().ReleaseHold(gomock.Any(), gomock.Eq(hold.Hold_uuid)).Return(nil)
+
+		err := accUC.ReleaseHold(ctx, acc_uuid, hold.Hold_uuid)
+		require.Nil(t, err)
+	})
+}
+
+func TestStatement_RenderPDF(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	st := &models.Statement{
+		Account: &models.Account{
+			Acc_name:        "Счёт (main)",
+			Acc_culc_number: "40817810000000000001",
+		},
+		From: from,
+		To:   from.AddDate(0, 0, 1),
+	}
+	for i := 0; i < 130; i++ {
+		st.Movements = append(st.Movements, &models.AccountMovement{
+			Movement_type: models.MovementTypeDeposit,
+			Amount:        1,
+			Balance:       float64(i + 1),
+			Created_at:    from,
+		})
+	}
+
+	content, fileName, err := statement.Render(st, statement.FormatPDF)
+	require.Nil(t, err)
+	require.Equal(t, fileName, "statement_40817810000000000001_01-01-2025_01-01-2025.pdf")
+
+	pdf := string(content)
+	require.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	require.Contains(t, pdf, "/Count 3 >>")
+	require.Contains(t, pdf, `(Account name:    ???? \(main\)) '`)
+
+	// xref offsets point to objects
+	xref := strings.Index(pdf, "\nxref\n") + 1
+	offsets := strings.Split(pdf[xref:strings.Index(pdf, "trailer")], "\n")[3:]
+	for i, line := range offsets {
+		if line == "" {
+			continue
+		}
+		var offset int
+		_, err = fmt.Sscanf(line, "%010d", &offset)
+		require.Nil(t, err)
+		require.True(t, strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj\n", i+1)))
+	}
+}
+
+func TestAccountUC_SetWithdrawalLimits(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
 
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.SetWithdrawalLimits")
+	defer span.End()
+
+	acc_uuid := uuid.New()
+	daily_amount := float64(1000)
+	negative_count := int64(-1)
+
+	var err error
+
+	t.Run("Error wrong subject", func(t *testing.T) {
+		err = accUC.SetWithdrawalLimits(ctx, &models.WithdrawalLimits{Subject_type: "bank", Subject_uuid: acc_uuid})
+		require.Equal(t, err, usecase.ErrorWrongLimitSubject)
+	})
+
+	t.Run("Error wrong value", func(t *testing.T) {
+		err = accUC.SetWithdrawalLimits(ctx, &models.WithdrawalLimits{Subject_type: models.LimitSubjectUser, Subject_uuid: acc_uuid, Daily_count: &negative_count})
+		require.Equal(t, err, usecase.ErrorWrongLimitValue)
+	})
+
+	t.Run("Error no acc", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(nil, repository.ErrorGetAccountData)
+
+		err = accUC.SetWithdrawalLimits(ctx, &models.WithdrawalLimits{Subject_type: models.LimitSubjectAccount, Subject_uuid: acc_uuid})
+		require.Equal(t, err, usecase.ErrorNoFoundAcc)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		limits := &models.WithdrawalLimits{Subject_type: models.LimitSubjectAccount, Subject_uuid: acc_uuid, Daily_amount: &daily_amount}
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(acc_uuid)).Return(&models.Account{Acc_uuid: acc_uuid}, nil)
+		mockRepo.EXPECT().SetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(limits)).Return(nil)
+
+		err = accUC.SetWithdrawalLimits(ctx, limits)
+		require.Nil(t, err)
+	})
+}
+
+func TestAccountUC_TransferAcc(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.TransferAcc")
+	defer span.End()
+
+	from_uuid := uuid.New()
+	to_uuid := uuid.New()
+	operation_uuid := uuid.New()
+
+	from_acc := &models.Account{
+		Acc_uuid:         from_uuid,
+		Acc_status:       usecase.AccStatusOpen,
+		Acc_currency:     usecase.CurrencyRub,
+		Acc_money_amount: float64(1000),
+	}
+	to_acc := &models.Account{
+		Acc_uuid:     to_uuid,
+		Acc_status:   usecase.AccStatusOpen,
+		Acc_currency: usecase.CurrencyUsd,
+	}
+
+	transfer_value := float64(1000)
+
+	var err error
+
+	// Repository runs withdrawal check against given locked source account state
+	withState := func(state *models.WithdrawalState, conversion **models.CurrencyConversion) func(context.Context, *models.AccountMovement, *models.AccountMovement, *models.CurrencyConversion, func(*models.WithdrawalState) error) error {
+		return func(_ context.Context, _ *models.AccountMovement, _ *models.AccountMovement, c *models.CurrencyConversion, check func(*models.WithdrawalState) error) error {
+			*conversion = c
+			return check(state)
+		}
+	}
+
+	t.Run("Error same acc", func(t *testing.T) {
+		err = accUC.TransferAcc(ctx, from_uuid, from_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongTransferAcc)
+	})
+
+	t.Run("Error amount", func(t *testing.T) {
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, 0, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongTransferAmount)
+	})
+
+	t.Run("Error amount minor units", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, 10.555, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongTransferAmount)
+	})
+
+	t.Run("Error unknown currency", func(t *testing.T) {
+		unknown_acc := &models.Account{
+			Acc_uuid:     to_uuid,
+			Acc_status:   usecase.AccStatusOpen,
+			Acc_currency: "XXX",
+		}
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(unknown_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(unknown_acc, nil)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(unknown_acc, nil)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
+	})
+
+	t.Run("Error no destination acc", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(nil, repository.ErrorGetAccountData)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorNoFoundAcc)
+	})
+
+	t.Run("Error destination acc status (block)", func(t *testing.T) {
+		tmp := to_acc.Acc_status
+		to_acc.Acc_status = usecase.AccStatusBlocked
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorWrongAccBlockStatus)
+
+		to_acc.Acc_status = tmp
+	})
+
+	t.Run("Error no exchange rate", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(from_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyUsd), gomock.Eq(usecase.CurrencyRub)).Return(nil, nil)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorNoExchangeRate)
+	})
+
+	t.Run("Error not enough money", func(t *testing.T) {
+		var conversion *models.CurrencyConversion
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(from_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(&models.ExchangeRate{Rate: 0.0108}, nil)
+		mockRepo.EXPECT().TransferAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(withState(&models.WithdrawalState{Amount: 500}, &conversion))
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorNotEnoughMoneyAmount)
+	})
+
+	t.Run("Transfer error", func(t *testing.T) {
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(from_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(&models.ExchangeRate{Rate: 0.0108}, nil)
+		mockRepo.EXPECT().TransferAmount(gomock.Eq(ctxWithTrace), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrorAddConversion)
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Equal(t, err, usecase.ErrorTransferAmount)
+	})
+
+	t.Run("Success with reverse rate", func(t *testing.T) {
+		var conversion *models.CurrencyConversion
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(to_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(from_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyRub), gomock.Eq(usecase.CurrencyUsd)).Return(nil, nil)
+		mockRepo.EXPECT().GetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(usecase.CurrencyUsd), gomock.Eq(usecase.CurrencyRub)).Return(&models.ExchangeRate{Rate: 92.5}, nil)
+		mockRepo.EXPECT().TransferAmount(
+			gomock.Eq(ctxWithTrace),
+			movementMatcher{from_uuid, models.MovementTypeWithdrawal, -transfer_value, 0, operation_uuid},
+			movementMatcher{to_uuid, models.MovementTypeDeposit, 10.81, 0, operation_uuid},
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(withState(&models.WithdrawalState{Amount: from_acc.Acc_money_amount}, &conversion))
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Nil(t, err)
+		require.NotNil(t, conversion)
+		require.Equal(t, conversion.From_currency, usecase.CurrencyRub)
+		require.Equal(t, conversion.To_currency, usecase.CurrencyUsd)
+		require.Equal(t, conversion.From_amount, transfer_value)
+		require.Equal(t, conversion.To_amount, 10.81)
+	})
+
+	t.Run("Success same currency", func(t *testing.T) {
+		var conversion *models.CurrencyConversion
+		rub_acc := &models.Account{
+			Acc_uuid:     to_uuid,
+			Acc_status:   usecase.AccStatusOpen,
+			Acc_currency: usecase.CurrencyRub,
+		}
+
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(from_uuid)).Return(from_acc, nil)
+		mockRepo.EXPECT().GetAccountData(gomock.Eq(ctxWithTrace), gomock.Eq(to_uuid)).Return(rub_acc, nil)
+		mockRepo.EXPECT().GetWithdrawalLimits(gomock.Eq(ctxWithTrace), gomock.Eq(models.LimitSubjectAccount), gomock.Eq(from_uuid)).Return(nil, nil)
+		mockRepo.EXPECT().TransferAmount(
+			gomock.Eq(ctxWithTrace),
+			movementMatcher{from_uuid, models.MovementTypeWithdrawal, -transfer_value, 0, operation_uuid},
+			movementMatcher{to_uuid, models.MovementTypeDeposit, transfer_value, 0, operation_uuid},
+			gomock.Any(),
+			gomock.Any(),
+		).DoAndReturn(withState(&models.WithdrawalState{Amount: from_acc.Acc_money_amount}, &conversion))
+
+		err = accUC.TransferAcc(ctx, from_uuid, to_uuid, transfer_value, operation_uuid, uuid.Nil)
+		require.Nil(t, err)
+		require.Nil(t, conversion)
+	})
+}
+
+func TestAccountUC_SetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	accUC := usecase.NewAccountUseCase(testCfgUC, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
+
+	ctx := context.Background()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.SetExchangeRate")
+	defer span.End()
+
+	var err error
+
+	t.Run("Error wrong currency", func(t *testing.T) {
+		err = accUC.SetExchangeRate(ctx, "ABC", usecase.CurrencyRub, 1)
+		require.Equal(t, err, usecase.ErrorWrongCurrencyValue)
+	})
+
+	t.Run("Error same currency", func(t *testing.T) {
+		err = accUC.SetExchangeRate(ctx, usecase.CurrencyRub, usecase.CurrencyRub, 1)
+		require.Equal(t, err, usecase.ErrorWrongExchangeRate)
+	})
+
+	t.Run("Error wrong rate", func(t *testing.T) {
+		err = accUC.SetExchangeRate(ctx, usecase.CurrencyUsd, usecase.CurrencyRub, 0)
+		require.Equal(t, err, usecase.ErrorWrongExchangeRate)
+	})
+
+	t.Run("Error set rate", func(t *testing.T) {
+		mockRepo.EXPECT().SetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Any()).Return(repository.ErrorSetExchangeRate)
+
+		err = accUC.SetExchangeRate(ctx, usecase.CurrencyUsd, usecase.CurrencyRub, 92.5)
+		require.Equal(t, err, usecase.ErrorSetExchangeRate)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().SetExchangeRate(gomock.Eq(ctxWithTrace), gomock.Eq(&models.ExchangeRate{
+			Base_currency:  usecase.CurrencyUsd,
+			Quote_currency: usecase.CurrencyRub,
+			Rate:           92.5,
+		})).Return(nil)
+
+		err = accUC.SetExchangeRate(ctx, usecase.CurrencyUsd, usecase.CurrencyRub, 92.5)
+		require.Nil(t, err)
+	})
 }
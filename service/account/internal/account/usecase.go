@@ -5,15 +5,16 @@ import (
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/google/uuid"
 	"golang.org/x/net/context"
+	"time"
 )
 
 type UseCase interface {
 	ValidateAccStatus(ctx context.Context, status uint8) error
-	ValidateAccMoneyValue(ctx context.Context, money_value uint8) error
+	ValidateAccCurrency(ctx context.Context, currency string) error
 	ValidateCulcNumber(ctx context.Context, culc_number string) error
 	ValidateOwner(ctx context.Context, owner string) error
 	ValidateActivity(ctx context.Context, owner string, activity string) error
-	ValidateCurrency(ctx context.Context, currency string) (uint8, error)
+	ValidateCurrency(ctx context.Context, currency string) (string, error)
 	ValidateBIC(ctx context.Context, BIC string) error
 	ValidatePaymentSystem(ctx context.Context, payment_system string) error
 	ValidateAccCountry(ctx context.Context, acc_country string) error
@@ -29,7 +30,18 @@ type UseCase interface {
 	CloseAcc(ctx context.Context, acc_uuid uuid.UUID) error
 	BlockAcc(ctx context.Context, acc_uuid uuid.UUID) error
 	GetAccInfo(ctx context.Context, acc_uuid uuid.UUID) (*models.FullAccountData, error)
-	AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64) error
-	WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64) error
+	AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64, operation_uuid uuid.UUID) error
+	WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64, operation_uuid uuid.UUID, user_uuid uuid.UUID) error
 	RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error
+	GetAccHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter) (*models.AccountHistory, error)
+	CreateStatement(ctx context.Context, acc_uuid uuid.UUID, owner_uuid uuid.UUID, from time.Time, to time.Time, format string) (uuid.UUID, error)
+	CreateHold(ctx context.Context, acc_uuid uuid.UUID, amount float64, ttl time.Duration, operation_uuid uuid.UUID) (*models.AccountHold, error)
+	CaptureHold(ctx context.Context, acc_uuid uuid.UUID, hold_uuid uuid.UUID, amount float64, operation_uuid uuid.UUID) error
+	ReleaseHold(ctx context.Context, acc_uuid uuid.UUID, hold_uuid uuid.UUID) error
+	ExpireHolds(ctx context.Context) error
+	GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error)
+	SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error
+	TransferAcc(ctx context.Context, from_acc_uuid uuid.UUID, to_acc_uuid uuid.UUID, amount float64, operation_uuid uuid.UUID, user_uuid uuid.UUID) error
+	GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error)
+	SetExchangeRate(ctx context.Context, base_currency string, quote_currency string, rate float64) error
 }
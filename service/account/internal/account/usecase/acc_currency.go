@@ -1,9 +1,53 @@
 package usecase
 
-var PossibleAccMoneyValueStr = map[string]uint8{
-	"000": AccMoneyValueUnknown,
-	"840": AccMoneyValueDollar,
-	"810": AccMoneyValueRub,
-	"978": AccMoneyValueEuro,
-	"999": AccMoneyValueError,
+import "math"
+
+const (
+	CurrencyRub string = "RUB"
+	CurrencyUsd string = "USD"
+	CurrencyEur string = "EUR"
+	CurrencyCny string = "CNY"
+	CurrencyGbp string = "GBP"
+	CurrencyChf string = "CHF"
+	CurrencyJpy string = "JPY"
+)
+
+// Валюта ISO-4217
+type Currency struct {
+	Numeric     string // Цифровой код
+	Minor_units int    // Кол-во знаков после запятой
+}
+
+// Валюты, в которых открываются счета
+var PossibleAccCurrency = map[string]Currency{
+	CurrencyRub: {Numeric: "643", Minor_units: 2},
+	CurrencyUsd: {Numeric: "840", Minor_units: 2},
+	CurrencyEur: {Numeric: "978", Minor_units: 2},
+	CurrencyCny: {Numeric: "156", Minor_units: 2},
+	CurrencyGbp: {Numeric: "826", Minor_units: 2},
+	CurrencyChf: {Numeric: "756", Minor_units: 2},
+	CurrencyJpy: {Numeric: "392", Minor_units: 0},
+}
+
+// Валюта по коду валюты в расчётном номере счёта (6-8 цифры).
+// Рубль в номерах счетов обозначается кодом 810, а не 643 из ISO-4217
+var PossibleAccCurrencyStr = map[string]string{
+	"810": CurrencyRub,
+	"840": CurrencyUsd,
+	"978": CurrencyEur,
+	"156": CurrencyCny,
+	"826": CurrencyGbp,
+	"756": CurrencyChf,
+	"392": CurrencyJpy,
+}
+
+// Округляет сумму до минимальной единицы валюты, валюта должна быть из списка валют счетов
+func roundToCurrency(amount float64, currency string) (float64, error) {
+	acc_currency, ok := PossibleAccCurrency[currency]
+	if !ok {
+		return 0, ErrorWrongCurrencyValue
+	}
+
+	scale := math.Pow10(acc_currency.Minor_units)
+	return math.Round(amount*scale) / scale, nil
 }
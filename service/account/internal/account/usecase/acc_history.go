@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"github.com/GCFactory/dbo-system/service/account/internal/models"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	// History page size when filter has no limit
+	HistoryDefaultLimit = 20
+	// Largest history page size
+	HistoryMaxLimit = 100
+	// Date format of history period in requests
+	HistoryDateFormat = "02-01-2006"
+)
+
+// Cursor is opaque for clients, it keeps time and uuid of last movement on page
+func encodeHistoryCursor(movement *models.AccountMovement) string {
+	raw := movement.Created_at.UTC().Format(time.RFC3339Nano) + "|" + movement.Movement_uuid.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeHistoryCursor(cursor string) (*models.HistoryCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrorWrongHistoryCursor
+	}
+
+	created_at_str, movement_uuid_str, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrorWrongHistoryCursor
+	}
+
+	created_at, err := time.Parse(time.RFC3339Nano, created_at_str)
+	if err != nil {
+		return nil, ErrorWrongHistoryCursor
+	}
+
+	movement_uuid, err := uuid.Parse(movement_uuid_str)
+	if err != nil {
+		return nil, ErrorWrongHistoryCursor
+	}
+
+	return &models.HistoryCursor{
+		Created_at:    created_at,
+		Movement_uuid: movement_uuid,
+	}, nil
+}
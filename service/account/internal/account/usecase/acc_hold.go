@@ -0,0 +1,12 @@
+package usecase
+
+import "time"
+
+const (
+	// Hold lifetime when it isn't set by operation
+	HoldDefaultTTL = 7 * 24 * time.Hour
+	// Longest hold lifetime
+	HoldMaxTTL = 30 * 24 * time.Hour
+	// How often active holds are checked for expiry
+	HoldExpireInterval = time.Minute
+)
@@ -0,0 +1,10 @@
+package usecase
+
+import "time"
+
+const (
+	// file-api business type of account statements
+	StatementBusinessType = "statement"
+	// Longest period of one statement
+	StatementMaxPeriod = 366 * 24 * time.Hour
+)
@@ -0,0 +1,209 @@
+package usecase
+
+import "github.com/GCFactory/dbo-system/service/account/internal/models"
+
+// Валюта лимитов пользователя и лимитов по умолчанию. Лимиты самого счёта задаются в валюте счёта
+const WithdrawalLimitCurrency = CurrencyRub
+
+// Лимиты снятия по умолчанию для типа счёта (первые три цифры расчётного номера) в валюте лимитов,
+// используются для полей, не заданных в лимитах самого счёта
+var DefaultWithdrawalLimits = map[string]models.WithdrawalLimits{
+	// Счета физических лиц
+	"408": {
+		Per_operation:  limitAmount(600000),
+		Daily_amount:   limitAmount(1000000),
+		Monthly_amount: limitAmount(10000000),
+		Daily_count:    limitCount(50),
+		Monthly_count:  limitCount(500),
+	},
+	// Счета негосударственных организаций
+	"407": {
+		Per_operation:  limitAmount(50000000),
+		Daily_amount:   limitAmount(100000000),
+		Monthly_amount: limitAmount(1000000000),
+		Daily_count:    limitCount(500),
+		Monthly_count:  limitCount(10000),
+	},
+}
+
+var PossibleLimitSubjects = [...]string{
+	models.LimitSubjectAccount,
+	models.LimitSubjectUser,
+}
+
+func limitAmount(value float64) *float64 {
+	return &value
+}
+
+func limitCount(value int64) *int64 {
+	return &value
+}
+
+// Лимиты по умолчанию в валюте счёта, rate - курс валюты лимитов в валюте счёта
+func convertWithdrawalLimits(limits models.WithdrawalLimits, rate float64) models.WithdrawalLimits {
+	convert := func(value *float64) *float64 {
+		if value == nil {
+			return nil
+		}
+		return limitAmount(*value * rate)
+	}
+
+	limits.Per_operation = convert(limits.Per_operation)
+	limits.Daily_amount = convert(limits.Daily_amount)
+	limits.Monthly_amount = convert(limits.Monthly_amount)
+
+	return limits
+}
+
+// Курсы валют счетов в валюте лимитов
+type limitRates map[string]float64
+
+// Курсы из списка курсов, обратный курс используется, когда прямой не задан
+func newLimitRates(rates []*models.ExchangeRate) limitRates {
+	result := limitRates{WithdrawalLimitCurrency: 1}
+	for _, rate := range rates {
+		if rate.Quote_currency == WithdrawalLimitCurrency {
+			result[rate.Base_currency] = rate.Rate
+		}
+	}
+	for _, rate := range rates {
+		if _, ok := result[rate.Quote_currency]; !ok && rate.Base_currency == WithdrawalLimitCurrency {
+			result[rate.Quote_currency] = 1 / rate.Rate
+		}
+	}
+
+	return result
+}
+
+// Сумма в валюте лимитов
+func (rates limitRates) convert(value float64, currency string) (float64, error) {
+	rate, ok := rates[currency]
+	if !ok {
+		return 0, ErrorNoExchangeRate
+	}
+
+	return value * rate, nil
+}
+
+// Снятия пользователя со счетов всех валют в валюте лимитов
+func (rates limitRates) userTotals(totals []models.CurrencyWithdrawalTotals) (*models.WithdrawalTotals, error) {
+	result := &models.WithdrawalTotals{}
+	for _, currency_totals := range totals {
+		daily_amount, err := rates.convert(currency_totals.Daily_amount, currency_totals.Currency)
+		if err != nil {
+			return nil, err
+		}
+		monthly_amount, err := rates.convert(currency_totals.Monthly_amount, currency_totals.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Daily_amount += daily_amount
+		result.Daily_count += currency_totals.Daily_count
+		result.Monthly_amount += monthly_amount
+		result.Monthly_count += currency_totals.Monthly_count
+	}
+
+	return result, nil
+}
+
+// Лимиты счёта: поля, не заданные для счёта, берутся из лимитов его типа
+func mergeWithdrawalLimits(own *models.WithdrawalLimits, defaults models.WithdrawalLimits) *models.WithdrawalLimits {
+	result := defaults
+	if own == nil {
+		return &result
+	}
+
+	result.Updated_at = own.Updated_at
+	if own.Per_operation != nil {
+		result.Per_operation = own.Per_operation
+	}
+	if own.Daily_amount != nil {
+		result.Daily_amount = own.Daily_amount
+	}
+	if own.Monthly_amount != nil {
+		result.Monthly_amount = own.Monthly_amount
+	}
+	if own.Daily_count != nil {
+		result.Daily_count = own.Daily_count
+	}
+	if own.Monthly_count != nil {
+		result.Monthly_count = own.Monthly_count
+	}
+
+	return &result
+}
+
+// Проверяет, что снятие value не превысит лимиты с учётом уже сделанных снятий
+func checkWithdrawalTotals(limits *models.WithdrawalLimits, totals *models.WithdrawalTotals, value float64) error {
+	if limits == nil {
+		return nil
+	}
+
+	if limits.Daily_amount != nil && totals.Daily_amount+value > *limits.Daily_amount {
+		return ErrorDailyLimitExceeded
+	}
+	if limits.Daily_count != nil && totals.Daily_count+1 > *limits.Daily_count {
+		return ErrorDailyCountExceeded
+	}
+	if limits.Monthly_amount != nil && totals.Monthly_amount+value > *limits.Monthly_amount {
+		return ErrorMonthlyLimitExceeded
+	}
+	if limits.Monthly_count != nil && totals.Monthly_count+1 > *limits.Monthly_count {
+		return ErrorMonthlyCountExceeded
+	}
+
+	return nil
+}
+
+func exceedsOperationLimit(limits *models.WithdrawalLimits, value float64) bool {
+	return limits != nil && limits.Per_operation != nil && value > *limits.Per_operation
+}
+
+// Проверяет лимиты на одну операцию снятия value в валюте currency
+func checkOperationLimits(account_limits *models.WithdrawalLimits, user_limits *models.WithdrawalLimits, rates limitRates, value float64, currency string) error {
+	if exceedsOperationLimit(account_limits, value) {
+		return ErrorOperationLimitExceeded
+	}
+
+	if user_limits == nil {
+		return nil
+	}
+
+	user_value, err := rates.convert(value, currency)
+	if err != nil {
+		return err
+	} else if exceedsOperationLimit(user_limits, user_value) {
+		return ErrorOperationLimitExceeded
+	}
+
+	return nil
+}
+
+// Проверка снятия value в валюте currency в заблокированном состоянии счёта: удержанные деньги не снимаются,
+// лимиты счёта и пользователя не превышаются. Снятия пользователя сравниваются с его лимитами в валюте лимитов.
+// Ошибка проверки сохраняется в check_err
+func withdrawalCheck(account_limits *models.WithdrawalLimits, user_limits *models.WithdrawalLimits, rates limitRates, value float64, currency string, check_err *error) func(state *models.WithdrawalState) error {
+	return func(state *models.WithdrawalState) error {
+		if state.Amount-value-state.Holds_amount < 0 {
+			*check_err = ErrorNotEnoughMoneyAmount
+		} else if *check_err = checkWithdrawalTotals(account_limits, &state.Account, value); *check_err == nil && user_limits != nil {
+			*check_err = checkUserWithdrawalTotals(user_limits, rates, state.User, value, currency)
+		}
+		return *check_err
+	}
+}
+
+func checkUserWithdrawalTotals(limits *models.WithdrawalLimits, rates limitRates, totals []models.CurrencyWithdrawalTotals, value float64, currency string) error {
+	user_totals, err := rates.userTotals(totals)
+	if err != nil {
+		return err
+	}
+
+	user_value, err := rates.convert(value, currency)
+	if err != nil {
+		return err
+	}
+
+	return checkWithdrawalTotals(limits, user_totals, user_value)
+}
@@ -15,7 +15,6 @@ var (
 	ErrorNoFoundAcc    = errors.New("No account found!")
 
 	ErrorWrongAccStatus               = errors.New("Wrong account status!")
-	ErrorWrongMoneyValue              = errors.New("Wrong money value!")
 	ErrorReasonIsExisting             = errors.New("Reason for this acc uuid is existing!")
 	ErrorCreateAcc                    = errors.New("accountRepo.CreateAccount")
 	ErrorUpdateAccStatus              = errors.New("accountRepo.UpdateAccountStatus")
@@ -47,4 +46,41 @@ var (
 	ErrorWrongAccKPPLen               = errors.New("Wrong KPP len")
 	ErrorWrongAccKPP                  = errors.New("Wrong KPP")
 	ErrorWrongCulcNumberLen           = errors.New("Wrong culc number len")
+
+	ErrorWrongStatementFormat = errors.New("Wrong statement format")
+	ErrorWrongStatementPeriod = errors.New("Wrong statement period")
+	ErrorGetMovements         = errors.New("accountRepo.GetAccountMovements")
+	ErrorSaveStatement        = errors.New("Error saving statement")
+
+	ErrorWrongHistoryFilter = errors.New("Wrong history filter")
+	ErrorWrongHistoryCursor = errors.New("Wrong history cursor")
+	ErrorGetHistory         = errors.New("accountRepo.GetAccountHistory")
+
+	ErrorWrongHoldAmount = errors.New("Wrong hold amount")
+	ErrorWrongHoldTTL    = errors.New("Wrong hold lifetime")
+	ErrorNoFoundHold     = errors.New("No hold found!")
+	ErrorHoldIsNotActive = errors.New("Hold is not active!")
+	ErrorGetHolds        = errors.New("accountRepo.GetAccountHoldsAmount")
+	ErrorCreateHold      = errors.New("accountRepo.CreateHold")
+	ErrorUpdateHold      = errors.New("Error updating hold")
+	ErrorExpireHolds     = errors.New("accountRepo.ExpireHolds")
+
+	ErrorOperationLimitExceeded = errors.New("Withdrawal exceeds per-operation limit!")
+	ErrorDailyLimitExceeded     = errors.New("Withdrawal exceeds daily amount limit!")
+	ErrorMonthlyLimitExceeded   = errors.New("Withdrawal exceeds monthly amount limit!")
+	ErrorDailyCountExceeded     = errors.New("Withdrawal exceeds daily count limit!")
+	ErrorMonthlyCountExceeded   = errors.New("Withdrawal exceeds monthly count limit!")
+	ErrorWrongLimitSubject      = errors.New("Wrong limit subject")
+	ErrorWrongLimitValue        = errors.New("Wrong limit value")
+	ErrorGetWithdrawalLimits    = errors.New("accountRepo.GetWithdrawalLimits")
+	ErrorSetWithdrawalLimits    = errors.New("accountRepo.SetWithdrawalLimits")
+
+	ErrorCurrencyMismatch    = errors.New("Account currency doesn't match culc number currency!")
+	ErrorWrongExchangeRate   = errors.New("Wrong exchange rate")
+	ErrorNoExchangeRate      = errors.New("No exchange rate for account currencies!")
+	ErrorGetExchangeRate     = errors.New("accountRepo.GetExchangeRate")
+	ErrorSetExchangeRate     = errors.New("accountRepo.SetExchangeRate")
+	ErrorWrongTransferAmount = errors.New("Wrong transfer amount")
+	ErrorWrongTransferAcc    = errors.New("Transfer to the same account!")
+	ErrorTransferAmount      = errors.New("accountRepo.TransferAmount")
 )
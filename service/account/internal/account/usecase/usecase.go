@@ -3,23 +3,27 @@ package usecase
 import (
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	"github.com/GCFactory/dbo-system/service/account/internal/account/statement"
 	"github.com/GCFactory/dbo-system/service/account/internal/models"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
+	"slices"
 	"strconv"
+	"time"
 )
 
 type accountUC struct {
 	cfg         *config.Config
 	accountRepo account.Repository
+	files       account.Files
 	logger      logger.Logger
 }
 
 func (UC *accountUC) ReservAcc(ctx context.Context, acc_data *models.FullAccountData) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ReservAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ReservAcc")
+	defer span.End()
 
 	acc, _ := UC.accountRepo.GetAccountData(ctxWithTrace, acc_data.Acc_uuid)
 	if acc != nil {
@@ -39,7 +43,6 @@ func (UC *accountUC) ReservAcc(ctx context.Context, acc_data *models.FullAccount
 		Acc_corr_number:  acc_data.Acc_corr_number,
 		Acc_bic:          acc_data.Acc_bic,
 		Acc_cio:          acc_data.Acc_cio,
-		Acc_money_value:  acc_data.Acc_money_value,
 		Acc_money_amount: 0.0,
 	}
 
@@ -53,6 +56,16 @@ func (UC *accountUC) ReservAcc(ctx context.Context, acc_data *models.FullAccount
 		return err
 	}
 
+	// Currency of account is set by culc number, requested one must be the same
+	currency, err := UC.ValidateCurrency(ctxWithTrace, acc_data.Acc_culc_number[5:8])
+	if err != nil {
+		return err
+	} else if acc_data.Acc_currency != "" && acc_data.Acc_currency != currency {
+		return ErrorCurrencyMismatch
+	}
+	acc.Acc_currency = currency
+	acc_data.Acc_currency = currency
+
 	reason = &models.ReserverReason{
 		Acc_uuid: acc_data.Acc_uuid,
 		Reason:   acc_data.Reason,
@@ -66,8 +79,8 @@ func (UC *accountUC) ReservAcc(ctx context.Context, acc_data *models.FullAccount
 }
 
 func (UC *accountUC) CreateAcc(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.CreateAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CreateAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -97,8 +110,8 @@ func (UC *accountUC) CreateAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 }
 
 func (UC *accountUC) OpenAcc(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.OpenAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.OpenAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -128,8 +141,8 @@ func (UC *accountUC) OpenAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 }
 
 func (UC *accountUC) CloseAcc(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.CloseAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CloseAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -159,8 +172,8 @@ func (UC *accountUC) CloseAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 }
 
 func (UC *accountUC) BlockAcc(ctx context.Context, acc_uuid uuid.UUID) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.BlockAcc")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.BlockAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -190,8 +203,8 @@ func (UC *accountUC) BlockAcc(ctx context.Context, acc_uuid uuid.UUID) error {
 }
 
 func (UC *accountUC) GetAccInfo(ctx context.Context, acc_uuid uuid.UUID) (*models.FullAccountData, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.GetAccInfo")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.GetAccInfo")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -202,7 +215,7 @@ func (UC *accountUC) GetAccInfo(ctx context.Context, acc_uuid uuid.UUID) (*model
 		Acc_uuid:         acc_uuid,
 		Acc_name:         acc.Acc_name,
 		Acc_money_amount: acc.Acc_money_amount,
-		Acc_money_value:  acc.Acc_money_value,
+		Acc_currency:     acc.Acc_currency,
 		Acc_cio:          acc.Acc_cio,
 		Acc_bic:          acc.Acc_bic,
 		Acc_corr_number:  acc.Acc_corr_number,
@@ -217,13 +230,19 @@ func (UC *accountUC) GetAccInfo(ctx context.Context, acc_uuid uuid.UUID) (*model
 		result.Reason = reason.Reason
 	}
 
+	holds_amount, err := UC.accountRepo.GetAccountHoldsAmount(ctxWithTrace, acc_uuid)
+	if err != nil {
+		return nil, ErrorGetHolds
+	}
+	result.Acc_available_amount = acc.Acc_money_amount - holds_amount
+
 	return &result, nil
 
 }
 
-func (UC *accountUC) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.AddingAcc")
-	defer span.Finish()
+func (UC *accountUC) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_value float64, operation_uuid uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.AddingAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -247,7 +266,7 @@ func (UC *accountUC) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_valu
 		return ErrorOverflowAmount
 	}
 
-	err = UC.accountRepo.UpdateAccountAmount(ctxWithTrace, acc_uuid, new_value)
+	err = UC.accountRepo.UpdateAccountAmountWithMovement(ctxWithTrace, newMovement(acc_uuid, models.MovementTypeDeposit, add_value, new_value, operation_uuid))
 	if err != nil {
 		return ErrorUpdateAmountValue
 	}
@@ -256,9 +275,9 @@ func (UC *accountUC) AddingAcc(ctx context.Context, acc_uuid uuid.UUID, add_valu
 
 }
 
-func (UC *accountUC) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.AddingAcc")
-	defer span.Finish()
+func (UC *accountUC) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_value float64, operation_uuid uuid.UUID, user_uuid uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.WidthAcc")
+	defer span.End()
 
 	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -276,13 +295,25 @@ func (UC *accountUC) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_val
 		}
 	}
 
-	new_value := acc.Acc_money_amount - width_value
-	if new_value < 0 {
-		return ErrorNotEnoughMoneyAmount
+	account_limits, user_limits, rates, err := UC.getWithdrawalLimits(ctxWithTrace, acc, user_uuid)
+	if err != nil {
+		return err
+	} else if err = checkOperationLimits(account_limits, user_limits, rates, width_value, acc.Acc_currency); err != nil {
+		return err
+	}
+
+	movement := newMovement(acc_uuid, models.MovementTypeWithdrawal, -width_value, 0, operation_uuid)
+	movement.User_uuid = uuid.NullUUID{
+		UUID:  user_uuid,
+		Valid: user_uuid != uuid.Nil,
 	}
 
-	err = UC.accountRepo.UpdateAccountAmount(ctxWithTrace, acc_uuid, new_value)
-	if err != nil {
+	// Funds and limits are checked while account is locked, so concurrent withdrawals can't exceed them
+	var check_err error
+	err = UC.accountRepo.WidthAccountAmount(ctxWithTrace, movement, withdrawalCheck(account_limits, user_limits, rates, width_value, acc.Acc_currency, &check_err))
+	if check_err != nil {
+		return check_err
+	} else if err != nil {
 		return ErrorUpdateAmountValue
 	}
 
@@ -292,8 +323,8 @@ func (UC *accountUC) WidthAcc(ctx context.Context, acc_uuid uuid.UUID, width_val
 
 // Валидирует статус счёта
 func (UC *accountUC) ValidateAccStatus(ctx context.Context, status uint8) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccStatus")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateAccStatus")
+	defer span.End()
 
 	for i := 0; i < len(PossibleAccStatus); i++ {
 		if status == PossibleAccStatus[i] {
@@ -304,24 +335,22 @@ func (UC *accountUC) ValidateAccStatus(ctx context.Context, status uint8) error
 	return ErrorWrongAccStatus
 }
 
-// Валидирует денежную величину
-func (UC *accountUC) ValidateAccMoneyValue(ctx context.Context, money_value uint8) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccMoneyValue")
-	defer span.Finish()
+// Валидирует валюту счёта
+func (UC *accountUC) ValidateAccCurrency(ctx context.Context, currency string) error {
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateAccCurrency")
+	defer span.End()
 
-	for i := 0; i < len(PossibleAccMoneyValue); i++ {
-		if money_value == PossibleAccMoneyValue[i] {
-			return nil
-		}
+	if _, ok := PossibleAccCurrency[currency]; !ok {
+		return ErrorWrongCurrencyValue
 	}
 
-	return ErrorWrongMoneyValue
+	return nil
 }
 
 // Валидирует расчётный номер счёта
 func (UC *accountUC) ValidateCulcNumber(ctx context.Context, culc_number string) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateCulcNumber")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ValidateCulcNumber")
+	defer span.End()
 
 	if len(culc_number) != 20 {
 		return ErrorWrongCulcNumberLen
@@ -329,7 +358,7 @@ func (UC *accountUC) ValidateCulcNumber(ctx context.Context, culc_number string)
 
 	//owner := culc_number[:3]
 	//activity_field := culc_number[3:5]
-	//currency := culc_number[5:8]
+	currency := culc_number[5:8]
 	//
 	//if err := UC.ValidateOwner(ctxWithTrace, owner); err != nil {
 	//	return err
@@ -338,18 +367,18 @@ func (UC *accountUC) ValidateCulcNumber(ctx context.Context, culc_number string)
 	//if err := UC.ValidateActivity(ctxWithTrace, owner, activity_field); err != nil {
 	//	return err
 	//}
-	//
-	//if _, err := UC.ValidateCurrency(ctxWithTrace, currency); err != nil {
-	//	return err
-	//}
+
+	if _, err := UC.ValidateCurrency(ctxWithTrace, currency); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // Проверяет владельца счёта
 func (UC *accountUC) ValidateOwner(ctx context.Context, owner string) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateOwner")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateOwner")
+	defer span.End()
 
 	if len(owner) != 3 {
 		return ErrorWrongOwnerLen
@@ -370,8 +399,8 @@ func (UC *accountUC) ValidateOwner(ctx context.Context, owner string) error {
 // Проверяет тип деятелности владельца счёта
 func (UC *accountUC) ValidateActivity(ctx context.Context, owner string, activity string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateOwner")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ValidateOwner")
+	defer span.End()
 
 	if len(activity) != 2 {
 		return ErrorWrongActivityLen
@@ -394,19 +423,19 @@ func (UC *accountUC) ValidateActivity(ctx context.Context, owner string, activit
 }
 
 // Проверят тип валюты
-func (UC *accountUC) ValidateCurrency(ctx context.Context, currency string) (uint8, error) {
+func (UC *accountUC) ValidateCurrency(ctx context.Context, currency string) (string, error) {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateCurrency")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateCurrency")
+	defer span.End()
 
 	if len(currency) != 3 {
-		return AccMoneyValueError, ErrorWrongCurrencyLen
+		return "", ErrorWrongCurrencyLen
 	}
 
-	local_currency, ok := PossibleAccMoneyValueStr[currency]
+	local_currency, ok := PossibleAccCurrencyStr[currency]
 
 	if !ok {
-		return AccMoneyValueUnknown, ErrorWrongCurrencyValue
+		return "", ErrorWrongCurrencyValue
 	}
 
 	return local_currency, nil
@@ -416,8 +445,8 @@ func (UC *accountUC) ValidateCurrency(ctx context.Context, currency string) (uin
 // Валидирует БИК счёта
 func (UC *accountUC) ValidateBIC(ctx context.Context, BIC string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateBIC")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateBIC")
+	defer span.End()
 
 	if len(BIC) != 9 {
 		return ErrorWrongBICLen
@@ -441,8 +470,8 @@ func (UC *accountUC) ValidateBIC(ctx context.Context, BIC string) error {
 // Валидирует участника платёжной системы
 func (UC *accountUC) ValidatePaymentSystem(ctx context.Context, payment_system string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidatePaymentSystem")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidatePaymentSystem")
+	defer span.End()
 
 	if len(payment_system) != 1 {
 		return ErrorWrongPaymentSystemLen
@@ -465,8 +494,8 @@ func (UC *accountUC) ValidatePaymentSystem(ctx context.Context, payment_system s
 // Валидирует страну счёта
 func (UC *accountUC) ValidateAccCountry(ctx context.Context, acc_country string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccCountry")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateAccCountry")
+	defer span.End()
 
 	if len(acc_country) != 1 {
 		return ErrorWrongAccCountryLen
@@ -490,8 +519,8 @@ func (UC *accountUC) ValidateAccCountry(ctx context.Context, acc_country string)
 // Валидирует регион страны
 func (UC *accountUC) ValidateAccCountryRegion(ctx context.Context, acc_country string, acc_country_region string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccCountryRegion")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ValidateAccCountryRegion")
+	defer span.End()
 
 	if len(acc_country_region) != 2 {
 		return ErrorWrongAccCountryRegionLen
@@ -529,8 +558,8 @@ func (UC *accountUC) ValidateAccMainOffice(
 	acc_country_region string,
 	acc_main_office string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccMainOffice")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ValidateAccMainOffice")
+	defer span.End()
 
 	if len(acc_main_office) != 2 {
 		return ErrorWrongAccMainOfficeLen
@@ -561,8 +590,8 @@ func (UC *accountUC) ValidateAccMainOffice(
 // Валидирует код банка
 func (UC *accountUC) ValidateAccBankNumber(ctx context.Context, acc_bank_number string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccCountryRegion")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateAccCountryRegion")
+	defer span.End()
 
 	if len(acc_bank_number) != 3 {
 		return ErrorWrongAccBankNumberLen
@@ -584,8 +613,8 @@ func (UC *accountUC) ValidateAccBankNumber(ctx context.Context, acc_bank_number
 // Валидирует корр счет
 func (UC *accountUC) ValidateCorrNumber(ctx context.Context, acc_corr_number string, acc_bic string) error {
 
-	span, contextWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateCorrNumber")
-	defer span.Finish()
+	contextWithTrace, span := tracing.StartSpan(ctx, "accountUC.ValidateCorrNumber")
+	defer span.End()
 
 	if err := UC.ValidateBIC(contextWithTrace, acc_bic); err != nil {
 		return err
@@ -617,8 +646,8 @@ func (UC *accountUC) ValidateCorrNumber(ctx context.Context, acc_corr_number str
 // Валидирует владельца корр счёта
 func (UC *accountUC) ValidateAccCorrNumberOwner(ctx context.Context, acc_corr_number_owner string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateAccCurrNumberOwner")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateAccCurrNumberOwner")
+	defer span.End()
 
 	if len(acc_corr_number_owner) != 2 {
 		return ErrorWrongAccCorrOwnerLen
@@ -637,8 +666,8 @@ func (UC *accountUC) ValidateAccCorrNumberOwner(ctx context.Context, acc_corr_nu
 // Валидирует КПП
 func (UC *accountUC) ValidateKPP(ctx context.Context, acc_kpp string, acc_bic string) error {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "accountUC.ValidateKPP")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "accountUC.ValidateKPP")
+	defer span.End()
 
 	if len(acc_kpp) != 9 {
 		return ErrorWrongAccKPPLen
@@ -659,8 +688,8 @@ func (UC *accountUC) ValidateKPP(ctx context.Context, acc_kpp string, acc_bic st
 
 func (UC *accountUC) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "accountUC.RemoveAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.RemoveAccount")
+	defer span.End()
 
 	_, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
 	if err != nil {
@@ -675,6 +704,571 @@ func (UC *accountUC) RemoveAccount(ctx context.Context, acc_uuid uuid.UUID) erro
 	return nil
 }
 
-func NewAccountUseCase(cfg *config.Config, account_repo account.Repository, log logger.Logger) account.UseCase {
-	return &accountUC{cfg: cfg, accountRepo: account_repo, logger: log}
+// CreateStatement makes account statement for [from, to) and stores it in file-api, owner is user allowed to download it.
+// Opening balance is counted back from current amount, so accounts opened before movements were saved have it right.
+func (UC *accountUC) CreateStatement(ctx context.Context, acc_uuid uuid.UUID, owner_uuid uuid.UUID, from time.Time, to time.Time, format string) (uuid.UUID, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CreateStatement")
+	defer span.End()
+
+	if format != statement.FormatPDF && format != statement.FormatCSV {
+		return uuid.Nil, ErrorWrongStatementFormat
+	}
+	if !from.Before(to) || to.Sub(from) > StatementMaxPeriod {
+		return uuid.Nil, ErrorWrongStatementPeriod
+	}
+
+	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
+	if err != nil {
+		return uuid.Nil, ErrorNoFoundAcc
+	}
+
+	since_from, err := UC.accountRepo.GetAccountMovementsSum(ctxWithTrace, acc_uuid, from)
+	if err != nil {
+		return uuid.Nil, ErrorGetMovements
+	}
+
+	movements, err := UC.accountRepo.GetAccountMovements(ctxWithTrace, acc_uuid, from, to)
+	if err != nil {
+		return uuid.Nil, ErrorGetMovements
+	}
+
+	result := &models.Statement{
+		Account:        acc,
+		From:           from,
+		To:             to,
+		OpeningBalance: acc.Acc_money_amount - since_from,
+		Movements:      movements,
+		GeneratedAt:    time.Now(),
+	}
+	result.ClosingBalance = result.OpeningBalance
+	for _, movement := range movements {
+		result.ClosingBalance += movement.Amount
+	}
+
+	content, file_name, err := statement.Render(result, format)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	file_uuid, err := UC.files.PutFile(ctxWithTrace, &models.File{
+		BusinessType: StatementBusinessType,
+		OwnerUUID:    owner_uuid,
+		FileName:     file_name,
+		Content:      content,
+	})
+	if err != nil {
+		UC.logger.Error(err)
+		return uuid.Nil, ErrorSaveStatement
+	}
+
+	return file_uuid, nil
+}
+
+// GetAccHistory returns page of account movements matching filter, newest first.
+// Next page is requested with NextCursor of previous one, it is empty on last page
+func (UC *accountUC) GetAccHistory(ctx context.Context, acc_uuid uuid.UUID, filter *models.HistoryFilter) (*models.AccountHistory, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.GetAccHistory")
+	defer span.End()
+
+	if filter.Direction != "" && filter.Direction != models.MovementTypeDeposit && filter.Direction != models.MovementTypeWithdrawal {
+		return nil, ErrorWrongHistoryFilter
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, ErrorWrongHistoryFilter
+	}
+	if (filter.MinAmount != nil && *filter.MinAmount < 0) || (filter.MaxAmount != nil && *filter.MaxAmount < 0) {
+		return nil, ErrorWrongHistoryFilter
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return nil, ErrorWrongHistoryFilter
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = HistoryDefaultLimit
+	} else if limit < 0 || limit > HistoryMaxLimit {
+		return nil, ErrorWrongHistoryFilter
+	}
+
+	var after *models.HistoryCursor
+	if filter.Cursor != "" {
+		var err error
+		after, err = decodeHistoryCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
+	if err != nil {
+		return nil, ErrorNoFoundAcc
+	}
+
+	// One more movement shows that next page exists
+	movements, err := UC.accountRepo.GetAccountHistory(ctxWithTrace, acc_uuid, filter, after, limit+1)
+	if err != nil {
+		return nil, ErrorGetHistory
+	}
+
+	result := &models.AccountHistory{
+		Movements:  movements,
+		NextCursor: "",
+	}
+	if len(movements) > limit {
+		result.Movements = movements[:limit]
+		result.NextCursor = encodeHistoryCursor(movements[limit-1])
+	}
+
+	return result, nil
+}
+
+// CreateHold reserves amount on open account for ttl, zero ttl means HoldDefaultTTL.
+// Held money is excluded from available amount until hold is captured, released or expired
+func (UC *accountUC) CreateHold(ctx context.Context, acc_uuid uuid.UUID, amount float64, ttl time.Duration, operation_uuid uuid.UUID) (*models.AccountHold, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CreateHold")
+	defer span.End()
+
+	if amount <= 0 {
+		return nil, ErrorWrongHoldAmount
+	}
+
+	if ttl == 0 {
+		ttl = HoldDefaultTTL
+	} else if ttl < 0 || ttl > HoldMaxTTL {
+		return nil, ErrorWrongHoldTTL
+	}
+
+	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
+	if err != nil {
+		return nil, ErrorNoFoundAcc
+	} else if acc.Acc_status != AccStatusOpen {
+		switch acc.Acc_status {
+		case AccStatusReserved:
+			return nil, ErrorWrongAccReservedStatus
+		case AccStatusCreated:
+			return nil, ErrorWrongAccCreatedStatus
+		case AccStatusClose:
+			return nil, ErrorWrongAccCloseStatus
+		case AccStatusBlocked:
+			return nil, ErrorWrongAccBlockStatus
+		}
+	}
+
+	now := time.Now()
+	hold := &models.AccountHold{
+		Hold_uuid:  uuid.New(),
+		Acc_uuid:   acc_uuid,
+		Amount:     amount,
+		Status:     models.HoldStatusActive,
+		Created_at: now,
+		Expires_at: now.Add(ttl),
+		Updated_at: now,
+		Operation_uuid: uuid.NullUUID{
+			UUID:  operation_uuid,
+			Valid: operation_uuid != uuid.Nil,
+		},
+	}
+
+	created, err := UC.accountRepo.CreateHold(ctxWithTrace, hold)
+	if err != nil {
+		return nil, ErrorCreateHold
+	} else if !created {
+		return nil, ErrorNotEnoughMoneyAmount
+	}
+
+	return hold, nil
+}
+
+// CaptureHold withdraws amount of active account hold, zero amount captures whole hold.
+// Rest of partially captured hold is released
+func (UC *accountUC) CaptureHold(ctx context.Context, acc_uuid uuid.UUID, hold_uuid uuid.UUID, amount float64, operation_uuid uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.CaptureHold")
+	defer span.End()
+
+	hold, err := UC.getActiveHold(ctxWithTrace, acc_uuid, hold_uuid)
+	if err != nil {
+		return err
+	}
+
+	if amount == 0 {
+		amount = hold.Amount
+	} else if amount < 0 || amount > hold.Amount {
+		return ErrorWrongHoldAmount
+	}
+
+	acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, acc_uuid)
+	if err != nil {
+		return ErrorNoFoundAcc
+	} else if acc.Acc_status != AccStatusOpen {
+		switch acc.Acc_status {
+		case AccStatusReserved:
+			return ErrorWrongAccReservedStatus
+		case AccStatusCreated:
+			return ErrorWrongAccCreatedStatus
+		case AccStatusClose:
+			return ErrorWrongAccCloseStatus
+		case AccStatusBlocked:
+			return ErrorWrongAccBlockStatus
+		}
+	}
+
+	// Balance is set by repository after withdrawal
+	err = UC.accountRepo.CaptureHold(ctxWithTrace, hold_uuid, newMovement(acc_uuid, models.MovementTypeWithdrawal, -amount, acc.Acc_money_amount-amount, operation_uuid))
+	if err != nil {
+		return ErrorUpdateHold
+	}
+
+	return nil
+}
+
+// ReleaseHold returns held amount of active account hold to available amount
+func (UC *accountUC) ReleaseHold(ctx context.Context, acc_uuid uuid.UUID, hold_uuid uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ReleaseHold")
+	defer span.End()
+
+	if _, err := UC.getActiveHold(ctxWithTrace, acc_uuid, hold_uuid); err != nil {
+		return err
+	}
+
+	if err := UC.accountRepo.ReleaseHold(ctxWithTrace, hold_uuid); err != nil {
+		return ErrorUpdateHold
+	}
+
+	return nil
+}
+
+// ExpireHolds marks expired holds which lifetime has passed
+func (UC *accountUC) ExpireHolds(ctx context.Context) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.ExpireHolds")
+	defer span.End()
+
+	count, err := UC.accountRepo.ExpireHolds(ctxWithTrace)
+	if err != nil {
+		return ErrorExpireHolds
+	}
+
+	if count > 0 {
+		UC.logger.Infof("Holds expired: %d", count)
+	}
+
+	return nil
+}
+
+// getActiveHold returns hold of account, which is neither finished nor expired
+func (UC *accountUC) getActiveHold(ctx context.Context, acc_uuid uuid.UUID, hold_uuid uuid.UUID) (*models.AccountHold, error) {
+	hold, err := UC.accountRepo.GetHold(ctx, hold_uuid)
+	if err != nil || hold.Acc_uuid != acc_uuid {
+		return nil, ErrorNoFoundHold
+	}
+
+	if hold.Status != models.HoldStatusActive || !hold.Expires_at.After(time.Now()) {
+		return nil, ErrorHoldIsNotActive
+	}
+
+	return hold, nil
+}
+
+// GetWithdrawalLimits returns limits applied to subject withdrawals, account limits include defaults of account type
+func (UC *accountUC) GetWithdrawalLimits(ctx context.Context, subject_type string, subject_uuid uuid.UUID) (*models.WithdrawalLimits, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.GetWithdrawalLimits")
+	defer span.End()
+
+	switch subject_type {
+	case models.LimitSubjectAccount:
+		acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, subject_uuid)
+		if err != nil {
+			return nil, ErrorNoFoundAcc
+		}
+
+		return UC.getAccountWithdrawalLimits(ctxWithTrace, acc)
+	case models.LimitSubjectUser:
+		limits, err := UC.accountRepo.GetWithdrawalLimits(ctxWithTrace, subject_type, subject_uuid)
+		if err != nil {
+			return nil, ErrorGetWithdrawalLimits
+		} else if limits == nil {
+			limits = &models.WithdrawalLimits{}
+		}
+		limits.Subject_type = subject_type
+		limits.Subject_uuid = subject_uuid
+
+		return limits, nil
+	}
+
+	return nil, ErrorWrongLimitSubject
+}
+
+// SetWithdrawalLimits replaces subject own limits, nil fields of account limits fall back to account type defaults
+func (UC *accountUC) SetWithdrawalLimits(ctx context.Context, limits *models.WithdrawalLimits) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.SetWithdrawalLimits")
+	defer span.End()
+
+	if !slices.Contains(PossibleLimitSubjects[:], limits.Subject_type) {
+		return ErrorWrongLimitSubject
+	}
+
+	for _, amount := range []*float64{limits.Per_operation, limits.Daily_amount, limits.Monthly_amount} {
+		if amount != nil && *amount < 0 {
+			return ErrorWrongLimitValue
+		}
+	}
+	for _, count := range []*int64{limits.Daily_count, limits.Monthly_count} {
+		if count != nil && *count < 0 {
+			return ErrorWrongLimitValue
+		}
+	}
+
+	if limits.Subject_type == models.LimitSubjectAccount {
+		_, err := UC.accountRepo.GetAccountData(ctxWithTrace, limits.Subject_uuid)
+		if err != nil {
+			return ErrorNoFoundAcc
+		}
+	}
+
+	err := UC.accountRepo.SetWithdrawalLimits(ctxWithTrace, limits)
+	if err != nil {
+		return ErrorSetWithdrawalLimits
+	}
+
+	return nil
+}
+
+// TransferAcc moves amount from one account to another, amount deposited to account of other currency
+// is converted by exchange rate and conversion is saved. Transfer is withdrawal of user from source account
+func (UC *accountUC) TransferAcc(ctx context.Context, from_acc_uuid uuid.UUID, to_acc_uuid uuid.UUID, amount float64, operation_uuid uuid.UUID, user_uuid uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.TransferAcc")
+	defer span.End()
+
+	if from_acc_uuid == to_acc_uuid {
+		return ErrorWrongTransferAcc
+	} else if amount <= 0 {
+		return ErrorWrongTransferAmount
+	}
+
+	from_acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, from_acc_uuid)
+	if err != nil {
+		return ErrorNoFoundAcc
+	} else if err = accStatusError(from_acc.Acc_status); err != nil {
+		return err
+	}
+
+	to_acc, err := UC.accountRepo.GetAccountData(ctxWithTrace, to_acc_uuid)
+	if err != nil {
+		return ErrorNoFoundAcc
+	} else if err = accStatusError(to_acc.Acc_status); err != nil {
+		return err
+	}
+
+	// Amount must be whole number of minor units of source currency, otherwise part of it can't be deposited
+	if from_amount, err := roundToCurrency(amount, from_acc.Acc_currency); err != nil {
+		return err
+	} else if from_amount != amount {
+		return ErrorWrongTransferAmount
+	} else if err = UC.ValidateAccCurrency(ctxWithTrace, to_acc.Acc_currency); err != nil {
+		return err
+	}
+
+	account_limits, user_limits, rates, err := UC.getWithdrawalLimits(ctxWithTrace, from_acc, user_uuid)
+	if err != nil {
+		return err
+	} else if err = checkOperationLimits(account_limits, user_limits, rates, amount, from_acc.Acc_currency); err != nil {
+		return err
+	}
+
+	rate, err := UC.getExchangeRate(ctxWithTrace, from_acc.Acc_currency, to_acc.Acc_currency)
+	if err != nil {
+		return err
+	}
+
+	to_amount, err := roundToCurrency(amount*rate, to_acc.Acc_currency)
+	if err != nil {
+		return err
+	} else if to_amount <= 0 {
+		return ErrorWrongTransferAmount
+	}
+
+	from := newMovement(from_acc_uuid, models.MovementTypeWithdrawal, -amount, 0, operation_uuid)
+	from.User_uuid = uuid.NullUUID{
+		UUID:  user_uuid,
+		Valid: user_uuid != uuid.Nil,
+	}
+	to := newMovement(to_acc_uuid, models.MovementTypeDeposit, to_amount, 0, operation_uuid)
+
+	var conversion *models.CurrencyConversion
+	if from_acc.Acc_currency != to_acc.Acc_currency {
+		conversion = &models.CurrencyConversion{
+			Conversion_uuid:    uuid.New(),
+			From_acc_uuid:      from_acc_uuid,
+			To_acc_uuid:        to_acc_uuid,
+			From_movement_uuid: from.Movement_uuid,
+			To_movement_uuid:   to.Movement_uuid,
+			From_currency:      from_acc.Acc_currency,
+			To_currency:        to_acc.Acc_currency,
+			From_amount:        amount,
+			To_amount:          to_amount,
+			Rate:               rate,
+			Operation_uuid:     from.Operation_uuid,
+			Created_at:         from.Created_at,
+		}
+	}
+
+	var check_err error
+	err = UC.accountRepo.TransferAmount(ctxWithTrace, from, to, conversion, withdrawalCheck(account_limits, user_limits, rates, amount, from_acc.Acc_currency, &check_err))
+	if check_err != nil {
+		return check_err
+	} else if err != nil {
+		return ErrorTransferAmount
+	}
+
+	return nil
+}
+
+func (UC *accountUC) GetExchangeRates(ctx context.Context) ([]*models.ExchangeRate, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.GetExchangeRates")
+	defer span.End()
+
+	rates, err := UC.accountRepo.GetExchangeRates(ctxWithTrace)
+	if err != nil {
+		return nil, ErrorGetExchangeRate
+	}
+
+	return rates, nil
+}
+
+// SetExchangeRate sets rate of base currency in quote one, reverse rate is counted from it when it isn't set
+func (UC *accountUC) SetExchangeRate(ctx context.Context, base_currency string, quote_currency string, rate float64) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "accountUC.SetExchangeRate")
+	defer span.End()
+
+	if err := UC.ValidateAccCurrency(ctxWithTrace, base_currency); err != nil {
+		return err
+	} else if err = UC.ValidateAccCurrency(ctxWithTrace, quote_currency); err != nil {
+		return err
+	} else if base_currency == quote_currency || rate <= 0 {
+		return ErrorWrongExchangeRate
+	}
+
+	err := UC.accountRepo.SetExchangeRate(ctxWithTrace, &models.ExchangeRate{
+		Base_currency:  base_currency,
+		Quote_currency: quote_currency,
+		Rate:           rate,
+	})
+	if err != nil {
+		return ErrorSetExchangeRate
+	}
+
+	return nil
+}
+
+// Rate of from currency in to one, reverse rate is used when direct one isn't set
+func (UC *accountUC) getExchangeRate(ctx context.Context, from_currency string, to_currency string) (float64, error) {
+	if from_currency == to_currency {
+		return 1, nil
+	}
+
+	rate, err := UC.accountRepo.GetExchangeRate(ctx, from_currency, to_currency)
+	if err != nil {
+		return 0, ErrorGetExchangeRate
+	} else if rate != nil {
+		return rate.Rate, nil
+	}
+
+	rate, err = UC.accountRepo.GetExchangeRate(ctx, to_currency, from_currency)
+	if err != nil {
+		return 0, ErrorGetExchangeRate
+	} else if rate != nil {
+		return 1 / rate.Rate, nil
+	}
+
+	return 0, ErrorNoExchangeRate
+}
+
+// Limits of account and, when user is set, of user withdrawing from account. User limits are in limit currency,
+// so when they are set rates of account currencies in limit currency are returned too
+func (UC *accountUC) getWithdrawalLimits(ctx context.Context, acc *models.Account, user_uuid uuid.UUID) (*models.WithdrawalLimits, *models.WithdrawalLimits, limitRates, error) {
+	account_limits, err := UC.getAccountWithdrawalLimits(ctx, acc)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if user_uuid == uuid.Nil {
+		return account_limits, nil, nil, nil
+	}
+
+	user_limits, err := UC.accountRepo.GetWithdrawalLimits(ctx, models.LimitSubjectUser, user_uuid)
+	if err != nil {
+		return nil, nil, nil, ErrorGetWithdrawalLimits
+	} else if user_limits == nil {
+		return account_limits, nil, nil, nil
+	}
+
+	rates, err := UC.accountRepo.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, nil, nil, ErrorGetExchangeRate
+	}
+
+	return account_limits, user_limits, newLimitRates(rates), nil
+}
+
+func (UC *accountUC) getAccountWithdrawalLimits(ctx context.Context, acc *models.Account) (*models.WithdrawalLimits, error) {
+	limits, err := UC.accountRepo.GetWithdrawalLimits(ctx, models.LimitSubjectAccount, acc.Acc_uuid)
+	if err != nil {
+		return nil, ErrorGetWithdrawalLimits
+	}
+
+	var defaults models.WithdrawalLimits
+	if len(acc.Acc_culc_number) >= 3 {
+		defaults = DefaultWithdrawalLimits[acc.Acc_culc_number[:3]]
+	}
+
+	// Defaults are set in limit currency, account of other currency gets them by exchange rate
+	if defaults != (models.WithdrawalLimits{}) && acc.Acc_currency != WithdrawalLimitCurrency {
+		rate, err := UC.getExchangeRate(ctx, WithdrawalLimitCurrency, acc.Acc_currency)
+		if err != nil {
+			return nil, err
+		}
+		defaults = convertWithdrawalLimits(defaults, rate)
+	}
+
+	result := mergeWithdrawalLimits(limits, defaults)
+	result.Subject_type = models.LimitSubjectAccount
+	result.Subject_uuid = acc.Acc_uuid
+
+	return result, nil
+}
+
+// Error of operation with account in given status, nil for open account
+func accStatusError(status uint8) error {
+	switch status {
+	case AccStatusOpen:
+		return nil
+	case AccStatusReserved:
+		return ErrorWrongAccReservedStatus
+	case AccStatusCreated:
+		return ErrorWrongAccCreatedStatus
+	case AccStatusClose:
+		return ErrorWrongAccCloseStatus
+	case AccStatusBlocked:
+		return ErrorWrongAccBlockStatus
+	}
+
+	return ErrorWrongAccStatus
+}
+
+func newMovement(acc_uuid uuid.UUID, movement_type string, amount float64, balance float64, operation_uuid uuid.UUID) *models.AccountMovement {
+	return &models.AccountMovement{
+		Movement_uuid: uuid.New(),
+		Acc_uuid:      acc_uuid,
+		Movement_type: movement_type,
+		Amount:        amount,
+		Balance:       balance,
+		Created_at:    time.Now(),
+		Operation_uuid: uuid.NullUUID{
+			UUID:  operation_uuid,
+			Valid: operation_uuid != uuid.Nil,
+		},
+	}
+}
+
+func NewAccountUseCase(cfg *config.Config, account_repo account.Repository, files account.Files, log logger.Logger) account.UseCase {
+	return &accountUC{cfg: cfg, accountRepo: account_repo, files: files, logger: log}
 }
@@ -1,6 +1,9 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type Account struct {
 	Acc_uuid         uuid.UUID `json:"acc_uuid" db:"acc_uuid" validate:"len=36 required unique"`
@@ -10,7 +13,7 @@ type Account struct {
 	Acc_corr_number  string    `json:"acc_corr_number" db:"acc_corr_number" validate:"len=20 required"`
 	Acc_bic          string    `json:"acc_bic" db:"acc_bic" validate:"len=9 required"`
 	Acc_cio          string    `json:"acc_cio" db:"acc_cio" validate:"len=9 required"`
-	Acc_money_value  uint8     `json:"acc_money_value" db:"acc_money_value" validate:"min=0 max=1 oneof=0 1 2 3"`
+	Acc_currency     string    `json:"acc_currency" db:"acc_currency" validate:"len=3 required"`
 	Acc_money_amount float64   `json:"acc_money_amount" db:"acc_money_amount" validate:"required"`
 }
 
@@ -27,7 +30,157 @@ type FullAccountData struct {
 	Acc_corr_number  string    `json:"acc_corr_number" db:"acc_corr_number" validate:"len=20 required"`
 	Acc_bic          string    `json:"acc_bic" db:"acc_bic" validate:"len=9 required"`
 	Acc_cio          string    `json:"acc_cio" db:"acc_cio" validate:"len=9 required"`
-	Acc_money_value  uint8     `json:"acc_money_value" db:"acc_money_value" validate:"min=0 max=1 oneof=0 1 2 3"`
+	Acc_currency     string    `json:"acc_currency" db:"acc_currency" validate:"len=3 required"`
 	Acc_money_amount float64   `json:"acc_money_amount" db:"acc_money_amount" validate:"required"`
 	Reason           string    `json:"reserve_reason" db:"reserve_reason" validate:"required"'`
+	// Ledger balance without active holds
+	Acc_available_amount float64 `json:"acc_available_amount" db:"acc_available_amount"`
+}
+
+const (
+	MovementTypeDeposit    = "deposit"
+	MovementTypeWithdrawal = "withdrawal"
+)
+
+// Change of account money amount, Amount is positive for deposits and negative for withdrawals,
+// Balance is account amount after movement. Operation is saga which made movement, empty for older movements.
+// User is set for withdrawals made by user, they are counted in user withdrawal limits
+type AccountMovement struct {
+	Movement_uuid  uuid.UUID     `json:"movement_uuid" db:"movement_uuid"`
+	Acc_uuid       uuid.UUID     `json:"acc_uuid" db:"acc_uuid"`
+	Movement_type  string        `json:"movement_type" db:"movement_type"`
+	Amount         float64       `json:"amount" db:"amount"`
+	Balance        float64       `json:"balance" db:"balance"`
+	Created_at     time.Time     `json:"created_at" db:"created_at"`
+	Operation_uuid uuid.NullUUID `json:"operation_uuid" db:"operation_uuid"`
+	User_uuid      uuid.NullUUID `json:"user_uuid" db:"user_uuid"`
+}
+
+const (
+	HoldStatusActive   = "active"
+	HoldStatusCaptured = "captured"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
+
+// Funds reserved on account until capture, release or expiry. Active hold reduces available balance,
+// ledger balance changes only on capture by Captured_amount, rest of captured hold is released
+type AccountHold struct {
+	Hold_uuid       uuid.UUID     `json:"hold_uuid" db:"hold_uuid"`
+	Acc_uuid        uuid.UUID     `json:"acc_uuid" db:"acc_uuid"`
+	Amount          float64       `json:"amount" db:"amount"`
+	Captured_amount float64       `json:"captured_amount" db:"captured_amount"`
+	Status          string        `json:"status" db:"status"`
+	Operation_uuid  uuid.NullUUID `json:"operation_uuid" db:"operation_uuid"`
+	Created_at      time.Time     `json:"created_at" db:"created_at"`
+	Expires_at      time.Time     `json:"expires_at" db:"expires_at"`
+	Updated_at      time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+const (
+	LimitSubjectAccount = "account"
+	LimitSubjectUser    = "user"
+)
+
+// Withdrawal limits of account or user, nil field means no limit. Day and month are calendar ones
+type WithdrawalLimits struct {
+	Subject_type   string    `json:"subject_type" db:"subject_type"`
+	Subject_uuid   uuid.UUID `json:"subject_uuid" db:"subject_uuid"`
+	Per_operation  *float64  `json:"per_operation" db:"per_operation"`
+	Daily_amount   *float64  `json:"daily_amount" db:"daily_amount"`
+	Monthly_amount *float64  `json:"monthly_amount" db:"monthly_amount"`
+	Daily_count    *int64    `json:"daily_count" db:"daily_count"`
+	Monthly_count  *int64    `json:"monthly_count" db:"monthly_count"`
+	Updated_at     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Withdrawals made since start of current day and month
+type WithdrawalTotals struct {
+	Daily_amount   float64 `db:"daily_amount"`
+	Daily_count    int64   `db:"daily_count"`
+	Monthly_amount float64 `db:"monthly_amount"`
+	Monthly_count  int64   `db:"monthly_count"`
+}
+
+// Withdrawals made from accounts of one currency
+type CurrencyWithdrawalTotals struct {
+	Currency string `db:"currency"`
+	WithdrawalTotals
+}
+
+// Account state seen by withdrawal while account is locked. User withdrawals are split by account currency
+type WithdrawalState struct {
+	Amount       float64
+	Holds_amount float64
+	Account      WithdrawalTotals
+	User         []CurrencyWithdrawalTotals
+}
+
+// Rate of base currency in quote currency: 1 Base_currency = Rate Quote_currency
+type ExchangeRate struct {
+	Base_currency  string    `json:"base_currency" db:"base_currency"`
+	Quote_currency string    `json:"quote_currency" db:"quote_currency"`
+	Rate           float64   `json:"rate" db:"rate"`
+	Updated_at     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Conversion made by transfer between accounts of different currencies. From_amount is withdrawn
+// by From movement, To_amount = From_amount * Rate rounded to To_currency units is deposited by To movement
+type CurrencyConversion struct {
+	Conversion_uuid    uuid.UUID     `json:"conversion_uuid" db:"conversion_uuid"`
+	From_acc_uuid      uuid.UUID     `json:"from_acc_uuid" db:"from_acc_uuid"`
+	To_acc_uuid        uuid.UUID     `json:"to_acc_uuid" db:"to_acc_uuid"`
+	From_movement_uuid uuid.UUID     `json:"from_movement_uuid" db:"from_movement_uuid"`
+	To_movement_uuid   uuid.UUID     `json:"to_movement_uuid" db:"to_movement_uuid"`
+	From_currency      string        `json:"from_currency" db:"from_currency"`
+	To_currency        string        `json:"to_currency" db:"to_currency"`
+	From_amount        float64       `json:"from_amount" db:"from_amount"`
+	To_amount          float64       `json:"to_amount" db:"to_amount"`
+	Rate               float64       `json:"rate" db:"rate"`
+	Operation_uuid     uuid.NullUUID `json:"operation_uuid" db:"operation_uuid"`
+	Created_at         time.Time     `json:"created_at" db:"created_at"`
+}
+
+// Filter of account history, zero values don't filter. Period is [From, To),
+// amounts are compared with movement amount modulo
+type HistoryFilter struct {
+	From           time.Time
+	To             time.Time
+	Direction      string
+	MinAmount      *float64
+	MaxAmount      *float64
+	Operation_uuid uuid.UUID
+	Cursor         string
+	Limit          int
+}
+
+// Position of last movement on history page, next page starts after it
+type HistoryCursor struct {
+	Created_at    time.Time
+	Movement_uuid uuid.UUID
+}
+
+// Page of account history, newest movements first
+type AccountHistory struct {
+	Movements  []*AccountMovement `json:"movements"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// Account statement for period [From, To)
+type Statement struct {
+	Account        *Account
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	ClosingBalance float64
+	Movements      []*AccountMovement
+	GeneratedAt    time.Time
+}
+
+// File stored in file-api
+type File struct {
+	BusinessType string
+	OwnerUUID    uuid.UUID
+	FileName     string
+	Content      []byte
 }
@@ -8,3 +8,54 @@ type DefaultHttpResponse struct {
 type GetAccountInfo struct {
 	AccId string `json:"acc_id" validate:"required"`
 }
+
+// Query of account history, empty fields don't filter. Dates are in 02-01-2006 format, To day is included,
+// amounts are compared with movement amount modulo
+type GetAccountHistoryRequest struct {
+	AccId       string `json:"acc_id" validate:"required,uuid"`
+	From        string `json:"from" validate:"omitempty,datetime=02-01-2006"`
+	To          string `json:"to" validate:"omitempty,datetime=02-01-2006"`
+	Direction   string `json:"direction" validate:"omitempty,oneof=deposit withdrawal"`
+	MinAmount   string `json:"min_amount" validate:"omitempty,numeric"`
+	MaxAmount   string `json:"max_amount" validate:"omitempty,numeric"`
+	OperationId string `json:"operation_id" validate:"omitempty,uuid"`
+	Cursor      string `json:"cursor"`
+	Limit       int    `json:"limit" validate:"min=0,max=100"`
+}
+
+type CreateStatementRequest struct {
+	AccId   string `json:"acc_id" validate:"required,uuid"`
+	OwnerId string `json:"owner_id" validate:"required,uuid"`
+	// Dates in 02-01-2006 format, period includes both days
+	From   string `json:"from" validate:"required,datetime=02-01-2006"`
+	To     string `json:"to" validate:"required,datetime=02-01-2006"`
+	Format string `json:"format" validate:"required,oneof=pdf csv"`
+}
+
+type CreateStatementResponse struct {
+	FileId string `json:"file_id"`
+}
+
+type GetWithdrawalLimitsRequest struct {
+	SubjectType string `json:"subject_type" validate:"required,oneof=account user"`
+	SubjectId   string `json:"subject_id" validate:"required,uuid"`
+}
+
+// Withdrawal limits of account or user, omitted fields aren't limited, for accounts they fall back
+// to account type defaults. Account limits are in account currency, user limits are in RUB
+type SetWithdrawalLimitsRequest struct {
+	SubjectType   string   `json:"subject_type" validate:"required,oneof=account user"`
+	SubjectId     string   `json:"subject_id" validate:"required,uuid"`
+	PerOperation  *float64 `json:"per_operation" validate:"omitempty,min=0"`
+	DailyAmount   *float64 `json:"daily_amount" validate:"omitempty,min=0"`
+	MonthlyAmount *float64 `json:"monthly_amount" validate:"omitempty,min=0"`
+	DailyCount    *int64   `json:"daily_count" validate:"omitempty,min=0"`
+	MonthlyCount  *int64   `json:"monthly_count" validate:"omitempty,min=0"`
+}
+
+// Rate of base currency in quote one (1 base = rate quote), currencies are ISO-4217 letter codes
+type SetExchangeRateRequest struct {
+	BaseCurrency  string  `json:"base_currency" validate:"required,len=3"`
+	QuoteCurrency string  `json:"quote_currency" validate:"required,len=3"`
+	Rate          float64 `json:"rate" validate:"required,gt=0"`
+}
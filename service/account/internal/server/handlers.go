@@ -2,15 +2,15 @@ package server
 
 import (
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	delivery "github.com/GCFactory/dbo-system/service/account/internal/account/delivery/http"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/repository"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/usecase"
 	accountMiddleware "github.com/GCFactory/dbo-system/service/account/internal/middleware"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"net/http"
 	"strings"
 )
 
@@ -27,7 +27,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	accRepo := repository.NewAccountRepository(s.db)
 
-	accUC := usecase.NewAccountUseCase(s.cfg, accRepo, s.logger)
+	accUC := usecase.NewAccountUseCase(s.cfg, accRepo, s.files, s.logger)
 
 	accHandlers := delivery.NewACCHandlers(s.cfg, accUC, s.logger)
 
@@ -57,6 +57,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -73,15 +74,16 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
 	apiGatewayGroup := v1.Group("/account")
 
 	delivery.MapACCRoutes(apiGatewayGroup, accHandlers, mw)
 
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+		health.KafkaChecker("kafka", s.cfg.KafkaConsumer.Brokers),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	return nil
 }
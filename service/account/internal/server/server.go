@@ -2,15 +2,16 @@ package server
 
 import (
 	"context"
-	"errors"
 	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
 	acc_proto_api "github.com/GCFactory/dbo-system/service/account/gen_proto/proto/api/account"
 	"github.com/GCFactory/dbo-system/service/account/internal/account"
+	accountFiles "github.com/GCFactory/dbo-system/service/account/internal/account/files"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/grpc_handlers"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/repository"
 	"github.com/GCFactory/dbo-system/service/account/internal/account/usecase"
-	"github.com/GCFactory/dbo-system/service/account/pkg/kafka"
 	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/jmoiron/sqlx"
@@ -39,23 +40,28 @@ type Server struct {
 	cfg           *config.Config
 	db            *sqlx.DB
 	logger        logger.Logger
-	// Channel to control goroutines
-	kafkaConsumerChan chan int
-	grpcHandlers      account.GRPCHandlers
+	grpcHandlers  account.GRPCHandlers
+	files         account.Files
+	accUC         account.UseCase
 }
 
-func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, logger logger.Logger) *Server {
+func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, metrics metric.BusinessMetrics, logger logger.Logger) *Server {
 	server := Server{
-		echo:              echo.New(),
-		cfg:               cfg,
-		db:                db,
-		logger:            logger,
-		kafkaConsumer:     kConsumer,
-		kafkaConsumerChan: make(chan int, 3),
-		kafkaProducer:     kProducer,
+		echo:          echo.New(),
+		cfg:           cfg,
+		db:            db,
+		logger:        logger,
+		kafkaConsumer: kConsumer,
+		kafkaProducer: kProducer,
 	}
 	server.echo.HidePort = true
 	server.echo.HideBanner = true
+	if cfg.FileApi.Address != "" {
+		server.files = accountFiles.NewFileApiClient(cfg.FileApi.Address, time.Second*cfg.FileApi.Timeout)
+	} else {
+		logger.Warn("file-api address isn't set, account statements can't be created")
+		server.files = accountFiles.NewNoopFiles()
+	}
 	accRepo := repository.NewAccountRepository(db)
 	accUC := usecase.NewAccountUseCase(&config.Config{
 		Env:      cfg.Env,
@@ -63,8 +69,12 @@ func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *ka
 		App:      cfg.App,
 		Postgres: cfg.Postgres,
 		Version:  cfg.Version,
-	}, accRepo, logger)
-	server.grpcHandlers = grpc_handlers.NewAccountGRPCHandlers(cfg, kProducer, accUC, logger)
+	}, accRepo, server.files, logger)
+	server.accUC = accUC
+	server.grpcHandlers = grpc_handlers.NewAccountGRPCHandlers(cfg, kProducer, accUC, logger, metrics)
+	for _, topic := range cfg.KafkaConsumer.Topics {
+		kConsumer.Handle(topic, server.handleData)
+	}
 	return &server
 }
 
@@ -108,71 +118,50 @@ func (s *Server) Run() error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-	//// Remove example
-	//go func() {
-	//	for i := 0; i < 1; i++ {
-	//		tmp_bytes := "0a0631323331323312280a0631323331323312063132333132331a0631323331323322063132333132332a06313233313233"
-	//		data, err := hex.DecodeString(tmp_bytes)
-	//		if err != nil {
-	//			panic(err)
-	//		}
-	//		err = s.kafkaProducer.ProduceRecord("test", sarama.ByteEncoder(data))
-	//		if err != nil {
-	//			s.logger.Warnf("Error on produce: %v", err)
-	//		}
-	//		time.Sleep(time.Second * 10)
-	//	}
-	//}()
-	//// Remove example
+	go func() {
+		if err := s.kafkaConsumer.Run(ctxWithCancel); err != nil {
+			s.logger.Errorf("Kafka consumer stopped: %v", err)
+		}
+	}()
 
-	for {
-		select {
-		// If goroutine exit for any reason - restart
-		case <-s.kafkaConsumerChan:
-			s.logger.Warn("Received end of kafka consumer goroutine")
-			go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-		// Handle system interrupts
-		case <-quit:
-			ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-			s.kafkaConsumer.Consumer.PauseAll()
-			s.kafkaConsumer.Consumer.Close()
-			time.Sleep(time.Second * 5)
-			close(quit)
-			close(s.kafkaConsumerChan)
-			defer shutdown()
-			defer cancel()
+	go s.runHoldsExpiry(ctxWithCancel)
 
-			s.logger.Info("Server Exited Properly")
-			return s.echo.Server.Shutdown(ctx)
-		}
+	<-quit
+	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+	defer shutdown()
+
+	if err := s.kafkaConsumer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka consumer: %v", err)
 	}
+	cancel()
+	<-s.kafkaConsumer.Done()
+	if err := s.kafkaProducer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka producer: %v", err)
+	}
+
+	s.logger.Info("Server Exited Properly")
+	return s.echo.Server.Shutdown(ctx)
 }
 
-func (s *Server) RunKafkaConsumer(ctx context.Context, quitChan chan<- int) {
-	consumer := kafka.Consumer{
-		Ready:       make(chan bool),
-		HandlerFunc: s.handleData,
-	}
+// runHoldsExpiry periodically expires holds which lifetime has passed
+func (s *Server) runHoldsExpiry(ctx context.Context) {
+	ticker := time.NewTicker(usecase.HoldExpireInterval)
+	defer ticker.Stop()
+
 	for {
-		if err := s.kafkaConsumer.Consumer.Consume(ctx, s.cfg.KafkaConsumer.Topics, &consumer); err != nil {
-			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
-				return
-			}
-			quitChan <- 1
-			s.logger.Errorf("Error from consumer: %v", err)
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			s.logger.Infof("Stopping kafka consumer: context close: %v", ctx.Err())
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+		}
+
+		if err := s.accUC.ExpireHolds(ctx); err != nil {
+			s.logger.Errorf("Error expire holds: %s", err)
 		}
-		s.logger.Info("Stopping kafka consumer...")
-		consumer.Ready = make(chan bool)
 	}
 }
 
-func (s *Server) handleData(message *sarama.ConsumerMessage) error {
+func (s *Server) handleData(ctx context.Context, message *sarama.ConsumerMessage) error {
 
 	data := &acc_proto_api.EventData{}
 	err := proto.Unmarshal(message.Value, data)
@@ -190,7 +179,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -203,7 +192,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAccountData(); extracted_data != nil {
-				if err = s.grpcHandlers.ReserveAccount(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.ReserveAccount(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -214,7 +203,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.ChangeAccountStatus(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), data.GetOperationName(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.ChangeAccountStatus(ctx, data.GetSagaUuid(), data.GetEventUuid(), data.GetOperationName(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -225,7 +214,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.GetAccountData(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.GetAccountData(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -233,11 +222,44 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			}
 
 		}
+	case grpc_handlers.GetAccountHistory:
+		{
+			// Unpack data and handle func
+			if extracted_data := data.GetHistoryFilter(); extracted_data != nil {
+				if err = s.grpcHandlers.GetAccountHistory(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+					return err
+				}
+			} else {
+				err = ErrorUnknownTypeData
+			}
+		}
 	case grpc_handlers.AddingAcc, grpc_handlers.WidthAcc:
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.OperationWithAccAmount(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), data.GetOperationName(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.OperationWithAccAmount(ctx, data.GetSagaUuid(), data.GetEventUuid(), data.GetOperationName(), extracted_data, s.kafkaProducer); err != nil {
+					return err
+				}
+			} else {
+				err = ErrorUnknownTypeData
+			}
+		}
+	case grpc_handlers.CreateHold, grpc_handlers.CaptureHold, grpc_handlers.ReleaseHold:
+		{
+			// Unpack data and handle func
+			if extracted_data := data.GetHoldDetails(); extracted_data != nil {
+				if err = s.grpcHandlers.OperationWithHold(ctx, data.GetSagaUuid(), data.GetEventUuid(), data.GetOperationName(), extracted_data, s.kafkaProducer); err != nil {
+					return err
+				}
+			} else {
+				err = ErrorUnknownTypeData
+			}
+		}
+	case grpc_handlers.TransferAcc:
+		{
+			// Unpack data and handle func
+			if extracted_data := data.GetTransferDetails(); extracted_data != nil {
+				if err = s.grpcHandlers.TransferAccount(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -248,7 +270,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.RemoveAccount(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.RemoveAccount(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -269,7 +291,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 				s.logger.Error(err)
 				return err
 			}
-			err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+			err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 			if err != nil {
 				s.logger.Error(err)
 				return err
@@ -290,7 +312,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
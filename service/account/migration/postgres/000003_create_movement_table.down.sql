@@ -0,0 +1 @@
+DROP TABLE IF EXISTS accounts_movements;
\ No newline at end of file
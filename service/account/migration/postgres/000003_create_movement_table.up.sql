@@ -0,0 +1,11 @@
+CREATE TABLE accounts_movements
+(
+    movement_uuid       UUID PRIMARY KEY                                            DEFAULT uuid_generate_v4(),
+    acc_uuid            UUID REFERENCES accounts(acc_uuid) ON DELETE CASCADE        NOT NULL,
+    movement_type       VARCHAR(32)                 NOT NULL,
+    amount              NUMERIC(34,4)               NOT NULL,
+    balance             NUMERIC(34,4)               NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now()
+);
+
+CREATE INDEX accounts_movements_acc_created_idx ON accounts_movements (acc_uuid, created_at);
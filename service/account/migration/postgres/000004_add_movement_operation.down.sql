@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS accounts_movements_acc_operation_idx;
+
+ALTER TABLE accounts_movements DROP COLUMN IF EXISTS operation_uuid;
@@ -0,0 +1,3 @@
+ALTER TABLE accounts_movements ADD COLUMN operation_uuid UUID;
+
+CREATE INDEX accounts_movements_acc_operation_idx ON accounts_movements (acc_uuid, operation_uuid);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS accounts_holds;
\ No newline at end of file
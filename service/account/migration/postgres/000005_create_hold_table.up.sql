@@ -0,0 +1,15 @@
+CREATE TABLE accounts_holds
+(
+    hold_uuid           UUID PRIMARY KEY                                            DEFAULT uuid_generate_v4(),
+    acc_uuid            UUID REFERENCES accounts(acc_uuid) ON DELETE CASCADE        NOT NULL,
+    amount              NUMERIC(34,4)               NOT NULL,
+    captured_amount     NUMERIC(34,4)               NOT NULL                DEFAULT 0,
+    status              VARCHAR(16)                 NOT NULL,
+    operation_uuid      UUID,
+    created_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now(),
+    expires_at          TIMESTAMP WITH TIME ZONE    NOT NULL,
+    updated_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now()
+);
+
+CREATE INDEX accounts_holds_acc_status_idx ON accounts_holds (acc_uuid, status);
+CREATE INDEX accounts_holds_status_expires_idx ON accounts_holds (status, expires_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS accounts_movements_user_created_idx;
+
+ALTER TABLE accounts_movements DROP COLUMN IF EXISTS user_uuid;
+
+DROP TABLE IF EXISTS accounts_withdrawal_limits;
@@ -0,0 +1,16 @@
+CREATE TABLE accounts_withdrawal_limits
+(
+    subject_type        VARCHAR(16)                 NOT NULL,
+    subject_uuid        UUID                        NOT NULL,
+    per_operation       NUMERIC(34,4),
+    daily_amount        NUMERIC(34,4),
+    monthly_amount      NUMERIC(34,4),
+    daily_count         INTEGER,
+    monthly_count       INTEGER,
+    updated_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now(),
+    PRIMARY KEY (subject_type, subject_uuid)
+);
+
+ALTER TABLE accounts_movements ADD COLUMN user_uuid UUID;
+
+CREATE INDEX accounts_movements_user_created_idx ON accounts_movements (user_uuid, created_at);
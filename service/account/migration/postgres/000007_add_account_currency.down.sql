@@ -0,0 +1,11 @@
+ALTER TABLE accounts ADD COLUMN acc_money_value NUMERIC(3) NOT NULL DEFAULT 0;
+
+UPDATE accounts SET acc_money_value = CASE acc_currency
+    WHEN 'USD' THEN 1
+    WHEN 'RUB' THEN 2
+    WHEN 'EUR' THEN 3
+    ELSE 0
+END;
+
+ALTER TABLE accounts ALTER COLUMN acc_money_value DROP DEFAULT;
+ALTER TABLE accounts DROP COLUMN IF EXISTS acc_currency;
@@ -0,0 +1,30 @@
+ALTER TABLE accounts ADD COLUMN acc_currency CHAR(3);
+
+-- Currency is taken from currency digits of account number, ruble is 810 there,
+-- old money value is used for numbers without known currency
+UPDATE accounts SET acc_currency = CASE substring(acc_culc_number from 6 for 3)
+    WHEN '810' THEN 'RUB'
+    WHEN '840' THEN 'USD'
+    WHEN '978' THEN 'EUR'
+    WHEN '156' THEN 'CNY'
+    WHEN '826' THEN 'GBP'
+    WHEN '756' THEN 'CHF'
+    WHEN '392' THEN 'JPY'
+    ELSE CASE acc_money_value
+        WHEN 1 THEN 'USD'
+        WHEN 2 THEN 'RUB'
+        WHEN 3 THEN 'EUR'
+    END
+END;
+
+-- Accounts without known currency can't be converted or rounded, they must be fixed by hand before migration
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM accounts WHERE acc_currency IS NULL) THEN
+        RAISE EXCEPTION 'accounts with unknown currency: %',
+            (SELECT string_agg(acc_uuid::text, ', ') FROM accounts WHERE acc_currency IS NULL);
+    END IF;
+END $$;
+
+ALTER TABLE accounts ALTER COLUMN acc_currency SET NOT NULL;
+ALTER TABLE accounts DROP COLUMN acc_money_value;
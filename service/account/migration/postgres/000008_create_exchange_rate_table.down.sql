@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS accounts_conversions;
+
+DROP TABLE IF EXISTS accounts_exchange_rates;
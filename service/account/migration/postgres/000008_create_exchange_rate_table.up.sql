@@ -0,0 +1,27 @@
+CREATE TABLE accounts_exchange_rates
+(
+    base_currency       CHAR(3)                     NOT NULL,
+    quote_currency      CHAR(3)                     NOT NULL,
+    rate                NUMERIC(24,10)              NOT NULL,
+    updated_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now(),
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+CREATE TABLE accounts_conversions
+(
+    conversion_uuid     UUID PRIMARY KEY                                            DEFAULT uuid_generate_v4(),
+    from_acc_uuid       UUID REFERENCES accounts(acc_uuid) ON DELETE CASCADE        NOT NULL,
+    to_acc_uuid         UUID REFERENCES accounts(acc_uuid) ON DELETE CASCADE        NOT NULL,
+    from_movement_uuid  UUID REFERENCES accounts_movements(movement_uuid) ON DELETE CASCADE     NOT NULL,
+    to_movement_uuid    UUID REFERENCES accounts_movements(movement_uuid) ON DELETE CASCADE     NOT NULL,
+    from_currency       CHAR(3)                     NOT NULL,
+    to_currency         CHAR(3)                     NOT NULL,
+    from_amount         NUMERIC(34,4)               NOT NULL,
+    to_amount           NUMERIC(34,4)               NOT NULL,
+    rate                NUMERIC(24,10)              NOT NULL,
+    operation_uuid      UUID,
+    created_at          TIMESTAMP WITH TIME ZONE    NOT NULL                DEFAULT now()
+);
+
+CREATE INDEX accounts_conversions_from_acc_idx ON accounts_conversions (from_acc_uuid, created_at);
+CREATE INDEX accounts_conversions_to_acc_idx ON accounts_conversions (to_acc_uuid, created_at);
@@ -4,17 +4,14 @@ import (
 	"context"
 	"fmt"
 	platformConfig "github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/api_gateway/config"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/server"
-	"github.com/opentracing/opentracing-go"
 	amqp "github.com/rabbitmq/amqp091-go"
 	redis "github.com/redis/go-redis/v9"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
 	"log"
 	"os"
 	"time"
@@ -94,47 +91,26 @@ func main() {
 	appLogger.Info("Open RMQ channel success")
 
 	appLogger.Info("Creating RMQ queue")
-	rmqQueue, err := rmqCh.QueueDeclare(
-		cfg.RabbitMQ.Queue, // name
-		false,              // durable
-		false,              // delete when unused
-		false,              // exclusive
-		false,              // no-wait
-		nil,                // arguments
-	)
+	rmqQueue, err := rmq.DeclareQueue(rmqCh, platformConfig.RabbitMQConfig(cfg.RabbitMQ))
 	if err != nil {
 		appLogger.Fatalf("Create RMQ queue error: %s", err)
 		return
 	}
 	appLogger.Info("Create RMQ queue success")
 
-	jaegerCfgInstance := jaegercfg.Configuration{
-		ServiceName: cfg.Jaeger.ServiceName,
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans:           cfg.Jaeger.LogSpans,
-			LocalAgentHostPort: cfg.Jaeger.Host,
-		},
-	}
-
-	tracer, closer, err := jaegerCfgInstance.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
-	)
+	tp, err := tracing.NewTracerProvider(context.Background(), &platformConfig.Config{
+		Env:     cfg.Env,
+		Version: cfg.Version,
+		Tracing: cfg.Tracing,
+	})
 	if err != nil {
-		log.Fatal("cannot create tracer", err)
+		log.Fatal("cannot create tracer provider", err)
 	}
-	appLogger.Info("Jaeger connected")
-
-	opentracing.SetGlobalTracer(tracer)
-	defer closer.Close()
-	appLogger.Info("Opentracing connected")
+	defer tp.Shutdown(context.Background())
+	appLogger.Info("OpenTelemetry tracing connected")
 
 	//Run server
-	s := server.NewServer(cfg, redis, rmqCh, rmqQueue, appLogger)
+	s := server.NewServer(cfg, redis, rmqCh, *rmqQueue, appLogger)
 	if err = s.Run(); err != nil {
 		appLogger.Fatal(err)
 	}
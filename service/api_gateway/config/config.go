@@ -15,9 +15,10 @@ type Config struct {
 	Version    string                      `yaml:"version"`
 	HTTPServer HTTPServerConfig            `yaml:"http-server,omitempty" mapstructure:"http-server"`
 
-	Logger  platformConfig.Logger `yaml:"logger"`
-	Jaeger  Jaeger                `yaml:"jaeger"`
-	Metrics Metrics               `yaml:"metrics"`
+	Logger  platformConfig.Logger  `yaml:"logger"`
+	Tracing platformConfig.Tracing `yaml:"tracing"`
+	Metrics Metrics                `yaml:"metrics"`
+	Health  platformConfig.Health  `yaml:"health"`
 	Docs    Docs
 
 	InternalServices map[string]InternalServer `yaml:"internalServices"`
@@ -9,13 +9,6 @@ type Logger struct {
 	Level             string
 }
 
-// Jaeger config
-type Jaeger struct {
-	Host        string
-	ServiceName string
-	LogSpans    bool
-}
-
 // Metrics config
 type Metrics struct {
 	URL         string
@@ -8,6 +8,7 @@ type Handlers interface {
 	HomePage() echo.HandlerFunc
 	OpenAccountPage() echo.HandlerFunc
 	AccountCreditsPage() echo.HandlerFunc
+	AccountStatementPage() echo.HandlerFunc
 	CloseAccountPage() echo.HandlerFunc
 	AddAccountCachePage() echo.HandlerFunc
 	WidthAccountCachePage() echo.HandlerFunc
@@ -15,8 +16,11 @@ type Handlers interface {
 	TurnOffTotpPage() echo.HandlerFunc
 	TotpQrPage() echo.HandlerFunc
 	TotpCheckPage() echo.HandlerFunc
+	NotificationSettingsPage() echo.HandlerFunc
+	NotificationInboxPage() echo.HandlerFunc
 	TotpCheck() echo.HandlerFunc
 	AdminPage() echo.HandlerFunc
+	AdminNotificationsPage() echo.HandlerFunc
 	SignIn() echo.HandlerFunc
 	SignUp() echo.HandlerFunc
 	SignOut() echo.HandlerFunc
@@ -24,8 +28,14 @@ type Handlers interface {
 	CloseAccount() echo.HandlerFunc
 	AddAccountCache() echo.HandlerFunc
 	WidthAccountCache() echo.HandlerFunc
+	AccountStatement() echo.HandlerFunc
 	TurnOnTotp() echo.HandlerFunc
 	TurnOffTotp() echo.HandlerFunc
+	UpdateNotificationSettings() echo.HandlerFunc
+	ReadInboxMessage() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ResendEmailVerification() echo.HandlerFunc
+	DownloadDocument() echo.HandlerFunc
 	GraphImage() echo.HandlerFunc
 	QrImage() echo.HandlerFunc
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -33,6 +34,10 @@ type ApiGatewayHandlers struct {
 const (
 	CookieTokenNameMain      string = "token"
 	CookieTokenNameFirstAuth string = "token_fa"
+	// Sign up form files field and its limits
+	FormFieldPassportScans string = "passport_scans"
+	MaxPassportScans       int    = 10
+	MaxPassportScanSize    int64  = 10 << 20
 )
 
 func (h ApiGatewayHandlers) safeReadBodyRequest(c echo.Context, v interface{}) error {
@@ -117,6 +122,44 @@ func (h ApiGatewayHandlers) safeReadQueryParamsRequest(c echo.Context, v interfa
 	return nil
 }
 
+// readPassportScans reads passport scans attached to sign up form, form without them is allowed
+func (h ApiGatewayHandlers) readPassportScans(c echo.Context) ([]*models.SignUpFile, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, nil
+	}
+
+	headers := form.File[FormFieldPassportScans]
+	if len(headers) > MaxPassportScans {
+		return nil, fmt.Errorf("too many passport scans, max %d", MaxPassportScans)
+	}
+
+	scans := make([]*models.SignUpFile, 0, len(headers))
+	for _, header := range headers {
+		if header.Size > MaxPassportScanSize {
+			return nil, fmt.Errorf("passport scan %s is larger than %d bytes", header.Filename, MaxPassportScanSize)
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(content) == 0 {
+			continue
+		}
+		scans = append(scans, &models.SignUpFile{
+			FileName: header.Filename,
+			Content:  content,
+		})
+	}
+
+	return scans, nil
+}
+
 func (h ApiGatewayHandlers) safeReadFormDataRequest(c echo.Context, v interface{}) error {
 	val := reflect.ValueOf(v).Elem() // Получаем значение структуры
 
@@ -318,6 +361,33 @@ func (h ApiGatewayHandlers) AdminPage() echo.HandlerFunc {
 	}
 }
 
+func (h ApiGatewayHandlers) AdminNotificationsPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		filter := &models.AdminNotificationsRequestBody{}
+		if err := h.safeReadQueryParamsRequest(c, filter); err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		page, err := h.useCase.CreateAdminNotificationsPage(filter)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		return c.HTML(http.StatusOK, page)
+	}
+}
+
 func (h ApiGatewayHandlers) SignIn() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
@@ -424,6 +494,17 @@ func (h ApiGatewayHandlers) SignUp() echo.HandlerFunc {
 			return c.HTML(http.StatusBadRequest, errPage)
 		}
 
+		operation_info.PassportScans, err = h.readPassportScans(c)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			errPage, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				operation_result.Error = err.Error()
+				return c.JSON(http.StatusInternalServerError, operation_result)
+			}
+			return c.HTML(http.StatusBadRequest, errPage)
+		}
+
 		token, err := h.useCase.SignUp(operation_info)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
@@ -864,6 +945,86 @@ func (h ApiGatewayHandlers) WidthAccountCache() echo.HandlerFunc {
 	}
 }
 
+func (h ApiGatewayHandlers) AccountStatement() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		operation_result := &models.PostRequestStatus{
+			Success: false,
+		}
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			errPage, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				operation_result.Error = err.Error()
+				return c.JSON(http.StatusInternalServerError, operation_result)
+			}
+			return c.HTML(http.StatusBadRequest, errPage)
+		}
+
+		operation_info := &models.AccountStatementRequestBody{}
+		if err := h.safeReadFormDataRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			errPage, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				operation_result.Error = err.Error()
+				return c.JSON(http.StatusInternalServerError, operation_result)
+			}
+			return c.HTML(http.StatusInternalServerError, errPage)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				errPage, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					operation_result.Error = err.Error()
+					return c.JSON(http.StatusInternalServerError, operation_result)
+				}
+				return c.HTML(http.StatusInternalServerError, errPage)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				errPage, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					operation_result.Error = err.Error()
+					return c.JSON(http.StatusInternalServerError, operation_result)
+				}
+				return c.HTML(http.StatusInternalServerError, errPage)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				errPage, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					operation_result.Error = err.Error()
+					return c.JSON(http.StatusInternalServerError, operation_result)
+				}
+				return c.HTML(http.StatusInternalServerError, errPage)
+			}
+
+			link, err := h.useCase.CreateAccountStatement(user_id, operation_info)
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				errPage, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					operation_result.Error = err.Error()
+					return c.JSON(http.StatusInternalServerError, operation_result)
+				}
+				return c.HTML(http.StatusInternalServerError, errPage)
+			}
+
+			return c.Redirect(http.StatusFound, link)
+		}
+
+		return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/main_page")
+	}
+}
+
 func (h ApiGatewayHandlers) OpenAccountPage() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
@@ -927,6 +1088,90 @@ func (h ApiGatewayHandlers) OpenAccountPage() echo.HandlerFunc {
 }
 
 func (h ApiGatewayHandlers) AccountCreditsPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		operation_info := &models.AccountCreditsRequest{}
+		err := h.safeReadQueryParamsRequest(c, operation_info)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			utils.LogResponseError(c, h.logger, err)
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			account_id, err := uuid.Parse(operation_info.AccountId)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			operation_page, err := h.useCase.CreateAccountCreditsPage(user_id, account_id, operation_info)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.HTML(http.StatusOK, operation_page)
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+func (h ApiGatewayHandlers) AccountStatementPage() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
 		operation_info := &models.AccountInfoRequest{}
@@ -993,7 +1238,7 @@ func (h ApiGatewayHandlers) AccountCreditsPage() echo.HandlerFunc {
 				return c.HTML(http.StatusInternalServerError, error_page)
 			}
 
-			operation_page, err := h.useCase.CreateAccountCreditsPage(user_id, account_id)
+			operation_page, err := h.useCase.CreateAccountStatementPage(user_id, account_id)
 			if err != nil {
 				error_page, err := h.useCase.CreateErrorPage(err.Error())
 				if err != nil {
@@ -1572,6 +1817,454 @@ func (h ApiGatewayHandlers) TurnOffTotp() echo.HandlerFunc {
 	}
 }
 
+func (h ApiGatewayHandlers) NotificationSettingsPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			settingsPage, err := h.useCase.CreateNotificationSettingsPage(user_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.HTML(http.StatusOK, settingsPage)
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+func (h ApiGatewayHandlers) UpdateNotificationSettings() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		settingsInfo := &models.NotificationSettingsRequestBody{}
+		if err := h.safeReadFormDataRequest(c, settingsInfo); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			webhookSecret, err := h.useCase.UpdateNotificationSettings(user_id, settingsInfo)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			if webhookSecret != "" {
+				secretPage, err := h.useCase.CreateWebhookSecretPage(user_id, webhookSecret)
+				if err != nil {
+					error_page, err := h.useCase.CreateErrorPage(err.Error())
+					if err != nil {
+						utils.LogResponseError(c, h.logger, err)
+						return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+					}
+					return c.HTML(http.StatusInternalServerError, error_page)
+				}
+				return c.HTML(http.StatusOK, secretPage)
+			}
+
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/main_page")
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+func (h ApiGatewayHandlers) ResendEmailVerification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.useCase.ResendEmailVerification(user_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/notification_settings")
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+func (h ApiGatewayHandlers) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		verifyInfo := &models.VerifyEmailRequestBody{}
+		if err := h.safeReadQueryParamsRequest(c, verifyInfo); err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		// Link is opened from email, user may be signed out
+		err := h.useCase.VerifyEmail(verifyInfo.Token)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/main_page")
+	}
+}
+
+func (h ApiGatewayHandlers) NotificationInboxPage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			inboxPage, err := h.useCase.CreateNotificationInboxPage(user_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.HTML(http.StatusOK, inboxPage)
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+func (h ApiGatewayHandlers) ReadInboxMessage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		readInfo := &models.NotificationInboxReadRequestBody{}
+		if err := h.safeReadFormDataRequest(c, readInfo); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.useCase.ReadInboxMessage(user_id, uuid.MustParse(readInfo.MessageId))
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/notification_inbox")
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
+// DownloadDocument redirects user to time-limited link of his document, so content isn't proxied by gateway
+func (h ApiGatewayHandlers) DownloadDocument() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		document_info := &models.DocumentDownloadRequestBody{}
+		err := h.safeReadQueryParamsRequest(c, document_info)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			utils.LogResponseError(c, h.logger, err)
+			return c.HTML(http.StatusBadRequest, error_page)
+		}
+
+		is_ok, token_id, err := h.CheckToken(c, CookieTokenNameMain)
+		if err != nil {
+			error_page, err := h.useCase.CreateErrorPage(err.Error())
+			if err != nil {
+				utils.LogResponseError(c, h.logger, err)
+				return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+			}
+			return c.HTML(http.StatusInternalServerError, error_page)
+		}
+
+		if is_ok && token_id != uuid.Nil {
+
+			err = h.useCase.UpdateToken(context.Background(), token_id, usecase.TokenLiveTime)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			user_id, err := h.useCase.GetTokenValue(context.Background(), token_id)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			err = h.UpdateCookie(c, CookieTokenNameMain)
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			link, err := h.useCase.GetDocumentDownloadLink(user_id, uuid.MustParse(document_info.FileId))
+			if err != nil {
+				error_page, err := h.useCase.CreateErrorPage(err.Error())
+				if err != nil {
+					utils.LogResponseError(c, h.logger, err)
+					return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+				}
+				return c.HTML(http.StatusInternalServerError, error_page)
+			}
+
+			return c.Redirect(http.StatusFound, link)
+		} else {
+			return c.Redirect(http.StatusSeeOther, "/api/v1/api_gateway/sign_in")
+		}
+	}
+}
+
 func (h ApiGatewayHandlers) TotpQrPage() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
@@ -11,8 +11,10 @@ func MapApiGatewayRoutes(apiGatewayGroup *echo.Group, h api_gateway.Handlers, mw
 	apiGatewayGroup.GET("/sign_up", h.SignUpPage())
 	apiGatewayGroup.GET("/main_page", h.HomePage())
 	apiGatewayGroup.GET("/admin", h.AdminPage())
+	apiGatewayGroup.GET("/admin/notifications", h.AdminNotificationsPage())
 	apiGatewayGroup.GET("/open_account", h.OpenAccountPage())
 	apiGatewayGroup.GET("/get_account_info", h.AccountCreditsPage())
+	apiGatewayGroup.GET("/account_statement", h.AccountStatementPage())
 	apiGatewayGroup.GET("/adding_account", h.AddAccountCachePage())
 	apiGatewayGroup.GET("/width_account", h.WidthAccountCachePage())
 	apiGatewayGroup.GET("/close_account", h.CloseAccountPage())
@@ -23,6 +25,7 @@ func MapApiGatewayRoutes(apiGatewayGroup *echo.Group, h api_gateway.Handlers, mw
 	apiGatewayGroup.POST("/close_account/close_account", h.CloseAccount())
 	apiGatewayGroup.POST("/adding_account/adding_account", h.AddAccountCache())
 	apiGatewayGroup.POST("/width_account/width_account", h.WidthAccountCache())
+	apiGatewayGroup.POST("/account_statement/account_statement", h.AccountStatement())
 	apiGatewayGroup.GET("/graph/*", h.GraphImage())
 	apiGatewayGroup.GET("/qr/*", h.QrImage())
 	apiGatewayGroup.GET("/totp_connect", h.TurnOnTotpPage())
@@ -32,4 +35,11 @@ func MapApiGatewayRoutes(apiGatewayGroup *echo.Group, h api_gateway.Handlers, mw
 	apiGatewayGroup.GET("/totp_qr", h.TotpQrPage())
 	apiGatewayGroup.GET("/totp_check", h.TotpCheckPage())
 	apiGatewayGroup.POST("/totp_check/totp_check", h.TotpCheck())
+	apiGatewayGroup.GET("/notification_settings", h.NotificationSettingsPage())
+	apiGatewayGroup.POST("/notification_settings/notification_settings", h.UpdateNotificationSettings())
+	apiGatewayGroup.POST("/notification_settings/resend_verification", h.ResendEmailVerification())
+	apiGatewayGroup.GET("/verify_email", h.VerifyEmail())
+	apiGatewayGroup.GET("/notification_inbox", h.NotificationInboxPage())
+	apiGatewayGroup.POST("/notification_inbox/read", h.ReadInboxMessage())
+	apiGatewayGroup.GET("/documents/download", h.DownloadDocument())
 }
@@ -2,10 +2,10 @@ package repository
 
 import (
 	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/models"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	redis "github.com/redis/go-redis/v9"
 	"time"
 )
@@ -16,8 +16,8 @@ type apiGatewayRepo struct {
 
 func (repo *apiGatewayRepo) AddToken(ctx context.Context, token *models.Token) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.AddToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.AddToken")
+	defer span.End()
 
 	err := repo.redis.Set(ctxWithTrace, token.ID.String(), token.Data.String(), token.Live_time).Err()
 	if err != nil {
@@ -29,8 +29,8 @@ func (repo *apiGatewayRepo) AddToken(ctx context.Context, token *models.Token) e
 
 func (repo *apiGatewayRepo) GetToken(ctx context.Context, token_id uuid.UUID) (*models.Token, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.GetToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.GetToken")
+	defer span.End()
 
 	data_str, err := repo.redis.Get(ctxWithTrace, token_id.String()).Result()
 	if err != nil {
@@ -58,8 +58,8 @@ func (repo *apiGatewayRepo) GetToken(ctx context.Context, token_id uuid.UUID) (*
 
 func (repo *apiGatewayRepo) UpdateToken(ctx context.Context, token_id uuid.UUID, new_live_time time.Duration) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.UpdateToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.UpdateToken")
+	defer span.End()
 
 	err := repo.redis.Expire(ctxWithTrace, token_id.String(), new_live_time).Err()
 	if err != nil {
@@ -71,8 +71,8 @@ func (repo *apiGatewayRepo) UpdateToken(ctx context.Context, token_id uuid.UUID,
 
 func (repo *apiGatewayRepo) DeleteToken(ctx context.Context, token_id uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.DeleteToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.DeleteToken")
+	defer span.End()
 
 	err := repo.redis.Del(ctxWithTrace, token_id.String()).Err()
 	if err != nil {
@@ -84,8 +84,8 @@ func (repo *apiGatewayRepo) DeleteToken(ctx context.Context, token_id uuid.UUID)
 
 func (repo *apiGatewayRepo) AddTokenFirstAuth(ctx context.Context, token *models.TokenFirstAuth) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.AddTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.AddTokenFirstAuth")
+	defer span.End()
 
 	err := repo.redis.Set(ctxWithTrace, token.TokenName, token.UserId.String(), token.Live_time).Err()
 	if err != nil {
@@ -97,8 +97,8 @@ func (repo *apiGatewayRepo) AddTokenFirstAuth(ctx context.Context, token *models
 
 func (repo *apiGatewayRepo) GetTokenFirstAuth(ctx context.Context, tokenName string) (*models.TokenFirstAuth, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.GetTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.GetTokenFirstAuth")
+	defer span.End()
 
 	data_str, err := repo.redis.Get(ctxWithTrace, tokenName).Result()
 	if err != nil {
@@ -126,8 +126,8 @@ func (repo *apiGatewayRepo) GetTokenFirstAuth(ctx context.Context, tokenName str
 
 func (repo *apiGatewayRepo) DeleteTokenFirstAuth(ctx context.Context, tokenName string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGatewayRepo.DeleteTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGatewayRepo.DeleteTokenFirstAuth")
+	defer span.End()
 
 	err := repo.redis.Del(ctxWithTrace, tokenName).Err()
 	if err != nil {
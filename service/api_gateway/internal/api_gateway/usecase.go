@@ -23,7 +23,8 @@ type UseCase interface {
 	CreateSignUpPage() (string, error)
 	CreateUserPage(user_id uuid.UUID) (string, error)
 	CreateOpenAccountPage(user_id uuid.UUID) (string, error)
-	CreateAccountCreditsPage(user_id uuid.UUID, account_id uuid.UUID) (string, error)
+	CreateAccountCreditsPage(user_id uuid.UUID, account_id uuid.UUID, history_filter *models.AccountCreditsRequest) (string, error)
+	CreateAccountStatementPage(user_id uuid.UUID, account_id uuid.UUID) (string, error)
 	CreateCloseAccountPage(user_id uuid.UUID, account_id uuid.UUID) (string, error)
 	CreateAddAccountCachePage(user_id uuid.UUID, account_id uuid.UUID) (string, error)
 	CreateWidthAccountCachePage(user_id uuid.UUID, account_id uuid.UUID) (string, error)
@@ -32,6 +33,10 @@ type UseCase interface {
 	CreateTotpQrPage(userId uuid.UUID) (string, error)
 	CreateTotpCheckPage() (string, error)
 	CreateAdminPage(begin string, end string) (string, error)
+	CreateAdminNotificationsPage(filter *models.AdminNotificationsRequestBody) (string, error)
+	CreateNotificationSettingsPage(userId uuid.UUID) (string, error)
+	CreateNotificationInboxPage(userId uuid.UUID) (string, error)
+	CreateWebhookSecretPage(userId uuid.UUID, webhookSecret string) (string, error)
 	//
 	SignIn(login_info *models.SignInInfo) (*models.Token, error)
 	SignUp(sign_up_info *models.SignUpInfo) (*models.Token, error)
@@ -39,9 +44,15 @@ type UseCase interface {
 	CloseAccount(user_id uuid.UUID, account_id uuid.UUID) error
 	AddAccountCache(user_id uuid.UUID, account_id uuid.UUID, money float64) error
 	WidthAccountCache(user_id uuid.UUID, account_id uuid.UUID, money float64) error
+	CreateAccountStatement(user_id uuid.UUID, statement_info *models.AccountStatementRequestBody) (string, error)
 	TurnOnTotp(userId uuid.UUID) error
 	TurnOffTotp(userId uuid.UUID) error
 	CheckTotp(userId uuid.UUID, code string) error
+	UpdateNotificationSettings(userId uuid.UUID, settingsInfo *models.NotificationSettingsRequestBody) (string, error)
+	ReadInboxMessage(userId uuid.UUID, messageId uuid.UUID) error
+	VerifyEmail(token string) error
+	ResendEmailVerification(userId uuid.UUID) error
+	GetDocumentDownloadLink(userId uuid.UUID, fileId uuid.UUID) (string, error)
 	//
 	GetUserTotpInfo(userId uuid.UUID) (*models.TotpInfo, error)
 	CreateNotificationSignUp(ctx context.Context, userId uuid.UUID) error
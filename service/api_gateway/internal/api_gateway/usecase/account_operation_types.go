@@ -3,6 +3,7 @@ package usecase
 const (
 	AccountOperationTypeOpen       string = "Open account"
 	AccountOperationTypeGetCredits string = "Credits"
+	AccountOperationTypeStatement  string = "Statement"
 	AccountOperationTypeClose      string = "Close account"
 	AccountOperationAddCache       string = "Add cache"
 	AccountOperationWidthCache     string = "Width cache"
@@ -14,6 +15,10 @@ var RequiredOperationTypeData = map[string][]string{
 		"account_id",
 		"user_id",
 	},
+	AccountOperationTypeStatement: {
+		"account_id",
+		"user_id",
+	},
 	AccountOperationTypeClose: {
 		"account_id",
 		"user_id",
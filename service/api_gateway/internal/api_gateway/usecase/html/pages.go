@@ -116,7 +116,7 @@ var (
   <body>
     <div class="center">
         <p>SIGN UP</p>
-      <form class="center form_grid" action="{{.SignUpRequest}}" method="POST">
+      <form class="center form_grid" action="{{.SignUpRequest}}" method="POST" enctype="multipart/form-data">
           
         <label for="login">Login</label>
         <input type="text" id="login" name="login" placeholder="login" required>
@@ -153,6 +153,8 @@ var (
         <input type="text" id="inn" name="inn" placeholder="01234567890123456789" required>
         <label for="email">Email</label>
         <input type="text" id="email" name="email" placeholder="email@mail.ru" required>
+        <label for="passport_scans">Passport scans</label>
+        <input type="file" id="passport_scans" name="passport_scans" accept="image/jpeg,image/png,application/pdf" multiple>
         <br>
 
         <div class="fird_div_full_line grid_full_line">
@@ -196,6 +198,9 @@ var (
     .form_grid_4 {
         grid-template-columns: repeat(4, 1fr);
     }
+    .form_grid_5 {
+        grid-template-columns: repeat(5, 1fr);
+    }
     .bold {
         
     }
@@ -269,6 +274,14 @@ var (
 
             <p><b>Email address</b></p>
             <p>{{.Email}}</p>
+
+            <p><b>Notifications</b></p>
+            <form action="{{.RequestNotificationSettings}}">
+                <input type="submit" value="Settings">
+            </form>
+            <form action="{{.RequestNotificationInbox}}">
+                <input type="submit" value="Inbox">
+            </form>
             
 			<p><b>Using TOTP</b></p>
 			<div style="display: flex;">
@@ -374,8 +387,9 @@ var (
 
   </body>
 </html>`
-	TotpOperationPage string = AccountOperationPage
-	AdminPage         string = `<!DOCTYPE html>
+	TotpOperationPage         string = AccountOperationPage
+	NotificationOperationPage string = AccountOperationPage
+	AdminPage                 string = `<!DOCTYPE html>
 <html>
   <head>
     <title>Registration page</title>
@@ -438,6 +452,9 @@ var (
           <input type="submit" value="Get operations">
           
         </form>
+        <form action="{{.NotificationsRequest}}">
+          <input type="submit" value="Notification history">
+        </form>
       </div>
         <table>
             <thead>
@@ -459,6 +476,121 @@ var (
     </main>
   </body>
 </html>`
+	AdminNotificationsPage string = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>Registration page</title>
+    <link rel="stylesheet" href="styles.css" />
+    <style>
+    html {
+        height: 100%;
+    }
+    body {
+        height: 99%;
+    }
+    header {
+        display: grid;
+        margin: auto;
+        justify-items: center;
+        width: 100%;
+    }
+    .center_content {
+        display: grid;
+        margin: auto;
+        justify-items: center;
+    }
+    .form_grid {
+        grid-template-columns: repeat(2, 1fr);
+    }
+    .pre-tab {
+        white-space: pre; /* Сохраняем изначальное форматирование */
+    }
+    table {
+        border: collapse;
+        width: 100%;
+    }
+    thead {
+      background-color: rgb(228 240 245);
+    }
+    td {
+        text-align: center;
+    }
+    tbody tr:nth-child(odd) {
+      background-color: rgb(123,123,123);
+      color: #fff;
+    }
+    </style>
+  </head>
+  <body>
+    <header>
+      <h1>NOTIFICATION HISTORY</h1>
+    </header>
+    <hr>
+    <main>
+      <div class="center_content">
+        <form action="{{.GetDeliveriesRequest}}">
+
+          <label for="login">User login:</label>
+          <input type="text" id="login" name="login">
+
+          <label for="channel">Channel:</label>
+          <select id="channel" name="channel">
+            <option value="">any</option>
+            <option value="email">email</option>
+            <option value="inbox">inbox</option>
+            <option value="sms">sms</option>
+            <option value="webhook">webhook</option>
+          </select>
+
+          <label for="status">Status:</label>
+          <select id="status" name="status">
+            <option value="">any</option>
+            <option value="queued">queued</option>
+            <option value="sent">sent</option>
+            <option value="failed">failed</option>
+            <option value="bounced">bounced</option>
+          </select>
+
+          <label for="template">Template:</label>
+          <input type="text" id="template" name="template" placeholder="sign_in">
+
+          <label for="start">Time begin:</label>
+          <input type="text" id="start" name="start" placeholder="01-02-2001 12:12:12">
+
+          <label for="end">Time end:</label>
+          <input type="text" id="end" name="end" placeholder="01-02-2001 12:12:12">
+
+          <input type="submit" value="Get notifications">
+
+        </form>
+        <form action="{{.AdminPageRequest}}">
+          <input type="submit" value="Operations">
+        </form>
+      </div>
+        <table>
+            <thead>
+                <tr>
+                    <th scope="col">Message id</th>
+                    <th scope="col">User id</th>
+                    <th scope="col">Channel</th>
+                    <th scope="col">Template</th>
+                    <th scope="col">Subject</th>
+                    <th scope="col">Status</th>
+                    <th scope="col">Attempts</th>
+                    <th scope="col">Last error</th>
+                    <th scope="col">Created</th>
+                    <th scope="col">Sent</th>
+                </tr>
+            </thead>
+            <tbody>
+                
+				{{.Deliveries}}
+
+            </tbody>
+        </table>
+    </main>
+  </body>
+</html>`
 	TotpCheckPage string = `<!DOCTYPE html>
 <html>
 	<head>
@@ -521,13 +653,17 @@ var (
 		<tr>
 			<td>{{.Name}}</td>
 			<td>{{.Status}}</td>
-			<td>{{.Cache}}</td>
+			<td>{{.Cache}} {{.Currency}}</td>
 			<td>
-				<div class="center_content form_grid_4">
+				<div class="center_content form_grid_5">
 					 <form action="{{.GetCreditsRequest}}">
 						<input type="hidden" name="account_id" value="{{.AccountId}}">
 						<input type="submit" value="Get credits" {{if .Disabled -}} disabled {{else -}} {{end}}>
 					</form>
+					<form action="{{.StatementRequest}}">
+						<input type="hidden" name="account_id" value="{{.AccountId}}">
+						<input type="submit" value="Statement">
+					</form>
 					<form action="{{.AddCacheRequest}}">
 						<input type="hidden" name="account_id" value="{{.AccountId}}">
 						<input type="submit" value="Add cache" {{if .Disabled -}} disabled {{else -}} {{end}}>
@@ -606,6 +742,66 @@ var (
 		
 		</div>
 	`
+	AccountOperationHistory string = `
+		<div>
+			<form class="center_content" action="{{.HistoryRequest}}">
+				<input type="hidden" name="account_id" value="{{.AccountId}}">
+				<label for="from">From</label>
+				<input type="text" id="from" name="from" placeholder="01-02-2001" value="{{.From}}">
+				<label for="to">To</label>
+				<input type="text" id="to" name="to" placeholder="28-02-2001" value="{{.To}}">
+				<label for="direction">Direction</label>
+				<select id="direction" name="direction">
+					<option value="" {{if eq .Direction "" -}} selected {{else -}} {{end}}>All</option>
+					<option value="deposit" {{if eq .Direction "deposit" -}} selected {{else -}} {{end}}>Deposits</option>
+					<option value="withdrawal" {{if eq .Direction "withdrawal" -}} selected {{else -}} {{end}}>Withdrawals</option>
+				</select>
+				<label for="min_amount">Amount from</label>
+				<input type="text" id="min_amount" name="min_amount" value="{{.MinAmount}}">
+				<label for="max_amount">Amount to</label>
+				<input type="text" id="max_amount" name="max_amount" value="{{.MaxAmount}}">
+				<label for="operation_id">Operation</label>
+				<input type="text" id="operation_id" name="operation_id" value="{{.OperationId}}">
+				<input type="submit" value="Filter">
+			</form>
+			<table>
+				<thead>
+					<tr>
+						<th scope="col">Date</th>
+						<th scope="col">Type</th>
+						<th scope="col">Amount</th>
+						<th scope="col">Balance</th>
+						<th scope="col">Operation</th>
+					</tr>
+				</thead>
+				<tbody>
+					{{.Movements}}
+				</tbody>
+			</table>
+			{{if .NextCursor -}}
+			<form class="center_content" action="{{.HistoryRequest}}">
+				<input type="hidden" name="account_id" value="{{.AccountId}}">
+				<input type="hidden" name="from" value="{{.From}}">
+				<input type="hidden" name="to" value="{{.To}}">
+				<input type="hidden" name="direction" value="{{.Direction}}">
+				<input type="hidden" name="min_amount" value="{{.MinAmount}}">
+				<input type="hidden" name="max_amount" value="{{.MaxAmount}}">
+				<input type="hidden" name="operation_id" value="{{.OperationId}}">
+				<input type="hidden" name="cursor" value="{{.NextCursor}}">
+				<input type="submit" value="Next page">
+			</form>
+			{{else -}} {{end}}
+		</div>
+	`
+	AccountHistoryRow string = `
+		<tr>
+			<td>{{.CreatedAt}}</td>
+			<td>{{.Type}}</td>
+			<td>{{.Amount}}</td>
+			<td>{{.Balance}}</td>
+			<td>{{.OperationId}}</td>
+		</tr>
+`
 	AccountOperationAddCache string = `
 	        <div>
             <form class="center_content" action="{{.OperationRequest}}" method="POST">
@@ -617,7 +813,23 @@ var (
             </form>    
         </div>`
 	AccountOperationWidthCache string = AccountOperationAddCache
-	AdminOperation             string = `
+	AccountOperationStatement  string = `
+	        <div>
+            <form class="center_content" action="{{.OperationRequest}}" method="POST">
+				<input type="hidden" name="account_id" value="{{.AccountId}}">
+                <label for="from">From</label>
+                <input type="text" id="from" name="from" placeholder="01-02-2001" required>
+                <label for="to">To</label>
+                <input type="text" id="to" name="to" placeholder="28-02-2001" required>
+                <label for="format">Format</label>
+                <select id="format" name="format">
+                    <option value="pdf">PDF</option>
+                    <option value="csv">CSV</option>
+                </select>
+                <input type="submit" value="Download" id="accountStatementButton">
+            </form>
+        </div>`
+	AdminOperation string = `
 	<tr>
 		<td>{{.Id}}</td>
 		<td>{{.Name}}</td>
@@ -630,6 +842,20 @@ var (
 			alt="operation_graph">
 		</td>
 	</tr>
+`
+	AdminDelivery string = `
+	<tr>
+		<td>{{.MessageId}}</td>
+		<td>{{.UserId}}</td>
+		<td>{{.Channel}}</td>
+		<td>{{.Template}}</td>
+		<td>{{.Subject}}</td>
+		<td>{{.Status}}</td>
+		<td>{{.Attempts}}</td>
+		<td>{{.LastError}}</td>
+		<td>{{.Created}}</td>
+		<td>{{.Sent}}</td>
+	</tr>
 `
 	TotpOperationOpen string = `
 		<div>
@@ -638,8 +864,113 @@ var (
             </form>
         </div>
 `
-	TotpOperationClose string = TotpOperationOpen
-	TotpOperationQr    string = `
+	TotpOperationClose            string = TotpOperationOpen
+	NotificationOperationSettings string = `
+		<div>
+			<form class="center_content" action="{{.OperationRequest}}" method="POST">
+
+				<label for="email_usage"><b>Use email</b></label>
+				<input type="checkbox" id="email_usage" name="email_usage" value="true" {{if .EmailUsage -}} checked {{else -}} {{end}} {{if .EmailVerified -}} {{else -}} disabled {{end}}>
+
+				<label for="locale"><b>Language</b></label>
+				<select id="locale" name="locale">
+					<option value="ru" {{if eq .Locale "ru" -}} selected {{else -}} {{end}}>Русский</option>
+					<option value="en" {{if eq .Locale "en" -}} selected {{else -}} {{end}}>English</option>
+				</select>
+
+				<label for="phone"><b>Phone for SMS</b></label>
+				<input type="text" id="phone" name="phone" placeholder="+79990000000" value="{{.Phone}}">
+
+				<label for="webhook_url"><b>Webhook URL</b></label>
+				<input type="text" id="webhook_url" name="webhook_url" placeholder="https://" value="{{.WebhookUrl}}">
+
+				<label for="digest_mode"><b>Digest</b></label>
+				<select id="digest_mode" name="digest_mode">
+					<option value="off" {{if eq .DigestMode "off" -}} selected {{else -}} {{end}}>Send immediately</option>
+					<option value="daily" {{if eq .DigestMode "daily" -}} selected {{else -}} {{end}}>Daily</option>
+					<option value="weekly" {{if eq .DigestMode "weekly" -}} selected {{else -}} {{end}}>Weekly</option>
+				</select>
+
+				<label for="timezone"><b>Timezone</b></label>
+				<input type="text" id="timezone" name="timezone" placeholder="Europe/Moscow" value="{{.Timezone}}">
+
+				<label for="quiet_hours_start"><b>Quiet hours from</b></label>
+				<input type="number" id="quiet_hours_start" name="quiet_hours_start" min="0" max="23" value="{{.QuietHoursStart}}">
+
+				<label for="quiet_hours_end"><b>Quiet hours to</b></label>
+				<input type="number" id="quiet_hours_end" name="quiet_hours_end" min="0" max="23" value="{{.QuietHoursEnd}}">
+
+				<table>
+					<thead>
+						<tr>
+							<th scope="col">Category</th>
+							<th scope="col">Channel</th>
+							<th scope="col">Enabled</th>
+						</tr>
+					</thead>
+					<tbody>
+						{{.Preferences}}
+					</tbody>
+				</table>
+
+				<input type="submit" value="Save">
+			</form>
+			{{if .EmailVerified -}} {{else -}}
+			<form class="center_content" action="{{.ResendRequest}}" method="POST">
+				<p>Email is not verified, open the link from confirmation email</p>
+				<input type="submit" value="Send confirmation again">
+			</form>
+			{{end}}
+		</div>
+`
+	NotificationOperationWebhookSecret string = `
+		<div class="center_content">
+			<p>Webhook requests are signed with HMAC-SHA256 by this secret, save it now, it isn't shown again</p>
+			<p><b>{{.WebhookSecret}}</b></p>
+		</div>
+`
+	NotificationPreferenceRow string = `
+		<tr>
+			<td>{{.Category}}</td>
+			<td>{{.Channel}}</td>
+			<td>
+				<input type="checkbox" name="preferences" value="{{.Value}}" {{if .Enabled -}} checked {{else -}} {{end}} {{if .Mandatory -}} disabled {{else -}} {{end}}>
+			</td>
+		</tr>
+`
+	NotificationOperationInbox string = `
+		<div>
+			<table>
+				<thead>
+					<tr>
+						<th scope="col">Date</th>
+						<th scope="col">Category</th>
+						<th scope="col">Subject</th>
+						<th scope="col">Message</th>
+						<th scope="col"></th>
+					</tr>
+				</thead>
+				<tbody>
+					{{.Messages}}
+				</tbody>
+			</table>
+		</div>
+`
+	NotificationInboxRow string = `
+		<tr>
+			<td>{{.CreatedAt}}</td>
+			<td>{{.Category}}</td>
+			<td>{{if .IsRead -}} {{.Subject}} {{else -}} <b>{{.Subject}}</b> {{end}}</td>
+			<td>{{.Body}}</td>
+			<td>
+				<form action="{{.OperationRequest}}" method="POST">
+					<input type="hidden" name="message_id" value="{{.MessageId}}">
+					<input type="submit" value="Read" {{if .IsRead -}} disabled {{else -}} {{end}}>
+				</form>
+			</td>
+		</tr>
+`
+	TotpOperationQr string = `
 		<div class="center_content">
 			<img src="{{.ImagePath}}" 
 			style="width:200px; height: 200px;"
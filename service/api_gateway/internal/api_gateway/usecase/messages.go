@@ -5,7 +5,11 @@ const (
 	MessageLvlAll   string = "all"
 )
 
+// Separates category and channel in preference checkbox value
+const NotificationPreferenceSeparator string = ":"
+
 const (
-	HeaderUserId          string = "user_id"
-	HeaderNotificationLvl string = "notification_level"
+	HeaderUserId   string = "user_id"
+	HeaderCategory string = "category"
+	HeaderTemplate string = "template"
 )
@@ -1,6 +1,9 @@
 package notifications
 
+// Event categories, notification service routes messages by user preferences of category
 const (
-	NotificationLvlAll   string = "all"
-	NotificationLvlEmail string = "email"
+	CategorySecurity     string = "security"
+	CategoryTransactions string = "transactions"
+	CategoryMarketing    string = "marketing"
+	CategorySystem       string = "system"
 )
@@ -0,0 +1,9 @@
+package notifications
+
+// Templates rendered by notification service
+const (
+	TemplateWelcome string = "welcome"
+	TemplateSignIn  string = "sign_in"
+	TemplateTotpOn  string = "totp_on"
+	TemplateTotpOff string = "totp_off"
+)
@@ -0,0 +1,52 @@
+package usecase
+
+// Registration events of account withdrawal and transfer between accounts
+const (
+	EventWidthAccountCache    string = "width_acc"
+	EventTransferAccountCache string = "transfer_acc"
+)
+
+var withdrawalErrorMessages = map[uint32]string{
+	1:    "Not enough money on account",
+	1070: "Amount exceeds withdrawal limit per operation",
+	1071: "Amount exceeds daily withdrawal limit",
+	1072: "Amount exceeds monthly withdrawal limit",
+	1073: "Daily number of withdrawals is exhausted",
+	1074: "Monthly number of withdrawals is exhausted",
+}
+
+// Messages shown instead of service error info, by event name and error code returned by service.
+// Codes are specific to service, so they are looked up only for events of that service
+var OperationErrorMessages = map[string]map[uint32]string{
+	EventWidthAccountCache:    withdrawalErrorMessages,
+	EventTransferAccountCache: transferErrorMessages(),
+}
+
+// Transfer is withdrawal from source account, so withdrawal messages are shared
+func transferErrorMessages() map[uint32]string {
+	messages := map[uint32]string{
+		1081: "No exchange rate between account currencies",
+		1083: "Wrong transfer amount",
+		1084: "Transfer to the same account",
+	}
+	for code, message := range withdrawalErrorMessages {
+		messages[code] = message
+	}
+	return messages
+}
+
+// Returns message of failed event, info of event is returned when its code has no message
+func operationErrorMessage(event_name string, event_data map[string]interface{}) (string, bool) {
+
+	if messages, ok := OperationErrorMessages[event_name]; ok {
+		// Event data passes registration as JSON, so code is float64
+		if status, ok := event_data["status"].(float64); ok {
+			if message, ok := messages[uint32(status)]; ok {
+				return message, true
+			}
+		}
+	}
+
+	info, ok := event_data["info"].(string)
+	return info, ok
+}
@@ -3,22 +3,25 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway/usecase/notifications"
 	"github.com/rabbitmq/amqp091-go"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 
 	//"errors"
 	"fmt"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/api_gateway/config"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway/usecase/html"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/models"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"io"
 	"net/http"
@@ -34,6 +37,7 @@ type apiGateWayUseCase struct {
 	accountsServerInfo     *models.InternalServerInfo
 	notificationServerInfo *models.InternalServerInfo
 	totpServerInfo         *models.InternalServerInfo
+	fileApiServerInfo      *models.InternalServerInfo
 	graphImagesPath        string
 	qrImagesPath           string
 	rmqChan                *amqp091.Channel
@@ -41,12 +45,19 @@ type apiGateWayUseCase struct {
 }
 
 var TokenLiveTime = time.Minute
+
+// Lifetime of document download links given to users
+var DocumentLinkLiveTime = 5 * time.Minute
+
+// file-api business type of passport scans
+const PassportFileBusinessType = "passport"
+
 var TokenFirstAuthLiveTime = time.Minute * 5
 
 func (uc *apiGateWayUseCase) AddTokenFirstAuth(ctx context.Context, token *models.TokenFirstAuth) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.AddTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.AddTokenFirstAuth")
+	defer span.End()
 
 	err := uc.repo.AddTokenFirstAuth(ctxWithTrace, token)
 	if err != nil {
@@ -59,8 +70,8 @@ func (uc *apiGateWayUseCase) AddTokenFirstAuth(ctx context.Context, token *model
 
 func (uc *apiGateWayUseCase) GetTokenFirstAuth(ctx context.Context, tokenName string) (*models.TokenFirstAuth, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.GetTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.GetTokenFirstAuth")
+	defer span.End()
 
 	token, err := uc.repo.GetTokenFirstAuth(ctxWithTrace, tokenName)
 	if err != nil {
@@ -72,8 +83,8 @@ func (uc *apiGateWayUseCase) GetTokenFirstAuth(ctx context.Context, tokenName st
 
 func (uc *apiGateWayUseCase) DeleteTokenFirstAuth(ctx context.Context, tokenName string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.DeleteTokenFirstAuth")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.DeleteTokenFirstAuth")
+	defer span.End()
 
 	err := uc.repo.DeleteTokenFirstAuth(ctxWithTrace, tokenName)
 	if err != nil {
@@ -86,8 +97,8 @@ func (uc *apiGateWayUseCase) DeleteTokenFirstAuth(ctx context.Context, tokenName
 
 func (uc *apiGateWayUseCase) CreateToken(ctx context.Context, token_id uuid.UUID, live_time time.Duration, token_value uuid.UUID) (*models.Token, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.CreateToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.CreateToken")
+	defer span.End()
 
 	token, err := uc.repo.GetToken(ctxWithTrace, token_id)
 	if err == nil && token != nil {
@@ -121,8 +132,8 @@ func (uc *apiGateWayUseCase) GetTokenValue(ctx context.Context, token_id uuid.UU
 
 func (uc *apiGateWayUseCase) CheckExistingToken(ctx context.Context, token_id uuid.UUID) (bool, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.CheckExistingToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.CheckExistingToken")
+	defer span.End()
 
 	_, err := uc.repo.GetToken(ctxWithTrace, token_id)
 	if err != nil {
@@ -134,8 +145,8 @@ func (uc *apiGateWayUseCase) CheckExistingToken(ctx context.Context, token_id uu
 
 func (uc *apiGateWayUseCase) UpdateToken(ctx context.Context, token_id uuid.UUID, new_expire_time time.Duration) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.UpdateToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.UpdateToken")
+	defer span.End()
 
 	err := uc.repo.UpdateToken(ctxWithTrace, token_id, new_expire_time)
 	if err != nil {
@@ -147,8 +158,8 @@ func (uc *apiGateWayUseCase) UpdateToken(ctx context.Context, token_id uuid.UUID
 
 func (uc *apiGateWayUseCase) DeleteToken(ctx context.Context, token_id uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.DeleteToken")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.DeleteToken")
+	defer span.End()
 
 	err := uc.repo.DeleteToken(ctxWithTrace, token_id)
 	if err != nil {
@@ -389,30 +400,58 @@ func (uc *apiGateWayUseCase) CreateUserPage(user_id uuid.UUID) (string, error) {
 	turn_off_totp_page_request := writer.String()
 	writer.Reset()
 
+	template_notification_settings_page_request, err := template.New("RequestNotificationSettingsPage").Parse(html.RequestNotificationSettingsPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = template_notification_settings_page_request.Execute(&writer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	notification_settings_page_request := writer.String()
+	writer.Reset()
+
+	template_notification_inbox_page_request, err := template.New("RequestNotificationInboxPage").Parse(html.RequestNotificationInboxPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = template_notification_inbox_page_request.Execute(&writer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	notification_inbox_page_request := writer.String()
+	writer.Reset()
+
 	authorityDate := strings.Split(user_data.PassportAuthorityDate, "T")[0]
 	birthDate := strings.Split(user_data.BirthDate, "T")[0]
 
 	user_page_info := &models.HomePage{
-		UserId:              user_id.String(),
-		Login:               user_data.Login,
-		SignInPageRequest:   sign_in_page_request,
-		SignOutRequest:      sign_out_request,
-		Surname:             user_data.Surname,
-		Name:                user_data.Name,
-		Patronymic:          user_data.Patronymic,
-		INN:                 user_data.Inn,
-		PassportCode:        user_data.PassportSeries + " " + user_data.PassportNumber,
-		BirthDate:           birthDate,
-		BirthLocation:       user_data.BirthLocation,
-		PickUpPoint:         user_data.PassportPickUpPoint,
-		Authority:           user_data.PassportAuthority,
-		AuthorityDate:       authorityDate,
-		RegistrationAddress: user_data.PassportRegistrationAddress,
-		Email:               user_data.Email,
-		ListOfAccounts:      "",
-		IsUseTotp:           user_data.UsingTotp,
-		RequestTurnOnTotp:   turn_on_totp_page_request,
-		RequestTurnOffTotp:  turn_off_totp_page_request,
+		UserId:                      user_id.String(),
+		Login:                       user_data.Login,
+		SignInPageRequest:           sign_in_page_request,
+		SignOutRequest:              sign_out_request,
+		Surname:                     user_data.Surname,
+		Name:                        user_data.Name,
+		Patronymic:                  user_data.Patronymic,
+		INN:                         user_data.Inn,
+		PassportCode:                user_data.PassportSeries + " " + user_data.PassportNumber,
+		BirthDate:                   birthDate,
+		BirthLocation:               user_data.BirthLocation,
+		PickUpPoint:                 user_data.PassportPickUpPoint,
+		Authority:                   user_data.PassportAuthority,
+		AuthorityDate:               authorityDate,
+		RegistrationAddress:         user_data.PassportRegistrationAddress,
+		Email:                       user_data.Email,
+		ListOfAccounts:              "",
+		IsUseTotp:                   user_data.UsingTotp,
+		RequestTurnOnTotp:           turn_on_totp_page_request,
+		RequestTurnOffTotp:          turn_off_totp_page_request,
+		RequestNotificationSettings: notification_settings_page_request,
+		RequestNotificationInbox:    notification_inbox_page_request,
 	}
 
 	accounts := ""
@@ -431,8 +470,10 @@ func (uc *apiGateWayUseCase) CreateUserPage(user_id uuid.UUID) (string, error) {
 			Name:                account_data.Name,
 			Status:              account_data.Status,
 			Cache:               fmt.Sprint(account_data.Cache),
+			Currency:            account_data.Currency,
 			AccountId:           account_id.String(),
 			GetCreditsRequest:   "",
+			StatementRequest:    "",
 			AddCacheRequest:     "",
 			ReduceCacheRequest:  "",
 			CloseAccountRequest: "",
@@ -456,6 +497,19 @@ func (uc *apiGateWayUseCase) CreateUserPage(user_id uuid.UUID) (string, error) {
 		account_html_data.GetCreditsRequest = writer.String()
 		writer.Reset()
 
+		template_account_statement_request, err := template.New("RequestAccountStatementPage").Parse(html.RequestAccountStatementPage)
+		if err != nil {
+			return "", nil
+		}
+
+		err = template_account_statement_request.Execute(&writer, &curr_server_data)
+		if err != nil {
+			return "", nil
+		}
+
+		account_html_data.StatementRequest = writer.String()
+		writer.Reset()
+
 		templage_close_account_request, err := template.New("RequestAccountClosePage").Parse(html.RequestAccountClosePage)
 		if err != nil {
 			return "", nil
@@ -555,7 +609,7 @@ func (uc *apiGateWayUseCase) CreateOpenAccountPage(user_id uuid.UUID) (string, e
 	return result, nil
 }
 
-func (uc *apiGateWayUseCase) CreateAccountCreditsPage(user_id uuid.UUID, account_id uuid.UUID) (string, error) {
+func (uc *apiGateWayUseCase) CreateAccountCreditsPage(user_id uuid.UUID, account_id uuid.UUID, history_filter *models.AccountCreditsRequest) (string, error) {
 	user_data, err := uc.GetUserDataRequest(user_id)
 	if err != nil {
 		return "", err
@@ -565,6 +619,7 @@ func (uc *apiGateWayUseCase) CreateAccountCreditsPage(user_id uuid.UUID, account
 	additional_data["login"] = user_data.Login
 	additional_data["user_id"] = user_id.String()
 	additional_data["account_id"] = account_id.String()
+	additional_data["history_filter"] = history_filter
 
 	result, err := uc.CreateOperationPage(AccountOperationTypeGetCredits, additional_data)
 	if err != nil {
@@ -574,6 +629,25 @@ func (uc *apiGateWayUseCase) CreateAccountCreditsPage(user_id uuid.UUID, account
 	return result, nil
 }
 
+func (uc *apiGateWayUseCase) CreateAccountStatementPage(user_id uuid.UUID, account_id uuid.UUID) (string, error) {
+	user_data, err := uc.GetUserDataRequest(user_id)
+	if err != nil {
+		return "", err
+	}
+
+	additional_data := make(map[string]interface{})
+	additional_data["login"] = user_data.Login
+	additional_data["user_id"] = user_id.String()
+	additional_data["account_id"] = account_id.String()
+
+	result, err := uc.CreateOperationPage(AccountOperationTypeStatement, additional_data)
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
 func (uc *apiGateWayUseCase) CreateCloseAccountPage(user_id uuid.UUID, account_id uuid.UUID) (string, error) {
 	user_data, err := uc.GetUserDataRequest(user_id)
 	if err != nil {
@@ -690,6 +764,7 @@ func (uc *apiGateWayUseCase) CreateOperationPage(operation_type string, addition
 
 			}
 		case AccountOperationTypeGetCredits,
+			AccountOperationTypeStatement,
 			AccountOperationTypeClose,
 			AccountOperationAddCache,
 			AccountOperationWidthCache:
@@ -734,6 +809,51 @@ func (uc *apiGateWayUseCase) CreateOperationPage(operation_type string, addition
 					account_operation_page_info.Operation = buffer.String()
 					buffer.Reset()
 
+					history_filter, ok := additional_data["history_filter"].(*models.AccountCreditsRequest)
+					if !ok || history_filter == nil {
+						history_filter = &models.AccountCreditsRequest{}
+					}
+
+					account_history, err := uc.createAccountHistory(account_id, history_filter, &curr_server_data)
+					if err != nil {
+						return "", err
+					}
+
+					account_operation_page_info.Operation += account_history
+
+				} else if operation_type == AccountOperationTypeStatement {
+
+					operation_info := &models.AccountOperationData{
+						OperationRequest: "",
+						AccountId:        account_id.String(),
+					}
+
+					template_account_statement_request, err := template.New("RequestAccountStatement").Parse(html.RequestAccountStatement)
+					if err != nil {
+						return "", err
+					}
+
+					err = template_account_statement_request.Execute(&buffer, &curr_server_data)
+					if err != nil {
+						return "", err
+					}
+
+					operation_info.OperationRequest = buffer.String()
+					buffer.Reset()
+
+					template_operation_account_statement, err := template.New("AccountOperationStatement").Parse(html.AccountOperationStatement)
+					if err != nil {
+						return "", err
+					}
+
+					err = template_operation_account_statement.Execute(&buffer, &operation_info)
+					if err != nil {
+						return "", err
+					}
+
+					account_operation_page_info.Operation = buffer.String()
+					buffer.Reset()
+
 				} else if operation_type == AccountOperationTypeClose {
 
 					operation_info := &models.AccountOperationData{
@@ -1237,6 +1357,18 @@ func (uc *apiGateWayUseCase) CreateAdminPage(begin string, end string) (string,
 	admin_page_data.GetOperationsRequest = buffer.String()
 	buffer.Reset()
 
+	template_notifications_request, err := template.New("RequestAdminNotificationsPage").Parse(html.RequestAdminNotificationsPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = template_notifications_request.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+	admin_page_data.NotificationsRequest = buffer.String()
+	buffer.Reset()
+
 	operations := ""
 
 	operations_id_list, err := uc.getListOfOperations(begin, end)
@@ -1345,7 +1477,20 @@ func (uc *apiGateWayUseCase) SignIn(login_info *models.SignInInfo) (*models.Toke
 
 func (uc *apiGateWayUseCase) SignUp(sign_up_info *models.SignUpInfo) (*models.Token, error) {
 
-	user_id, err := uc.CreateUserRequest(sign_up_info)
+	// User UUID is chosen before sign up, so passport scans are uploaded with new user as owner
+	user_id := uuid.New()
+
+	passport_files := make([]string, 0, len(sign_up_info.PassportScans))
+	for _, scan := range sign_up_info.PassportScans {
+		file_id, err := uc.PutFileRequest(PassportFileBusinessType, scan.FileName, scan.Content, user_id)
+		if err != nil {
+			uc.deleteFiles(passport_files)
+			return nil, err
+		}
+		passport_files = append(passport_files, file_id.String())
+	}
+
+	user_id, err := uc.CreateUserRequest(sign_up_info, user_id, passport_files)
 	if err != nil {
 		return nil, err
 	}
@@ -1362,29 +1507,17 @@ func (uc *apiGateWayUseCase) SignUp(sign_up_info *models.SignUpInfo) (*models.To
 
 func (uc *apiGateWayUseCase) CreateNotificationSignUp(ctx context.Context, userId uuid.UUID) error {
 
-	template_welcome_notify, err := template.New("NotificationWelcome").Parse(notifications.NotificationWelcome)
-	if err != nil {
-		return err
-	}
-
 	userInfo, err := uc.GetUserDataRequest(userId)
 	if err != nil {
 		return err
 	}
 
-	var buffer bytes.Buffer
-
 	userNotifyInfo := &models.WelcomeMessage{
 		Login: userInfo.Login,
 		Name:  userInfo.Surname + " " + userInfo.Name + " " + userInfo.Patronymic,
 	}
 
-	err = template_welcome_notify.Execute(&buffer, userNotifyInfo)
-	if err != nil {
-		return err
-	}
-
-	err = uc.createNotification(ctx, userId, notifications.NotificationLvlAll, buffer.String())
+	err = uc.createNotification(ctx, userId, notifications.CategorySystem, notifications.TemplateWelcome, userNotifyInfo)
 	if err != nil {
 		return err
 	}
@@ -1395,11 +1528,6 @@ func (uc *apiGateWayUseCase) CreateNotificationSignUp(ctx context.Context, userI
 
 func (uc *apiGateWayUseCase) CreateNotificationSignIn(ctx context.Context, userId uuid.UUID) error {
 
-	template_message_sign_in, err := template.New("NotificationSignIn").Parse(notifications.NotificationSignIn)
-	if err != nil {
-		return err
-	}
-
 	userInfo, err := uc.GetUserDataRequest(userId)
 	if err != nil {
 		return err
@@ -1409,14 +1537,7 @@ func (uc *apiGateWayUseCase) CreateNotificationSignIn(ctx context.Context, userI
 		Login: userInfo.Login,
 	}
 
-	var buffer bytes.Buffer
-
-	err = template_message_sign_in.Execute(&buffer, &msgInfo)
-	if err != nil {
-		return err
-	}
-
-	err = uc.createNotification(ctx, userId, notifications.NotificationLvlEmail, buffer.String())
+	err = uc.createNotification(ctx, userId, notifications.CategorySecurity, notifications.TemplateSignIn, msgInfo)
 	if err != nil {
 		return err
 	}
@@ -1443,176 +1564,412 @@ func (uc *apiGateWayUseCase) WidthAccountCache(user_id uuid.UUID, account_id uui
 	return uc.widthAccountCacheRequest(user_id, account_id, money)
 }
 
-func (uc *apiGateWayUseCase) widthAccountCacheRequest(user_id uuid.UUID, account_id uuid.UUID, money float64) error {
+// createAccountHistory renders account movements table with filters and link to next page
+func (uc *apiGateWayUseCase) createAccountHistory(account_id uuid.UUID, history_filter *models.AccountCreditsRequest, curr_server_data interface{}) (string, error) {
 
-	template_request_width_account_cache, err := template.New("RequestWidthAccountCache").Parse(RequestWidthAccountCache)
+	history, err := uc.getAccountHistoryRequest(account_id, history_filter)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	var buffer bytes.Buffer
 
-	err = template_request_width_account_cache.Execute(&buffer, uc.registrationServerInfo)
-	if err != nil {
-		return err
-	}
-
-	request_width_account_cache := buffer.String()
-	buffer.Reset()
-
-	request_width_account_cache_body := &models.AddAccountCacheBody{
-		UserId:    user_id,
-		AccountId: account_id,
-		CacheDiff: money,
-	}
-
-	request_body, err := json.Marshal(&request_width_account_cache_body)
+	template_history_request, err := template.New("RequestAccountCreditsPage").Parse(html.RequestAccountCreditsPage)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, request_width_account_cache, bytes.NewBuffer(request_body))
+	err = template_history_request.Execute(&buffer, curr_server_data)
 	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{
-		Timeout: uc.registrationServerInfo.TimeWaitResponse,
+		return "", err
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	history_data := &models.AccountHistoryData{
+		HistoryRequest: buffer.String(),
+		AccountId:      account_id.String(),
+		From:           history_filter.From,
+		To:             history_filter.To,
+		Direction:      history_filter.Direction,
+		MinAmount:      history_filter.MinAmount,
+		MaxAmount:      history_filter.MaxAmount,
+		OperationId:    history_filter.OperationId,
+		NextCursor:     history.NextCursor,
 	}
+	buffer.Reset()
 
-	resp_body, err := io.ReadAll(resp.Body)
+	template_history_row, err := template.New("AccountHistoryRow").Parse(html.AccountHistoryRow)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	var resp_data = &models.OperationResponse{}
+	for _, movement := range history.Movements {
+		row := &models.AccountHistoryRow{
+			CreatedAt:   movement.CreatedAt.Format("02-01-2006 15:04:05"),
+			Type:        movement.MovementType,
+			Amount:      strconv.FormatFloat(movement.Amount, 'f', 2, 64),
+			Balance:     strconv.FormatFloat(movement.Balance, 'f', 2, 64),
+			OperationId: "",
+		}
+		if movement.OperationUuid.Valid {
+			row.OperationId = movement.OperationUuid.UUID.String()
+		}
 
-	err = json.Unmarshal(resp_body, &resp_data)
-	if err != nil {
-		return err
+		err = template_history_row.Execute(&buffer, &row)
+		if err != nil {
+			return "", err
+		}
 	}
 
-	operation_id_str := resp_data.Info
+	history_data.Movements = buffer.String()
+	buffer.Reset()
 
-	operation_id, err := uuid.Parse(operation_id_str)
+	template_history, err := template.New("AccountOperationHistory").Parse(html.AccountOperationHistory)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = uc.GetOperationData(operation_id)
+	err = template_history.Execute(&buffer, &history_data)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return buffer.String(), nil
 }
 
-func (uc *apiGateWayUseCase) addAccountCacheRequest(user_id uuid.UUID, account_id uuid.UUID, money float64) error {
+func (uc *apiGateWayUseCase) getAccountHistoryRequest(account_id uuid.UUID, history_filter *models.AccountCreditsRequest) (*models.GetAccountHistoryResponse, error) {
 
-	template_request_add_account_cache, err := template.New("RequestAddAccountCache").Parse(RequestAddAccountCache)
+	template_request_get_account_history, err := template.New("RequestGetAccountHistory").Parse(RequestGetAccountHistory)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var buffer bytes.Buffer
 
-	err = template_request_add_account_cache.Execute(&buffer, uc.registrationServerInfo)
+	err = template_request_get_account_history.Execute(&buffer, uc.accountsServerInfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	request_add_account_cache := buffer.String()
-	buffer.Reset()
-
-	request_add_account_cache_body := &models.AddAccountCacheBody{
-		UserId:    user_id,
-		AccountId: account_id,
-		CacheDiff: money,
+	params := url.Values{}
+	params.Add("acc_id", account_id.String())
+	for name, value := range map[string]string{
+		"from":         history_filter.From,
+		"to":           history_filter.To,
+		"direction":    history_filter.Direction,
+		"min_amount":   history_filter.MinAmount,
+		"max_amount":   history_filter.MaxAmount,
+		"operation_id": history_filter.OperationId,
+		"cursor":       history_filter.Cursor,
+	} {
+		if value != "" {
+			params.Add(name, value)
+		}
 	}
 
-	request_body, err := json.Marshal(&request_add_account_cache_body)
-	if err != nil {
-		return err
-	}
+	fullUrl := fmt.Sprintf("%s?%s", buffer.String(), params.Encode())
 
-	req, err := http.NewRequest(http.MethodPost, request_add_account_cache, bytes.NewBuffer(request_body))
+	req, err := http.NewRequest(http.MethodGet, fullUrl, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	client := &http.Client{
-		Timeout: uc.registrationServerInfo.TimeWaitResponse,
+		Timeout: uc.accountsServerInfo.TimeWaitResponse,
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	resp_body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var resp_data = &models.OperationResponse{}
+	if resp.StatusCode != http.StatusOK {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(resp_data.Info)
+	}
+
+	var resp_data = &models.GetAccountHistoryResponse{}
 
 	err = json.Unmarshal(resp_body, &resp_data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	operation_id_str := resp_data.Info
+	return resp_data, nil
+}
 
-	operation_id, err := uuid.Parse(operation_id_str)
+// CreateAccountStatement makes account statement for period and returns link to download it
+func (uc *apiGateWayUseCase) CreateAccountStatement(user_id uuid.UUID, statement_info *models.AccountStatementRequestBody) (string, error) {
+
+	account_id, err := uuid.Parse(statement_info.AccountId)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = uc.GetOperationData(operation_id)
+	user_data, err := uc.GetUserDataRequest(user_id)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
-}
-
-func (uc *apiGateWayUseCase) closeAccountRequest(user_id uuid.UUID, account_id uuid.UUID) error {
+	if !slices.Contains(user_data.Accounts, account_id) {
+		return "", ErrorNoAccountData
+	}
 
-	template_request_close_account, err := template.New("RequestCloseAccount").Parse(RequestCloseAccount)
+	file_id, err := uc.createAccountStatementRequest(user_id, account_id, statement_info)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	var buffer bytes.Buffer
+	return uc.GetDocumentDownloadLink(user_id, file_id)
+}
 
-	err = template_request_close_account.Execute(&buffer, uc.registrationServerInfo)
+func (uc *apiGateWayUseCase) createAccountStatementRequest(user_id uuid.UUID, account_id uuid.UUID, statement_info *models.AccountStatementRequestBody) (uuid.UUID, error) {
+
+	template_request_create_statement, err := template.New("RequestCreateAccountStatement").Parse(RequestCreateAccountStatement)
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
-	request_close_account := buffer.String()
-	buffer.Reset()
+	var buffer bytes.Buffer
 
-	request_close_account_body := &models.CloseAccountBody{
-		UserId:    user_id,
-		AccountId: account_id,
+	err = template_request_create_statement.Execute(&buffer, uc.accountsServerInfo)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
-	request_body, err := json.Marshal(&request_close_account_body)
+	request_body, err := json.Marshal(&models.CreateAccountStatementBody{
+		AccId:   account_id.String(),
+		OwnerId: user_id.String(),
+		From:    statement_info.From,
+		To:      statement_info.To,
+		Format:  statement_info.Format,
+	})
 	if err != nil {
-		return err
+		return uuid.Nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, request_close_account, bytes.NewBuffer(request_body))
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
 	if err != nil {
-		return err
+		return uuid.Nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.accountsServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer resp.Body.Close()
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return uuid.Nil, errors.New(resp_data.Info)
+	}
+
+	var resp_data = &models.CreateAccountStatementResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(resp_data.FileId)
+}
+
+func (uc *apiGateWayUseCase) widthAccountCacheRequest(user_id uuid.UUID, account_id uuid.UUID, money float64) error {
+
+	template_request_width_account_cache, err := template.New("RequestWidthAccountCache").Parse(RequestWidthAccountCache)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = template_request_width_account_cache.Execute(&buffer, uc.registrationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_width_account_cache := buffer.String()
+	buffer.Reset()
+
+	request_width_account_cache_body := &models.AddAccountCacheBody{
+		UserId:    user_id,
+		AccountId: account_id,
+		CacheDiff: money,
+	}
+
+	request_body, err := json.Marshal(&request_width_account_cache_body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, request_width_account_cache, bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.registrationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var resp_data = &models.OperationResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return err
+	}
+
+	operation_id_str := resp_data.Info
+
+	operation_id, err := uuid.Parse(operation_id_str)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.GetOperationData(operation_id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *apiGateWayUseCase) addAccountCacheRequest(user_id uuid.UUID, account_id uuid.UUID, money float64) error {
+
+	template_request_add_account_cache, err := template.New("RequestAddAccountCache").Parse(RequestAddAccountCache)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = template_request_add_account_cache.Execute(&buffer, uc.registrationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_add_account_cache := buffer.String()
+	buffer.Reset()
+
+	request_add_account_cache_body := &models.AddAccountCacheBody{
+		UserId:    user_id,
+		AccountId: account_id,
+		CacheDiff: money,
+	}
+
+	request_body, err := json.Marshal(&request_add_account_cache_body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, request_add_account_cache, bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.registrationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var resp_data = &models.OperationResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return err
+	}
+
+	operation_id_str := resp_data.Info
+
+	operation_id, err := uuid.Parse(operation_id_str)
+	if err != nil {
+		return err
+	}
+
+	_, err = uc.GetOperationData(operation_id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc *apiGateWayUseCase) closeAccountRequest(user_id uuid.UUID, account_id uuid.UUID) error {
+
+	template_request_close_account, err := template.New("RequestCloseAccount").Parse(RequestCloseAccount)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = template_request_close_account.Execute(&buffer, uc.registrationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_close_account := buffer.String()
+	buffer.Reset()
+
+	request_close_account_body := &models.CloseAccountBody{
+		UserId:    user_id,
+		AccountId: account_id,
+	}
+
+	request_body, err := json.Marshal(&request_close_account_body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, request_close_account, bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
@@ -2017,6 +2374,7 @@ func (uc *apiGateWayUseCase) GetAccountDataRequest(account_id uuid.UUID) (*model
 		CIO:        resp_data.Acc_cio,
 		BIC:        resp_data.Acc_bic,
 		Cache:      resp_data.Acc_money_amount,
+		Currency:   resp_data.Acc_currency,
 	}
 
 	status_str := "Unknown"
@@ -2324,13 +2682,6 @@ func (uc *apiGateWayUseCase) TurnOnTotp(userId uuid.UUID) error {
 
 func (uc *apiGateWayUseCase) createNotificationTurnOnTotp(ctx context.Context, userId uuid.UUID) error {
 
-	templateMessageTurnOnTotp, err := template.New("NotificationTurnOnTotp").Parse(notifications.NotificationTurnOnTotp)
-	if err != nil {
-		return err
-	}
-
-	var buffer bytes.Buffer
-
 	userInfo, err := uc.GetUserDataRequest(userId)
 	if err != nil {
 		return err
@@ -2340,12 +2691,7 @@ func (uc *apiGateWayUseCase) createNotificationTurnOnTotp(ctx context.Context, u
 		Login: userInfo.Login,
 	}
 
-	err = templateMessageTurnOnTotp.Execute(&buffer, &messageData)
-	if err != nil {
-		return err
-	}
-
-	err = uc.createNotification(ctx, userId, notifications.NotificationLvlEmail, buffer.String())
+	err = uc.createNotification(ctx, userId, notifications.CategorySecurity, notifications.TemplateTotpOn, messageData)
 	if err != nil {
 		return err
 	}
@@ -2356,13 +2702,6 @@ func (uc *apiGateWayUseCase) createNotificationTurnOnTotp(ctx context.Context, u
 
 func (uc *apiGateWayUseCase) createNotificationTurnOffTotp(ctx context.Context, userId uuid.UUID) error {
 
-	templateMessageTurnOffTotp, err := template.New("NotificationTurnOffTotp").Parse(notifications.NotificationTurnOffTotp)
-	if err != nil {
-		return err
-	}
-
-	var buffer bytes.Buffer
-
 	userInfo, err := uc.GetUserDataRequest(userId)
 	if err != nil {
 		return err
@@ -2372,12 +2711,7 @@ func (uc *apiGateWayUseCase) createNotificationTurnOffTotp(ctx context.Context,
 		Login: userInfo.Login,
 	}
 
-	err = templateMessageTurnOffTotp.Execute(&buffer, &messageData)
-	if err != nil {
-		return err
-	}
-
-	err = uc.createNotification(ctx, userId, notifications.NotificationLvlAll, buffer.String())
+	err = uc.createNotification(ctx, userId, notifications.CategorySecurity, notifications.TemplateTotpOff, messageData)
 	if err != nil {
 		return err
 	}
@@ -2738,7 +3072,9 @@ func (uc *apiGateWayUseCase) GetUserDataByLoginRequest(login string) (*models.Us
 
 }
 
-func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo) (uuid.UUID, error) {
+// CreateUserRequest starts create_user operation, passport files not accepted by registration are deleted,
+// later failures are compensated by registration saga
+func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo, user_id uuid.UUID, passport_files []string) (uuid.UUID, error) {
 
 	template_request_create_user, err := template.New("ReuqestCreateUser").Parse(RequestCreateUser)
 	if err != nil {
@@ -2773,7 +3109,9 @@ func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo) (uu
 			AuthorityDate:       user_info.PassportAuthorityDate + " 00:00:00",
 			BirthDate:           user_info.BirthDate + " 00:00:00",
 		},
-		UserEmail: user_info.Email,
+		UserEmail:     user_info.Email,
+		PassportFiles: passport_files,
+		UserUuid:      user_id.String(),
 	}
 
 	request_body, err := json.Marshal(&request_get_user_data_by_login_body)
@@ -2793,6 +3131,7 @@ func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo) (uu
 
 	resp, err := client.Do(req)
 	if err != nil {
+		uc.deleteFiles(passport_files)
 		return uuid.Nil, err
 	}
 
@@ -2809,6 +3148,7 @@ func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo) (uu
 	}
 
 	if resp_data.Info == "" {
+		uc.deleteFiles(passport_files)
 		err_str := "Operation_error, code: " + strconv.Itoa(resp_data.Status)
 		return uuid.Nil, errors.New(err_str)
 	}
@@ -2827,12 +3167,12 @@ func (uc *apiGateWayUseCase) CreateUserRequest(user_info *models.SignUpInfo) (uu
 
 	additional_info := operation_data.AdditionalInfo.(map[string]interface{})
 
-	user_id, err := uuid.Parse(additional_info["user_id"].(string))
+	created_user_id, err := uuid.Parse(additional_info["user_id"].(string))
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	return user_id, nil
+	return created_user_id, nil
 }
 
 func (uc *apiGateWayUseCase) GetOperationData(operation_id uuid.UUID) (*models.OperationResponse, error) {
@@ -2855,10 +3195,10 @@ func (uc *apiGateWayUseCase) GetOperationData(operation_id uuid.UUID) (*models.O
 
 			errors_list := additional_info["errors"].(map[string]interface{})
 			for _, saga := range errors_list {
-				for _, event := range saga.(map[string]interface{}) {
-					error_msg, ok := event.(map[string]interface{})["info"]
+				for event_name, event := range saga.(map[string]interface{}) {
+					error_msg, ok := operationErrorMessage(event_name, event.(map[string]interface{}))
 					if ok {
-						error_string += error_msg.(string) + "\n"
+						error_string += error_msg + "\n"
 					}
 				}
 			}
@@ -2928,23 +3268,33 @@ func (uc *apiGateWayUseCase) GetOperationDataRequest(operation_id uuid.UUID) (*m
 	return resp_data, nil
 }
 
-func (uc *apiGateWayUseCase) createNotification(ctx context.Context, userId uuid.UUID, notificationLvl string, message string) error {
+// createNotification publishes template name and its variables, message is rendered by notification service
+func (uc *apiGateWayUseCase) createNotification(ctx context.Context, userId uuid.UUID, category string, templateName string, data interface{}) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "apiGateWayUseCase.CreateNotification")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "apiGateWayUseCase.CreateNotification")
+	defer span.End()
 
 	headers := make(amqp.Table)
 	headers[HeaderUserId] = userId.String()
-	headers[HeaderNotificationLvl] = notificationLvl
-	err := uc.rmqChan.PublishWithContext(ctxWithTrace,
+	headers[HeaderCategory] = category
+	headers[HeaderTemplate] = templateName
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	err = uc.rmqChan.PublishWithContext(ctxWithTrace,
 		"",               // exchange
 		uc.rmqQueue.Name, // routing key
 		false,            // mandatory
 		false,            // immediate
 		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        []byte(message),
-			Headers:     headers,
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    uuid.New().String(),
+			Body:         body,
+			Headers:      headers,
 		})
 	if err != nil {
 		return err
@@ -2953,12 +3303,1080 @@ func (uc *apiGateWayUseCase) createNotification(ctx context.Context, userId uuid
 	return nil
 }
 
-func NewApiGatewayUseCase(cfg *config.Config, repo api_gateway.Repository, registration_server_info *models.InternalServerInfo,
-	usersServerInfo *models.InternalServerInfo, accountsServerInfo *models.InternalServerInfo,
-	notificationServerInfo *models.InternalServerInfo, totpServerInfo *models.InternalServerInfo,
-	graphImagesPath string, qrImagesPath string, rmqChan *amqp091.Channel,
-	rmqQueue amqp091.Queue) api_gateway.UseCase {
-	return &apiGateWayUseCase{cfg: cfg, repo: repo, registrationServerInfo: registration_server_info, graphImagesPath: graphImagesPath,
-		rmqQueue: rmqQueue, rmqChan: rmqChan, accountsServerInfo: accountsServerInfo, usersServerInfo: usersServerInfo,
-		notificationServerInfo: notificationServerInfo, totpServerInfo: totpServerInfo, qrImagesPath: qrImagesPath}
+func (uc *apiGateWayUseCase) CreateNotificationSettingsPage(userId uuid.UUID) (string, error) {
+
+	userData, err := uc.GetUserDataRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	settings, err := uc.getUserNotificationSettingsRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo := &models.NotificationOperationPage{
+		OperationName:  "Notification settings",
+		Login:          userData.Login,
+		Operation:      "",
+		ReturnRequest:  "",
+		SignOutRequest: "",
+	}
+
+	curr_server_data := &models.RequestData{
+		Port: uc.cfg.HTTPServer.Port[1:],
+	}
+
+	var buffer bytes.Buffer
+
+	templateSignOutRequest, err := template.New("RequestSignOut").Parse(html.RequestSignOut)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateSignOutRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.SignOutRequest = buffer.String()
+	buffer.Reset()
+
+	templateUserPageRequest, err := template.New("RequestUserPage").Parse(html.RequestUserPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateUserPageRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.ReturnRequest = buffer.String()
+	buffer.Reset()
+
+	templateUpdateSettingsRequest, err := template.New("RequestUpdateNotificationSettings").Parse(html.RequestUpdateNotificationSettings)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateUpdateSettingsRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	settingsData := &models.NotificationSettingsData{
+		OperationRequest: buffer.String(),
+		EmailUsage:       settings.EmailUsage,
+		EmailVerified:    settings.Verified,
+		ResendRequest:    "",
+		Locale:           settings.Locale,
+		Phone:            settings.Phone,
+		WebhookUrl:       settings.WebhookUrl,
+		DigestMode:       settings.DigestMode,
+		Timezone:         settings.Timezone,
+		QuietHoursStart:  settings.QuietHoursStart,
+		QuietHoursEnd:    settings.QuietHoursEnd,
+		Preferences:      "",
+	}
+	buffer.Reset()
+
+	templateResendRequest, err := template.New("RequestResendEmailVerification").Parse(html.RequestResendEmailVerification)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateResendRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	settingsData.ResendRequest = buffer.String()
+	buffer.Reset()
+
+	templatePreferenceRow, err := template.New("NotificationPreferenceRow").Parse(html.NotificationPreferenceRow)
+	if err != nil {
+		return "", err
+	}
+
+	preferences := ""
+	for _, preference := range settings.Preferences {
+		row := &models.NotificationPreferenceRow{
+			Category:  preference.Category,
+			Channel:   preference.Channel,
+			Value:     preference.Category + NotificationPreferenceSeparator + preference.Channel,
+			Enabled:   preference.Enabled,
+			Mandatory: preference.Mandatory,
+		}
+
+		err = templatePreferenceRow.Execute(&buffer, &row)
+		if err != nil {
+			return "", err
+		}
+
+		preferences += buffer.String()
+		buffer.Reset()
+	}
+	settingsData.Preferences = preferences
+
+	templateSettings, err := template.New("NotificationOperationSettings").Parse(html.NotificationOperationSettings)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateSettings.Execute(&buffer, &settingsData)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.Operation = buffer.String()
+	buffer.Reset()
+
+	templatePage, err := template.New("NotificationOperationPage").Parse(html.NotificationOperationPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templatePage.Execute(&buffer, &pageInfo)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// UpdateNotificationSettings saves preferences from settings page. Preference is enabled when its checkbox was sent,
+// mandatory ones are kept enabled. New webhook secret is returned when webhook url was changed.
+func (uc *apiGateWayUseCase) UpdateNotificationSettings(userId uuid.UUID, settingsInfo *models.NotificationSettingsRequestBody) (string, error) {
+
+	settings, err := uc.getUserNotificationSettingsRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	requestBody := &models.UpdateUserNotifySettingsBody{
+		UserId:            userId,
+		EmailNotification: settingsInfo.EmailUsage,
+		Email:             settings.Email,
+		Locale:            settingsInfo.Locale,
+		Phone:             settingsInfo.Phone,
+		WebhookUrl:        settingsInfo.WebhookUrl,
+		DigestMode:        settingsInfo.DigestMode,
+		Timezone:          settingsInfo.Timezone,
+		QuietHoursStart:   settingsInfo.QuietHoursStart,
+		QuietHoursEnd:     settingsInfo.QuietHoursEnd,
+		Preferences:       make([]models.NotificationPreference, 0, len(settings.Preferences)),
+	}
+
+	for _, preference := range settings.Preferences {
+		value := preference.Category + NotificationPreferenceSeparator + preference.Channel
+		preference.Enabled = preference.Mandatory || slices.Contains(settingsInfo.Preferences, value)
+		requestBody.Preferences = append(requestBody.Preferences, preference)
+	}
+
+	return uc.updateUserNotificationSettingsRequest(requestBody)
+}
+
+func (uc *apiGateWayUseCase) getUserNotificationSettingsRequest(userId uuid.UUID) (*models.GetUserNotifySettingsResponse, error) {
+
+	templateRequestGetSettings, err := template.New("GetUserNotificationSettings").Parse(GetUserNotificationSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequestGetSettings.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("user_id", userId.String())
+
+	fullUrl := fmt.Sprintf("%s?%s", buffer.String(), params.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, fullUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(resp_data.Info)
+	}
+
+	var resp_data = &models.GetUserNotifySettingsResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp_data, nil
+}
+
+func (uc *apiGateWayUseCase) updateUserNotificationSettingsRequest(requestBody *models.UpdateUserNotifySettingsBody) (string, error) {
+
+	templateRequestUpdateSettings, err := template.New("RequestUpdateUserNotificationSettings").Parse(RequestUpdateUserNotificationSettings)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequestUpdateSettings.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return "", err
+	}
+
+	request_body, err := json.Marshal(&requestBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var resp_data = &models.UpdateUserNotifySettingsResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New(resp_data.Info)
+	}
+
+	return resp_data.WebhookSecret, nil
+}
+
+func NewApiGatewayUseCase(cfg *config.Config, repo api_gateway.Repository, registration_server_info *models.InternalServerInfo,
+	usersServerInfo *models.InternalServerInfo, accountsServerInfo *models.InternalServerInfo,
+	notificationServerInfo *models.InternalServerInfo, totpServerInfo *models.InternalServerInfo,
+	fileApiServerInfo *models.InternalServerInfo, graphImagesPath string, qrImagesPath string, rmqChan *amqp091.Channel,
+	rmqQueue amqp091.Queue) api_gateway.UseCase {
+	return &apiGateWayUseCase{cfg: cfg, repo: repo, registrationServerInfo: registration_server_info, graphImagesPath: graphImagesPath,
+		rmqQueue: rmqQueue, rmqChan: rmqChan, accountsServerInfo: accountsServerInfo, usersServerInfo: usersServerInfo,
+		notificationServerInfo: notificationServerInfo, totpServerInfo: totpServerInfo, fileApiServerInfo: fileApiServerInfo,
+		qrImagesPath: qrImagesPath}
+}
+
+func (uc *apiGateWayUseCase) CreateNotificationInboxPage(userId uuid.UUID) (string, error) {
+
+	userData, err := uc.GetUserDataRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	inbox, err := uc.getUserNotificationInboxRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo := &models.NotificationOperationPage{
+		OperationName:  "Inbox",
+		Login:          userData.Login,
+		Operation:      "",
+		ReturnRequest:  "",
+		SignOutRequest: "",
+	}
+
+	curr_server_data := &models.RequestData{
+		Port: uc.cfg.HTTPServer.Port[1:],
+	}
+
+	var buffer bytes.Buffer
+
+	templateSignOutRequest, err := template.New("RequestSignOut").Parse(html.RequestSignOut)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateSignOutRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.SignOutRequest = buffer.String()
+	buffer.Reset()
+
+	templateUserPageRequest, err := template.New("RequestUserPage").Parse(html.RequestUserPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateUserPageRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.ReturnRequest = buffer.String()
+	buffer.Reset()
+
+	templateReadRequest, err := template.New("RequestReadNotificationInbox").Parse(html.RequestReadNotificationInbox)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateReadRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	readRequest := buffer.String()
+	buffer.Reset()
+
+	templateInboxRow, err := template.New("NotificationInboxRow").Parse(html.NotificationInboxRow)
+	if err != nil {
+		return "", err
+	}
+
+	messages := ""
+	for _, message := range inbox.Messages {
+		row := &models.NotificationInboxRow{
+			OperationRequest: readRequest,
+			MessageId:        message.MessageUuid.String(),
+			CreatedAt:        message.CreatedAt.Format("02-01-2006 15:04:05"),
+			Category:         message.Category,
+			Subject:          message.Subject,
+			Body:             message.Body,
+			IsRead:           message.IsRead,
+		}
+
+		err = templateInboxRow.Execute(&buffer, &row)
+		if err != nil {
+			return "", err
+		}
+
+		messages += buffer.String()
+		buffer.Reset()
+	}
+
+	templateInbox, err := template.New("NotificationOperationInbox").Parse(html.NotificationOperationInbox)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateInbox.Execute(&buffer, &models.NotificationInboxData{Messages: messages})
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.Operation = buffer.String()
+	buffer.Reset()
+
+	templatePage, err := template.New("NotificationOperationPage").Parse(html.NotificationOperationPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templatePage.Execute(&buffer, &pageInfo)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// CreateWebhookSecretPage shows secret of new webhook endpoint, notification service returns it only once
+func (uc *apiGateWayUseCase) CreateWebhookSecretPage(userId uuid.UUID, webhookSecret string) (string, error) {
+
+	userData, err := uc.GetUserDataRequest(userId)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo := &models.NotificationOperationPage{
+		OperationName:  "Webhook secret",
+		Login:          userData.Login,
+		Operation:      "",
+		ReturnRequest:  "",
+		SignOutRequest: "",
+	}
+
+	curr_server_data := &models.RequestData{
+		Port: uc.cfg.HTTPServer.Port[1:],
+	}
+
+	var buffer bytes.Buffer
+
+	templateSignOutRequest, err := template.New("RequestSignOut").Parse(html.RequestSignOut)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateSignOutRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.SignOutRequest = buffer.String()
+	buffer.Reset()
+
+	templateUserPageRequest, err := template.New("RequestUserPage").Parse(html.RequestUserPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateUserPageRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.ReturnRequest = buffer.String()
+	buffer.Reset()
+
+	templateSecret, err := template.New("NotificationOperationWebhookSecret").Parse(html.NotificationOperationWebhookSecret)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateSecret.Execute(&buffer, &models.NotificationWebhookSecretData{WebhookSecret: webhookSecret})
+	if err != nil {
+		return "", err
+	}
+
+	pageInfo.Operation = buffer.String()
+	buffer.Reset()
+
+	templatePage, err := template.New("NotificationOperationPage").Parse(html.NotificationOperationPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templatePage.Execute(&buffer, &pageInfo)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+func (uc *apiGateWayUseCase) ReadInboxMessage(userId uuid.UUID, messageId uuid.UUID) error {
+
+	templateRequestRead, err := template.New("RequestReadUserInboxMessage").Parse(RequestReadUserInboxMessage)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequestRead.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_body, err := json.Marshal(&models.ReadInboxMessageBody{
+		UserId:    userId,
+		MessageId: messageId,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+
+	} else {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return err
+		}
+		return errors.New(resp_data.Info)
+	}
+}
+
+func (uc *apiGateWayUseCase) getUserNotificationInboxRequest(userId uuid.UUID) (*models.GetUserInboxResponse, error) {
+
+	templateRequestGetInbox, err := template.New("GetUserNotificationInbox").Parse(GetUserNotificationInbox)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequestGetInbox.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("user_id", userId.String())
+
+	fullUrl := fmt.Sprintf("%s?%s", buffer.String(), params.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, fullUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(resp_data.Info)
+	}
+
+	var resp_data = &models.GetUserInboxResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp_data, nil
+}
+
+func (uc *apiGateWayUseCase) CreateAdminNotificationsPage(filter *models.AdminNotificationsRequestBody) (string, error) {
+
+	pageData := &models.AdminNotificationsPageData{
+		Deliveries:           "",
+		GetDeliveriesRequest: "",
+		AdminPageRequest:     "",
+	}
+
+	curr_server_data := &models.RequestData{
+		Port: uc.cfg.HTTPServer.Port[1:],
+	}
+
+	var buffer bytes.Buffer
+
+	templateDeliveriesRequest, err := template.New("RequestAdminNotificationsPage").Parse(html.RequestAdminNotificationsPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateDeliveriesRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageData.GetDeliveriesRequest = buffer.String()
+	buffer.Reset()
+
+	templateAdminPageRequest, err := template.New("RequestAdminPage").Parse(html.RequestAdminPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templateAdminPageRequest.Execute(&buffer, &curr_server_data)
+	if err != nil {
+		return "", err
+	}
+
+	pageData.AdminPageRequest = buffer.String()
+	buffer.Reset()
+
+	params := url.Values{}
+	if filter.Login != "" {
+		userData, err := uc.GetUserDataByLoginRequest(filter.Login)
+		if err != nil {
+			return "", err
+		}
+		params.Add("user_id", userData.Id.String())
+	}
+	if filter.Channel != "" {
+		params.Add("channel", filter.Channel)
+	}
+	if filter.Status != "" {
+		params.Add("status", filter.Status)
+	}
+	if filter.Template != "" {
+		params.Add("template", filter.Template)
+	}
+	if filter.Start != "" {
+		params.Add("begin", filter.Start)
+	}
+	if filter.End != "" {
+		params.Add("end", filter.End)
+	}
+
+	deliveries, err := uc.getNotificationDeliveriesRequest(params)
+	if err != nil {
+		return "", err
+	}
+
+	templateDelivery, err := template.New("AdminDelivery").Parse(html.AdminDelivery)
+	if err != nil {
+		return "", err
+	}
+
+	rows := ""
+	for _, delivery := range deliveries.Deliveries {
+		deliveryData := &models.AdminDeliveryData{
+			MessageId: delivery.MessageUuid.String(),
+			UserId:    delivery.UserUuid.String(),
+			Channel:   delivery.Channel,
+			Template:  delivery.Template,
+			Subject:   delivery.Subject,
+			Status:    delivery.Status,
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			Created:   delivery.CreatedAt.Format("02-01-2006 15:04:05"),
+			Sent:      "",
+		}
+		if delivery.SentAt != nil {
+			deliveryData.Sent = delivery.SentAt.Format("02-01-2006 15:04:05")
+		}
+
+		err = templateDelivery.Execute(&buffer, &deliveryData)
+		if err != nil {
+			return "", err
+		}
+
+		rows += buffer.String()
+		buffer.Reset()
+	}
+	pageData.Deliveries = rows
+
+	templatePage, err := template.New("AdminNotificationsPage").Parse(html.AdminNotificationsPage)
+	if err != nil {
+		return "", err
+	}
+
+	err = templatePage.Execute(&buffer, &pageData)
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+func (uc *apiGateWayUseCase) getNotificationDeliveriesRequest(params url.Values) (*models.GetNotificationDeliveriesResponse, error) {
+
+	templateRequestGetDeliveries, err := template.New("GetNotificationDeliveries").Parse(GetNotificationDeliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequestGetDeliveries.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	fullUrl := fmt.Sprintf("%s?%s", buffer.String(), params.Encode())
+
+	req, err := http.NewRequest(http.MethodGet, fullUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(resp_data.Info)
+	}
+
+	var resp_data = &models.GetNotificationDeliveriesResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp_data, nil
+}
+
+func (uc *apiGateWayUseCase) VerifyEmail(token string) error {
+
+	templateRequest, err := template.New("RequestVerifyUserEmail").Parse(RequestVerifyUserEmail)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequest.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_body, err := json.Marshal(&models.VerifyEmailBody{
+		Token: token,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+
+	} else {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return err
+		}
+		return errors.New(resp_data.Info)
+	}
+}
+
+func (uc *apiGateWayUseCase) ResendEmailVerification(userId uuid.UUID) error {
+
+	templateRequest, err := template.New("RequestResendUserEmailVerification").Parse(RequestResendUserEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequest.Execute(&buffer, uc.notificationServerInfo)
+	if err != nil {
+		return err
+	}
+
+	request_body, err := json.Marshal(&models.ResendEmailVerificationBody{
+		UserId: userId,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.notificationServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+
+	} else {
+		var resp_data = &models.OperationResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return err
+		}
+		return errors.New(resp_data.Info)
+	}
+}
+
+// GetDocumentDownloadLink returns time-limited file-api link to user's document, file-api checks user owns it
+func (uc *apiGateWayUseCase) GetDocumentDownloadLink(userId uuid.UUID, fileId uuid.UUID) (string, error) {
+
+	templateRequest, err := template.New("RequestGetFileDownloadLink").Parse(RequestGetFileDownloadLink)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequest.Execute(&buffer, uc.fileApiServerInfo)
+	if err != nil {
+		return "", err
+	}
+	buffer.WriteString(fileId.String())
+
+	request_body, err := json.Marshal(&models.GetFileDownloadLinkBody{
+		OwnerUUID: userId.String(),
+		ExpiresIn: uint64(DocumentLinkLiveTime.Seconds()),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.fileApiServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var resp_data = &models.GetFileDownloadLinkResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return "", err
+		}
+		return resp_data.Url, nil
+
+	} else {
+		var resp_data = &models.FileApiErrorResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return "", err
+		}
+		return "", errors.New(resp_data.Message)
+	}
+}
+
+// PutFileRequest uploads file owned by ownerId to file-api and returns its UUID
+func (uc *apiGateWayUseCase) PutFileRequest(businessType string, fileName string, content []byte, ownerId uuid.UUID) (uuid.UUID, error) {
+
+	templateRequest, err := template.New("RequestPutFile").Parse(RequestPutFile)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequest.Execute(&buffer, uc.fileApiServerInfo)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	hash := sha256.Sum256(content)
+	request_body, err := json.Marshal(&models.PutFileBody{
+		BusinessType: businessType,
+		FileName:     fileName,
+		Hash:         hex.EncodeToString(hash[:]),
+		Size:         uint64(len(content)),
+		OwnerUUID:    ownerId.String(),
+		Content:      content,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, buffer.String(), bytes.NewBuffer(request_body))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: uc.fileApiServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer resp.Body.Close()
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		var resp_data = &models.PutFileResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return uuid.Parse(resp_data.UUID)
+
+	} else {
+		var resp_data = &models.FileApiErrorResponse{}
+
+		err = json.Unmarshal(resp_body, &resp_data)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return uuid.Nil, errors.New(resp_data.Message)
+	}
+}
+
+// DeleteFileRequest deletes file from file-api, missing file is not an error
+func (uc *apiGateWayUseCase) DeleteFileRequest(fileId uuid.UUID) error {
+
+	templateRequest, err := template.New("RequestDeleteFile").Parse(RequestDeleteFile)
+	if err != nil {
+		return err
+	}
+
+	var buffer bytes.Buffer
+
+	err = templateRequest.Execute(&buffer, uc.fileApiServerInfo)
+	if err != nil {
+		return err
+	}
+	buffer.WriteString(fileId.String())
+
+	req, err := http.NewRequest(http.MethodDelete, buffer.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: uc.fileApiServerInfo.TimeWaitResponse,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	resp_body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var resp_data = &models.FileApiErrorResponse{}
+
+	err = json.Unmarshal(resp_body, &resp_data)
+	if err != nil {
+		return err
+	}
+	return errors.New(resp_data.Message)
+}
+
+// deleteFiles removes uploaded files nobody references, best effort
+func (uc *apiGateWayUseCase) deleteFiles(files []string) {
+	for _, file := range files {
+		fileId, err := uuid.Parse(file)
+		if err != nil {
+			continue
+		}
+		_ = uc.DeleteFileRequest(fileId)
+	}
 }
@@ -30,6 +30,26 @@ type AccountInfoRequest struct {
 	AccountId string `json:"account_id" validate:"required" `
 }
 
+// Account page query, history filters are optional. Dates are in 02-01-2006 format, both days are included
+type AccountCreditsRequest struct {
+	AccountId   string `json:"account_id" validate:"required"`
+	From        string `json:"from" validate:"omitempty,datetime=02-01-2006"`
+	To          string `json:"to" validate:"omitempty,datetime=02-01-2006"`
+	Direction   string `json:"direction" validate:"omitempty,oneof=deposit withdrawal"`
+	MinAmount   string `json:"min_amount" validate:"omitempty,numeric"`
+	MaxAmount   string `json:"max_amount" validate:"omitempty,numeric"`
+	OperationId string `json:"operation_id" validate:"omitempty,uuid"`
+	Cursor      string `json:"cursor"`
+}
+
+// Statement period dates are in 02-01-2006 format, both days are included
+type AccountStatementRequestBody struct {
+	AccountId string `json:"account_id" validate:"required"`
+	From      string `json:"from" validate:"required,datetime=02-01-2006"`
+	To        string `json:"to" validate:"required,datetime=02-01-2006"`
+	Format    string `json:"format" validate:"required,oneof=pdf csv"`
+}
+
 type AccountChangeMoneyRequestBody struct {
 	AccountId string `json:"account_id" validate:"required" `
 	Money     string `json:"money" validate:"required,numeric"`
@@ -56,6 +76,14 @@ type SignUpInfo struct {
 	PassportRegistrationAddress string `json:"passport_registration_address" validate:"required" msg:"Error validation passport_registration_address"`
 	Inn                         string `json:"inn" validate:"required,number,min=20,max=20" msg:"Error validation inn"`
 	Email                       string `json:"email" validate:"required,email"`
+	// Read from multipart files, not form values
+	PassportScans []*SignUpFile
+}
+
+// Passport scan attached to sign up form
+type SignUpFile struct {
+	FileName string
+	Content  []byte
 }
 
 type PostRequestStatus struct {
@@ -65,35 +93,39 @@ type PostRequestStatus struct {
 }
 
 type HomePage struct {
-	Login                string
-	SignOutRequest       string
-	SignInPageRequest    string
-	RequestTurnOnTotp    string
-	RequestTurnOffTotp   string
-	Surname              string
-	Name                 string
-	Patronymic           string
-	INN                  string
-	PassportCode         string
-	BirthDate            string
-	BirthLocation        string
-	PickUpPoint          string
-	Authority            string
-	AuthorityDate        string
-	RegistrationAddress  string
-	CreateAccountRequest string
-	UserId               string
-	ListOfAccounts       string
-	Email                string
-	IsUseTotp            bool
+	Login                       string
+	SignOutRequest              string
+	SignInPageRequest           string
+	RequestTurnOnTotp           string
+	RequestTurnOffTotp          string
+	RequestNotificationSettings string
+	RequestNotificationInbox    string
+	Surname                     string
+	Name                        string
+	Patronymic                  string
+	INN                         string
+	PassportCode                string
+	BirthDate                   string
+	BirthLocation               string
+	PickUpPoint                 string
+	Authority                   string
+	AuthorityDate               string
+	RegistrationAddress         string
+	CreateAccountRequest        string
+	UserId                      string
+	ListOfAccounts              string
+	Email                       string
+	IsUseTotp                   bool
 }
 
 type HomePageAccountDescription struct {
 	Name                string
 	Status              string
 	Cache               string
+	Currency            string
 	AccountId           string
 	GetCreditsRequest   string
+	StatementRequest    string
 	AddCacheRequest     string
 	ReduceCacheRequest  string
 	CloseAccountRequest string
@@ -124,9 +156,59 @@ type AccountOperationCreditsData struct {
 	CIO        string
 }
 
+type AccountHistoryData struct {
+	HistoryRequest string
+	AccountId      string
+	From           string
+	To             string
+	Direction      string
+	MinAmount      string
+	MaxAmount      string
+	OperationId    string
+	Movements      string
+	NextCursor     string
+}
+
+type AccountHistoryRow struct {
+	CreatedAt   string
+	Type        string
+	Amount      string
+	Balance     string
+	OperationId string
+}
+
 type AdminPageData struct {
 	Operations           string
 	GetOperationsRequest string
+	NotificationsRequest string
+}
+
+type AdminNotificationsRequestBody struct {
+	Login    string `json:"login"`
+	Channel  string `json:"channel"`
+	Status   string `json:"status" validate:"omitempty,oneof=queued sent failed bounced"`
+	Template string `json:"template"`
+	Start    string `json:"start" validate:"omitempty,datetime=02-01-2006 15:04:05"`
+	End      string `json:"end" validate:"omitempty,datetime=02-01-2006 15:04:05"`
+}
+
+type AdminNotificationsPageData struct {
+	Deliveries           string
+	GetDeliveriesRequest string
+	AdminPageRequest     string
+}
+
+type AdminDeliveryData struct {
+	MessageId string
+	UserId    string
+	Channel   string
+	Template  string
+	Subject   string
+	Status    string
+	Attempts  int
+	LastError string
+	Created   string
+	Sent      string
 }
 
 type AdminOperationData struct {
@@ -146,6 +228,79 @@ type TotpOperationPage struct {
 	ReturnRequest  string
 }
 
+type NotificationOperationPage struct {
+	Login          string
+	SignOutRequest string
+	OperationName  string
+	Operation      string
+	ReturnRequest  string
+}
+
+type NotificationSettingsData struct {
+	OperationRequest string
+	EmailUsage       bool
+	EmailVerified    bool
+	ResendRequest    string
+	Locale           string
+	Phone            string
+	WebhookUrl       string
+	DigestMode       string
+	Timezone         string
+	QuietHoursStart  int
+	QuietHoursEnd    int
+	Preferences      string
+}
+
+type NotificationWebhookSecretData struct {
+	WebhookSecret string
+}
+
+type NotificationInboxData struct {
+	Messages string
+}
+
+type NotificationInboxRow struct {
+	OperationRequest string
+	MessageId        string
+	CreatedAt        string
+	Category         string
+	Subject          string
+	Body             string
+	IsRead           bool
+}
+
+type NotificationPreferenceRow struct {
+	Category  string
+	Channel   string
+	Value     string
+	Enabled   bool
+	Mandatory bool
+}
+
+type NotificationSettingsRequestBody struct {
+	EmailUsage      bool     `json:"email_usage"`
+	Locale          string   `json:"locale" validate:"omitempty,oneof=ru en"`
+	Phone           string   `json:"phone" validate:"omitempty,e164"`
+	WebhookUrl      string   `json:"webhook_url" validate:"omitempty,url"`
+	DigestMode      string   `json:"digest_mode" validate:"omitempty,oneof=off daily weekly"`
+	Timezone        string   `json:"timezone" validate:"omitempty,timezone"`
+	QuietHoursStart int      `json:"quiet_hours_start" validate:"min=0,max=23"`
+	QuietHoursEnd   int      `json:"quiet_hours_end" validate:"min=0,max=23"`
+	Preferences     []string `json:"preferences"`
+}
+
+type VerifyEmailRequestBody struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type DocumentDownloadRequestBody struct {
+	FileId string `json:"file_id" validate:"required,uuid"`
+}
+
+type NotificationInboxReadRequestBody struct {
+	MessageId string `json:"message_id" validate:"required,uuid"`
+}
+
 type TotpOperationData struct {
 	OperationRequest string
 }
@@ -31,6 +31,7 @@ type AccountInfo struct {
 	Name       string    `json:"name"`
 	Status     string    `json:"status"`
 	Cache      float64   `json:"cache"`
+	Currency   string    `json:"currency"`
 	BIC        string    `json:"bic"`
 	CIO        string    `json:"cio"`
 	CulcNumber string    `json:"culc_number"`
@@ -77,6 +78,10 @@ type CreateUserBody struct {
 	UserData  *CreateUserBodyUserData `json:"user_data"`
 	Passport  *CreateUserBodyPassport `json:"passport"`
 	UserEmail string                  `json:"user_email"`
+	// UUID of passport scans in file-api
+	PassportFiles []string `json:"passport_files"`
+	// UUID of new user, owner of passport scans
+	UserUuid string `json:"user_uuid"`
 }
 
 type CheckUserPasswordBody struct {
@@ -197,9 +202,152 @@ type GetUserDataResponse struct {
 }
 
 type GetUserNotifySettingsResponse struct {
-	UserId     uuid.UUID `json:"user_id"`
-	Email      string    `json:"email"`
-	EmailUsage bool      `json:"email_usage"`
+	UserId          uuid.UUID                `json:"user_id"`
+	Email           string                   `json:"email"`
+	EmailUsage      bool                     `json:"email_usage"`
+	Locale          string                   `json:"locale"`
+	Verified        bool                     `json:"email_verified"`
+	Phone           string                   `json:"phone"`
+	WebhookUrl      string                   `json:"webhook_url"`
+	DigestMode      string                   `json:"digest_mode"`
+	Timezone        string                   `json:"timezone"`
+	QuietHoursStart int                      `json:"quiet_hours_start"`
+	QuietHoursEnd   int                      `json:"quiet_hours_end"`
+	Preferences     []NotificationPreference `json:"preferences"`
+}
+
+type NotificationPreference struct {
+	Category  string `json:"category"`
+	Channel   string `json:"channel"`
+	Enabled   bool   `json:"enabled"`
+	Mandatory bool   `json:"mandatory"`
+}
+
+type UpdateUserNotifySettingsBody struct {
+	UserId            uuid.UUID                `json:"user_id"`
+	EmailNotification bool                     `json:"email_notification"`
+	Email             string                   `json:"email"`
+	Locale            string                   `json:"locale"`
+	Phone             string                   `json:"phone"`
+	WebhookUrl        string                   `json:"webhook_url"`
+	DigestMode        string                   `json:"digest_mode"`
+	Timezone          string                   `json:"timezone"`
+	QuietHoursStart   int                      `json:"quiet_hours_start"`
+	QuietHoursEnd     int                      `json:"quiet_hours_end"`
+	Preferences       []NotificationPreference `json:"preferences"`
+}
+
+type UpdateUserNotifySettingsResponse struct {
+	Status        int    `json:"status"`
+	Info          string `json:"info"`
+	WebhookSecret string `json:"webhook_secret"`
+}
+
+type NotificationInboxMessage struct {
+	MessageUuid uuid.UUID `json:"message_uuid"`
+	Category    string    `json:"category"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body"`
+	IsRead      bool      `json:"is_read"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type GetUserInboxResponse struct {
+	Messages []NotificationInboxMessage `json:"messages"`
+}
+
+type NotificationDelivery struct {
+	DeliveryUuid uuid.UUID  `json:"delivery_uuid"`
+	MessageUuid  uuid.UUID  `json:"message_uuid"`
+	UserUuid     uuid.UUID  `json:"user_uuid"`
+	Channel      string     `json:"channel"`
+	Category     string     `json:"category"`
+	Template     string     `json:"template"`
+	Subject      string     `json:"subject"`
+	Status       string     `json:"status"`
+	Attempts     int        `json:"attempts"`
+	LastError    string     `json:"last_error"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	SentAt       *time.Time `json:"sent_at"`
+}
+
+type GetNotificationDeliveriesResponse struct {
+	Deliveries []NotificationDelivery `json:"deliveries"`
+}
+
+type VerifyEmailBody struct {
+	Token string `json:"token"`
+}
+
+type ResendEmailVerificationBody struct {
+	UserId uuid.UUID `json:"user_id"`
+}
+
+type AccountHistoryMovement struct {
+	MovementUuid  uuid.UUID     `json:"movement_uuid"`
+	MovementType  string        `json:"movement_type"`
+	Amount        float64       `json:"amount"`
+	Balance       float64       `json:"balance"`
+	CreatedAt     time.Time     `json:"created_at"`
+	OperationUuid uuid.NullUUID `json:"operation_uuid"`
+}
+
+// Page of account history, newest movements first, NextCursor is empty on last page
+type GetAccountHistoryResponse struct {
+	Movements  []AccountHistoryMovement `json:"movements"`
+	NextCursor string                   `json:"next_cursor"`
+}
+
+// Body of account create_statement, OwnerId is user allowed to download statement
+type CreateAccountStatementBody struct {
+	AccId   string `json:"acc_id"`
+	OwnerId string `json:"owner_id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Format  string `json:"format"`
+}
+
+type CreateAccountStatementResponse struct {
+	FileId string `json:"file_id"`
+}
+
+// Body of file-api GetDownloadLink, names are proto ones
+type GetFileDownloadLinkBody struct {
+	OwnerUUID string `json:"OwnerUUID"`
+	ExpiresIn uint64 `json:"ExpiresIn"`
+}
+
+type GetFileDownloadLinkResponse struct {
+	UUID      string `json:"UUID"`
+	Url       string `json:"Url"`
+	ExpiresAt int64  `json:"ExpiresAt,string"`
+}
+
+// File of file-api PutFile, names are proto ones
+type PutFileBody struct {
+	BusinessType string `json:"BusinessType"`
+	FileName     string `json:"FileName"`
+	Hash         string `json:"Hash"`
+	Size         uint64 `json:"Size"`
+	OwnerUUID    string `json:"OwnerUUID"`
+	Content      []byte `json:"Content"`
+}
+
+type PutFileResponse struct {
+	UUID   string `json:"UUID"`
+	Status string `json:"Status"`
+}
+
+// grpc-gateway error of file-api
+type FileApiErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type ReadInboxMessageBody struct {
+	UserId    uuid.UUID `json:"user_id"`
+	MessageId uuid.UUID `json:"message_id"`
 }
 
 type GetAccountDataResponse struct {
@@ -210,7 +358,7 @@ type GetAccountDataResponse struct {
 	Acc_corr_number  string    `json:"acc_corr_number" db:"acc_corr_number" validate:"len=20 required"`
 	Acc_bic          string    `json:"acc_bic" db:"acc_bic" validate:"len=9 required"`
 	Acc_cio          string    `json:"acc_cio" db:"acc_cio" validate:"len=9 required"`
-	Acc_money_value  uint8     `json:"acc_money_value" db:"acc_money_value" validate:"min=0 max=1 oneof=0 1 2 3"`
+	Acc_currency     string    `json:"acc_currency" db:"acc_currency" validate:"len=3 required"`
 	Acc_money_amount float64   `json:"acc_money_amount" db:"acc_money_amount" validate:"required"`
 	Reason           string    `json:"reserve_reason" db:"reserve_reason" validate:"required"`
 }
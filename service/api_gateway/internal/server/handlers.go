@@ -3,8 +3,9 @@ package server
 import (
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	delivery "github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway/delivery/http"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway/repository"
 	"github.com/GCFactory/dbo-system/service/api_gateway/internal/api_gateway/usecase"
@@ -18,7 +19,6 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	"net/http"
 	"strings"
 )
 
@@ -41,6 +41,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	accountsServerInfo := &models.InternalServerInfo{}
 	notificationServerInfo := &models.InternalServerInfo{}
 	totpServerInfo := &models.InternalServerInfo{}
+	fileApiServerInfo := &models.InternalServerInfo{}
 
 	if regCfg, ok := s.cfg.InternalServices[ServerNameRegistration]; ok {
 		registrationServerInfo.Port = regCfg.Port
@@ -82,6 +83,14 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		totpServerInfo.TimeWaitResponse = time.Duration(time.Millisecond.Nanoseconds() * int64(totpCfg.TimeWaitResponse))
 	}
 
+	if fileApiCfg, ok := s.cfg.InternalServices[ServerNameFileApi]; ok {
+		fileApiServerInfo.Port = fileApiCfg.Port
+		fileApiServerInfo.Host = fileApiCfg.Host
+		fileApiServerInfo.NumRetry = fileApiCfg.Retry
+		fileApiServerInfo.WaitTimeRetry = time.Duration(time.Millisecond.Nanoseconds() * int64(fileApiCfg.TimeWaitRetry))
+		fileApiServerInfo.TimeWaitResponse = time.Duration(time.Millisecond.Nanoseconds() * int64(fileApiCfg.TimeWaitResponse))
+	}
+
 	//executable, err := os.Executable()
 	//if err != nil {
 	//	panic(err)
@@ -124,7 +133,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	fmt.Println(filepath.Abs(folderQrImagesPath))
 
 	apiGatewayUsecase := usecase.NewApiGatewayUseCase(s.cfg, apiGatewayRepo, registrationServerInfo, usersServerInfo,
-		accountsServerInfo, notificationServerInfo, totpServerInfo, folderGrapthImagesPath, folderQrImagesPath, s.rmqChan, s.rmqQueue)
+		accountsServerInfo, notificationServerInfo, totpServerInfo, fileApiServerInfo, folderGrapthImagesPath, folderQrImagesPath, s.rmqChan, s.rmqQueue)
 	// Init handlers
 	apiGatewayHalndlers := delivery.NewApiGatewayHandlers(s.cfg, s.logger, folderGrapthImagesPath, folderQrImagesPath, apiGatewayUsecase)
 
@@ -151,6 +160,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -167,15 +177,16 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
 	apiGatewayGroup := v1.Group("/api_gateway")
 
 	delivery.MapApiGatewayRoutes(apiGatewayGroup, apiGatewayHalndlers, mw)
 
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.RedisChecker(s.redis),
+		health.AMQPChecker(s.rmqChan),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	return nil
 }
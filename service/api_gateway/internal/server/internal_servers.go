@@ -6,4 +6,5 @@ const (
 	ServerNameAccounts     string = "accounts"
 	ServerNameNotification string = "notification"
 	ServerNameTotp         string = "totp"
+	ServerNameFileApi      string = "file_api"
 )
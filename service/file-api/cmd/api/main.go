@@ -1,22 +1,24 @@
 package main
 
 import (
+	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/db/postgres"
 	"github.com/GCFactory/dbo-system/platform/pkg/db/redis"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/storage/s3"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/scanner"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
 	server "github.com/GCFactory/dbo-system/service/file-api/internal/server/grpc"
 	"github.com/golang-migrate/migrate/v4"
 	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/opentracing/opentracing-go"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
 	"log"
 	"os"
+	"time"
 )
 
 // @description     File Api Service
@@ -78,40 +80,47 @@ func main() {
 	defer redisClient.Close()
 	appLogger.Info("Redis connected")
 
-	//awsClient, err := aws.NewAWSClient(cfg.AWS.Endpoint, cfg.AWS.AccessKey, cfg.AWS.SecretKey, cfg.AWS.UseSSL)
-	//if err != nil {
-	//	appLogger.Errorf("AWS Client init: %s", err)
-	//}
-	//appLogger.Infof("AWS Status: %s", awsClient.IsOnline())
-	//appLogger.Info("AWS S3 connected")
-
-	jaegerCfgInstance := jaegercfg.Configuration{
-		ServiceName: cfg.Jaeger.ServiceName,
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans:           cfg.Jaeger.LogSpans,
-			LocalAgentHostPort: cfg.Jaeger.Host,
-		},
+	var fileStorage file.Storage
+	switch cfg.FileStorage.Backend {
+	case "local":
+		fileStorage, err = storage.NewLocalStorage(cfg.FileStorage.LocalPath)
+		if err != nil {
+			appLogger.Fatalf("Local storage init: %s", err)
+		}
+		appLogger.Infof("Local storage at %s", cfg.FileStorage.LocalPath)
+	case "", "s3":
+		awsClient, err := s3.NewAWSClient(cfg.AWS.Endpoint, cfg.AWS.AccessKey, cfg.AWS.SecretKey, cfg.AWS.UseSSL)
+		if err != nil {
+			appLogger.Fatalf("AWS Client init: %s", err)
+		}
+		if err = s3.MakeBucket(context.Background(), awsClient, cfg.AWS.Bucket); err != nil {
+			appLogger.Fatalf("AWS bucket init: %s", err)
+		}
+		appLogger.Infof("AWS Status: %v", awsClient.IsOnline())
+		appLogger.Info("AWS S3 connected")
+		fileStorage = storage.NewS3Storage(awsClient, cfg.AWS.Bucket)
+	default:
+		appLogger.Fatalf("Unknown file storage backend: %s", cfg.FileStorage.Backend)
 	}
 
-	tracer, closer, err := jaegerCfgInstance.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
-	)
-	if err != nil {
-		log.Fatal("cannot create tracer", err)
+	var fileScanner file.Scanner
+	if cfg.FileScan.ClamdAddress != "" {
+		fileScanner = scanner.NewClamdScanner(cfg.FileScan.ClamdNetwork, cfg.FileScan.ClamdAddress, time.Second*cfg.FileScan.ClamdTimeout)
+		appLogger.Infof("Uploads are scanned by clamd at %s", cfg.FileScan.ClamdAddress)
+	} else {
+		fileScanner = scanner.NewNoopScanner()
+		appLogger.Info("Uploads malware scanning is disabled")
 	}
-	appLogger.Info("Jaeger connected")
 
-	opentracing.SetGlobalTracer(tracer)
-	defer closer.Close()
-	appLogger.Info("Opentracing connected")
+	tp, err := tracing.NewTracerProvider(context.Background(), cfg)
+	if err != nil {
+		log.Fatal("cannot create tracer provider", err)
+	}
+	defer tp.Shutdown(context.Background())
+	appLogger.Info("OpenTelemetry tracing connected")
 
 	//Run server
-	s := server.NewServer(cfg, appLogger)
+	s := server.NewServer(cfg, psqlDB, redisClient, fileStorage, fileScanner, appLogger)
 	if err = s.Run(); err != nil {
 		appLogger.Fatal(err)
 	}
@@ -21,6 +21,127 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/fileapi/deletefile/{UUID}": {
+            "delete": {
+                "summary": "Delete file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File UUID",
+                        "name": "UUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DeleteFileResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/downloadlink/{UUID}": {
+            "post": {
+                "summary": "Get time-limited download link, file with owner is given to its owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File UUID",
+                        "name": "UUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Owner and link lifetime in seconds",
+                        "name": "Link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DeliveryGetDownloadLink"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DownloadLink"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/getfile/{UUID}": {
+            "get": {
+                "summary": "Get file with content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File UUID",
+                        "name": "UUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/headfile/{UUID}": {
+            "get": {
+                "summary": "Get file metadata without content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File UUID",
+                        "name": "UUID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/putfile": {
+            "post": {
+                "summary": "Upload file, Hash is hex encoded SHA-256 of content",
+                "parameters": [
+                    {
+                        "description": "File",
+                        "name": "File",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/fileapi/ready/live": {
             "get": {
                 "summary": "Ping Service",
@@ -33,6 +154,268 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/api/v1/fileapi/signedfile/{UUID}": {
+            "get": {
+                "summary": "Download file content by link from GetDownloadLink, single Range is supported",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File UUID",
+                        "name": "UUID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Link expiry unix time",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Link signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bytes=0-1023",
+                        "name": "Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "206": {
+                        "description": "Partial Content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/upload": {
+            "post": {
+                "summary": "Start resumable upload, Hash and Size are of the whole content",
+                "parameters": [
+                    {
+                        "description": "File",
+                        "name": "File",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.UploadSession"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/upload/{UploadId}": {
+            "get": {
+                "summary": "Get upload offset to resume from",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "UploadId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.UploadSession"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "summary": "Abort upload and drop received content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "UploadId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.AbortUploadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/upload/{UploadId}/complete": {
+            "post": {
+                "summary": "Check hash of uploaded content and save file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "UploadId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/fileapi/uploadfile/{UploadId}": {
+            "put": {
+                "summary": "Write upload content from offset given by Content-Range start",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload id",
+                        "name": "UploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "bytes 0-8388607/10485760",
+                        "name": "Content-Range",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.UploadSession"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.AbortUploadResponse": {
+            "type": "object"
+        },
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DeleteFileResponse": {
+            "type": "object"
+        },
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DeliveryGetDownloadLink": {
+            "type": "object",
+            "properties": {
+                "ExpiresIn": {
+                    "description": "Link lifetime in seconds, zero means default",
+                    "type": "integer"
+                },
+                "OwnerUUID": {
+                    "description": "User requesting link, must be file owner when file has one",
+                    "type": "string"
+                },
+                "UUID": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.DownloadLink": {
+            "type": "object",
+            "properties": {
+                "ExpiresAt": {
+                    "description": "Unix time in seconds",
+                    "type": "integer"
+                },
+                "UUID": {
+                    "type": "string"
+                },
+                "Url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.File": {
+            "type": "object",
+            "properties": {
+                "BusinessType": {
+                    "type": "string"
+                },
+                "Content": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "ContentType": {
+                    "description": "Detected by content magic bytes",
+                    "type": "string"
+                },
+                "FileName": {
+                    "type": "string"
+                },
+                "Hash": {
+                    "type": "string"
+                },
+                "OwnerUUID": {
+                    "description": "Empty for service documents",
+                    "type": "string"
+                },
+                "Size": {
+                    "type": "integer"
+                },
+                "Status": {
+                    "description": "quarantined until scanned, then clean or infected, only clean files can be downloaded",
+                    "type": "string"
+                },
+                "UUID": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_GCFactory_dbo-system_service_file-api_proto_api_v1.UploadSession": {
+            "type": "object",
+            "properties": {
+                "FileUUID": {
+                    "type": "string"
+                },
+                "Offset": {
+                    "description": "Stored bytes, upload is resumed from this offset",
+                    "type": "integer"
+                },
+                "PartSize": {
+                    "type": "integer"
+                },
+                "Size": {
+                    "type": "integer"
+                },
+                "UploadId": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddUploadPart mocks base method.
+func (m *MockRepository) AddUploadPart(ctx context.Context, uploadId uuid.UUID, received int64, part models.UploadPart, hashState []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUploadPart", ctx, uploadId, received, part, hashState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUploadPart indicates an expected call of AddUploadPart.
+func (mr *MockRepositoryMockRecorder) AddUploadPart(ctx, uploadId, received, part, hashState interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUploadPart", reflect.TypeOf((*MockRepository)(nil).AddUploadPart), ctx, uploadId, received, part, hashState)
+}
+
+// CreateFile mocks base method.
+func (m *MockRepository) CreateFile(ctx context.Context, file *models.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFile", ctx, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFile indicates an expected call of CreateFile.
+func (mr *MockRepositoryMockRecorder) CreateFile(ctx, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockRepository)(nil).CreateFile), ctx, file)
+}
+
+// CreateUpload mocks base method.
+func (m *MockRepository) CreateUpload(ctx context.Context, upload *models.Upload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockRepositoryMockRecorder) CreateUpload(ctx, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockRepository)(nil).CreateUpload), ctx, upload)
+}
+
+// DeleteFile mocks base method.
+func (m *MockRepository) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockRepositoryMockRecorder) DeleteFile(ctx, fileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockRepository)(nil).DeleteFile), ctx, fileId)
+}
+
+// DeleteUpload mocks base method.
+func (m *MockRepository) DeleteUpload(ctx context.Context, uploadId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUpload", ctx, uploadId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUpload indicates an expected call of DeleteUpload.
+func (mr *MockRepositoryMockRecorder) DeleteUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUpload", reflect.TypeOf((*MockRepository)(nil).DeleteUpload), ctx, uploadId)
+}
+
+// FinishUpload mocks base method.
+func (m *MockRepository) FinishUpload(ctx context.Context, uploadId uuid.UUID, file *models.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishUpload", ctx, uploadId, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishUpload indicates an expected call of FinishUpload.
+func (mr *MockRepositoryMockRecorder) FinishUpload(ctx, uploadId, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishUpload", reflect.TypeOf((*MockRepository)(nil).FinishUpload), ctx, uploadId, file)
+}
+
+// GetFile mocks base method.
+func (m *MockRepository) GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", ctx, fileId)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockRepositoryMockRecorder) GetFile(ctx, fileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockRepository)(nil).GetFile), ctx, fileId)
+}
+
+// GetFilesByStatus mocks base method.
+func (m *MockRepository) GetFilesByStatus(ctx context.Context, status string, before time.Time, limit int) ([]*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilesByStatus", ctx, status, before, limit)
+	ret0, _ := ret[0].([]*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilesByStatus indicates an expected call of GetFilesByStatus.
+func (mr *MockRepositoryMockRecorder) GetFilesByStatus(ctx, status, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesByStatus", reflect.TypeOf((*MockRepository)(nil).GetFilesByStatus), ctx, status, before, limit)
+}
+
+// GetUpload mocks base method.
+func (m *MockRepository) GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, uploadId)
+	ret0, _ := ret[0].(*models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockRepositoryMockRecorder) GetUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockRepository)(nil).GetUpload), ctx, uploadId)
+}
+
+// UpdateFileStatus mocks base method.
+func (m *MockRepository) UpdateFileStatus(ctx context.Context, fileId uuid.UUID, status, scanResult string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileStatus", ctx, fileId, status, scanResult)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileStatus indicates an expected call of UpdateFileStatus.
+func (mr *MockRepositoryMockRecorder) UpdateFileStatus(ctx, fileId, status, scanResult interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileStatus", reflect.TypeOf((*MockRepository)(nil).UpdateFileStatus), ctx, fileId, status, scanResult)
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder struct {
+	mock *MockUseCase
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
+	mock := &MockUseCase{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AbortUpload mocks base method.
+func (m *MockUseCase) AbortUpload(ctx context.Context, uploadId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortUpload", ctx, uploadId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortUpload indicates an expected call of AbortUpload.
+func (mr *MockUseCaseMockRecorder) AbortUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortUpload", reflect.TypeOf((*MockUseCase)(nil).AbortUpload), ctx, uploadId)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockUseCase) CompleteUpload(ctx context.Context, uploadId uuid.UUID) (*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, uploadId)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockUseCaseMockRecorder) CompleteUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockUseCase)(nil).CompleteUpload), ctx, uploadId)
+}
+
+// DeleteFile mocks base method.
+func (m *MockUseCase) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockUseCaseMockRecorder) DeleteFile(ctx, fileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockUseCase)(nil).DeleteFile), ctx, fileId)
+}
+
+// GetDownloadLink mocks base method.
+func (m *MockUseCase) GetDownloadLink(ctx context.Context, fileId, ownerId uuid.UUID, expiresIn time.Duration) (*models.DownloadLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDownloadLink", ctx, fileId, ownerId, expiresIn)
+	ret0, _ := ret[0].(*models.DownloadLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDownloadLink indicates an expected call of GetDownloadLink.
+func (mr *MockUseCaseMockRecorder) GetDownloadLink(ctx, fileId, ownerId, expiresIn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownloadLink", reflect.TypeOf((*MockUseCase)(nil).GetDownloadLink), ctx, fileId, ownerId, expiresIn)
+}
+
+// GetFile mocks base method.
+func (m *MockUseCase) GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", ctx, fileId)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockUseCaseMockRecorder) GetFile(ctx, fileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockUseCase)(nil).GetFile), ctx, fileId)
+}
+
+// GetUpload mocks base method.
+func (m *MockUseCase) GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpload", ctx, uploadId)
+	ret0, _ := ret[0].(*models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpload indicates an expected call of GetUpload.
+func (mr *MockUseCaseMockRecorder) GetUpload(ctx, uploadId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpload", reflect.TypeOf((*MockUseCase)(nil).GetUpload), ctx, uploadId)
+}
+
+// HeadFile mocks base method.
+func (m *MockUseCase) HeadFile(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadFile", ctx, fileId)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadFile indicates an expected call of HeadFile.
+func (mr *MockUseCaseMockRecorder) HeadFile(ctx, fileId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadFile", reflect.TypeOf((*MockUseCase)(nil).HeadFile), ctx, fileId)
+}
+
+// OpenFile mocks base method.
+func (m *MockUseCase) OpenFile(ctx context.Context, fileId uuid.UUID, offset, length int64) (*models.File, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", ctx, fileId, offset, length)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockUseCaseMockRecorder) OpenFile(ctx, fileId, offset, length interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockUseCase)(nil).OpenFile), ctx, fileId, offset, length)
+}
+
+// PutFile mocks base method.
+func (m *MockUseCase) PutFile(ctx context.Context, file *models.File, content []byte) (*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFile", ctx, file, content)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFile indicates an expected call of PutFile.
+func (mr *MockUseCaseMockRecorder) PutFile(ctx, file, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFile", reflect.TypeOf((*MockUseCase)(nil).PutFile), ctx, file, content)
+}
+
+// RescanQuarantined mocks base method.
+func (m *MockUseCase) RescanQuarantined(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescanQuarantined", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescanQuarantined indicates an expected call of RescanQuarantined.
+func (mr *MockUseCaseMockRecorder) RescanQuarantined(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescanQuarantined", reflect.TypeOf((*MockUseCase)(nil).RescanQuarantined), ctx)
+}
+
+// StartUpload mocks base method.
+func (m *MockUseCase) StartUpload(ctx context.Context, file *models.File) (*models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartUpload", ctx, file)
+	ret0, _ := ret[0].(*models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartUpload indicates an expected call of StartUpload.
+func (mr *MockUseCaseMockRecorder) StartUpload(ctx, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartUpload", reflect.TypeOf((*MockUseCase)(nil).StartUpload), ctx, file)
+}
+
+// VerifyDownloadLink mocks base method.
+func (m *MockUseCase) VerifyDownloadLink(ctx context.Context, fileId uuid.UUID, expires int64, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDownloadLink", ctx, fileId, expires, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyDownloadLink indicates an expected call of VerifyDownloadLink.
+func (mr *MockUseCaseMockRecorder) VerifyDownloadLink(ctx, fileId, expires, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDownloadLink", reflect.TypeOf((*MockUseCase)(nil).VerifyDownloadLink), ctx, fileId, expires, signature)
+}
+
+// WriteUpload mocks base method.
+func (m *MockUseCase) WriteUpload(ctx context.Context, uploadId uuid.UUID, offset int64, content io.Reader) (*models.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteUpload", ctx, uploadId, offset, content)
+	ret0, _ := ret[0].(*models.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteUpload indicates an expected call of WriteUpload.
+func (mr *MockUseCaseMockRecorder) WriteUpload(ctx, uploadId, offset, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteUpload", reflect.TypeOf((*MockUseCase)(nil).WriteUpload), ctx, uploadId, offset, content)
+}
@@ -0,0 +1,22 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package file
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Repository interface {
+	CreateFile(ctx context.Context, file *models.File) error
+	GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, error)
+	DeleteFile(ctx context.Context, fileId uuid.UUID) error
+	UpdateFileStatus(ctx context.Context, fileId uuid.UUID, status string, scanResult string) error
+	GetFilesByStatus(ctx context.Context, status string, before time.Time, limit int) ([]*models.File, error)
+	CreateUpload(ctx context.Context, upload *models.Upload) error
+	GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error)
+	AddUploadPart(ctx context.Context, uploadId uuid.UUID, received int64, part models.UploadPart, hashState []byte) error
+	DeleteUpload(ctx context.Context, uploadId uuid.UUID) error
+	FinishUpload(ctx context.Context, uploadId uuid.UUID, file *models.File) error
+}
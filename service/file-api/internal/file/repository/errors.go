@@ -0,0 +1,21 @@
+package repository
+
+import "errors"
+
+var (
+	ErrorCreateFile = errors.New("fileRepo.CreateFile.QueryRowxContext")
+	ErrorGetFile    = errors.New("fileRepo.GetFile.QueryRowxContext")
+	ErrorDeleteFile = errors.New("fileRepo.DeleteFile.ExecContext")
+	ErrorNoFile     = errors.New("fileRepo.NoRows")
+
+	ErrorUpdateFileStatus = errors.New("fileRepo.UpdateFileStatus.ExecContext")
+	ErrorGetFiles         = errors.New("fileRepo.GetFilesByStatus.SelectContext")
+
+	ErrorCreateUpload   = errors.New("fileRepo.CreateUpload.QueryRowxContext")
+	ErrorGetUpload      = errors.New("fileRepo.GetUpload.QueryRowxContext")
+	ErrorNoUpload       = errors.New("fileRepo.Upload.NoRows")
+	ErrorAddUploadPart  = errors.New("fileRepo.AddUploadPart.ExecContext")
+	ErrorUploadConflict = errors.New("fileRepo.AddUploadPart.ReceivedChanged")
+	ErrorDeleteUpload   = errors.New("fileRepo.DeleteUpload.ExecContext")
+	ErrorFinishUpload   = errors.New("fileRepo.FinishUpload")
+)
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type fileRepo struct {
+	db *sqlx.DB
+}
+
+func (r fileRepo) CreateFile(ctx context.Context, f *models.File) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.CreateFile")
+	defer span.End()
+
+	if err := r.db.QueryRowxContext(ctx,
+		createFile,
+		&f.FileUuid,
+		&f.BusinessType,
+		&f.FileName,
+		&f.Hash,
+		&f.Size,
+		&f.OwnerUuid,
+		&f.ContentType,
+		&f.Status,
+	).StructScan(f); err != nil {
+		return ErrorCreateFile
+	}
+	return nil
+}
+
+func (r fileRepo) GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.GetFile")
+	defer span.End()
+
+	var f models.File
+
+	if err := r.db.QueryRowxContext(ctx,
+		getFile,
+		&fileId,
+	).StructScan(&f); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorNoFile
+		}
+		return nil, ErrorGetFile
+	}
+	return &f, nil
+}
+
+func (r fileRepo) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.DeleteFile")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx,
+		deleteFile,
+		&fileId,
+	)
+	if err != nil {
+		return ErrorDeleteFile
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return ErrorDeleteFile
+	} else if count == 0 {
+		return ErrorNoFile
+	}
+	return nil
+}
+
+func (r fileRepo) UpdateFileStatus(ctx context.Context, fileId uuid.UUID, status string, scanResult string) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.UpdateFileStatus")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx,
+		updateFileStatus,
+		&fileId,
+		status,
+		scanResult,
+	)
+	if err != nil {
+		return ErrorUpdateFileStatus
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return ErrorUpdateFileStatus
+	} else if count == 0 {
+		return ErrorNoFile
+	}
+	return nil
+}
+
+// GetFilesByStatus returns up to limit oldest files with given status created before given time
+func (r fileRepo) GetFilesByStatus(ctx context.Context, status string, before time.Time, limit int) ([]*models.File, error) {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.GetFilesByStatus")
+	defer span.End()
+
+	var files []*models.File
+
+	if err := r.db.SelectContext(ctx, &files, getFilesByStatus, status, before, limit); err != nil {
+		return nil, ErrorGetFiles
+	}
+	return files, nil
+}
+
+func (r fileRepo) CreateUpload(ctx context.Context, upload *models.Upload) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.CreateUpload")
+	defer span.End()
+
+	if err := r.db.QueryRowxContext(ctx,
+		createUpload,
+		&upload.UploadUuid,
+		&upload.FileUuid,
+		&upload.BusinessType,
+		&upload.FileName,
+		&upload.Hash,
+		&upload.Size,
+		&upload.OwnerUuid,
+		&upload.StorageUploadId,
+		&upload.HashState,
+	).StructScan(upload); err != nil {
+		return ErrorCreateUpload
+	}
+	return nil
+}
+
+func (r fileRepo) GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error) {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.GetUpload")
+	defer span.End()
+
+	var upload models.Upload
+
+	if err := r.db.QueryRowxContext(ctx,
+		getUpload,
+		&uploadId,
+	).StructScan(&upload); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrorNoUpload
+		}
+		return nil, ErrorGetUpload
+	}
+
+	if err := r.db.SelectContext(ctx, &upload.Parts, getUploadParts, &uploadId); err != nil {
+		return nil, ErrorGetUpload
+	}
+	return &upload, nil
+}
+
+// AddUploadPart saves stored part and moves upload offset from received to received + part size.
+// Offset is checked, so two streams can't write same upload concurrently.
+func (r fileRepo) AddUploadPart(ctx context.Context, uploadId uuid.UUID, received int64, part models.UploadPart, hashState []byte) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.AddUploadPart")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrorAddUploadPart
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		updateUploadReceived,
+		&uploadId,
+		received,
+		received+part.Size,
+		hashState,
+	)
+	if err != nil {
+		return ErrorAddUploadPart
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return ErrorAddUploadPart
+	} else if count == 0 {
+		return ErrorUploadConflict
+	}
+
+	if _, err = tx.ExecContext(ctx,
+		addUploadPart,
+		&uploadId,
+		part.PartNumber,
+		part.ETag,
+		part.Size,
+	); err != nil {
+		return ErrorAddUploadPart
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ErrorAddUploadPart
+	}
+	return nil
+}
+
+func (r fileRepo) DeleteUpload(ctx context.Context, uploadId uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.DeleteUpload")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx,
+		deleteUpload,
+		&uploadId,
+	)
+	if err != nil {
+		return ErrorDeleteUpload
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return ErrorDeleteUpload
+	} else if count == 0 {
+		return ErrorNoUpload
+	}
+	return nil
+}
+
+// FinishUpload saves file metadata and removes upload session in one transaction
+func (r fileRepo) FinishUpload(ctx context.Context, uploadId uuid.UUID, f *models.File) error {
+	ctx, span := tracing.StartSpan(ctx, "fileRepo.FinishUpload")
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return ErrorFinishUpload
+	}
+	defer tx.Rollback()
+
+	if err = tx.QueryRowxContext(ctx,
+		createFile,
+		&f.FileUuid,
+		&f.BusinessType,
+		&f.FileName,
+		&f.Hash,
+		&f.Size,
+		&f.OwnerUuid,
+		&f.ContentType,
+		&f.Status,
+	).StructScan(f); err != nil {
+		return ErrorFinishUpload
+	}
+
+	if _, err = tx.ExecContext(ctx,
+		deleteUpload,
+		&uploadId,
+	); err != nil {
+		return ErrorFinishUpload
+	}
+
+	if err = tx.Commit(); err != nil {
+		return ErrorFinishUpload
+	}
+	return nil
+}
+
+func NewFileRepository(db *sqlx.DB) file.Repository {
+	return &fileRepo{db: db}
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var fileColumns = []string{"file_uuid", "business_type", "file_name", "hash", "size", "owner_uuid", "content_type", "status", "scan_result", "created_at"}
+
+func TestFileRepo_CreateFile(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	f := &models.File{
+		FileUuid:     uuid.New(),
+		BusinessType: "passport",
+		FileName:     "passport.png",
+		Hash:         "hash",
+		Size:         13,
+		OwnerUuid:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		ContentType:  "image/png",
+		Status:       models.FileStatusQuarantined,
+	}
+	createdAt := time.Now()
+
+	mock.ExpectQuery(createFile).
+		WithArgs(f.FileUuid, f.BusinessType, f.FileName, f.Hash, f.Size, f.OwnerUuid, f.ContentType, f.Status).
+		WillReturnRows(sqlmock.NewRows(fileColumns).AddRow(f.FileUuid, f.BusinessType, f.FileName, f.Hash, f.Size, f.OwnerUuid.UUID, f.ContentType, f.Status, "", createdAt))
+
+	require.NoError(t, repo.CreateFile(context.Background(), f))
+	require.Equal(t, createdAt, f.CreatedAt)
+	require.True(t, f.OwnerUuid.Valid)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepo_GetFile(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	t.Run("Valid", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectQuery(getFile).
+			WithArgs(fileId).
+			WillReturnRows(sqlmock.NewRows(fileColumns).AddRow(fileId, "passport", "passport.png", "hash", 13, nil, "image/png", models.FileStatusClean, "", time.Now()))
+
+		result, err := repo.GetFile(context.Background(), fileId)
+		require.NoError(t, err)
+		require.Equal(t, fileId, result.FileUuid)
+		require.Equal(t, int64(13), result.Size)
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectQuery(getFile).
+			WithArgs(fileId).
+			WillReturnError(sql.ErrNoRows)
+
+		result, err := repo.GetFile(context.Background(), fileId)
+		require.Equal(t, ErrorNoFile, err)
+		require.Nil(t, result)
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepo_UpdateFileStatus(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	t.Run("Valid", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectExec(updateFileStatus).
+			WithArgs(fileId, models.FileStatusInfected, "Eicar-Test-Signature").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.UpdateFileStatus(context.Background(), fileId, models.FileStatusInfected, "Eicar-Test-Signature"))
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectExec(updateFileStatus).
+			WithArgs(fileId, models.FileStatusClean, "").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.Equal(t, ErrorNoFile, repo.UpdateFileStatus(context.Background(), fileId, models.FileStatusClean, ""))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepo_GetFilesByStatus(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	before := time.Now()
+	fileId := uuid.New()
+	mock.ExpectQuery(getFilesByStatus).
+		WithArgs(models.FileStatusQuarantined, before, 10).
+		WillReturnRows(sqlmock.NewRows(fileColumns).AddRow(fileId, "passport", "passport.png", "hash", 13, nil, "image/png", models.FileStatusQuarantined, "", before.Add(-time.Hour)))
+
+	result, err := repo.GetFilesByStatus(context.Background(), models.FileStatusQuarantined, before, 10)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, fileId, result[0].FileUuid)
+	require.Equal(t, models.FileStatusQuarantined, result[0].Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepo_DeleteFile(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	t.Run("Valid", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectExec(deleteFile).WithArgs(fileId).WillReturnResult(sqlmock.NewResult(0, 1))
+		require.NoError(t, repo.DeleteFile(context.Background(), fileId))
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		fileId := uuid.New()
+		mock.ExpectExec(deleteFile).WithArgs(fileId).WillReturnResult(sqlmock.NewResult(0, 0))
+		require.Equal(t, ErrorNoFile, repo.DeleteFile(context.Background(), fileId))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFileRepo_AddUploadPart(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewFileRepository(sqlxDB)
+
+	uploadId := uuid.New()
+	part := models.UploadPart{UploadUuid: uploadId, PartNumber: 2, ETag: "etag", Size: 10}
+	state := []byte("state")
+
+	t.Run("Valid", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateUploadReceived).WithArgs(uploadId, int64(10), int64(20), state).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(addUploadPart).WithArgs(uploadId, part.PartNumber, part.ETag, part.Size).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.AddUploadPart(context.Background(), uploadId, 10, part, state))
+	})
+	t.Run("Conflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateUploadReceived).WithArgs(uploadId, int64(10), int64(20), state).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		require.Equal(t, ErrorUploadConflict, repo.AddUploadPart(context.Background(), uploadId, 10, part, state))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,22 @@
+package repository
+
+const (
+	createFile = `INSERT INTO file (file_uuid, business_type, file_name, hash, size, owner_uuid, content_type, status, scan_result, created_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, '', now())
+						RETURNING *`
+	getFile          = `SELECT * FROM file WHERE file_uuid = $1`
+	deleteFile       = `DELETE FROM file WHERE file_uuid = $1`
+	updateFileStatus = `UPDATE file SET status = $2, scan_result = $3 WHERE file_uuid = $1`
+	getFilesByStatus = `SELECT * FROM file WHERE status = $1 AND created_at < $2 ORDER BY created_at LIMIT $3`
+	createUpload     = `INSERT INTO file_upload (upload_uuid, file_uuid, business_type, file_name, hash, size, owner_uuid, storage_upload_id, received, hash_state, created_at, updated_at)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0, $9, now(), now())
+						RETURNING *`
+	getUpload      = `SELECT * FROM file_upload WHERE upload_uuid = $1`
+	getUploadParts = `SELECT * FROM file_upload_part WHERE upload_uuid = $1 ORDER BY part_number`
+	addUploadPart  = `INSERT INTO file_upload_part (upload_uuid, part_number, etag, size)
+						VALUES ($1, $2, $3, $4)
+						ON CONFLICT (upload_uuid, part_number) DO UPDATE SET etag = EXCLUDED.etag, size = EXCLUDED.size`
+	updateUploadReceived = `UPDATE file_upload SET received = $3, hash_state = $4, updated_at = now()
+						WHERE upload_uuid = $1 AND received = $2`
+	deleteUpload = `DELETE FROM file_upload WHERE upload_uuid = $1`
+)
@@ -0,0 +1,12 @@
+//go:generate mockgen -source scanner.go -destination mock/scanner_mock.go -package mock
+package file
+
+import (
+	"context"
+	"io"
+)
+
+// Scanner checks file content for malware, threat is name of found one and empty for clean content
+type Scanner interface {
+	Scan(ctx context.Context, content io.Reader) (threat string, err error)
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	clamdChunkSize = 64 << 10
+	clamdClean     = "stream: OK"
+	clamdFound     = " FOUND"
+	clamdStream    = "stream: "
+)
+
+// clamd client streaming content with INSTREAM command
+type clamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func (s clamdScanner) Scan(ctx context.Context, content io.Reader) (string, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if err = s.stream(conn, content); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	reply = strings.TrimRight(reply, "\x00\n")
+
+	switch {
+	case reply == clamdClean:
+		return "", nil
+	case strings.HasPrefix(reply, clamdStream) && strings.HasSuffix(reply, clamdFound):
+		return strings.TrimSuffix(strings.TrimPrefix(reply, clamdStream), clamdFound), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrorClamdResponse, reply)
+	}
+}
+
+// stream sends content as length prefixed chunks ended by zero length one
+func (s clamdScanner) stream(conn net.Conn, content io.Reader) error {
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	chunk := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(content, chunk[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(chunk[:4], uint32(n))
+			if _, writeErr := conn.Write(chunk[:4+n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+func NewClamdScanner(network string, address string, timeout time.Duration) file.Scanner {
+	return clamdScanner{network: network, address: address, timeout: timeout}
+}
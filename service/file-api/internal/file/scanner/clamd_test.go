@@ -0,0 +1,96 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd answers INSTREAM commands, content containing EICAR string is infected
+func fakeClamd(t *testing.T, reply func(content []byte) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				if command, err := reader.ReadString(0); err != nil || command != "zINSTREAM\x00" {
+					return
+				}
+
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, reader, int64(size)); err != nil {
+						return
+					}
+				}
+				conn.Write([]byte(reply(content.Bytes()) + "\x00"))
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestClamdScanner_Scan(t *testing.T) {
+	address := fakeClamd(t, func(content []byte) string {
+		switch {
+		case bytes.Contains(content, []byte(eicar)):
+			return "stream: Eicar-Test-Signature FOUND"
+		case len(content) == 0:
+			return "INSTREAM size limit exceeded. ERROR"
+		default:
+			return "stream: OK"
+		}
+	})
+	scanner := NewClamdScanner("tcp", address, time.Second)
+
+	t.Run("Clean", func(t *testing.T) {
+		// Content is longer than one chunk
+		threat, err := scanner.Scan(context.Background(), strings.NewReader(strings.Repeat("clean content", clamdChunkSize)))
+		require.NoError(t, err)
+		require.Empty(t, threat)
+	})
+	t.Run("Infected", func(t *testing.T) {
+		threat, err := scanner.Scan(context.Background(), strings.NewReader(eicar))
+		require.NoError(t, err)
+		require.Equal(t, "Eicar-Test-Signature", threat)
+	})
+	t.Run("Error", func(t *testing.T) {
+		_, err := scanner.Scan(context.Background(), strings.NewReader(""))
+		require.ErrorIs(t, err, ErrorClamdResponse)
+	})
+}
+
+func TestClamdScanner_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = NewClamdScanner("tcp", address, time.Second).Scan(context.Background(), strings.NewReader("content"))
+	require.Error(t, err)
+}
@@ -0,0 +1,7 @@
+package scanner
+
+import "errors"
+
+var (
+	ErrorClamdResponse = errors.New("Unexpected clamd response")
+)
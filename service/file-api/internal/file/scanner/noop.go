@@ -0,0 +1,18 @@
+package scanner
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"io"
+)
+
+// Scanner for installs without antivirus, every content is clean
+type noopScanner struct{}
+
+func (s noopScanner) Scan(ctx context.Context, content io.Reader) (string, error) {
+	return "", nil
+}
+
+func NewNoopScanner() file.Scanner {
+	return noopScanner{}
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source storage.go -destination mock/storage_mock.go -package mock
+package file
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"io"
+	"time"
+)
+
+// Storage keeps file content by key
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange returns length bytes of content starting from offset
+	GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+
+	// Multipart upload, content is visible by key only after CompleteUpload
+	CreateUpload(ctx context.Context, key string) (string, error)
+	UploadPart(ctx context.Context, key string, uploadId string, partNumber int, content io.Reader, size int64) (string, error)
+	CompleteUpload(ctx context.Context, key string, uploadId string, parts []models.UploadPart) error
+	AbortUpload(ctx context.Context, key string, uploadId string) error
+}
+
+// Presigner is implemented by storages able to give clients direct time-limited content links
+type Presigner interface {
+	PresignGet(ctx context.Context, key string, fileName string, expires time.Duration) (string, error)
+}
@@ -0,0 +1,10 @@
+package storage
+
+import "errors"
+
+var (
+	ErrorObjectNotFound = errors.New("Object not found")
+	ErrorUploadNotFound = errors.New("Multipart upload not found")
+	ErrorInvalidRange   = errors.New("Invalid content range")
+	ErrorInvalidKey     = errors.New("Invalid object key")
+)
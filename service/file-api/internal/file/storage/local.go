@@ -0,0 +1,377 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	localObjectsDir = "objects"
+	localKeysDir    = "keys"
+	localUploadsDir = "uploads"
+	localTmpDir     = "tmp"
+	localRefsSuffix = ".refs"
+	localDirMode    = 0o750
+	localFileMode   = 0o640
+)
+
+// Local filesystem storage for installs without S3.
+// Content is kept once per SHA-256 in objects/ab/cd/<hash>, keys/<key> holds hash of key content
+// and objects/ab/cd/<hash>.refs/<key> marks object usage, so object is removed with its last key.
+// Every file is written to temporary one, fsynced and renamed into place, so readers never see partial content.
+type localStorage struct {
+	mu   sync.Mutex
+	root string
+}
+
+func (s *localStorage) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	if !validKey(key) {
+		return ErrorInvalidKey
+	}
+
+	tmpPath, hash, err := s.writeTemp(filepath.Join(s.root, localTmpDir), content)
+	if err != nil {
+		return err
+	}
+
+	return s.link(key, tmpPath, hash)
+}
+
+func (s *localStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !validKey(key) {
+		return nil, ErrorInvalidKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash, err := s.readKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Opened file stays readable even if key is deleted meanwhile
+	object, err := os.Open(s.objectPath(hash))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrorObjectNotFound
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+func (s *localStorage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	object, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	objectFile := object.(*os.File)
+
+	info, err := objectFile.Stat()
+	if err != nil {
+		objectFile.Close()
+		return nil, err
+	}
+	if offset < 0 || length < 0 || offset+length > info.Size() {
+		objectFile.Close()
+		return nil, ErrorInvalidRange
+	}
+
+	return &rangeReader{
+		Reader: io.NewSectionReader(objectFile, offset, length),
+		Closer: objectFile,
+	}, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return ErrorInvalidKey
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash, err := s.readKey(key)
+	if err != nil {
+		if errors.Is(err, ErrorObjectNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err = os.Remove(s.keyPath(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err = syncDir(filepath.Join(s.root, localKeysDir)); err != nil {
+		return err
+	}
+
+	return s.unref(key, hash)
+}
+
+func (s *localStorage) CreateUpload(ctx context.Context, key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrorInvalidKey
+	}
+
+	uploadId := uuid.NewString()
+	if err := os.Mkdir(s.uploadPath(uploadId), localDirMode); err != nil {
+		return "", err
+	}
+	return uploadId, nil
+}
+
+func (s *localStorage) UploadPart(ctx context.Context, key string, uploadId string, partNumber int, content io.Reader, size int64) (string, error) {
+	uploadPath, err := s.existingUpload(uploadId)
+	if err != nil {
+		return "", err
+	}
+
+	tmpPath, hash, err := s.writeTemp(uploadPath, content)
+	if err != nil {
+		return "", err
+	}
+
+	// Part can be uploaded again after broken stream, the last one wins
+	if err = os.Rename(tmpPath, filepath.Join(uploadPath, strconv.Itoa(partNumber))); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err = syncDir(uploadPath); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+func (s *localStorage) CompleteUpload(ctx context.Context, key string, uploadId string, parts []models.UploadPart) error {
+	if !validKey(key) {
+		return ErrorInvalidKey
+	}
+
+	uploadPath, err := s.existingUpload(uploadId)
+	if err != nil {
+		return err
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		partFile, err := os.Open(filepath.Join(uploadPath, strconv.Itoa(part.PartNumber)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return ErrorUploadNotFound
+			}
+			return err
+		}
+		defer partFile.Close()
+		readers = append(readers, partFile)
+	}
+
+	tmpPath, hash, err := s.writeTemp(filepath.Join(s.root, localTmpDir), io.MultiReader(readers...))
+	if err != nil {
+		return err
+	}
+
+	if err = s.link(key, tmpPath, hash); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(uploadPath)
+}
+
+func (s *localStorage) AbortUpload(ctx context.Context, key string, uploadId string) error {
+	if !validKey(uploadId) {
+		return ErrorUploadNotFound
+	}
+	return os.RemoveAll(s.uploadPath(uploadId))
+}
+
+// link moves temporary file with content of given hash to objects and points key to it
+func (s *localStorage) link(key string, tmpPath string, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objectPath := s.objectPath(hash)
+	objectDir := filepath.Dir(objectPath)
+	if err := os.MkdirAll(objectDir, localDirMode); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if _, err := os.Stat(objectPath); err == nil {
+		os.Remove(tmpPath)
+	} else if os.IsNotExist(err) {
+		if err = os.Rename(tmpPath, objectPath); err != nil {
+			os.Remove(tmpPath)
+			return err
+		}
+	} else {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	refsPath := objectPath + localRefsSuffix
+	if err := os.MkdirAll(refsPath, localDirMode); err != nil {
+		return err
+	}
+	ref, err := os.OpenFile(filepath.Join(refsPath, key), os.O_CREATE|os.O_WRONLY, localFileMode)
+	if err != nil {
+		return err
+	}
+	if err = ref.Close(); err != nil {
+		return err
+	}
+	if err = syncDir(refsPath); err != nil {
+		return err
+	}
+	if err = syncDir(objectDir); err != nil {
+		return err
+	}
+
+	oldHash, err := s.readKey(key)
+	if err != nil && !errors.Is(err, ErrorObjectNotFound) {
+		return err
+	}
+
+	keysDir := filepath.Join(s.root, localKeysDir)
+	tmpKeyPath, _, err := s.writeTemp(keysDir, strings.NewReader(hash))
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmpKeyPath, s.keyPath(key)); err != nil {
+		os.Remove(tmpKeyPath)
+		return err
+	}
+	if err = syncDir(keysDir); err != nil {
+		return err
+	}
+
+	if oldHash != "" && oldHash != hash {
+		return s.unref(key, oldHash)
+	}
+	return nil
+}
+
+// unref removes key mark of object and object itself when it was the last key
+func (s *localStorage) unref(key string, hash string) error {
+	objectPath := s.objectPath(hash)
+	refsPath := objectPath + localRefsSuffix
+
+	if err := os.Remove(filepath.Join(refsPath, key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Directory is removed only when it's empty, so object is still used otherwise
+	if err := os.Remove(refsPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return syncDir(refsPath)
+	}
+
+	if err := os.Remove(objectPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return syncDir(filepath.Dir(objectPath))
+}
+
+// writeTemp writes content to new fsynced file in dir and returns its path and hex SHA-256 of content
+func (s *localStorage) writeTemp(dir string, content io.Reader) (string, string, error) {
+	tmpFile, err := os.CreateTemp(dir, "tmp-*")
+	if err != nil {
+		return "", "", err
+	}
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmpFile, hasher), content)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpFile.Name())
+		return "", "", err
+	}
+
+	return tmpFile.Name(), hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func (s *localStorage) readKey(key string) (string, error) {
+	hash, err := os.ReadFile(s.keyPath(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrorObjectNotFound
+		}
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (s *localStorage) existingUpload(uploadId string) (string, error) {
+	if !validKey(uploadId) {
+		return "", ErrorUploadNotFound
+	}
+
+	uploadPath := s.uploadPath(uploadId)
+	if _, err := os.Stat(uploadPath); err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrorUploadNotFound
+		}
+		return "", err
+	}
+	return uploadPath, nil
+}
+
+func (s *localStorage) objectPath(hash string) string {
+	return filepath.Join(s.root, localObjectsDir, hash[0:2], hash[2:4], hash)
+}
+
+func (s *localStorage) keyPath(key string) string {
+	return filepath.Join(s.root, localKeysDir, key)
+}
+
+func (s *localStorage) uploadPath(uploadId string) string {
+	return filepath.Join(s.root, localUploadsDir, uploadId)
+}
+
+// validKey checks key can be used as file name
+func validKey(key string) bool {
+	return key != "" && key != "." && key != ".." && !strings.ContainsAny(key, `/\`)
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+type rangeReader struct {
+	io.Reader
+	io.Closer
+}
+
+func NewLocalStorage(root string) (file.Storage, error) {
+	for _, dir := range []string{localObjectsDir, localKeysDir, localUploadsDir, localTmpDir} {
+		if err := os.MkdirAll(filepath.Join(root, dir), localDirMode); err != nil {
+			return nil, err
+		}
+	}
+	return &localStorage{root: root}, nil
+}
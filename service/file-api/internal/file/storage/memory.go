@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"sync"
+)
+
+// In-memory storage for tests and local runs without S3
+type memoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+	uploads map[string]map[int][]byte
+}
+
+func (s *memoryStorage) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrorObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryStorage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, ErrorObjectNotFound
+	}
+	if offset < 0 || length < 0 || offset+length > int64(len(data)) {
+		return nil, ErrorInvalidRange
+	}
+	return io.NopCloser(bytes.NewReader(data[offset : offset+length])), nil
+}
+
+func (s *memoryStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *memoryStorage) CreateUpload(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uploadId := uuid.NewString()
+	s.uploads[uploadId] = make(map[int][]byte)
+	return uploadId, nil
+}
+
+func (s *memoryStorage) UploadPart(ctx context.Context, key string, uploadId string, partNumber int, content io.Reader, size int64) (string, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts, ok := s.uploads[uploadId]
+	if !ok {
+		return "", ErrorUploadNotFound
+	}
+	parts[partNumber] = data
+
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (s *memoryStorage) CompleteUpload(ctx context.Context, key string, uploadId string, parts []models.UploadPart) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.uploads[uploadId]
+	if !ok {
+		return ErrorUploadNotFound
+	}
+
+	var content []byte
+	for _, part := range parts {
+		data, ok := stored[part.PartNumber]
+		if !ok {
+			return ErrorUploadNotFound
+		}
+		content = append(content, data...)
+	}
+
+	s.objects[key] = content
+	delete(s.uploads, uploadId)
+	return nil
+}
+
+func (s *memoryStorage) AbortUpload(ctx context.Context, key string, uploadId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploads, uploadId)
+	return nil
+}
+
+func NewMemoryStorage() file.Storage {
+	return &memoryStorage{objects: make(map[string][]byte), uploads: make(map[string]map[int][]byte)}
+}
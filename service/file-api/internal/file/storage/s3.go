@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	minio "github.com/minio/minio-go/v7"
+	"io"
+	"mime"
+	"net/url"
+	"time"
+)
+
+const (
+	s3NoSuchKey    = "NoSuchKey"
+	s3InvalidRange = "InvalidRange"
+	s3NoSuchUpload = "NoSuchUpload"
+)
+
+type s3Storage struct {
+	client *minio.Client
+	core   minio.Core
+	bucket string
+}
+
+func (s s3Storage) Put(ctx context.Context, key string, content io.Reader, size int64) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, content, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	return err
+}
+
+func (s s3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// GetObject is lazy, missing object is reported on first request
+	if _, err = object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == s3NoSuchKey {
+			return nil, ErrorObjectNotFound
+		}
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (s s3Storage) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	if length == 0 {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+length-1); err != nil {
+		return nil, err
+	}
+
+	object, _, _, err := s.core.GetObject(ctx, s.bucket, key, opts)
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case s3NoSuchKey:
+			return nil, ErrorObjectNotFound
+		case s3InvalidRange:
+			return nil, ErrorInvalidRange
+		}
+		return nil, err
+	}
+	return object, nil
+}
+
+func (s s3Storage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s s3Storage) CreateUpload(ctx context.Context, key string) (string, error) {
+	return s.core.NewMultipartUpload(ctx, s.bucket, key, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+}
+
+func (s s3Storage) UploadPart(ctx context.Context, key string, uploadId string, partNumber int, content io.Reader, size int64) (string, error) {
+	part, err := s.core.PutObjectPart(ctx, s.bucket, key, uploadId, partNumber, content, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == s3NoSuchUpload {
+			return "", ErrorUploadNotFound
+		}
+		return "", err
+	}
+	return part.ETag, nil
+}
+
+func (s s3Storage) CompleteUpload(ctx context.Context, key string, uploadId string, parts []models.UploadPart) error {
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
+		})
+	}
+
+	_, err := s.core.CompleteMultipartUpload(ctx, s.bucket, key, uploadId, completeParts, minio.PutObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code == s3NoSuchUpload {
+		return ErrorUploadNotFound
+	}
+	return err
+}
+
+func (s s3Storage) AbortUpload(ctx context.Context, key string, uploadId string) error {
+	err := s.core.AbortMultipartUpload(ctx, s.bucket, key, uploadId)
+	// Upload is already gone, as with Delete it's not an error
+	if err != nil && minio.ToErrorResponse(err).Code == s3NoSuchUpload {
+		return nil
+	}
+	return err
+}
+
+// PresignGet makes link downloading object as attachment with given file name
+func (s s3Storage) PresignGet(ctx context.Context, key string, fileName string, expires time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+
+	link, err := s.client.PresignedGetObject(ctx, s.bucket, key, expires, params)
+	if err != nil {
+		return "", err
+	}
+	return link.String(), nil
+}
+
+func NewS3Storage(client *minio.Client, bucket string) file.Storage {
+	return &s3Storage{client: client, core: minio.Core{Client: client}, bucket: bucket}
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/GCFactory/dbo-system/platform/pkg/storage/s3"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Conformance suite, every backend must pass it
+
+func TestMemoryStorage(t *testing.T) {
+	t.Parallel()
+
+	testStorage(t, func(t *testing.T) file.Storage {
+		return NewMemoryStorage()
+	})
+}
+
+func TestLocalStorage(t *testing.T) {
+	t.Parallel()
+
+	testStorage(t, func(t *testing.T) file.Storage {
+		storage, err := NewLocalStorage(t.TempDir())
+		require.NoError(t, err)
+		return storage
+	})
+}
+
+// S3 suite runs against MinIO given by FILE_API_TEST_S3_ENDPOINT, e.g. localhost:9000 with minioadmin credentials
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("FILE_API_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("FILE_API_TEST_S3_ENDPOINT is not set")
+	}
+
+	client, err := s3.NewAWSClient(endpoint, os.Getenv("FILE_API_TEST_S3_ACCESS_KEY"), os.Getenv("FILE_API_TEST_S3_SECRET_KEY"), false)
+	require.NoError(t, err)
+
+	testStorage(t, func(t *testing.T) file.Storage {
+		bucket := "file-api-test-" + uuid.NewString()
+		require.NoError(t, s3.MakeBucket(context.Background(), client, bucket))
+		return NewS3Storage(client, bucket)
+	})
+}
+
+func TestLocalStorage_Layout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	root := t.TempDir()
+	storage, err := NewLocalStorage(root)
+	require.NoError(t, err)
+
+	content := []byte("same content")
+	require.NoError(t, storage.Put(ctx, "first", bytes.NewReader(content), int64(len(content))))
+	require.NoError(t, storage.Put(ctx, "second", bytes.NewReader(content), int64(len(content))))
+
+	t.Run("ContentStoredOnce", func(t *testing.T) {
+		sum := sha256.Sum256(content)
+		hash := hex.EncodeToString(sum[:])
+		objects, err := filepath.Glob(filepath.Join(root, localObjectsDir, "*", "*", "*"))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			filepath.Join(root, localObjectsDir, hash[0:2], hash[2:4], hash),
+			filepath.Join(root, localObjectsDir, hash[0:2], hash[2:4], hash+localRefsSuffix),
+		}, objects)
+	})
+
+	t.Run("NoTemporaryFiles", func(t *testing.T) {
+		tmp, err := os.ReadDir(filepath.Join(root, localTmpDir))
+		require.NoError(t, err)
+		require.Empty(t, tmp)
+	})
+
+	t.Run("ObjectRemovedWithLastKey", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, "first"))
+		require.NoError(t, storage.Delete(ctx, "second"))
+
+		objects, err := filepath.Glob(filepath.Join(root, localObjectsDir, "*", "*", "*"))
+		require.NoError(t, err)
+		require.Empty(t, objects)
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		err := storage.Put(ctx, "../escape", bytes.NewReader(content), int64(len(content)))
+		require.ErrorIs(t, err, ErrorInvalidKey)
+	})
+}
+
+func testStorage(t *testing.T, newStorage func(t *testing.T) file.Storage) {
+	ctx := context.Background()
+	content := []byte("storage test content")
+
+	put := func(t *testing.T, storage file.Storage, key string, content []byte) {
+		require.NoError(t, storage.Put(ctx, key, bytes.NewReader(content), int64(len(content))))
+	}
+	// read(t)(storage.Get(...)) returns whole object content
+	read := func(t *testing.T) func(object io.ReadCloser, err error) []byte {
+		return func(object io.ReadCloser, err error) []byte {
+			require.NoError(t, err)
+			defer object.Close()
+			data, err := io.ReadAll(object)
+			require.NoError(t, err)
+			return data
+		}
+	}
+
+	t.Run("PutGet", func(t *testing.T) {
+		storage := newStorage(t)
+		put(t, storage, "key", content)
+
+		require.Equal(t, content, read(t)(storage.Get(ctx, "key")))
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		storage := newStorage(t)
+		put(t, storage, "key", content)
+		put(t, storage, "key", []byte("new content"))
+
+		require.Equal(t, []byte("new content"), read(t)(storage.Get(ctx, "key")))
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		storage := newStorage(t)
+
+		_, err := storage.Get(ctx, "missing")
+		require.ErrorIs(t, err, ErrorObjectNotFound)
+
+		_, err = storage.GetRange(ctx, "missing", 0, 1)
+		require.ErrorIs(t, err, ErrorObjectNotFound)
+	})
+
+	t.Run("GetRange", func(t *testing.T) {
+		storage := newStorage(t)
+		put(t, storage, "key", content)
+
+		require.Equal(t, content[8:12], read(t)(storage.GetRange(ctx, "key", 8, 4)))
+		require.Equal(t, content, read(t)(storage.GetRange(ctx, "key", 0, int64(len(content)))))
+
+		_, err := storage.GetRange(ctx, "key", int64(len(content))+1, 4)
+		require.ErrorIs(t, err, ErrorInvalidRange)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		storage := newStorage(t)
+		put(t, storage, "key", content)
+
+		require.NoError(t, storage.Delete(ctx, "key"))
+		_, err := storage.Get(ctx, "key")
+		require.ErrorIs(t, err, ErrorObjectNotFound)
+
+		// Repeated delete is not an error
+		require.NoError(t, storage.Delete(ctx, "key"))
+	})
+
+	t.Run("SameContent", func(t *testing.T) {
+		storage := newStorage(t)
+		put(t, storage, "first", content)
+		put(t, storage, "second", content)
+
+		require.NoError(t, storage.Delete(ctx, "first"))
+		require.Equal(t, content, read(t)(storage.Get(ctx, "second")))
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		storage := newStorage(t)
+
+		// S3 requires every part except the last one to be at least 5MiB
+		first := bytes.Repeat([]byte{'a'}, 5<<20)
+		second := []byte("last part")
+
+		uploadId, err := storage.CreateUpload(ctx, "key")
+		require.NoError(t, err)
+
+		secondETag, err := storage.UploadPart(ctx, "key", uploadId, 2, bytes.NewReader(second), int64(len(second)))
+		require.NoError(t, err)
+		firstETag, err := storage.UploadPart(ctx, "key", uploadId, 1, bytes.NewReader(first), int64(len(first)))
+		require.NoError(t, err)
+
+		_, err = storage.Get(ctx, "key")
+		require.ErrorIs(t, err, ErrorObjectNotFound)
+
+		require.NoError(t, storage.CompleteUpload(ctx, "key", uploadId, []models.UploadPart{
+			{PartNumber: 1, ETag: firstETag, Size: int64(len(first))},
+			{PartNumber: 2, ETag: secondETag, Size: int64(len(second))},
+		}))
+
+		require.Equal(t, append(first, second...), read(t)(storage.Get(ctx, "key")))
+	})
+
+	t.Run("AbortUpload", func(t *testing.T) {
+		storage := newStorage(t)
+
+		uploadId, err := storage.CreateUpload(ctx, "key")
+		require.NoError(t, err)
+		require.NoError(t, storage.AbortUpload(ctx, "key", uploadId))
+
+		_, err = storage.UploadPart(ctx, "key", uploadId, 1, bytes.NewReader(content), int64(len(content)))
+		require.ErrorIs(t, err, ErrorUploadNotFound)
+	})
+}
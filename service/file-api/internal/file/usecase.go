@@ -0,0 +1,26 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package file
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"time"
+)
+
+type UseCase interface {
+	PutFile(ctx context.Context, file *models.File, content []byte) (*models.File, error)
+	GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, []byte, error)
+	HeadFile(ctx context.Context, fileId uuid.UUID) (*models.File, error)
+	DeleteFile(ctx context.Context, fileId uuid.UUID) error
+	OpenFile(ctx context.Context, fileId uuid.UUID, offset int64, length int64) (*models.File, io.ReadCloser, error)
+	StartUpload(ctx context.Context, file *models.File) (*models.Upload, error)
+	GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error)
+	WriteUpload(ctx context.Context, uploadId uuid.UUID, offset int64, content io.Reader) (*models.Upload, error)
+	CompleteUpload(ctx context.Context, uploadId uuid.UUID) (*models.File, error)
+	AbortUpload(ctx context.Context, uploadId uuid.UUID) error
+	GetDownloadLink(ctx context.Context, fileId uuid.UUID, ownerId uuid.UUID, expiresIn time.Duration) (*models.DownloadLink, error)
+	VerifyDownloadLink(ctx context.Context, fileId uuid.UUID, expires int64, signature string) error
+	RescanQuarantined(ctx context.Context) error
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrorFileNotFound     = errors.New("File not found")
+	ErrorEmptyContent     = errors.New("File content is empty")
+	ErrorNoFileName       = errors.New("File name is empty")
+	ErrorNoBusinessType   = errors.New("Business type is empty")
+	ErrorHashMismatch     = errors.New("File hash doesn't match content")
+	ErrorSizeMismatch     = errors.New("File size doesn't match content")
+	ErrorPutContent       = errors.New("fileUC.storage.Put")
+	ErrorGetContent       = errors.New("fileUC.storage.Get")
+	ErrorDeleteContent    = errors.New("fileUC.storage.Delete")
+	ErrorContentCorrupted = errors.New("Stored file content doesn't match its hash")
+	ErrorInvalidRange     = errors.New("Requested range is out of file")
+	ErrorInvalidHash      = errors.New("File hash must be hex encoded SHA-256")
+	ErrorUploadNotFound   = errors.New("Upload not found")
+	ErrorInvalidOffset    = errors.New("Upload offset doesn't match stored content")
+	ErrorUploadIncomplete = errors.New("Upload content isn't fully received")
+	ErrorUploadConflict   = errors.New("Upload is written by another request")
+	ErrorHashState        = errors.New("fileUC.hash.State")
+	ErrorNotFileOwner     = errors.New("File belongs to another user")
+	ErrorLinksDisabled    = errors.New("Download links are not configured")
+	ErrorPresignLink      = errors.New("fileUC.storage.PresignGet")
+	ErrorInvalidSignature = errors.New("Invalid download link signature")
+	ErrorLinkExpired      = errors.New("Download link is expired")
+
+	ErrorBusinessTypeNotAllowed = errors.New("Business type isn't allowed for upload")
+	ErrorFileTooLarge           = errors.New("File is too large for its business type")
+	ErrorContentTypeNotAllowed  = errors.New("File content type isn't allowed for its business type")
+	ErrorFileQuarantined        = errors.New("File isn't scanned yet")
+	ErrorFileInfected           = errors.New("File is infected")
+)
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// HashContent returns hex encoded SHA-256 of content
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// validHash checks hash is hex encoded SHA-256
+func validHash(value string) bool {
+	decoded, err := hex.DecodeString(value)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// Upload hash is kept between streams as marshaled SHA-256 state
+func newHashState() ([]byte, error) {
+	return sha256.New().(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+func restoreHash(state []byte) (hash.Hash, error) {
+	h := sha256.New()
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func hashState(h hash.Hash) ([]byte, error) {
+	return h.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+// hashReader hashes content while it's read and checks it on io.EOF
+type hashReader struct {
+	reader   io.ReadCloser
+	hash     hash.Hash
+	expected string
+}
+
+func (r *hashReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(r.hash.Sum(nil)) != r.expected {
+		return n, ErrorContentCorrupted
+	}
+	return n, err
+}
+
+func (r *hashReader) Close() error {
+	return r.reader.Close()
+}
+
+func newHashReader(reader io.ReadCloser, expected string) io.ReadCloser {
+	return &hashReader{reader: reader, hash: sha256.New(), expected: expected}
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignedLinkPath is file-api path serving HMAC signed links
+	SignedLinkPath = "/signedfile/"
+
+	DefaultLinkTTL    = 15 * time.Minute
+	DefaultLinkMaxTTL = 24 * time.Hour
+)
+
+// GetDownloadLink returns time-limited link to file content for its owner, files without owner have no links.
+// Storage presigned link is used when storage supports it, otherwise link to SignedLinkPath is signed by config LinkSecret.
+func (f fileUC) GetDownloadLink(ctx context.Context, fileId uuid.UUID, ownerId uuid.UUID, expiresIn time.Duration) (*models.DownloadLink, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.GetDownloadLink")
+	defer span.End()
+
+	fileInfo, err := f.HeadFile(ctxWithTrace, fileId)
+	if err != nil {
+		return nil, err
+	}
+	if !fileInfo.OwnerUuid.Valid || fileInfo.OwnerUuid.UUID != ownerId {
+		return nil, ErrorNotFileOwner
+	}
+	if err = CheckDownloadable(fileInfo); err != nil {
+		return nil, err
+	}
+
+	expiresIn = f.linkTTL(expiresIn)
+	expiresAt := time.Now().Add(expiresIn).Truncate(time.Second)
+
+	if presigner, ok := f.storage.(file.Presigner); ok {
+		link, err := presigner.PresignGet(ctxWithTrace, fileId.String(), fileInfo.FileName, expiresIn)
+		if err != nil {
+			f.logger.Errorf("Presign file %s link: %v", fileId, err)
+			return nil, ErrorPresignLink
+		}
+		return &models.DownloadLink{FileUuid: fileId, Url: link, ExpiresAt: expiresAt}, nil
+	}
+
+	if f.cfg.FileStorage.LinkSecret == "" {
+		return nil, ErrorLinksDisabled
+	}
+
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", f.signLink(fileId, expires))
+
+	return &models.DownloadLink{
+		FileUuid:  fileId,
+		Url:       strings.TrimSuffix(f.cfg.FileStorage.LinkBaseUrl, "/") + SignedLinkPath + fileId.String() + "?" + query.Encode(),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyDownloadLink checks signature and expiry of link made by GetDownloadLink
+func (f fileUC) VerifyDownloadLink(ctx context.Context, fileId uuid.UUID, expires int64, signature string) error {
+	_, span := tracing.StartSpan(ctx, "fileUC.VerifyDownloadLink")
+	defer span.End()
+
+	if f.cfg.FileStorage.LinkSecret == "" {
+		return ErrorLinksDisabled
+	}
+
+	if !hmac.Equal([]byte(f.signLink(fileId, expires)), []byte(strings.ToLower(signature))) {
+		return ErrorInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return ErrorLinkExpired
+	}
+
+	return nil
+}
+
+// signLink returns hex HMAC-SHA256 of file id and link expiry time
+func (f fileUC) signLink(fileId uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(f.cfg.FileStorage.LinkSecret))
+	mac.Write([]byte(fileId.String() + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// linkTTL returns requested link lifetime, config default for zero and config maximum for longer ones
+func (f fileUC) linkTTL(expiresIn time.Duration) time.Duration {
+	maxTTL := DefaultLinkMaxTTL
+	if f.cfg.FileStorage.LinkMaxTTL > 0 {
+		maxTTL = time.Second * f.cfg.FileStorage.LinkMaxTTL
+	}
+
+	if expiresIn <= 0 {
+		expiresIn = DefaultLinkTTL
+		if f.cfg.FileStorage.LinkTTL > 0 {
+			expiresIn = time.Second * f.cfg.FileStorage.LinkTTL
+		}
+	}
+
+	if expiresIn > maxTTL {
+		return maxTTL
+	}
+	return expiresIn
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/mock"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/scanner"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// presigningStorage is storage mock with presigning support
+type presigningStorage struct {
+	*mock.MockStorage
+	*mock.MockPresigner
+}
+
+func TestFileUC_GetDownloadLink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	linkCfg := *testCfg
+	linkCfg.FileStorage = config.FileStorage{
+		LinkSecret:  "secret",
+		LinkBaseUrl: "http://localhost:8080/",
+		LinkTTL:     60,
+		LinkMaxTTL:  3600,
+	}
+
+	ownerId := uuid.New()
+	fileInfo := newTestFile()
+	fileInfo.FileUuid = uuid.New()
+	fileInfo.OwnerUuid = uuid.NullUUID{UUID: ownerId, Valid: true}
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil).AnyTimes()
+
+	t.Run("Signed", func(t *testing.T) {
+		fileUC := NewFileUseCase(&linkCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), fileInfo.FileUuid, ownerId, 0)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(time.Minute), link.ExpiresAt, 2*time.Second)
+		require.True(t, strings.HasPrefix(link.Url, "http://localhost:8080"+SignedLinkPath+fileInfo.FileUuid.String()+"?"))
+
+		parsed, err := url.Parse(link.Url)
+		require.NoError(t, err)
+		expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, link.ExpiresAt.Unix(), expires)
+
+		signature := parsed.Query().Get("signature")
+		require.NoError(t, fileUC.VerifyDownloadLink(context.Background(), fileInfo.FileUuid, expires, signature))
+		require.Equal(t, ErrorInvalidSignature, fileUC.VerifyDownloadLink(context.Background(), fileInfo.FileUuid, expires+1, signature))
+		require.Equal(t, ErrorInvalidSignature, fileUC.VerifyDownloadLink(context.Background(), uuid.New(), expires, signature))
+	})
+	t.Run("Expired", func(t *testing.T) {
+		fileUC := NewFileUseCase(&linkCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger).(*fileUC)
+
+		expires := time.Now().Add(-time.Second).Unix()
+		err := fileUC.VerifyDownloadLink(context.Background(), fileInfo.FileUuid, expires, fileUC.signLink(fileInfo.FileUuid, expires))
+		require.Equal(t, ErrorLinkExpired, err)
+	})
+	t.Run("MaxTTL", func(t *testing.T) {
+		fileUC := NewFileUseCase(&linkCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), fileInfo.FileUuid, ownerId, 48*time.Hour)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, 2*time.Second)
+	})
+	t.Run("NotOwner", func(t *testing.T) {
+		fileUC := NewFileUseCase(&linkCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), fileInfo.FileUuid, uuid.New(), 0)
+		require.Equal(t, ErrorNotFileOwner, err)
+		require.Nil(t, link)
+	})
+	t.Run("NoOwner", func(t *testing.T) {
+		fileUC := NewFileUseCase(&linkCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		ownerless := newTestFile()
+		ownerless.FileUuid = uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(ownerless.FileUuid)).Return(ownerless, nil)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), ownerless.FileUuid, ownerId, 0)
+		require.Equal(t, ErrorNotFileOwner, err)
+		require.Nil(t, link)
+	})
+	t.Run("UploadedPassportOtherUser", func(t *testing.T) {
+		uploadRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(&linkCfg, uploadRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		var uploaded *models.File
+		uploadRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f *models.File) error {
+			uploaded = f
+			return nil
+		})
+		uploadRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), models.FileStatusClean, "").DoAndReturn(func(ctx context.Context, fileId uuid.UUID, status string, scanResult string) error {
+			uploaded.Status = status
+			return nil
+		})
+		uploadRepo.EXPECT().GetFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+			return uploaded, nil
+		}).Times(2)
+
+		passport := newTestFile()
+		passport.OwnerUuid = uuid.NullUUID{UUID: ownerId, Valid: true}
+		result, err := fileUC.PutFile(context.Background(), passport, testContent)
+		require.NoError(t, err)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), result.FileUuid, uuid.New(), 0)
+		require.Equal(t, ErrorNotFileOwner, err)
+		require.Nil(t, link)
+
+		link, err = fileUC.GetDownloadLink(context.Background(), result.FileUuid, ownerId, 0)
+		require.NoError(t, err)
+		require.NotNil(t, link)
+	})
+	t.Run("LinksDisabled", func(t *testing.T) {
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		_, err := fileUC.GetDownloadLink(context.Background(), fileInfo.FileUuid, ownerId, 0)
+		require.Equal(t, ErrorLinksDisabled, err)
+		require.Equal(t, ErrorLinksDisabled, fileUC.VerifyDownloadLink(context.Background(), fileInfo.FileUuid, 0, ""))
+	})
+	t.Run("Presigned", func(t *testing.T) {
+		fileStorage := presigningStorage{MockStorage: mock.NewMockStorage(ctrl), MockPresigner: mock.NewMockPresigner(ctrl)}
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		fileStorage.MockPresigner.EXPECT().
+			PresignGet(gomock.Any(), fileInfo.FileUuid.String(), fileInfo.FileName, DefaultLinkTTL).
+			Return("http://minio/files/presigned", nil)
+
+		link, err := fileUC.GetDownloadLink(context.Background(), fileInfo.FileUuid, ownerId, 0)
+		require.NoError(t, err)
+		require.Equal(t, "http://minio/files/presigned", link.Url)
+	})
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultScanPolicy is used for business types without own policy
+	DefaultScanPolicy = "default"
+	// Content type detection reads at most that many first bytes
+	sniffLength = 512
+
+	DefaultRescanInterval = time.Minute
+	rescanBatchSize       = 100
+)
+
+// scanPolicy returns upload policy of business type, nil means no restrictions
+func (f fileUC) scanPolicy(businessType string) (*config.FileScanPolicy, error) {
+	if len(f.cfg.FileScan.Policies) == 0 {
+		return nil, nil
+	}
+	if policy, ok := f.cfg.FileScan.Policies[strings.ToLower(businessType)]; ok {
+		return &policy, nil
+	}
+	if policy, ok := f.cfg.FileScan.Policies[DefaultScanPolicy]; ok {
+		return &policy, nil
+	}
+	return nil, ErrorBusinessTypeNotAllowed
+}
+
+func (f fileUC) checkSize(businessType string, size int64) error {
+	policy, err := f.scanPolicy(businessType)
+	if err != nil {
+		return err
+	}
+	if policy != nil && policy.MaxSize > 0 && size > policy.MaxSize {
+		return ErrorFileTooLarge
+	}
+	return nil
+}
+
+// checkContentType detects content type by first content bytes and checks it's allowed for business type
+func (f fileUC) checkContentType(businessType string, head []byte) (string, error) {
+	contentType := detectContentType(head)
+
+	policy, err := f.scanPolicy(businessType)
+	if err != nil {
+		return "", err
+	}
+	if policy == nil || len(policy.ContentTypes) == 0 {
+		return contentType, nil
+	}
+	for _, allowed := range policy.ContentTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return contentType, nil
+		}
+	}
+	return "", ErrorContentTypeNotAllowed
+}
+
+// detectContentType returns media type without parameters
+func detectContentType(head []byte) string {
+	if len(head) > sniffLength {
+		head = head[:sniffLength]
+	}
+	contentType := http.DetectContentType(head)
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return contentType
+}
+
+// readHead returns first bytes of stored content for content type detection
+func (f fileUC) readHead(ctx context.Context, key string, size int64) ([]byte, error) {
+	length := int64(sniffLength)
+	if size < length {
+		length = size
+	}
+
+	object, err := f.storage.GetRange(ctx, key, 0, length)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return io.ReadAll(object)
+}
+
+// scanFile scans quarantined file and updates its status. File stays quarantined when scanner fails,
+// it's scanned again by RescanQuarantined.
+func (f fileUC) scanFile(ctx context.Context, fileInfo *models.File) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.scanFile")
+	defer span.End()
+
+	object, err := f.storage.Get(ctxWithTrace, fileInfo.FileUuid.String())
+	if err != nil {
+		f.logger.Errorf("Get file %s content for scan: %v", fileInfo.FileUuid, err)
+		return nil
+	}
+	defer object.Close()
+
+	threat, err := f.scanner.Scan(ctxWithTrace, object)
+	if err != nil {
+		f.logger.Errorf("Scan file %s: %v", fileInfo.FileUuid, err)
+		return nil
+	}
+
+	status := models.FileStatusClean
+	if threat != "" {
+		status = models.FileStatusInfected
+		f.logger.Warnf("File %s is infected by %s", fileInfo.FileUuid, threat)
+	}
+
+	if err = f.fileRepo.UpdateFileStatus(ctxWithTrace, fileInfo.FileUuid, status, threat); err != nil {
+		if errors.Is(err, repository.ErrorNoFile) {
+			return ErrorFileNotFound
+		}
+		return err
+	}
+	fileInfo.Status = status
+	fileInfo.ScanResult = threat
+
+	if status == models.FileStatusInfected {
+		return ErrorFileInfected
+	}
+	return nil
+}
+
+// RescanQuarantined scans files left quarantined by failed scanner or restart
+func (f fileUC) RescanQuarantined(ctx context.Context) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.RescanQuarantined")
+	defer span.End()
+
+	// Fresh files are being scanned by their upload requests
+	files, err := f.fileRepo.GetFilesByStatus(ctxWithTrace, models.FileStatusQuarantined, time.Now().Add(-f.rescanInterval()), rescanBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, fileInfo := range files {
+		if err = f.scanFile(ctxWithTrace, fileInfo); err != nil && !errors.Is(err, ErrorFileInfected) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f fileUC) rescanInterval() time.Duration {
+	if f.cfg.FileScan.RescanInterval > 0 {
+		return time.Duration(f.cfg.FileScan.RescanInterval) * time.Second
+	}
+	return DefaultRescanInterval
+}
+
+// CheckDownloadable returns error for files not passed scan
+func CheckDownloadable(fileInfo *models.File) error {
+	switch fileInfo.Status {
+	case models.FileStatusQuarantined:
+		return ErrorFileQuarantined
+	case models.FileStatusInfected:
+		return ErrorFileInfected
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/mock"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/scanner"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var testPdf = []byte("%PDF-1.7\n1 0 obj\n<<>>\nendobj\n")
+
+func newScanTestFile(businessType string, content []byte) *models.File {
+	return &models.File{
+		BusinessType: businessType,
+		FileName:     "document",
+		Hash:         HashContent(content),
+		Size:         int64(len(content)),
+	}
+}
+
+func TestFileUC_ScanPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	scanCfg := *testCfg
+	scanCfg.FileScan = config.FileScan{
+		Policies: map[string]config.FileScanPolicy{
+			"passport": {ContentTypes: []string{"application/pdf", "image/png"}, MaxSize: 64},
+		},
+	}
+
+	t.Run("Allowed", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(&scanCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		mockRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), models.FileStatusClean, "").Return(nil)
+
+		result, err := fileUC.PutFile(context.Background(), newScanTestFile("Passport", testPdf), testPdf)
+		require.NoError(t, err)
+		require.Equal(t, "application/pdf", result.ContentType)
+	})
+	t.Run("ContentTypeNotAllowed", func(t *testing.T) {
+		fileUC := NewFileUseCase(&scanCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		_, err := fileUC.PutFile(context.Background(), newScanTestFile("passport", testContent), testContent)
+		require.Equal(t, ErrorContentTypeNotAllowed, err)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		fileUC := NewFileUseCase(&scanCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		content := append(append([]byte(nil), testPdf...), make([]byte, 64)...)
+		_, err := fileUC.PutFile(context.Background(), newScanTestFile("passport", content), content)
+		require.Equal(t, ErrorFileTooLarge, err)
+
+		_, err = fileUC.StartUpload(context.Background(), newScanTestFile("passport", content))
+		require.Equal(t, ErrorFileTooLarge, err)
+	})
+	t.Run("BusinessTypeNotAllowed", func(t *testing.T) {
+		fileUC := NewFileUseCase(&scanCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		_, err := fileUC.PutFile(context.Background(), newScanTestFile("contract", testPdf), testPdf)
+		require.Equal(t, ErrorBusinessTypeNotAllowed, err)
+	})
+	t.Run("DefaultPolicy", func(t *testing.T) {
+		defaultCfg := scanCfg
+		defaultCfg.FileScan.Policies = map[string]config.FileScanPolicy{
+			DefaultScanPolicy: {ContentTypes: []string{"application/pdf"}},
+		}
+		fileUC := NewFileUseCase(&defaultCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		_, err := fileUC.PutFile(context.Background(), newScanTestFile("contract", testContent), testContent)
+		require.Equal(t, ErrorContentTypeNotAllowed, err)
+	})
+	t.Run("UploadContentTypeNotAllowed", func(t *testing.T) {
+		uploads := make(map[uuid.UUID]*models.Upload)
+		mockRepo := mock.NewMockRepository(ctrl)
+		expectUploadRepo(mockRepo, uploads, make(map[uuid.UUID]*models.File))
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(&scanCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		upload, err := fileUC.StartUpload(context.Background(), newScanTestFile("passport", testContent))
+		require.NoError(t, err)
+		_, err = fileUC.WriteUpload(context.Background(), upload.UploadUuid, 0, bytes.NewReader(testContent))
+		require.NoError(t, err)
+
+		_, err = fileUC.CompleteUpload(context.Background(), upload.UploadUuid)
+		require.Equal(t, ErrorContentTypeNotAllowed, err)
+		require.Empty(t, uploads)
+
+		_, err = fileStorage.Get(context.Background(), upload.FileUuid.String())
+		require.ErrorIs(t, err, storage.ErrorObjectNotFound)
+	})
+}
+
+func TestFileUC_Scan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	t.Run("Infected", func(t *testing.T) {
+		files := make(map[uuid.UUID]*models.File)
+		mockRepo := mock.NewMockRepository(ctrl)
+		expectScanRepo(mockRepo, files)
+		mockScanner := mock.NewMockScanner(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), mockScanner, apiLogger)
+
+		mockScanner.EXPECT().Scan(gomock.Any(), gomock.Any()).Return("Eicar-Test-Signature", nil)
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), testContent)
+		require.Equal(t, ErrorFileInfected, err)
+		require.Nil(t, result)
+		require.Len(t, files, 1)
+
+		for fileId, fileInfo := range files {
+			require.Equal(t, models.FileStatusInfected, fileInfo.Status)
+			require.Equal(t, "Eicar-Test-Signature", fileInfo.ScanResult)
+
+			_, _, err = fileUC.OpenFile(context.Background(), fileId, 0, 0)
+			require.Equal(t, ErrorFileInfected, err)
+		}
+	})
+	t.Run("ScannerFailed", func(t *testing.T) {
+		files := make(map[uuid.UUID]*models.File)
+		mockRepo := mock.NewMockRepository(ctrl)
+		expectScanRepo(mockRepo, files)
+		mockScanner := mock.NewMockScanner(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), mockScanner, apiLogger)
+
+		mockScanner.EXPECT().Scan(gomock.Any(), gomock.Any()).Return("", errors.New("clamd is down"))
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), testContent)
+		require.NoError(t, err)
+		require.Equal(t, models.FileStatusQuarantined, result.Status)
+
+		_, _, err = fileUC.OpenFile(context.Background(), result.FileUuid, 0, 0)
+		require.Equal(t, ErrorFileQuarantined, err)
+
+		// Scanner is back
+		mockRepo.EXPECT().GetFilesByStatus(gomock.Any(), models.FileStatusQuarantined, gomock.Any(), gomock.Any()).Return([]*models.File{files[result.FileUuid]}, nil)
+		mockScanner.EXPECT().Scan(gomock.Any(), gomock.Any()).Return("", nil)
+
+		require.NoError(t, fileUC.RescanQuarantined(context.Background()))
+		require.Equal(t, models.FileStatusClean, files[result.FileUuid].Status)
+
+		_, object, err := fileUC.OpenFile(context.Background(), result.FileUuid, 0, 0)
+		require.NoError(t, err)
+		object.Close()
+	})
+}
+
+// expectScanRepo makes mock repository keep files in map
+func expectScanRepo(mockRepo *mock.MockRepository, files map[uuid.UUID]*models.File) {
+	mockRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f *models.File) error {
+		stored := *f
+		files[f.FileUuid] = &stored
+		return nil
+	}).AnyTimes()
+	mockRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fileId uuid.UUID, status string, scanResult string) error {
+			files[fileId].Status = status
+			files[fileId].ScanResult = scanResult
+			return nil
+		}).AnyTimes()
+	mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+		stored := *files[fileId]
+		return &stored, nil
+	}).AnyTimes()
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+)
+
+const (
+	// S3 requires at least 5MiB for every part except the last one
+	UploadPartSize = 8 << 20
+	// Content size of one download stream message
+	DownloadChunkSize = 1 << 20
+)
+
+// StartUpload creates resumable upload session for content of given size and hash
+func (f fileUC) StartUpload(ctx context.Context, fileInfo *models.File) (*models.Upload, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.StartUpload")
+	defer span.End()
+
+	if fileInfo.FileName == "" {
+		return nil, ErrorNoFileName
+	}
+	if fileInfo.BusinessType == "" {
+		return nil, ErrorNoBusinessType
+	}
+	if fileInfo.Size <= 0 {
+		return nil, ErrorEmptyContent
+	}
+	if !validHash(fileInfo.Hash) {
+		return nil, ErrorInvalidHash
+	}
+	if err := f.checkSize(fileInfo.BusinessType, fileInfo.Size); err != nil {
+		return nil, err
+	}
+
+	state, err := newHashState()
+	if err != nil {
+		return nil, ErrorHashState
+	}
+
+	upload := &models.Upload{
+		UploadUuid:   uuid.New(),
+		FileUuid:     uuid.New(),
+		BusinessType: fileInfo.BusinessType,
+		FileName:     fileInfo.FileName,
+		Hash:         strings.ToLower(fileInfo.Hash),
+		Size:         fileInfo.Size,
+		OwnerUuid:    fileInfo.OwnerUuid,
+		HashState:    state,
+	}
+
+	upload.StorageUploadId, err = f.storage.CreateUpload(ctxWithTrace, upload.FileUuid.String())
+	if err != nil {
+		f.logger.Errorf("Create upload of file %s: %v", upload.FileUuid, err)
+		return nil, ErrorPutContent
+	}
+
+	if err = f.fileRepo.CreateUpload(ctxWithTrace, upload); err != nil {
+		if abortErr := f.storage.AbortUpload(ctxWithTrace, upload.FileUuid.String(), upload.StorageUploadId); abortErr != nil {
+			f.logger.Errorf("Abort upload of file %s after failed create: %v", upload.FileUuid, abortErr)
+		}
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (f fileUC) GetUpload(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.GetUpload")
+	defer span.End()
+
+	upload, err := f.fileRepo.GetUpload(ctxWithTrace, uploadId)
+	if err != nil {
+		if errors.Is(err, repository.ErrorNoUpload) {
+			return nil, ErrorUploadNotFound
+		}
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// WriteUpload reads content starting from offset and stores it by UploadPartSize parts.
+// Hash state and offset are saved after every part, so broken upload is resumed from the last stored part.
+// Content of incomplete part is dropped when reader ends before the upload size is reached.
+func (f fileUC) WriteUpload(ctx context.Context, uploadId uuid.UUID, offset int64, content io.Reader) (*models.Upload, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.WriteUpload")
+	defer span.End()
+
+	upload, err := f.GetUpload(ctxWithTrace, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Received {
+		return nil, ErrorInvalidOffset
+	}
+
+	hasher, err := restoreHash(upload.HashState)
+	if err != nil {
+		return nil, ErrorHashState
+	}
+
+	buffer := make([]byte, UploadPartSize)
+	for upload.Received < upload.Size {
+		partSize := min(int64(UploadPartSize), upload.Size-upload.Received)
+
+		n, err := io.ReadFull(content, buffer[:partSize])
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return upload, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		hasher.Write(buffer[:n])
+		state, err := hashState(hasher)
+		if err != nil {
+			return nil, ErrorHashState
+		}
+
+		part := models.UploadPart{
+			UploadUuid: upload.UploadUuid,
+			PartNumber: len(upload.Parts) + 1,
+			Size:       int64(n),
+		}
+		part.ETag, err = f.storage.UploadPart(ctxWithTrace, upload.FileUuid.String(), upload.StorageUploadId, part.PartNumber, bytes.NewReader(buffer[:n]), part.Size)
+		if err != nil {
+			f.logger.Errorf("Upload part %d of file %s: %v", part.PartNumber, upload.FileUuid, err)
+			return nil, ErrorPutContent
+		}
+
+		if err = f.fileRepo.AddUploadPart(ctxWithTrace, upload.UploadUuid, upload.Received, part, state); err != nil {
+			if errors.Is(err, repository.ErrorUploadConflict) {
+				return nil, ErrorUploadConflict
+			}
+			return nil, err
+		}
+
+		upload.Received += part.Size
+		upload.HashState = state
+		upload.Parts = append(upload.Parts, part)
+	}
+
+	// Content longer than declared size would be silently cut otherwise
+	if n, _ := content.Read(buffer[:1]); n > 0 {
+		return nil, ErrorSizeMismatch
+	}
+
+	return upload, nil
+}
+
+// CompleteUpload checks hash and content type of received content and saves quarantined file, then scans it.
+// Upload with wrong hash is aborted, its content can't be used anymore, as well as content of not allowed type.
+func (f fileUC) CompleteUpload(ctx context.Context, uploadId uuid.UUID) (*models.File, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.CompleteUpload")
+	defer span.End()
+
+	upload, err := f.GetUpload(ctxWithTrace, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Received != upload.Size {
+		return nil, ErrorUploadIncomplete
+	}
+
+	hasher, err := restoreHash(upload.HashState)
+	if err != nil {
+		return nil, ErrorHashState
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != upload.Hash {
+		if err = f.AbortUpload(ctxWithTrace, uploadId); err != nil {
+			f.logger.Errorf("Abort upload %s with wrong hash: %v", uploadId, err)
+		}
+		return nil, ErrorHashMismatch
+	}
+
+	if err = f.storage.CompleteUpload(ctxWithTrace, upload.FileUuid.String(), upload.StorageUploadId, upload.Parts); err != nil {
+		f.logger.Errorf("Complete upload of file %s: %v", upload.FileUuid, err)
+		return nil, ErrorPutContent
+	}
+
+	head, err := f.readHead(ctxWithTrace, upload.FileUuid.String(), upload.Size)
+	if err != nil {
+		f.logger.Errorf("Read file %s content head: %v", upload.FileUuid, err)
+		return nil, ErrorGetContent
+	}
+	contentType, err := f.checkContentType(upload.BusinessType, head)
+	if err != nil {
+		if deleteErr := f.storage.Delete(ctxWithTrace, upload.FileUuid.String()); deleteErr != nil {
+			f.logger.Errorf("Delete file %s content of not allowed type: %v", upload.FileUuid, deleteErr)
+		}
+		if deleteErr := f.fileRepo.DeleteUpload(ctxWithTrace, uploadId); deleteErr != nil {
+			f.logger.Errorf("Delete upload %s of not allowed type: %v", uploadId, deleteErr)
+		}
+		return nil, err
+	}
+
+	result := &models.File{
+		FileUuid:     upload.FileUuid,
+		BusinessType: upload.BusinessType,
+		FileName:     upload.FileName,
+		Hash:         upload.Hash,
+		Size:         upload.Size,
+		OwnerUuid:    upload.OwnerUuid,
+		ContentType:  contentType,
+		Status:       models.FileStatusQuarantined,
+	}
+
+	if err = f.fileRepo.FinishUpload(ctxWithTrace, uploadId, result); err != nil {
+		if deleteErr := f.storage.Delete(ctxWithTrace, upload.FileUuid.String()); deleteErr != nil {
+			f.logger.Errorf("Delete file %s content after failed upload finish: %v", upload.FileUuid, deleteErr)
+		}
+		if deleteErr := f.fileRepo.DeleteUpload(ctxWithTrace, uploadId); deleteErr != nil {
+			f.logger.Errorf("Delete upload %s after failed finish: %v", uploadId, deleteErr)
+		}
+		return nil, err
+	}
+
+	if err = f.scanFile(ctxWithTrace, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (f fileUC) AbortUpload(ctx context.Context, uploadId uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.AbortUpload")
+	defer span.End()
+
+	upload, err := f.GetUpload(ctxWithTrace, uploadId)
+	if err != nil {
+		return err
+	}
+
+	if err = f.storage.AbortUpload(ctxWithTrace, upload.FileUuid.String(), upload.StorageUploadId); err != nil {
+		f.logger.Errorf("Abort upload of file %s: %v", upload.FileUuid, err)
+		return ErrorDeleteContent
+	}
+
+	if err = f.fileRepo.DeleteUpload(ctxWithTrace, uploadId); err != nil {
+		if errors.Is(err, repository.ErrorNoUpload) {
+			return ErrorUploadNotFound
+		}
+		return err
+	}
+
+	return nil
+}
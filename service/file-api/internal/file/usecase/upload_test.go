@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/mock"
+	fileRepo "github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/scanner"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+// expectUploadRepo makes mock repository keep uploads and files in maps
+func expectUploadRepo(mockRepo *mock.MockRepository, uploads map[uuid.UUID]*models.Upload, files map[uuid.UUID]*models.File) {
+	mockRepo.EXPECT().CreateUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, upload *models.Upload) error {
+		stored := *upload
+		uploads[upload.UploadUuid] = &stored
+		return nil
+	}).AnyTimes()
+	mockRepo.EXPECT().GetUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uploadId uuid.UUID) (*models.Upload, error) {
+		upload, ok := uploads[uploadId]
+		if !ok {
+			return nil, fileRepo.ErrorNoUpload
+		}
+		result := *upload
+		result.Parts = append([]models.UploadPart(nil), upload.Parts...)
+		return &result, nil
+	}).AnyTimes()
+	mockRepo.EXPECT().AddUploadPart(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, uploadId uuid.UUID, received int64, part models.UploadPart, hashState []byte) error {
+			upload := uploads[uploadId]
+			if upload.Received != received {
+				return fileRepo.ErrorUploadConflict
+			}
+			upload.Received += part.Size
+			upload.HashState = hashState
+			upload.Parts = append(upload.Parts, part)
+			return nil
+		}).AnyTimes()
+	mockRepo.EXPECT().DeleteUpload(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uploadId uuid.UUID) error {
+		delete(uploads, uploadId)
+		return nil
+	}).AnyTimes()
+	mockRepo.EXPECT().FinishUpload(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, uploadId uuid.UUID, f *models.File) error {
+		delete(uploads, uploadId)
+		files[f.FileUuid] = f
+		return nil
+	}).AnyTimes()
+	mockRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fileId uuid.UUID, status string, scanResult string) error {
+			f, ok := files[fileId]
+			if !ok {
+				return fileRepo.ErrorNoFile
+			}
+			f.Status = status
+			f.ScanResult = scanResult
+			return nil
+		}).AnyTimes()
+	mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+		f, ok := files[fileId]
+		if !ok {
+			return nil, fileRepo.ErrorNoFile
+		}
+		return f, nil
+	}).AnyTimes()
+}
+
+func newUploadTest(t *testing.T) (*gomock.Controller, map[uuid.UUID]*models.Upload, *fileUC) {
+	ctrl := gomock.NewController(t)
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	uploads := make(map[uuid.UUID]*models.Upload)
+	mockRepo := mock.NewMockRepository(ctrl)
+	expectUploadRepo(mockRepo, uploads, make(map[uuid.UUID]*models.File))
+
+	return ctrl, uploads, NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger).(*fileUC)
+}
+
+func newUploadContent() []byte {
+	content := make([]byte, 2*UploadPartSize+100)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	return content
+}
+
+func TestFileUC_Upload(t *testing.T) {
+	content := newUploadContent()
+	fileInfo := &models.File{
+		BusinessType: "statement",
+		FileName:     "statement.pdf",
+		Hash:         HashContent(content),
+		Size:         int64(len(content)),
+	}
+
+	t.Run("Resumed", func(t *testing.T) {
+		ctrl, _, fileUC := newUploadTest(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		upload, err := fileUC.StartUpload(ctx, fileInfo)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), upload.Received)
+
+		// Stream is broken in the middle of the second part
+		upload, err = fileUC.WriteUpload(ctx, upload.UploadUuid, 0, bytes.NewReader(content[:UploadPartSize+UploadPartSize/2]))
+		require.NoError(t, err)
+		require.Equal(t, int64(UploadPartSize), upload.Received)
+
+		_, err = fileUC.WriteUpload(ctx, upload.UploadUuid, 0, bytes.NewReader(content))
+		require.Equal(t, ErrorInvalidOffset, err)
+
+		_, err = fileUC.CompleteUpload(ctx, upload.UploadUuid)
+		require.Equal(t, ErrorUploadIncomplete, err)
+
+		upload, err = fileUC.GetUpload(ctx, upload.UploadUuid)
+		require.NoError(t, err)
+		upload, err = fileUC.WriteUpload(ctx, upload.UploadUuid, upload.Received, bytes.NewReader(content[upload.Received:]))
+		require.NoError(t, err)
+		require.Equal(t, upload.Size, upload.Received)
+		require.Len(t, upload.Parts, 3)
+
+		result, err := fileUC.CompleteUpload(ctx, upload.UploadUuid)
+		require.NoError(t, err)
+		require.Equal(t, upload.FileUuid, result.FileUuid)
+		require.Equal(t, fileInfo.Hash, result.Hash)
+
+		_, stored, err := fileUC.GetFile(ctx, result.FileUuid)
+		require.NoError(t, err)
+		require.Equal(t, content, stored)
+
+		_, err = fileUC.GetUpload(ctx, upload.UploadUuid)
+		require.Equal(t, ErrorUploadNotFound, err)
+	})
+	t.Run("HashMismatch", func(t *testing.T) {
+		ctrl, uploads, fileUC := newUploadTest(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		wrongHash := *fileInfo
+		wrongHash.Hash = HashContent([]byte("other content"))
+		upload, err := fileUC.StartUpload(ctx, &wrongHash)
+		require.NoError(t, err)
+
+		_, err = fileUC.WriteUpload(ctx, upload.UploadUuid, 0, bytes.NewReader(content))
+		require.NoError(t, err)
+
+		result, err := fileUC.CompleteUpload(ctx, upload.UploadUuid)
+		require.Equal(t, ErrorHashMismatch, err)
+		require.Nil(t, result)
+		require.Empty(t, uploads)
+	})
+	t.Run("ContentTooLong", func(t *testing.T) {
+		ctrl, _, fileUC := newUploadTest(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		upload, err := fileUC.StartUpload(ctx, fileInfo)
+		require.NoError(t, err)
+
+		_, err = fileUC.WriteUpload(ctx, upload.UploadUuid, 0, io.MultiReader(bytes.NewReader(content), bytes.NewReader([]byte{1})))
+		require.Equal(t, ErrorSizeMismatch, err)
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		ctrl, _, fileUC := newUploadTest(t)
+		defer ctrl.Finish()
+
+		invalidHash := *fileInfo
+		invalidHash.Hash = "not a hash"
+		_, err := fileUC.StartUpload(context.Background(), &invalidHash)
+		require.Equal(t, ErrorInvalidHash, err)
+	})
+	t.Run("Abort", func(t *testing.T) {
+		ctrl, uploads, fileUC := newUploadTest(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		upload, err := fileUC.StartUpload(ctx, fileInfo)
+		require.NoError(t, err)
+		require.NoError(t, fileUC.AbortUpload(ctx, upload.UploadUuid))
+		require.Empty(t, uploads)
+
+		_, err = fileUC.WriteUpload(ctx, upload.UploadUuid, 0, bytes.NewReader(content))
+		require.Equal(t, ErrorUploadNotFound, err)
+	})
+}
+
+func TestFileUC_OpenFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+	mockRepo := mock.NewMockRepository(ctrl)
+	fileStorage := storage.NewMemoryStorage()
+	fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+	fileInfo := newTestFile()
+	fileInfo.FileUuid = uuid.New()
+	require.NoError(t, fileStorage.Put(context.Background(), fileInfo.FileUuid.String(), bytes.NewReader(testContent), fileInfo.Size))
+	mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil).AnyTimes()
+
+	t.Run("Range", func(t *testing.T) {
+		_, object, err := fileUC.OpenFile(context.Background(), fileInfo.FileUuid, 2, 5)
+		require.NoError(t, err)
+		defer object.Close()
+
+		content, err := io.ReadAll(object)
+		require.NoError(t, err)
+		require.Equal(t, testContent[2:7], content)
+	})
+	t.Run("TillEnd", func(t *testing.T) {
+		_, object, err := fileUC.OpenFile(context.Background(), fileInfo.FileUuid, 9, 0)
+		require.NoError(t, err)
+		defer object.Close()
+
+		content, err := io.ReadAll(object)
+		require.NoError(t, err)
+		require.Equal(t, testContent[9:], content)
+	})
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, _, err := fileUC.OpenFile(context.Background(), fileInfo.FileUuid, 5, fileInfo.Size)
+		require.Equal(t, ErrorInvalidRange, err)
+	})
+}
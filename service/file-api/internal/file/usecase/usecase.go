@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+)
+
+type fileUC struct {
+	cfg      *config.Config
+	fileRepo file.Repository
+	storage  file.Storage
+	scanner  file.Scanner
+	logger   logger.Logger
+}
+
+// PutFile checks SHA-256 hash, size and content type of content, saves content to storage and metadata to db.
+// Content is removed back if metadata can't be saved, so storage has no files unknown to db.
+// File is quarantined until scanner finds it clean, infected one is kept quarantined and ErrorFileInfected is returned.
+func (f fileUC) PutFile(ctx context.Context, fileInfo *models.File, content []byte) (*models.File, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.PutFile")
+	defer span.End()
+
+	if fileInfo.FileName == "" {
+		return nil, ErrorNoFileName
+	}
+	if fileInfo.BusinessType == "" {
+		return nil, ErrorNoBusinessType
+	}
+	if len(content) == 0 {
+		return nil, ErrorEmptyContent
+	}
+	if fileInfo.Size != 0 && fileInfo.Size != int64(len(content)) {
+		return nil, ErrorSizeMismatch
+	}
+	if err := f.checkSize(fileInfo.BusinessType, int64(len(content))); err != nil {
+		return nil, err
+	}
+
+	hash := HashContent(content)
+	if !strings.EqualFold(fileInfo.Hash, hash) {
+		return nil, ErrorHashMismatch
+	}
+
+	contentType, err := f.checkContentType(fileInfo.BusinessType, content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.File{
+		FileUuid:     uuid.New(),
+		BusinessType: fileInfo.BusinessType,
+		FileName:     fileInfo.FileName,
+		Hash:         hash,
+		Size:         int64(len(content)),
+		OwnerUuid:    fileInfo.OwnerUuid,
+		ContentType:  contentType,
+		Status:       models.FileStatusQuarantined,
+	}
+
+	if err := f.storage.Put(ctxWithTrace, result.FileUuid.String(), bytes.NewReader(content), result.Size); err != nil {
+		f.logger.Errorf("Put file %s content: %v", result.FileUuid, err)
+		return nil, ErrorPutContent
+	}
+
+	if err := f.fileRepo.CreateFile(ctxWithTrace, result); err != nil {
+		if deleteErr := f.storage.Delete(ctxWithTrace, result.FileUuid.String()); deleteErr != nil {
+			f.logger.Errorf("Delete file %s content after failed create: %v", result.FileUuid, deleteErr)
+		}
+		return nil, err
+	}
+
+	if err := f.scanFile(ctxWithTrace, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetFile returns metadata and content, content is checked against stored hash
+func (f fileUC) GetFile(ctx context.Context, fileId uuid.UUID) (*models.File, []byte, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.GetFile")
+	defer span.End()
+
+	fileInfo, object, err := f.OpenFile(ctxWithTrace, fileId, 0, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer object.Close()
+
+	content, err := io.ReadAll(object)
+	if err != nil {
+		if errors.Is(err, ErrorContentCorrupted) {
+			return nil, nil, ErrorContentCorrupted
+		}
+		f.logger.Errorf("Read file %s content: %v", fileId, err)
+		return nil, nil, ErrorGetContent
+	}
+
+	return fileInfo, content, nil
+}
+
+// OpenFile returns reader of length bytes starting from offset, zero length means till the end of file.
+// Whole file reader checks content hash incrementally and returns ErrorContentCorrupted instead of io.EOF on mismatch.
+func (f fileUC) OpenFile(ctx context.Context, fileId uuid.UUID, offset int64, length int64) (*models.File, io.ReadCloser, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.OpenFile")
+	defer span.End()
+
+	fileInfo, err := f.HeadFile(ctxWithTrace, fileId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = CheckDownloadable(fileInfo); err != nil {
+		return nil, nil, err
+	}
+
+	if offset < 0 || length < 0 || offset > fileInfo.Size || offset+length > fileInfo.Size {
+		return nil, nil, ErrorInvalidRange
+	}
+	if length == 0 {
+		length = fileInfo.Size - offset
+	}
+
+	var object io.ReadCloser
+	if offset == 0 && length == fileInfo.Size {
+		object, err = f.storage.Get(ctxWithTrace, fileId.String())
+		if err == nil {
+			object = newHashReader(object, fileInfo.Hash)
+		}
+	} else {
+		object, err = f.storage.GetRange(ctxWithTrace, fileId.String(), offset, length)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrorObjectNotFound) {
+			return nil, nil, ErrorFileNotFound
+		}
+		f.logger.Errorf("Get file %s content: %v", fileId, err)
+		return nil, nil, ErrorGetContent
+	}
+
+	return fileInfo, object, nil
+}
+
+func (f fileUC) HeadFile(ctx context.Context, fileId uuid.UUID) (*models.File, error) {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.HeadFile")
+	defer span.End()
+
+	fileInfo, err := f.fileRepo.GetFile(ctxWithTrace, fileId)
+	if err != nil {
+		if errors.Is(err, repository.ErrorNoFile) {
+			return nil, ErrorFileNotFound
+		}
+		return nil, err
+	}
+
+	return fileInfo, nil
+}
+
+// DeleteFile removes content first, so failed call can be repeated while metadata still exists
+func (f fileUC) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileUC.DeleteFile")
+	defer span.End()
+
+	if _, err := f.HeadFile(ctxWithTrace, fileId); err != nil {
+		return err
+	}
+
+	if err := f.storage.Delete(ctxWithTrace, fileId.String()); err != nil {
+		f.logger.Errorf("Delete file %s content: %v", fileId, err)
+		return ErrorDeleteContent
+	}
+
+	if err := f.fileRepo.DeleteFile(ctxWithTrace, fileId); err != nil {
+		if errors.Is(err, repository.ErrorNoFile) {
+			return ErrorFileNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func NewFileUseCase(cfg *config.Config, fileRepo file.Repository, storage file.Storage, scanner file.Scanner, log logger.Logger) file.UseCase {
+	return &fileUC{cfg: cfg, fileRepo: fileRepo, storage: storage, scanner: scanner, logger: log}
+}
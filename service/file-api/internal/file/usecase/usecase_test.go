@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/mock"
+	fileRepo "github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/scanner"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/storage"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+)
+
+var (
+	testCfg = &config.Config{
+		Env: "Development",
+		Logger: config.Logger{
+			Development: true,
+			Level:       "Debug",
+		},
+	}
+	testContent = []byte("passport scan")
+)
+
+func newTestFile() *models.File {
+	return &models.File{
+		BusinessType: "passport",
+		FileName:     "passport.png",
+		Hash:         HashContent(testContent),
+		Size:         int64(len(testContent)),
+	}
+}
+
+func TestFileUC_PutFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		mockRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f *models.File) error {
+			require.Equal(t, models.FileStatusQuarantined, f.Status)
+			return nil
+		})
+		mockRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), models.FileStatusClean, "").Return(nil)
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), testContent)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, models.FileStatusClean, result.Status)
+		require.Equal(t, "text/plain", result.ContentType)
+		require.NotEqual(t, uuid.Nil, result.FileUuid)
+		require.Equal(t, HashContent(testContent), result.Hash)
+		require.Equal(t, int64(len(testContent)), result.Size)
+
+		object, err := fileStorage.Get(context.Background(), result.FileUuid.String())
+		require.NoError(t, err)
+		stored, err := io.ReadAll(object)
+		require.NoError(t, err)
+		require.Equal(t, testContent, stored)
+	})
+	t.Run("UpperCaseHash", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		mockRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).Return(nil)
+		mockRepo.EXPECT().UpdateFileStatus(gomock.Any(), gomock.Any(), models.FileStatusClean, "").Return(nil)
+
+		fileInfo := newTestFile()
+		fileInfo.Hash = string(bytes.ToUpper([]byte(fileInfo.Hash)))
+		result, err := fileUC.PutFile(context.Background(), fileInfo, testContent)
+		require.NoError(t, err)
+		require.Equal(t, HashContent(testContent), result.Hash)
+	})
+	t.Run("HashMismatch", func(t *testing.T) {
+		fileUC := NewFileUseCase(testCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.Hash = HashContent([]byte("other content"))
+		result, err := fileUC.PutFile(context.Background(), fileInfo, testContent)
+		require.Equal(t, ErrorHashMismatch, err)
+		require.Nil(t, result)
+	})
+	t.Run("SizeMismatch", func(t *testing.T) {
+		fileUC := NewFileUseCase(testCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.Size++
+		result, err := fileUC.PutFile(context.Background(), fileInfo, testContent)
+		require.Equal(t, ErrorSizeMismatch, err)
+		require.Nil(t, result)
+	})
+	t.Run("EmptyContent", func(t *testing.T) {
+		fileUC := NewFileUseCase(testCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), nil)
+		require.Equal(t, ErrorEmptyContent, err)
+		require.Nil(t, result)
+	})
+	t.Run("NoFileName", func(t *testing.T) {
+		fileUC := NewFileUseCase(testCfg, mock.NewMockRepository(ctrl), storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileName = ""
+		result, err := fileUC.PutFile(context.Background(), fileInfo, testContent)
+		require.Equal(t, ErrorNoFileName, err)
+		require.Nil(t, result)
+	})
+	t.Run("ErrorCreateFile", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		var created *models.File
+		mockRepo.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, f *models.File) error {
+			created = f
+			return fileRepo.ErrorCreateFile
+		})
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), testContent)
+		require.Equal(t, fileRepo.ErrorCreateFile, err)
+		require.Nil(t, result)
+
+		_, err = fileStorage.Get(context.Background(), created.FileUuid.String())
+		require.Equal(t, storage.ErrorObjectNotFound, err)
+	})
+	t.Run("ErrorPutContent", func(t *testing.T) {
+		mockStorage := mock.NewMockStorage(ctrl)
+		fileUC := NewFileUseCase(testCfg, mock.NewMockRepository(ctrl), mockStorage, scanner.NewNoopScanner(), apiLogger)
+
+		mockStorage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+		result, err := fileUC.PutFile(context.Background(), newTestFile(), testContent)
+		require.Equal(t, ErrorPutContent, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestFileUC_GetFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		require.NoError(t, fileStorage.Put(context.Background(), fileInfo.FileUuid.String(), bytes.NewReader(testContent), fileInfo.Size))
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+
+		result, content, err := fileUC.GetFile(context.Background(), fileInfo.FileUuid)
+		require.NoError(t, err)
+		require.Equal(t, fileInfo, result)
+		require.Equal(t, testContent, content)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileId := uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileId)).Return(nil, fileRepo.ErrorNoFile)
+
+		result, content, err := fileUC.GetFile(context.Background(), fileId)
+		require.Equal(t, ErrorFileNotFound, err)
+		require.Nil(t, result)
+		require.Nil(t, content)
+	})
+	t.Run("NoContent", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+
+		result, _, err := fileUC.GetFile(context.Background(), fileInfo.FileUuid)
+		require.Equal(t, ErrorFileNotFound, err)
+		require.Nil(t, result)
+	})
+	t.Run("ContentCorrupted", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		corrupted := []byte("passport scan!")
+		require.NoError(t, fileStorage.Put(context.Background(), fileInfo.FileUuid.String(), bytes.NewReader(corrupted), int64(len(corrupted))))
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+
+		result, content, err := fileUC.GetFile(context.Background(), fileInfo.FileUuid)
+		require.Equal(t, ErrorContentCorrupted, err)
+		require.Nil(t, result)
+		require.Nil(t, content)
+	})
+}
+
+func TestFileUC_HeadFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+	mockRepo := mock.NewMockRepository(ctrl)
+	fileUC := NewFileUseCase(testCfg, mockRepo, mock.NewMockStorage(ctrl), scanner.NewNoopScanner(), apiLogger)
+
+	t.Run("Valid", func(t *testing.T) {
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+
+		result, err := fileUC.HeadFile(context.Background(), fileInfo.FileUuid)
+		require.NoError(t, err)
+		require.Equal(t, fileInfo, result)
+	})
+	t.Run("ErrorGetFile", func(t *testing.T) {
+		fileId := uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileId)).Return(nil, fileRepo.ErrorGetFile)
+
+		result, err := fileUC.HeadFile(context.Background(), fileId)
+		require.Equal(t, fileRepo.ErrorGetFile, err)
+		require.Nil(t, result)
+	})
+}
+
+func TestFileUC_DeleteFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfg)
+	apiLogger.InitLogger()
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileStorage := storage.NewMemoryStorage()
+		fileUC := NewFileUseCase(testCfg, mockRepo, fileStorage, scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		require.NoError(t, fileStorage.Put(context.Background(), fileInfo.FileUuid.String(), bytes.NewReader(testContent), fileInfo.Size))
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+		mockRepo.EXPECT().DeleteFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(nil)
+
+		require.NoError(t, fileUC.DeleteFile(context.Background(), fileInfo.FileUuid))
+
+		_, err := fileStorage.Get(context.Background(), fileInfo.FileUuid.String())
+		require.Equal(t, storage.ErrorObjectNotFound, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, storage.NewMemoryStorage(), scanner.NewNoopScanner(), apiLogger)
+
+		fileId := uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileId)).Return(nil, fileRepo.ErrorNoFile)
+
+		require.Equal(t, ErrorFileNotFound, fileUC.DeleteFile(context.Background(), fileId))
+	})
+	t.Run("ErrorDeleteContent", func(t *testing.T) {
+		mockRepo := mock.NewMockRepository(ctrl)
+		mockStorage := mock.NewMockStorage(ctrl)
+		fileUC := NewFileUseCase(testCfg, mockRepo, mockStorage, scanner.NewNoopScanner(), apiLogger)
+
+		fileInfo := newTestFile()
+		fileInfo.FileUuid = uuid.New()
+		mockRepo.EXPECT().GetFile(gomock.Any(), gomock.Eq(fileInfo.FileUuid)).Return(fileInfo, nil)
+		mockStorage.EXPECT().Delete(gomock.Any(), gomock.Eq(fileInfo.FileUuid.String())).Return(errors.New("connection refused"))
+
+		require.Equal(t, ErrorDeleteContent, fileUC.DeleteFile(context.Background(), fileInfo.FileUuid))
+	})
+}
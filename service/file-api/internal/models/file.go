@@ -0,0 +1,61 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// File scan statuses, only clean files can be downloaded
+const (
+	FileStatusQuarantined = "quarantined"
+	FileStatusClean       = "clean"
+	FileStatusInfected    = "infected"
+)
+
+// File metadata, content is kept in object storage under FileUuid key
+type File struct {
+	FileUuid     uuid.UUID `json:"file_uuid" db:"file_uuid"`
+	BusinessType string    `json:"business_type" db:"business_type"`
+	FileName     string    `json:"file_name" db:"file_name"`
+	Hash         string    `json:"hash" db:"hash"`
+	Size         int64     `json:"size" db:"size"`
+	// User owning the file, files without owner are service documents
+	OwnerUuid uuid.NullUUID `json:"owner_uuid" db:"owner_uuid"`
+	// Detected by content magic bytes
+	ContentType string `json:"content_type" db:"content_type"`
+	Status      string `json:"status" db:"status"`
+	// Threat name of infected file
+	ScanResult string    `json:"scan_result" db:"scan_result"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Resumable upload session, content is stored as multipart upload in object storage
+type Upload struct {
+	UploadUuid      uuid.UUID     `json:"upload_uuid" db:"upload_uuid"`
+	FileUuid        uuid.UUID     `json:"file_uuid" db:"file_uuid"`
+	BusinessType    string        `json:"business_type" db:"business_type"`
+	FileName        string        `json:"file_name" db:"file_name"`
+	Hash            string        `json:"hash" db:"hash"`
+	Size            int64         `json:"size" db:"size"`
+	OwnerUuid       uuid.NullUUID `json:"owner_uuid" db:"owner_uuid"`
+	StorageUploadId string        `json:"-" db:"storage_upload_id"`
+	Received        int64         `json:"received" db:"received"`
+	HashState       []byte        `json:"-" db:"hash_state"`
+	Parts           []UploadPart  `json:"-" db:"-"`
+	CreatedAt       time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+type UploadPart struct {
+	UploadUuid uuid.UUID `json:"upload_uuid" db:"upload_uuid"`
+	PartNumber int       `json:"part_number" db:"part_number"`
+	ETag       string    `json:"etag" db:"etag"`
+	Size       int64     `json:"size" db:"size"`
+}
+
+// Time-limited link to file content
+type DownloadLink struct {
+	FileUuid  uuid.UUID `json:"file_uuid"`
+	Url       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
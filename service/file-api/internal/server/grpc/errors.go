@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"errors"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrorInvalidFileId   = errors.New("Invalid file uuid")
+	ErrorNoFile          = errors.New("No file into request")
+	ErrorInvalidUploadId = errors.New("Invalid upload id")
+	ErrorNoChunks        = errors.New("No chunks into upload stream")
+	ErrorInvalidRange    = errors.New("Invalid Content-Range header")
+	ErrorInvalidOwnerId  = errors.New("Invalid owner uuid")
+	ErrorInvalidLink     = errors.New("Invalid download link")
+)
+
+var grpcCodes = map[error]codes.Code{
+	usecase.ErrorFileNotFound:     codes.NotFound,
+	usecase.ErrorEmptyContent:     codes.InvalidArgument,
+	usecase.ErrorNoFileName:       codes.InvalidArgument,
+	usecase.ErrorNoBusinessType:   codes.InvalidArgument,
+	usecase.ErrorHashMismatch:     codes.InvalidArgument,
+	usecase.ErrorSizeMismatch:     codes.InvalidArgument,
+	usecase.ErrorContentCorrupted: codes.DataLoss,
+	usecase.ErrorInvalidRange:     codes.OutOfRange,
+	usecase.ErrorInvalidHash:      codes.InvalidArgument,
+	usecase.ErrorUploadNotFound:   codes.NotFound,
+	usecase.ErrorInvalidOffset:    codes.FailedPrecondition,
+	usecase.ErrorUploadIncomplete: codes.FailedPrecondition,
+	usecase.ErrorUploadConflict:   codes.Aborted,
+	usecase.ErrorNotFileOwner:     codes.PermissionDenied,
+	usecase.ErrorLinksDisabled:    codes.Unimplemented,
+	usecase.ErrorInvalidSignature: codes.PermissionDenied,
+	usecase.ErrorLinkExpired:      codes.PermissionDenied,
+
+	usecase.ErrorBusinessTypeNotAllowed: codes.InvalidArgument,
+	usecase.ErrorFileTooLarge:           codes.InvalidArgument,
+	usecase.ErrorContentTypeNotAllowed:  codes.InvalidArgument,
+	usecase.ErrorFileQuarantined:        codes.FailedPrecondition,
+	usecase.ErrorFileInfected:           codes.PermissionDenied,
+}
+
+// grpcError converts usecase error to grpc status, unknown errors are internal
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code, ok := grpcCodes[err]
+	if !ok {
+		code = codes.Internal
+	}
+	return status.Error(code, err.Error())
+}
@@ -4,20 +4,32 @@ import (
 	"context"
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/file-api/docs"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/repository"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/usecase"
 	apiMiddlewares "github.com/GCFactory/dbo-system/service/file-api/internal/middleware"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
 	pb "github.com/GCFactory/dbo-system/service/file-api/proto/api/v1"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type ServiceServer struct {
 	pb.UnimplementedFileApiServiceServer
+	useCase file.UseCase
 }
 
 func (s *Server) MapHandlers(e *echo.Echo) error {
@@ -46,24 +58,53 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	//e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			// Ranged downloads need original Content-Length
+			return strings.Contains(c.Request().URL.Path, "swagger") ||
+				strings.HasPrefix(c.Request().URL.Path, usecase.SignedLinkPath)
 		},
 	}))
 	e.Use(middleware.Secure())
-	e.Use(middleware.BodyLimit("5M"))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: "5M",
+		// Streaming uploads are stored by parts and don't keep whole body in memory
+		Skipper: func(c echo.Context) bool {
+			return strings.Contains(c.Request().Header.Get(echo.HeaderContentType), "application/grpc") ||
+				strings.HasPrefix(c.Request().URL.Path, httpUploadPrefix)
+		},
+	}))
 	if s.cfg.HTTPServer.Debug {
 		e.Use(mw.DebugMiddleware)
 	}
 
-	pb.RegisterFileApiServiceServer(s.grpcServer, &ServiceServer{})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+		health.RedisChecker(s.redis),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
+
+	fileRepo := repository.NewFileRepository(s.db)
+	fileUC := usecase.NewFileUseCase(s.cfg, fileRepo, s.storage, s.scanner, s.logger)
+	serviceServer := &ServiceServer{useCase: fileUC}
+	s.useCase = fileUC
+
+	pb.RegisterFileApiServiceServer(s.grpcServer, serviceServer)
+
+	gatewayMux := runtime.NewServeMux()
+	if err := pb.RegisterFileApiServiceHandlerServer(context.Background(), gatewayMux, serviceServer); err != nil {
+		return err
+	}
 
 	httpMux := http.NewServeMux()
-	httpMux.HandleFunc("/", (&ServiceServer{}).httpEchoPing)
+	httpMux.HandleFunc(httpUploadPrefix, serviceServer.httpUploadFile)
+	httpMux.HandleFunc(usecase.SignedLinkPath, serviceServer.httpSignedFile)
+	httpMux.Handle("/", gatewayMux)
 
 	//rootMux := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 	//	fmt.Println("Called loger")
@@ -101,8 +142,87 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 	return nil
 }
 
+// @Summary      Get file with content
+// @Param        UUID path string true "File UUID"
+// @Success      200  {object} pb.File
+// @Router       /api/v1/fileapi/getfile/{UUID} [get]
 func (s *ServiceServer) GetFile(ctx context.Context, in *pb.DeliveryGetFile) (*pb.File, error) {
-	return nil, nil
+	fileId, err := uuid.Parse(in.GetUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidFileId.Error())
+	}
+
+	fileInfo, content, err := s.useCase.GetFile(ctx, fileId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	result := fileToProto(fileInfo)
+	result.Content = content
+	return result, nil
+}
+
+// @Summary      Upload file, Hash is hex encoded SHA-256 of content
+// @Param        File body pb.File true "File"
+// @Success      200  {object} pb.File
+// @Router       /api/v1/fileapi/putfile [post]
+func (s *ServiceServer) PutFile(ctx context.Context, in *pb.DeliveryPutFile) (*pb.File, error) {
+	if in.GetFile() == nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorNoFile.Error())
+	}
+
+	ownerId, err := parseOwnerId(in.GetFile().GetOwnerUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidOwnerId.Error())
+	}
+
+	fileInfo, err := s.useCase.PutFile(ctx, &models.File{
+		BusinessType: in.GetFile().GetBusinessType(),
+		FileName:     in.GetFile().GetFileName(),
+		Hash:         in.GetFile().GetHash(),
+		Size:         int64(in.GetFile().GetSize()),
+		OwnerUuid:    ownerId,
+	}, in.GetFile().GetContent())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return fileToProto(fileInfo), nil
+}
+
+// @Summary      Get file metadata without content
+// @Param        UUID path string true "File UUID"
+// @Success      200  {object} pb.File
+// @Router       /api/v1/fileapi/headfile/{UUID} [get]
+func (s *ServiceServer) HeadFile(ctx context.Context, in *pb.DeliveryHeadFile) (*pb.File, error) {
+	fileId, err := uuid.Parse(in.GetUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidFileId.Error())
+	}
+
+	fileInfo, err := s.useCase.HeadFile(ctx, fileId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return fileToProto(fileInfo), nil
+}
+
+// @Summary      Delete file
+// @Param        UUID path string true "File UUID"
+// @Success      200  {object} pb.DeleteFileResponse
+// @Router       /api/v1/fileapi/deletefile/{UUID} [delete]
+func (s *ServiceServer) DeleteFile(ctx context.Context, in *pb.DeliveryDeleteFile) (*pb.DeleteFileResponse, error) {
+	fileId, err := uuid.Parse(in.GetUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidFileId.Error())
+	}
+
+	if err = s.useCase.DeleteFile(ctx, fileId); err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.DeleteFileResponse{}, nil
 }
 
 // @Summary      Ping Service
@@ -113,6 +233,58 @@ func (s *ServiceServer) IsAlive(ctx context.Context, in *pb.IsAliveRequest) (*pb
 	return &pb.IsAliveResponse{}, nil
 }
 
-func (s *ServiceServer) httpEchoPing(writer http.ResponseWriter, request *http.Request) {
+// @Summary      Get time-limited download link, file with owner is given to its owner only
+// @Param        UUID path string true "File UUID"
+// @Param        Link body pb.DeliveryGetDownloadLink true "Owner and link lifetime in seconds"
+// @Success      200  {object} pb.DownloadLink
+// @Router       /api/v1/fileapi/downloadlink/{UUID} [post]
+func (s *ServiceServer) GetDownloadLink(ctx context.Context, in *pb.DeliveryGetDownloadLink) (*pb.DownloadLink, error) {
+	fileId, err := uuid.Parse(in.GetUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidFileId.Error())
+	}
+
+	ownerId, err := parseOwnerId(in.GetOwnerUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidOwnerId.Error())
+	}
+
+	link, err := s.useCase.GetDownloadLink(ctx, fileId, ownerId.UUID, time.Second*time.Duration(in.GetExpiresIn()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.DownloadLink{
+		UUID:      link.FileUuid.String(),
+		Url:       link.Url,
+		ExpiresAt: link.ExpiresAt.Unix(),
+	}, nil
+}
+
+func fileToProto(fileInfo *models.File) *pb.File {
+	result := &pb.File{
+		UUID:         fileInfo.FileUuid.String(),
+		BusinessType: fileInfo.BusinessType,
+		FileName:     fileInfo.FileName,
+		Hash:         fileInfo.Hash,
+		Size:         uint64(fileInfo.Size),
+		ContentType:  fileInfo.ContentType,
+		Status:       fileInfo.Status,
+	}
+	if fileInfo.OwnerUuid.Valid {
+		result.OwnerUUID = fileInfo.OwnerUuid.UUID.String()
+	}
+	return result
+}
 
+// parseOwnerId parses optional owner uuid, empty string means file without owner
+func parseOwnerId(ownerId string) (uuid.NullUUID, error) {
+	if ownerId == "" {
+		return uuid.NullUUID{}, nil
+	}
+	id, err := uuid.Parse(ownerId)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}, nil
 }
@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/usecase"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	httpUploadPrefix = "/uploadfile/"
+)
+
+// @Summary      Download file content by link from GetDownloadLink, single Range is supported
+// @Param        UUID path string true "File UUID"
+// @Param        expires query int true "Link expiry unix time"
+// @Param        signature query string true "Link signature"
+// @Param        Range header string false "bytes=0-1023"
+// @Success      200  {file} file
+// @Success      206  {file} file
+// @Router       /api/v1/fileapi/signedfile/{UUID} [get]
+func (s *ServiceServer) httpSignedFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	fileId, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, usecase.SignedLinkPath))
+	if err != nil {
+		http.Error(w, ErrorInvalidFileId.Error(), http.StatusBadRequest)
+		return
+	}
+
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		http.Error(w, ErrorInvalidLink.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = s.useCase.VerifyDownloadLink(r.Context(), fileId, expires, r.URL.Query().Get("signature")); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	s.serveFile(w, r, fileId)
+}
+
+// serveFile writes file content with Range support
+func (s *ServiceServer) serveFile(w http.ResponseWriter, r *http.Request, fileId uuid.UUID) {
+	fileInfo, err := s.useCase.HeadFile(r.Context(), fileId)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if err = usecase.CheckDownloadable(fileInfo); err != nil {
+		httpError(w, err)
+		return
+	}
+
+	offset, length, partial, ok := parseRange(r.Header.Get("Range"), fileInfo.Size)
+	if !ok {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", fileInfo.Size))
+		http.Error(w, http.StatusText(http.StatusRequestedRangeNotSatisfiable), http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", strconv.Quote(fileInfo.Hash))
+	contentType := fileInfo.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileInfo.FileName}))
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+
+	code := http.StatusOK
+	if partial {
+		code = http.StatusPartialContent
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, fileInfo.Size))
+	}
+
+	if r.Method == http.MethodHead || length == 0 {
+		w.WriteHeader(code)
+		return
+	}
+
+	_, object, err := s.useCase.OpenFile(r.Context(), fileId, offset, length)
+	if err != nil {
+		w.Header().Del("Content-Length")
+		w.Header().Del("Content-Range")
+		httpError(w, err)
+		return
+	}
+	defer object.Close()
+
+	w.WriteHeader(code)
+	// Headers are already sent, broken or corrupted content is seen by client as short body
+	io.Copy(w, object)
+}
+
+// httpError writes usecase error with the same http status grpc-gateway uses for its grpc code
+func httpError(w http.ResponseWriter, err error) {
+	st, _ := status.FromError(grpcError(err))
+	http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+}
+
+// parseRange parses single range of Range header. Missing, malformed and multiple ranges mean whole file,
+// ok is false for unsatisfiable range only.
+func parseRange(header string, size int64) (offset int64, length int64, partial bool, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, size, false, true
+	}
+
+	startValue, endValue, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, size, false, true
+	}
+
+	if startValue == "" {
+		// Suffix range, last N bytes
+		suffix, err := strconv.ParseInt(endValue, 10, 64)
+		if err != nil || suffix < 0 {
+			return 0, size, false, true
+		}
+		if suffix == 0 || size == 0 {
+			return 0, 0, false, false
+		}
+		suffix = min(suffix, size)
+		return size - suffix, suffix, true, true
+	}
+
+	start, err := strconv.ParseInt(startValue, 10, 64)
+	if err != nil || start < 0 {
+		return 0, size, false, true
+	}
+	if start >= size {
+		return 0, 0, false, false
+	}
+
+	end := size - 1
+	if endValue != "" {
+		end, err = strconv.ParseInt(endValue, 10, 64)
+		if err != nil || end < start {
+			return 0, size, false, true
+		}
+		end = min(end, size-1)
+	}
+
+	return start, end - start + 1, true, true
+}
+
+// parseContentRangeStart returns start of "bytes start-end/size" header, zero when header is missing
+func parseContentRangeStart(header string) (int64, error) {
+	if header == "" {
+		return 0, nil
+	}
+
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, ErrorInvalidRange
+	}
+	startValue, _, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, ErrorInvalidRange
+	}
+
+	start, err := strconv.ParseInt(startValue, 10, 64)
+	if err != nil || start < 0 {
+		return 0, ErrorInvalidRange
+	}
+	return start, nil
+}
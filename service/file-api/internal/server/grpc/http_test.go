@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	const size = 100
+	tests := []struct {
+		name    string
+		header  string
+		offset  int64
+		length  int64
+		partial bool
+		ok      bool
+	}{
+		{name: "NoHeader", header: "", offset: 0, length: size, partial: false, ok: true},
+		{name: "Closed", header: "bytes=10-19", offset: 10, length: 10, partial: true, ok: true},
+		{name: "Open", header: "bytes=90-", offset: 90, length: 10, partial: true, ok: true},
+		{name: "Suffix", header: "bytes=-5", offset: 95, length: 5, partial: true, ok: true},
+		{name: "EndAfterSize", header: "bytes=95-200", offset: 95, length: 5, partial: true, ok: true},
+		{name: "SuffixAfterSize", header: "bytes=-200", offset: 0, length: size, partial: true, ok: true},
+		{name: "StartAfterSize", header: "bytes=100-", ok: false},
+		{name: "ZeroSuffix", header: "bytes=-0", ok: false},
+		{name: "Multiple", header: "bytes=0-1,5-6", offset: 0, length: size, partial: false, ok: true},
+		{name: "Malformed", header: "bytes=a-b", offset: 0, length: size, partial: false, ok: true},
+		{name: "Reversed", header: "bytes=20-10", offset: 0, length: size, partial: false, ok: true},
+		{name: "OtherUnit", header: "items=0-1", offset: 0, length: size, partial: false, ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offset, length, partial, ok := parseRange(test.header, size)
+			require.Equal(t, test.ok, ok)
+			if !ok {
+				return
+			}
+			require.Equal(t, test.offset, offset)
+			require.Equal(t, test.length, length)
+			require.Equal(t, test.partial, partial)
+		})
+	}
+}
+
+func TestParseContentRangeStart(t *testing.T) {
+	t.Parallel()
+
+	start, err := parseContentRangeStart("")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), start)
+
+	start, err = parseContentRangeStart("bytes 8388608-16777215/20000000")
+	require.NoError(t, err)
+	require.Equal(t, int64(8388608), start)
+
+	_, err = parseContentRangeStart("bytes */20000000")
+	require.Equal(t, ErrorInvalidRange, err)
+}
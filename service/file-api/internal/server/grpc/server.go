@@ -4,7 +4,11 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/usecase"
+	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"net/http"
@@ -22,8 +26,13 @@ const (
 )
 
 type Server struct {
-	cfg  *config.Config
-	echo *echo.Echo
+	cfg     *config.Config
+	echo    *echo.Echo
+	db      *sqlx.DB
+	redis   *redis.Client
+	storage file.Storage
+	scanner file.Scanner
+	useCase file.UseCase
 
 	grpcServer      *grpc.Server
 	httpServer      *http.Server
@@ -31,8 +40,8 @@ type Server struct {
 	logger          logger.Logger
 }
 
-func NewServer(cfg *config.Config, logger logger.Logger) *Server {
-	return &Server{echo: echo.New(), cfg: cfg, logger: logger}
+func NewServer(cfg *config.Config, db *sqlx.DB, redis *redis.Client, storage file.Storage, scanner file.Scanner, logger logger.Logger) *Server {
+	return &Server{echo: echo.New(), cfg: cfg, db: db, redis: redis, storage: storage, scanner: scanner, logger: logger}
 }
 
 func (s *Server) Run() error {
@@ -48,10 +57,14 @@ func (s *Server) Run() error {
 
 	s.grpcServer = grpc.NewServer(grpcOpts...)
 
+	ctxWithCancel, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	if s.cfg.HTTPServer.SSL {
 		if err := s.MapHandlers(s.echo); err != nil {
 			return err
 		}
+		go s.runRescan(ctxWithCancel)
 		s.echo.Server.ReadTimeout = time.Second * s.cfg.HTTPServer.ReadTimeout
 		s.echo.Server.WriteTimeout = time.Second * s.cfg.HTTPServer.WriteTimeout
 		s.echo.Server.MaxHeaderBytes = maxHeaderBytes
@@ -110,6 +123,7 @@ func (s *Server) Run() error {
 	if err := s.MapHandlers(s.echo); err != nil {
 		return err
 	}
+	go s.runRescan(ctxWithCancel)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -123,3 +137,26 @@ func (s *Server) Run() error {
 	s.logger.Info("Server Exited Properly")
 	return s.echo.Shutdown(ctx)
 }
+
+// runRescan periodically scans files left quarantined when scanner was unavailable or service was stopped
+func (s *Server) runRescan(ctx context.Context) {
+	interval := usecase.DefaultRescanInterval
+	if s.cfg.FileScan.RescanInterval > 0 {
+		interval = time.Duration(s.cfg.FileScan.RescanInterval) * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := s.useCase.RescanQuarantined(ctx); err != nil {
+			s.logger.Errorf("Error rescan quarantined files: %s", err)
+		}
+	}
+}
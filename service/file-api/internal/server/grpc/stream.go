@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/file/usecase"
+	"github.com/GCFactory/dbo-system/service/file-api/internal/models"
+	pb "github.com/GCFactory/dbo-system/service/file-api/proto/api/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// @Summary      Start resumable upload, Hash and Size are of the whole content
+// @Param        File body pb.File true "File"
+// @Success      200  {object} pb.UploadSession
+// @Router       /api/v1/fileapi/upload [post]
+func (s *ServiceServer) StartUpload(ctx context.Context, in *pb.DeliveryStartUpload) (*pb.UploadSession, error) {
+	if in.GetFile() == nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorNoFile.Error())
+	}
+
+	ownerId, err := parseOwnerId(in.GetFile().GetOwnerUUID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidOwnerId.Error())
+	}
+
+	upload, err := s.useCase.StartUpload(ctx, &models.File{
+		BusinessType: in.GetFile().GetBusinessType(),
+		FileName:     in.GetFile().GetFileName(),
+		Hash:         in.GetFile().GetHash(),
+		Size:         int64(in.GetFile().GetSize()),
+		OwnerUuid:    ownerId,
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return uploadToProto(upload), nil
+}
+
+// @Summary      Get upload offset to resume from
+// @Param        UploadId path string true "Upload id"
+// @Success      200  {object} pb.UploadSession
+// @Router       /api/v1/fileapi/upload/{UploadId} [get]
+func (s *ServiceServer) GetUpload(ctx context.Context, in *pb.DeliveryGetUpload) (*pb.UploadSession, error) {
+	uploadId, err := uuid.Parse(in.GetUploadId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidUploadId.Error())
+	}
+
+	upload, err := s.useCase.GetUpload(ctx, uploadId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return uploadToProto(upload), nil
+}
+
+func (s *ServiceServer) UploadFile(stream pb.FileApiService_UploadFileServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, ErrorNoChunks.Error())
+		}
+		return err
+	}
+
+	uploadId, err := uuid.Parse(first.GetUploadId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, ErrorInvalidUploadId.Error())
+	}
+
+	reader := &uploadStreamReader{stream: stream, pending: first.GetContent()}
+	upload, err := s.useCase.WriteUpload(stream.Context(), uploadId, int64(first.GetOffset()), reader)
+	if err != nil {
+		return grpcError(err)
+	}
+
+	return stream.SendAndClose(uploadToProto(upload))
+}
+
+// @Summary      Write upload content from offset given by Content-Range start
+// @Param        UploadId path string true "Upload id"
+// @Param        Content-Range header string false "bytes 0-8388607/10485760"
+// @Success      200  {object} pb.UploadSession
+// @Router       /api/v1/fileapi/uploadfile/{UploadId} [put]
+func (s *ServiceServer) httpUploadFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", "PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	uploadId, err := uuid.Parse(strings.TrimPrefix(r.URL.Path, httpUploadPrefix))
+	if err != nil {
+		http.Error(w, ErrorInvalidUploadId.Error(), http.StatusBadRequest)
+		return
+	}
+
+	offset, err := parseContentRangeStart(r.Header.Get("Content-Range"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	upload, err := s.useCase.WriteUpload(r.Context(), uploadId, offset, r.Body)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	body, err := protojson.Marshal(uploadToProto(upload))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// @Summary      Check hash of uploaded content and save file
+// @Param        UploadId path string true "Upload id"
+// @Success      200  {object} pb.File
+// @Router       /api/v1/fileapi/upload/{UploadId}/complete [post]
+func (s *ServiceServer) CompleteUpload(ctx context.Context, in *pb.DeliveryCompleteUpload) (*pb.File, error) {
+	uploadId, err := uuid.Parse(in.GetUploadId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidUploadId.Error())
+	}
+
+	fileInfo, err := s.useCase.CompleteUpload(ctx, uploadId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return fileToProto(fileInfo), nil
+}
+
+// @Summary      Abort upload and drop received content
+// @Param        UploadId path string true "Upload id"
+// @Success      200  {object} pb.AbortUploadResponse
+// @Router       /api/v1/fileapi/upload/{UploadId} [delete]
+func (s *ServiceServer) AbortUpload(ctx context.Context, in *pb.DeliveryAbortUpload) (*pb.AbortUploadResponse, error) {
+	uploadId, err := uuid.Parse(in.GetUploadId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, ErrorInvalidUploadId.Error())
+	}
+
+	if err = s.useCase.AbortUpload(ctx, uploadId); err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.AbortUploadResponse{}, nil
+}
+
+func (s *ServiceServer) DownloadFile(in *pb.DeliveryDownloadFile, stream pb.FileApiService_DownloadFileServer) error {
+	fileId, err := uuid.Parse(in.GetUUID())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, ErrorInvalidFileId.Error())
+	}
+
+	fileInfo, object, err := s.useCase.OpenFile(stream.Context(), fileId, int64(in.GetOffset()), int64(in.GetLength()))
+	if err != nil {
+		return grpcError(err)
+	}
+	defer object.Close()
+
+	chunk := &pb.FileChunk{
+		File:   fileToProto(fileInfo),
+		Offset: in.GetOffset(),
+	}
+	buffer := make([]byte, usecase.DownloadChunkSize)
+	for {
+		n, err := io.ReadFull(object, buffer)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return grpcError(err)
+		}
+		if n == 0 && chunk.File == nil {
+			return nil
+		}
+
+		chunk.Content = buffer[:n]
+		if sendErr := stream.Send(chunk); sendErr != nil {
+			return sendErr
+		}
+		if err != nil {
+			return nil
+		}
+
+		chunk = &pb.FileChunk{Offset: chunk.GetOffset() + uint64(n)}
+	}
+}
+
+// uploadStreamReader reads content of upload chunks as one stream
+type uploadStreamReader struct {
+	stream  pb.FileApiService_UploadFileServer
+	pending []byte
+}
+
+func (r *uploadStreamReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = chunk.GetContent()
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func uploadToProto(upload *models.Upload) *pb.UploadSession {
+	return &pb.UploadSession{
+		UploadId: upload.UploadUuid.String(),
+		FileUUID: upload.FileUuid.String(),
+		Offset:   uint64(upload.Received),
+		Size:     uint64(upload.Size),
+		PartSize: usecase.UploadPartSize,
+	}
+}
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS file CASCADE;
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE file
+(
+    file_uuid           UUID                PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    business_type       varchar(64)         NOT NULL,
+    file_name           varchar(256)        NOT NULL,
+    hash                char(64)            NOT NULL,
+    size                bigint              NOT NULL        DEFAULT 0,
+    created_at          timestamptz         NOT NULL        DEFAULT now()
+);
+
+CREATE INDEX file_business_type_idx ON file (business_type);
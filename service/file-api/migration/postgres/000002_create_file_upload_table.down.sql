@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS file_upload_part CASCADE;
+DROP TABLE IF EXISTS file_upload CASCADE;
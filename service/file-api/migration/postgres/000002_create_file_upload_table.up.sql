@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS file_upload CASCADE;
+DROP TABLE IF EXISTS file_upload_part CASCADE;
+
+CREATE TABLE file_upload
+(
+    upload_uuid         UUID                PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    file_uuid           UUID                NOT NULL,
+    business_type       varchar(64)         NOT NULL,
+    file_name           varchar(256)        NOT NULL,
+    hash                char(64)            NOT NULL,
+    size                bigint              NOT NULL,
+    storage_upload_id   varchar(1024)       NOT NULL,
+    received            bigint              NOT NULL        DEFAULT 0,
+    hash_state          bytea               NOT NULL,
+    created_at          timestamptz         NOT NULL        DEFAULT now(),
+    updated_at          timestamptz         NOT NULL        DEFAULT now()
+);
+
+CREATE TABLE file_upload_part
+(
+    upload_uuid         UUID                NOT NULL        REFERENCES file_upload (upload_uuid) ON DELETE CASCADE,
+    part_number         integer             NOT NULL,
+    etag                varchar(256)        NOT NULL,
+    size                bigint              NOT NULL,
+    PRIMARY KEY (upload_uuid, part_number)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS file_owner_uuid_idx;
+
+ALTER TABLE file_upload DROP COLUMN IF EXISTS owner_uuid;
+ALTER TABLE file DROP COLUMN IF EXISTS owner_uuid;
@@ -0,0 +1,4 @@
+ALTER TABLE file ADD COLUMN IF NOT EXISTS owner_uuid UUID;
+ALTER TABLE file_upload ADD COLUMN IF NOT EXISTS owner_uuid UUID;
+
+CREATE INDEX IF NOT EXISTS file_owner_uuid_idx ON file (owner_uuid);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS file_quarantined_idx;
+
+ALTER TABLE file DROP COLUMN IF EXISTS scan_result;
+ALTER TABLE file DROP COLUMN IF EXISTS status;
+ALTER TABLE file DROP COLUMN IF EXISTS content_type;
@@ -0,0 +1,6 @@
+-- Files uploaded before scanning are considered clean
+ALTER TABLE file ADD COLUMN IF NOT EXISTS content_type varchar(128) NOT NULL DEFAULT 'application/octet-stream';
+ALTER TABLE file ADD COLUMN IF NOT EXISTS status varchar(16) NOT NULL DEFAULT 'clean';
+ALTER TABLE file ADD COLUMN IF NOT EXISTS scan_result varchar(256) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS file_quarantined_idx ON file (created_at) WHERE status = 'quarantined';
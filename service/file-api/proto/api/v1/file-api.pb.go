@@ -144,31 +144,713 @@ func (x *DeliveryGetFile) GetUUID() string {
 	return ""
 }
 
+type DeliveryHeadFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID string `protobuf:"bytes,101,opt,name=UUID,proto3" json:"UUID,omitempty"`
+}
+
+func (x *DeliveryHeadFile) Reset() {
+	*x = DeliveryHeadFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryHeadFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryHeadFile) ProtoMessage() {}
+
+func (x *DeliveryHeadFile) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryHeadFile.ProtoReflect.Descriptor instead.
+func (*DeliveryHeadFile) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeliveryHeadFile) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+type DeliveryDeleteFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID string `protobuf:"bytes,101,opt,name=UUID,proto3" json:"UUID,omitempty"`
+}
+
+func (x *DeliveryDeleteFile) Reset() {
+	*x = DeliveryDeleteFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryDeleteFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryDeleteFile) ProtoMessage() {}
+
+func (x *DeliveryDeleteFile) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryDeleteFile.ProtoReflect.Descriptor instead.
+func (*DeliveryDeleteFile) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveryDeleteFile) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{5}
+}
+
 type DeliveryPutFile struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	File *File `protobuf:"bytes,21,opt,name=File,proto3" json:"File,omitempty"`
+	File *File `protobuf:"bytes,21,opt,name=File,proto3" json:"File,omitempty"`
+}
+
+func (x *DeliveryPutFile) Reset() {
+	*x = DeliveryPutFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryPutFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryPutFile) ProtoMessage() {}
+
+func (x *DeliveryPutFile) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryPutFile.ProtoReflect.Descriptor instead.
+func (*DeliveryPutFile) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeliveryPutFile) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type DeliveryStartUpload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File metadata, Hash and Size are of the whole content
+	File *File `protobuf:"bytes,21,opt,name=File,proto3" json:"File,omitempty"`
+}
+
+func (x *DeliveryStartUpload) Reset() {
+	*x = DeliveryStartUpload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryStartUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryStartUpload) ProtoMessage() {}
+
+func (x *DeliveryStartUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryStartUpload.ProtoReflect.Descriptor instead.
+func (*DeliveryStartUpload) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeliveryStartUpload) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type DeliveryGetUpload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,101,opt,name=UploadId,proto3" json:"UploadId,omitempty"`
+}
+
+func (x *DeliveryGetUpload) Reset() {
+	*x = DeliveryGetUpload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryGetUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryGetUpload) ProtoMessage() {}
+
+func (x *DeliveryGetUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryGetUpload.ProtoReflect.Descriptor instead.
+func (*DeliveryGetUpload) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeliveryGetUpload) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type DeliveryCompleteUpload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,101,opt,name=UploadId,proto3" json:"UploadId,omitempty"`
+}
+
+func (x *DeliveryCompleteUpload) Reset() {
+	*x = DeliveryCompleteUpload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryCompleteUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryCompleteUpload) ProtoMessage() {}
+
+func (x *DeliveryCompleteUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryCompleteUpload.ProtoReflect.Descriptor instead.
+func (*DeliveryCompleteUpload) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeliveryCompleteUpload) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type DeliveryAbortUpload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,101,opt,name=UploadId,proto3" json:"UploadId,omitempty"`
+}
+
+func (x *DeliveryAbortUpload) Reset() {
+	*x = DeliveryAbortUpload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryAbortUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAbortUpload) ProtoMessage() {}
+
+func (x *DeliveryAbortUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAbortUpload.ProtoReflect.Descriptor instead.
+func (*DeliveryAbortUpload) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeliveryAbortUpload) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{11}
+}
+
+type UploadSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId string `protobuf:"bytes,21,opt,name=UploadId,proto3" json:"UploadId,omitempty"`
+	FileUUID string `protobuf:"bytes,22,opt,name=FileUUID,proto3" json:"FileUUID,omitempty"`
+	// Stored bytes, upload is resumed from this offset
+	Offset   uint64 `protobuf:"varint,23,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Size     uint64 `protobuf:"varint,24,opt,name=Size,proto3" json:"Size,omitempty"`
+	PartSize uint64 `protobuf:"varint,25,opt,name=PartSize,proto3" json:"PartSize,omitempty"`
+}
+
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadSession) ProtoMessage() {}
+
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UploadSession) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadSession) GetFileUUID() string {
+	if x != nil {
+		return x.FileUUID
+	}
+	return ""
+}
+
+func (x *UploadSession) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadSession) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadSession) GetPartSize() uint64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+type UploadChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UploadId and Offset are read from the first chunk only
+	UploadId string `protobuf:"bytes,21,opt,name=UploadId,proto3" json:"UploadId,omitempty"`
+	Offset   uint64 `protobuf:"varint,22,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Content  []byte `protobuf:"bytes,23,opt,name=Content,proto3" json:"Content,omitempty"`
+}
+
+func (x *UploadChunk) Reset() {
+	*x = UploadChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunk) ProtoMessage() {}
+
+func (x *UploadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunk.ProtoReflect.Descriptor instead.
+func (*UploadChunk) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UploadChunk) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadChunk) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadChunk) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type DeliveryDownloadFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID   string `protobuf:"bytes,101,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Offset uint64 `protobuf:"varint,102,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Zero length means till the end of file
+	Length uint64 `protobuf:"varint,103,opt,name=Length,proto3" json:"Length,omitempty"`
+}
+
+func (x *DeliveryDownloadFile) Reset() {
+	*x = DeliveryDownloadFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryDownloadFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryDownloadFile) ProtoMessage() {}
+
+func (x *DeliveryDownloadFile) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryDownloadFile.ProtoReflect.Descriptor instead.
+func (*DeliveryDownloadFile) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeliveryDownloadFile) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *DeliveryDownloadFile) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *DeliveryDownloadFile) GetLength() uint64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type FileChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// File metadata is sent in the first chunk only
+	File    *File  `protobuf:"bytes,21,opt,name=File,proto3" json:"File,omitempty"`
+	Offset  uint64 `protobuf:"varint,22,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Content []byte `protobuf:"bytes,23,opt,name=Content,proto3" json:"Content,omitempty"`
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FileChunk) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *FileChunk) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileChunk) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type DeliveryGetDownloadLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID string `protobuf:"bytes,101,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	// User requesting link, must be file owner when file has one
+	OwnerUUID string `protobuf:"bytes,102,opt,name=OwnerUUID,proto3" json:"OwnerUUID,omitempty"`
+	// Link lifetime in seconds, zero means default
+	ExpiresIn uint64 `protobuf:"varint,103,opt,name=ExpiresIn,proto3" json:"ExpiresIn,omitempty"`
 }
 
-func (x *DeliveryPutFile) Reset() {
-	*x = DeliveryPutFile{}
+func (x *DeliveryGetDownloadLink) Reset() {
+	*x = DeliveryGetDownloadLink{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_file_api_proto_msgTypes[3]
+		mi := &file_file_api_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeliveryPutFile) String() string {
+func (x *DeliveryGetDownloadLink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeliveryPutFile) ProtoMessage() {}
+func (*DeliveryGetDownloadLink) ProtoMessage() {}
 
-func (x *DeliveryPutFile) ProtoReflect() protoreflect.Message {
-	mi := &file_file_api_proto_msgTypes[3]
+func (x *DeliveryGetDownloadLink) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,16 +861,94 @@ func (x *DeliveryPutFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeliveryPutFile.ProtoReflect.Descriptor instead.
-func (*DeliveryPutFile) Descriptor() ([]byte, []int) {
-	return file_file_api_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DeliveryGetDownloadLink.ProtoReflect.Descriptor instead.
+func (*DeliveryGetDownloadLink) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeliveryPutFile) GetFile() *File {
+func (x *DeliveryGetDownloadLink) GetUUID() string {
 	if x != nil {
-		return x.File
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *DeliveryGetDownloadLink) GetOwnerUUID() string {
+	if x != nil {
+		return x.OwnerUUID
+	}
+	return ""
+}
+
+func (x *DeliveryGetDownloadLink) GetExpiresIn() uint64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type DownloadLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UUID string `protobuf:"bytes,21,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	Url  string `protobuf:"bytes,22,opt,name=Url,proto3" json:"Url,omitempty"`
+	// Unix time in seconds
+	ExpiresAt int64 `protobuf:"varint,23,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+}
+
+func (x *DownloadLink) Reset() {
+	*x = DownloadLink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_file_api_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
+}
+
+func (x *DownloadLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadLink) ProtoMessage() {}
+
+func (x *DownloadLink) ProtoReflect() protoreflect.Message {
+	mi := &file_file_api_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadLink.ProtoReflect.Descriptor instead.
+func (*DownloadLink) Descriptor() ([]byte, []int) {
+	return file_file_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DownloadLink) GetUUID() string {
+	if x != nil {
+		return x.UUID
+	}
+	return ""
+}
+
+func (x *DownloadLink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DownloadLink) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
 }
 
 type File struct {
@@ -198,16 +958,22 @@ type File struct {
 
 	UUID         string `protobuf:"bytes,21,opt,name=UUID,proto3" json:"UUID,omitempty"`
 	BusinessType string `protobuf:"bytes,22,opt,name=BusinessType,proto3" json:"BusinessType,omitempty"`
-	FileName     string `protobuf:"bytes,30,opt,name=FileName,proto3" json:"FileName,omitempty"`
-	Hash         string `protobuf:"bytes,40,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	Size         uint64 `protobuf:"varint,41,opt,name=Size,proto3" json:"Size,omitempty"`
-	Content      []byte `protobuf:"bytes,42,opt,name=Content,proto3,oneof" json:"Content,omitempty"`
+	// Empty for service documents
+	OwnerUUID string `protobuf:"bytes,23,opt,name=OwnerUUID,proto3" json:"OwnerUUID,omitempty"`
+	// Detected by content magic bytes
+	ContentType string `protobuf:"bytes,24,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// quarantined until scanned, then clean or infected, only clean files can be downloaded
+	Status   string `protobuf:"bytes,25,opt,name=Status,proto3" json:"Status,omitempty"`
+	FileName string `protobuf:"bytes,30,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	Hash     string `protobuf:"bytes,40,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Size     uint64 `protobuf:"varint,41,opt,name=Size,proto3" json:"Size,omitempty"`
+	Content  []byte `protobuf:"bytes,42,opt,name=Content,proto3,oneof" json:"Content,omitempty"`
 }
 
 func (x *File) Reset() {
 	*x = File{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_file_api_proto_msgTypes[4]
+		mi := &file_file_api_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -220,7 +986,7 @@ func (x *File) String() string {
 func (*File) ProtoMessage() {}
 
 func (x *File) ProtoReflect() protoreflect.Message {
-	mi := &file_file_api_proto_msgTypes[4]
+	mi := &file_file_api_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,7 +999,7 @@ func (x *File) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use File.ProtoReflect.Descriptor instead.
 func (*File) Descriptor() ([]byte, []int) {
-	return file_file_api_proto_rawDescGZIP(), []int{4}
+	return file_file_api_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *File) GetUUID() string {
@@ -250,6 +1016,27 @@ func (x *File) GetBusinessType() string {
 	return ""
 }
 
+func (x *File) GetOwnerUUID() string {
+	if x != nil {
+		return x.OwnerUUID
+	}
+	return ""
+}
+
+func (x *File) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *File) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 func (x *File) GetFileName() string {
 	if x != nil {
 		return x.FileName
@@ -289,32 +1076,146 @@ var file_file_api_proto_rawDesc = []byte{
 	0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x47,
 	0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x65,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x65,
-	0x52, 0x02, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
-	0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18,
-	0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x46, 0x69,
-	0x6c, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x22, 0xb9, 0x01, 0x0a, 0x04, 0x46, 0x69, 0x6c,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x42, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73,
-	0x73, 0x54, 0x79, 0x70, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x42, 0x75, 0x73,
-	0x69, 0x6e, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x28, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a,
-	0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a,
-	0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
-	0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08,
-	0x5f, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x4a, 0x04,
-	0x08, 0x1f, 0x10, 0x28, 0x32, 0x90, 0x01, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x70, 0x69,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x69,
-	0x6c, 0x65, 0x12, 0x10, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x47, 0x65, 0x74,
-	0x46, 0x69, 0x6c, 0x65, 0x1a, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x67, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x7b, 0x55,
-	0x55, 0x49, 0x44, 0x7d, 0x12, 0x41, 0x0a, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12,
-	0x0f, 0x2e, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x10, 0x2e, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x72, 0x65, 0x61,
-	0x64, 0x79, 0x2f, 0x6c, 0x69, 0x76, 0x65, 0x42, 0x08, 0x5a, 0x06, 0x61, 0x70, 0x69, 0x2f, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x02, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x48, 0x65, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x65, 0x22, 0x2e, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x46,
+	0x69, 0x6c, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x22, 0x36, 0x0a, 0x13,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x15, 0x22, 0x35, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x65, 0x22, 0x3a, 0x0a, 0x16, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49,
+	0x64, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49,
+	0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x65, 0x22, 0x37, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x65,
+	0x22, 0x15, 0x0a, 0x13, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x95, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x55, 0x49,
+	0x44, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x55, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x50, 0x61, 0x72, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x50, 0x61, 0x72, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x22,
+	0x61, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1a,
+	0x0a, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x01,
+	0x10, 0x15, 0x22, 0x60, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55,
+	0x49, 0x44, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x16,
+	0x0a, 0x06, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x66, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x67, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x65, 0x22, 0x5e, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x19, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x4f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x17, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x15, 0x22, 0x6f, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79,
+	0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12,
+	0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x65, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55,
+	0x55, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x66, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x55, 0x49,
+	0x44, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x18, 0x67,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x49, 0x6e, 0x4a,
+	0x04, 0x08, 0x01, 0x10, 0x65, 0x22, 0x58, 0x0a, 0x0c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x72, 0x6c,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x22,
+	0x91, 0x02, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x55, 0x55, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c,
+	0x42, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x42, 0x75, 0x73, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1c, 0x0a, 0x09, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x55, 0x49, 0x44, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x55, 0x55, 0x49, 0x44, 0x12, 0x20,
+	0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x28, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x29, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x07,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52,
+	0x07, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x15, 0x4a, 0x04, 0x08,
+	0x1f, 0x10, 0x28, 0x32, 0xe7, 0x06, 0x0a, 0x0e, 0x46, 0x69, 0x6c, 0x65, 0x41, 0x70, 0x69, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x10, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x47, 0x65, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x1a, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x11, 0x12, 0x0f, 0x2f, 0x67, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x7b, 0x55, 0x55,
+	0x49, 0x44, 0x7d, 0x12, 0x3a, 0x0a, 0x07, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10,
+	0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x50, 0x75, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x1a, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a,
+	0x04, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x08, 0x2f, 0x70, 0x75, 0x74, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x3e, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x11, 0x2e, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x48, 0x65, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x1a, 0x05,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f,
+	0x68, 0x65, 0x61, 0x64, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x7b, 0x55, 0x55, 0x49, 0x44, 0x7d, 0x12,
+	0x52, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x13, 0x2e,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x1a, 0x13, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x2a,
+	0x12, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x7b, 0x55, 0x55,
+	0x49, 0x44, 0x7d, 0x12, 0x4a, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x14, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x0e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f,
+	0x3a, 0x04, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x07, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x4b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x2e, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x1a, 0x0e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x2f, 0x7b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x7d, 0x12, 0x2c, 0x0a, 0x0a,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x0c, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x0e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x28, 0x01, 0x12, 0x55, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x17, 0x2e, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x23, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1d, 0x22, 0x1b, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2f, 0x7b, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x7d, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x12, 0x55, 0x0a, 0x0b, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x12, 0x14, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x1a, 0x14, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x14, 0x2a, 0x12, 0x2f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x2f, 0x7b, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x7d, 0x12, 0x33, 0x0a, 0x0c, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x1a,
+	0x0a, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x5b, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b,
+	0x12, 0x18, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x47, 0x65, 0x74, 0x44, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x1a, 0x0d, 0x2e, 0x44, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x6b, 0x2f, 0x7b, 0x55, 0x55, 0x49, 0x44, 0x7d, 0x12, 0x41, 0x0a, 0x07, 0x49, 0x73,
+	0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x0f, 0x2e, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d,
+	0x12, 0x0b, 0x2f, 0x72, 0x65, 0x61, 0x64, 0x79, 0x2f, 0x6c, 0x69, 0x76, 0x65, 0x42, 0x08, 0x5a,
+	0x06, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -329,25 +1230,61 @@ func file_file_api_proto_rawDescGZIP() []byte {
 	return file_file_api_proto_rawDescData
 }
 
-var file_file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_file_api_proto_goTypes = []interface{}{
-	(*IsAliveRequest)(nil),  // 0: IsAliveRequest
-	(*IsAliveResponse)(nil), // 1: IsAliveResponse
-	(*DeliveryGetFile)(nil), // 2: DeliveryGetFile
-	(*DeliveryPutFile)(nil), // 3: DeliveryPutFile
-	(*File)(nil),            // 4: File
+	(*IsAliveRequest)(nil),          // 0: IsAliveRequest
+	(*IsAliveResponse)(nil),         // 1: IsAliveResponse
+	(*DeliveryGetFile)(nil),         // 2: DeliveryGetFile
+	(*DeliveryHeadFile)(nil),        // 3: DeliveryHeadFile
+	(*DeliveryDeleteFile)(nil),      // 4: DeliveryDeleteFile
+	(*DeleteFileResponse)(nil),      // 5: DeleteFileResponse
+	(*DeliveryPutFile)(nil),         // 6: DeliveryPutFile
+	(*DeliveryStartUpload)(nil),     // 7: DeliveryStartUpload
+	(*DeliveryGetUpload)(nil),       // 8: DeliveryGetUpload
+	(*DeliveryCompleteUpload)(nil),  // 9: DeliveryCompleteUpload
+	(*DeliveryAbortUpload)(nil),     // 10: DeliveryAbortUpload
+	(*AbortUploadResponse)(nil),     // 11: AbortUploadResponse
+	(*UploadSession)(nil),           // 12: UploadSession
+	(*UploadChunk)(nil),             // 13: UploadChunk
+	(*DeliveryDownloadFile)(nil),    // 14: DeliveryDownloadFile
+	(*FileChunk)(nil),               // 15: FileChunk
+	(*DeliveryGetDownloadLink)(nil), // 16: DeliveryGetDownloadLink
+	(*DownloadLink)(nil),            // 17: DownloadLink
+	(*File)(nil),                    // 18: File
 }
 var file_file_api_proto_depIdxs = []int32{
-	4, // 0: DeliveryPutFile.File:type_name -> File
-	2, // 1: FileApiService.GetFile:input_type -> DeliveryGetFile
-	0, // 2: FileApiService.IsAlive:input_type -> IsAliveRequest
-	4, // 3: FileApiService.GetFile:output_type -> File
-	1, // 4: FileApiService.IsAlive:output_type -> IsAliveResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	18, // 0: DeliveryPutFile.File:type_name -> File
+	18, // 1: DeliveryStartUpload.File:type_name -> File
+	18, // 2: FileChunk.File:type_name -> File
+	2,  // 3: FileApiService.GetFile:input_type -> DeliveryGetFile
+	6,  // 4: FileApiService.PutFile:input_type -> DeliveryPutFile
+	3,  // 5: FileApiService.HeadFile:input_type -> DeliveryHeadFile
+	4,  // 6: FileApiService.DeleteFile:input_type -> DeliveryDeleteFile
+	7,  // 7: FileApiService.StartUpload:input_type -> DeliveryStartUpload
+	8,  // 8: FileApiService.GetUpload:input_type -> DeliveryGetUpload
+	13, // 9: FileApiService.UploadFile:input_type -> UploadChunk
+	9,  // 10: FileApiService.CompleteUpload:input_type -> DeliveryCompleteUpload
+	10, // 11: FileApiService.AbortUpload:input_type -> DeliveryAbortUpload
+	14, // 12: FileApiService.DownloadFile:input_type -> DeliveryDownloadFile
+	16, // 13: FileApiService.GetDownloadLink:input_type -> DeliveryGetDownloadLink
+	0,  // 14: FileApiService.IsAlive:input_type -> IsAliveRequest
+	18, // 15: FileApiService.GetFile:output_type -> File
+	18, // 16: FileApiService.PutFile:output_type -> File
+	18, // 17: FileApiService.HeadFile:output_type -> File
+	5,  // 18: FileApiService.DeleteFile:output_type -> DeleteFileResponse
+	12, // 19: FileApiService.StartUpload:output_type -> UploadSession
+	12, // 20: FileApiService.GetUpload:output_type -> UploadSession
+	12, // 21: FileApiService.UploadFile:output_type -> UploadSession
+	18, // 22: FileApiService.CompleteUpload:output_type -> File
+	11, // 23: FileApiService.AbortUpload:output_type -> AbortUploadResponse
+	15, // 24: FileApiService.DownloadFile:output_type -> FileChunk
+	17, // 25: FileApiService.GetDownloadLink:output_type -> DownloadLink
+	1,  // 26: FileApiService.IsAlive:output_type -> IsAliveResponse
+	15, // [15:27] is the sub-list for method output_type
+	3,  // [3:15] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_file_api_proto_init() }
@@ -393,7 +1330,7 @@ func file_file_api_proto_init() {
 			}
 		}
 		file_file_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeliveryPutFile); i {
+			switch v := v.(*DeliveryHeadFile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -405,6 +1342,174 @@ func file_file_api_proto_init() {
 			}
 		}
 		file_file_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryDeleteFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryPutFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryStartUpload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryGetUpload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryCompleteUpload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryAbortUpload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortUploadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryDownloadFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryGetDownloadLink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadLink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_file_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*File); i {
 			case 0:
 				return &v.state
@@ -417,14 +1522,14 @@ func file_file_api_proto_init() {
 			}
 		}
 	}
-	file_file_api_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_file_api_proto_msgTypes[18].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_file_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FileApiService_GetFile_FullMethodName = "/FileApiService/GetFile"
-	FileApiService_IsAlive_FullMethodName = "/FileApiService/IsAlive"
+	FileApiService_GetFile_FullMethodName         = "/FileApiService/GetFile"
+	FileApiService_PutFile_FullMethodName         = "/FileApiService/PutFile"
+	FileApiService_HeadFile_FullMethodName        = "/FileApiService/HeadFile"
+	FileApiService_DeleteFile_FullMethodName      = "/FileApiService/DeleteFile"
+	FileApiService_StartUpload_FullMethodName     = "/FileApiService/StartUpload"
+	FileApiService_GetUpload_FullMethodName       = "/FileApiService/GetUpload"
+	FileApiService_UploadFile_FullMethodName      = "/FileApiService/UploadFile"
+	FileApiService_CompleteUpload_FullMethodName  = "/FileApiService/CompleteUpload"
+	FileApiService_AbortUpload_FullMethodName     = "/FileApiService/AbortUpload"
+	FileApiService_DownloadFile_FullMethodName    = "/FileApiService/DownloadFile"
+	FileApiService_GetDownloadLink_FullMethodName = "/FileApiService/GetDownloadLink"
+	FileApiService_IsAlive_FullMethodName         = "/FileApiService/IsAlive"
 )
 
 // FileApiServiceClient is the client API for FileApiService service.
@@ -28,6 +38,23 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type FileApiServiceClient interface {
 	GetFile(ctx context.Context, in *DeliveryGetFile, opts ...grpc.CallOption) (*File, error)
+	PutFile(ctx context.Context, in *DeliveryPutFile, opts ...grpc.CallOption) (*File, error)
+	// Returns file metadata without content
+	HeadFile(ctx context.Context, in *DeliveryHeadFile, opts ...grpc.CallOption) (*File, error)
+	DeleteFile(ctx context.Context, in *DeliveryDeleteFile, opts ...grpc.CallOption) (*DeleteFileResponse, error)
+	// Resumable upload: StartUpload, then UploadFile streams from session Offset
+	// (GetUpload after broken stream), then CompleteUpload checks hash and saves file
+	StartUpload(ctx context.Context, in *DeliveryStartUpload, opts ...grpc.CallOption) (*UploadSession, error)
+	GetUpload(ctx context.Context, in *DeliveryGetUpload, opts ...grpc.CallOption) (*UploadSession, error)
+	// HTTP clients use PUT /uploadfile/{UploadId} with Content-Range instead
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (FileApiService_UploadFileClient, error)
+	CompleteUpload(ctx context.Context, in *DeliveryCompleteUpload, opts ...grpc.CallOption) (*File, error)
+	AbortUpload(ctx context.Context, in *DeliveryAbortUpload, opts ...grpc.CallOption) (*AbortUploadResponse, error)
+	// For internal gRPC clients only, HTTP clients download by link from GetDownloadLink
+	DownloadFile(ctx context.Context, in *DeliveryDownloadFile, opts ...grpc.CallOption) (FileApiService_DownloadFileClient, error)
+	// Time-limited link for clients to download content without proxying it, S3 presigned
+	// or HMAC signed GET /signedfile/{UUID} for storages without presigning
+	GetDownloadLink(ctx context.Context, in *DeliveryGetDownloadLink, opts ...grpc.CallOption) (*DownloadLink, error)
 	// FOR TESTING ONLY
 	IsAlive(ctx context.Context, in *IsAliveRequest, opts ...grpc.CallOption) (*IsAliveResponse, error)
 }
@@ -49,6 +76,144 @@ func (c *fileApiServiceClient) GetFile(ctx context.Context, in *DeliveryGetFile,
 	return out, nil
 }
 
+func (c *fileApiServiceClient) PutFile(ctx context.Context, in *DeliveryPutFile, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, FileApiService_PutFile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) HeadFile(ctx context.Context, in *DeliveryHeadFile, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, FileApiService_HeadFile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) DeleteFile(ctx context.Context, in *DeliveryDeleteFile, opts ...grpc.CallOption) (*DeleteFileResponse, error) {
+	out := new(DeleteFileResponse)
+	err := c.cc.Invoke(ctx, FileApiService_DeleteFile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) StartUpload(ctx context.Context, in *DeliveryStartUpload, opts ...grpc.CallOption) (*UploadSession, error) {
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, FileApiService_StartUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) GetUpload(ctx context.Context, in *DeliveryGetUpload, opts ...grpc.CallOption) (*UploadSession, error) {
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, FileApiService_GetUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (FileApiService_UploadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FileApiService_ServiceDesc.Streams[0], FileApiService_UploadFile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileApiServiceUploadFileClient{stream}
+	return x, nil
+}
+
+type FileApiService_UploadFileClient interface {
+	Send(*UploadChunk) error
+	CloseAndRecv() (*UploadSession, error)
+	grpc.ClientStream
+}
+
+type fileApiServiceUploadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileApiServiceUploadFileClient) Send(m *UploadChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fileApiServiceUploadFileClient) CloseAndRecv() (*UploadSession, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadSession)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileApiServiceClient) CompleteUpload(ctx context.Context, in *DeliveryCompleteUpload, opts ...grpc.CallOption) (*File, error) {
+	out := new(File)
+	err := c.cc.Invoke(ctx, FileApiService_CompleteUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) AbortUpload(ctx context.Context, in *DeliveryAbortUpload, opts ...grpc.CallOption) (*AbortUploadResponse, error) {
+	out := new(AbortUploadResponse)
+	err := c.cc.Invoke(ctx, FileApiService_AbortUpload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileApiServiceClient) DownloadFile(ctx context.Context, in *DeliveryDownloadFile, opts ...grpc.CallOption) (FileApiService_DownloadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FileApiService_ServiceDesc.Streams[1], FileApiService_DownloadFile_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileApiServiceDownloadFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileApiService_DownloadFileClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type fileApiServiceDownloadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileApiServiceDownloadFileClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileApiServiceClient) GetDownloadLink(ctx context.Context, in *DeliveryGetDownloadLink, opts ...grpc.CallOption) (*DownloadLink, error) {
+	out := new(DownloadLink)
+	err := c.cc.Invoke(ctx, FileApiService_GetDownloadLink_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fileApiServiceClient) IsAlive(ctx context.Context, in *IsAliveRequest, opts ...grpc.CallOption) (*IsAliveResponse, error) {
 	out := new(IsAliveResponse)
 	err := c.cc.Invoke(ctx, FileApiService_IsAlive_FullMethodName, in, out, opts...)
@@ -63,6 +228,23 @@ func (c *fileApiServiceClient) IsAlive(ctx context.Context, in *IsAliveRequest,
 // for forward compatibility
 type FileApiServiceServer interface {
 	GetFile(context.Context, *DeliveryGetFile) (*File, error)
+	PutFile(context.Context, *DeliveryPutFile) (*File, error)
+	// Returns file metadata without content
+	HeadFile(context.Context, *DeliveryHeadFile) (*File, error)
+	DeleteFile(context.Context, *DeliveryDeleteFile) (*DeleteFileResponse, error)
+	// Resumable upload: StartUpload, then UploadFile streams from session Offset
+	// (GetUpload after broken stream), then CompleteUpload checks hash and saves file
+	StartUpload(context.Context, *DeliveryStartUpload) (*UploadSession, error)
+	GetUpload(context.Context, *DeliveryGetUpload) (*UploadSession, error)
+	// HTTP clients use PUT /uploadfile/{UploadId} with Content-Range instead
+	UploadFile(FileApiService_UploadFileServer) error
+	CompleteUpload(context.Context, *DeliveryCompleteUpload) (*File, error)
+	AbortUpload(context.Context, *DeliveryAbortUpload) (*AbortUploadResponse, error)
+	// For internal gRPC clients only, HTTP clients download by link from GetDownloadLink
+	DownloadFile(*DeliveryDownloadFile, FileApiService_DownloadFileServer) error
+	// Time-limited link for clients to download content without proxying it, S3 presigned
+	// or HMAC signed GET /signedfile/{UUID} for storages without presigning
+	GetDownloadLink(context.Context, *DeliveryGetDownloadLink) (*DownloadLink, error)
 	// FOR TESTING ONLY
 	IsAlive(context.Context, *IsAliveRequest) (*IsAliveResponse, error)
 	mustEmbedUnimplementedFileApiServiceServer()
@@ -75,6 +257,36 @@ type UnimplementedFileApiServiceServer struct {
 func (UnimplementedFileApiServiceServer) GetFile(context.Context, *DeliveryGetFile) (*File, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
 }
+func (UnimplementedFileApiServiceServer) PutFile(context.Context, *DeliveryPutFile) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutFile not implemented")
+}
+func (UnimplementedFileApiServiceServer) HeadFile(context.Context, *DeliveryHeadFile) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HeadFile not implemented")
+}
+func (UnimplementedFileApiServiceServer) DeleteFile(context.Context, *DeliveryDeleteFile) (*DeleteFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFile not implemented")
+}
+func (UnimplementedFileApiServiceServer) StartUpload(context.Context, *DeliveryStartUpload) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartUpload not implemented")
+}
+func (UnimplementedFileApiServiceServer) GetUpload(context.Context, *DeliveryGetUpload) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUpload not implemented")
+}
+func (UnimplementedFileApiServiceServer) UploadFile(FileApiService_UploadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedFileApiServiceServer) CompleteUpload(context.Context, *DeliveryCompleteUpload) (*File, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedFileApiServiceServer) AbortUpload(context.Context, *DeliveryAbortUpload) (*AbortUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
+func (UnimplementedFileApiServiceServer) DownloadFile(*DeliveryDownloadFile, FileApiService_DownloadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
+}
+func (UnimplementedFileApiServiceServer) GetDownloadLink(context.Context, *DeliveryGetDownloadLink) (*DownloadLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDownloadLink not implemented")
+}
 func (UnimplementedFileApiServiceServer) IsAlive(context.Context, *IsAliveRequest) (*IsAliveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IsAlive not implemented")
 }
@@ -109,6 +321,197 @@ func _FileApiService_GetFile_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileApiService_PutFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryPutFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).PutFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_PutFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).PutFile(ctx, req.(*DeliveryPutFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_HeadFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryHeadFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).HeadFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_HeadFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).HeadFile(ctx, req.(*DeliveryHeadFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_DeleteFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryDeleteFile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).DeleteFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_DeleteFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).DeleteFile(ctx, req.(*DeliveryDeleteFile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_StartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryStartUpload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).StartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_StartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).StartUpload(ctx, req.(*DeliveryStartUpload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_GetUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryGetUpload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).GetUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_GetUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).GetUpload(ctx, req.(*DeliveryGetUpload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FileApiServiceServer).UploadFile(&fileApiServiceUploadFileServer{stream})
+}
+
+type FileApiService_UploadFileServer interface {
+	SendAndClose(*UploadSession) error
+	Recv() (*UploadChunk, error)
+	grpc.ServerStream
+}
+
+type fileApiServiceUploadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileApiServiceUploadFileServer) SendAndClose(m *UploadSession) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fileApiServiceUploadFileServer) Recv() (*UploadChunk, error) {
+	m := new(UploadChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _FileApiService_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryCompleteUpload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_CompleteUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).CompleteUpload(ctx, req.(*DeliveryCompleteUpload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryAbortUpload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_AbortUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).AbortUpload(ctx, req.(*DeliveryAbortUpload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileApiService_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeliveryDownloadFile)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileApiServiceServer).DownloadFile(m, &fileApiServiceDownloadFileServer{stream})
+}
+
+type FileApiService_DownloadFileServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type fileApiServiceDownloadFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileApiServiceDownloadFileServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileApiService_GetDownloadLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliveryGetDownloadLink)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileApiServiceServer).GetDownloadLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FileApiService_GetDownloadLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileApiServiceServer).GetDownloadLink(ctx, req.(*DeliveryGetDownloadLink))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FileApiService_IsAlive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IsAliveRequest)
 	if err := dec(in); err != nil {
@@ -138,11 +541,54 @@ var FileApiService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFile",
 			Handler:    _FileApiService_GetFile_Handler,
 		},
+		{
+			MethodName: "PutFile",
+			Handler:    _FileApiService_PutFile_Handler,
+		},
+		{
+			MethodName: "HeadFile",
+			Handler:    _FileApiService_HeadFile_Handler,
+		},
+		{
+			MethodName: "DeleteFile",
+			Handler:    _FileApiService_DeleteFile_Handler,
+		},
+		{
+			MethodName: "StartUpload",
+			Handler:    _FileApiService_StartUpload_Handler,
+		},
+		{
+			MethodName: "GetUpload",
+			Handler:    _FileApiService_GetUpload_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _FileApiService_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _FileApiService_AbortUpload_Handler,
+		},
+		{
+			MethodName: "GetDownloadLink",
+			Handler:    _FileApiService_GetDownloadLink_Handler,
+		},
 		{
 			MethodName: "IsAlive",
 			Handler:    _FileApiService_IsAlive_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadFile",
+			Handler:       _FileApiService_UploadFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _FileApiService_DownloadFile_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "file-api.proto",
 }
@@ -1,26 +1,23 @@
 package main
 
 import (
-	"crypto/tls"
+	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
 	"github.com/GCFactory/dbo-system/platform/pkg/db/postgres"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/mail"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
 	"github.com/GCFactory/dbo-system/service/notification/internal/server"
-	"github.com/GCFactory/dbo-system/service/notification/pkg/kafka"
 	"github.com/golang-migrate/migrate/v4"
 	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/opentracing/opentracing-go"
-	amqp "github.com/rabbitmq/amqp091-go"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
 	"log"
-	"net/smtp"
 	"os"
-	"strings"
 )
 
 //	@Title			Users Service
@@ -89,12 +86,7 @@ func main() {
 	appLogger.Info("Migration completed")
 
 	appLogger.Info("Connecting to RMQ")
-	rmqUrl := "amqp://" +
-		cfg.RabbitMQ.User + ":" +
-		cfg.RabbitMQ.Password + "@" +
-		cfg.RabbitMQ.Host + ":" +
-		cfg.RabbitMQ.Port
-	rmqConn, err := amqp.Dial(rmqUrl)
+	rmqConn, err := rmq.NewAMQP(cfg)
 	if err != nil {
 		appLogger.Fatalf("Connecting error to RMQ: %s", err)
 		return
@@ -103,7 +95,7 @@ func main() {
 	appLogger.Info("Connecting to RMQ success")
 
 	appLogger.Info("Open RMQ channel")
-	rmqCh, err := rmqConn.Channel()
+	rmqCh, err := rmq.CreateChannel(rmqConn)
 	if err != nil {
 		appLogger.Fatalf("Open RMQ channel error: %s", err)
 		return
@@ -112,14 +104,7 @@ func main() {
 	appLogger.Info("Open RMQ channel success")
 
 	appLogger.Info("Creating RMQ queue")
-	rmqQueue, err := rmqCh.QueueDeclare(
-		cfg.RabbitMQ.Queue, // name
-		false,              // durable
-		false,              // delete when unused
-		false,              // exclusive
-		false,              // no-wait
-		nil,                // arguments
-	)
+	_, err = rmq.DeclareQueue(rmqCh, cfg.RabbitMQ)
 	if err != nil {
 		appLogger.Fatalf("Create RMQ queue error: %s", err)
 		return
@@ -127,91 +112,60 @@ func main() {
 	appLogger.Info("Create RMQ queue success")
 
 	appLogger.Info("Register RMQ consumer")
-	msgChan, err := rmqCh.Consume(
-		rmqQueue.Name, // queue
-		"",            // consumer
-		true,          // auto-ack
-		false,         // exclusive
-		false,         // no-local
-		false,         // no-wait
-		nil,           // args
-	)
+	rmqConsumer, err := rmq.NewConsumer(rmqCh, cfg.RabbitMQ, appLogger)
 	if err != nil {
 		appLogger.Fatalf("Register RMQ consumer error: %s", err)
 		return
 	}
 	appLogger.Info("Register RMQ consumer success")
 
-	smtpAuth := smtp.PlainAuth(
-		cfg.NotificationSmtp.NickName,
-		cfg.NotificationSmtp.User,
-		cfg.NotificationSmtp.Password,
-		cfg.NotificationSmtp.Host)
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: true, // Проверять сертификат (лучше `false` в продакшене)
-		ServerName:         cfg.NotificationSmtp.Host,
-	}
-
-	appLogger.Info("Open tls connection with smtp")
-	tlsConn, err := tls.Dial("tcp", cfg.NotificationSmtp.Host+":"+cfg.NotificationSmtp.Port, tlsConfig)
+	appLogger.Info("Create smtp sender")
+	mailSender, err := mail.NewSmtpSender(cfg.NotificationSmtp)
 	if err != nil {
-		appLogger.Fatalf("Open tls connection with smtp error: %s", err)
+		appLogger.Fatalf("Create smtp sender error: %s", err)
 		return
 	}
-	defer tlsConn.Close()
-	appLogger.Info("Open tls connection with smtp success")
-
-	appLogger.Info("Create smtp client")
-	smtpClient, err := smtp.NewClient(tlsConn, cfg.NotificationSmtp.Host)
-	if err != nil {
-		appLogger.Fatalf("Create smtp client error: %s", err)
-		return
+	defer mailSender.Close()
+	if err = mailSender.Check(context.Background()); err != nil {
+		appLogger.Warnf("Smtp server is unavailable: %s", err)
+	} else {
+		appLogger.Info("Create smtp sender success")
 	}
-	defer smtpClient.Close()
-	appLogger.Info("Create smtp client success")
 
-	appLogger.Info("Smtp auth")
-	if err = smtpClient.Auth(smtpAuth); err != nil {
-		appLogger.Fatalf("Smtp auth error: %s", err)
+	renderer, err := templates.NewRenderer()
+	if err != nil {
+		appLogger.Fatalf("Parse notification templates error: %s", err)
 		return
 	}
-	appLogger.Info("Smtp auth success")
 
-	kc, err := kafka.NewKafkaConsumer(strings.Split(cfg.KafkaConsumer.Brokers, ";"), cfg.KafkaConsumer.GroupID)
+	kc, err := kafka.NewKafkaConsumer(cfg.KafkaConsumer, appLogger)
 	if err != nil {
 		appLogger.Fatal(err)
 	}
 	appLogger.Infof("Kafka consumer with group '%s' connected", cfg.KafkaConsumer.GroupID)
 
-	kp := kafka.NewKafkaProducer(cfg, appLogger)
+	kp := kafka.NewKafkaProducer(cfg.KafkaProducer, appLogger)
 
-	jaegerCfgInstance := jaegercfg.Configuration{
-		ServiceName: cfg.Jaeger.ServiceName,
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans:           cfg.Jaeger.LogSpans,
-			LocalAgentHostPort: cfg.Jaeger.Host,
-		},
+	businessMetrics, err := metric.CreateBusinessMetrics(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateBusinessMetrics Error: %s", err)
 	}
+	kc.SetMetrics(businessMetrics)
+	kp.SetMetrics(businessMetrics)
+	kc.SetDeadLetterProducer(kp)
 
-	tracer, closer, err := jaegerCfgInstance.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
-	)
+	tp, err := tracing.NewTracerProvider(context.Background(), cfg)
 	if err != nil {
-		log.Fatal("cannot create tracer", err)
+		log.Fatal("cannot create tracer provider", err)
 	}
-	appLogger.Info("Jaeger connected")
-
-	opentracing.SetGlobalTracer(tracer)
-	defer closer.Close()
-	appLogger.Info("Opentracing connected")
+	defer tp.Shutdown(context.Background())
+	appLogger.Info("OpenTelemetry tracing connected")
 
 	//Run server
-	s := server.NewServer(cfg, kc, kp, psqlDB, msgChan, smtpClient, appLogger)
+	s, err := server.NewServer(cfg, kc, kp, psqlDB, businessMetrics, rmqCh, rmqConsumer, mailSender, renderer, appLogger)
+	if err != nil {
+		appLogger.Fatalf("Create server error: %s", err)
+	}
 	if err = s.Run(); err != nil {
 		appLogger.Fatal(err)
 	}
@@ -7,4 +7,10 @@ type RabbitMQConfig struct {
 	Password string
 	Exchange string
 	Queue    string
+	// Delivery attempts after the first failure before message goes to dead-letter queue
+	MaxRetries int
+	// Delay before the first retry in seconds, doubled on every next attempt
+	RetryDelay int
+	// Unacknowledged deliveries per consumer
+	PrefetchCount int
 }
@@ -16,7 +16,7 @@ type Config struct {
 	HTTPServer HTTPServerConfig            `yaml:"http-server,omitempty" mapstructure:"http-server"`
 
 	Logger  Logger  `yaml:"logger"`
-	Jaeger  Jaeger  `yaml:"jaeger"`
+	Tracing Tracing `yaml:"tracing"`
 	Metrics Metrics `yaml:"metrics"`
 	Docs    Docs
 
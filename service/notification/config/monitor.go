@@ -9,11 +9,13 @@ type Logger struct {
 	Level             string
 }
 
-// Jaeger config
-type Jaeger struct {
-	Host        string
-	ServiceName string
-	LogSpans    bool
+// Tracing config. Spans are exported with OTLP over HTTP
+type Tracing struct {
+	Endpoint     string
+	Insecure     bool
+	ServiceName  string
+	Sampler      string
+	SamplerRatio float64
 }
 
 // Metrics config
@@ -8,11 +8,56 @@ type DefaultHttpResponse struct {
 }
 
 type UpdateUserSettings struct {
-	UserId            uuid.UUID `json:"user_id" validate:"required"`
-	EmailNotification bool      `json:"email_notification" validate:"omitempty"`
-	Email             string    `json:"email" validate:"required,email"`
+	UserId            uuid.UUID                `json:"user_id" validate:"required"`
+	EmailNotification bool                     `json:"email_notification" validate:"omitempty"`
+	Email             string                   `json:"email" validate:"required,email"`
+	Locale            string                   `json:"locale" validate:"omitempty,oneof=ru en"`
+	Phone             string                   `json:"phone" validate:"omitempty,e164"`
+	WebhookUrl        string                   `json:"webhook_url" validate:"omitempty,url,startswith=https://"`
+	DigestMode        string                   `json:"digest_mode" validate:"omitempty,oneof=off daily weekly"`
+	Timezone          string                   `json:"timezone" validate:"omitempty,timezone"`
+	QuietHoursStart   int                      `json:"quiet_hours_start" validate:"min=0,max=23"`
+	QuietHoursEnd     int                      `json:"quiet_hours_end" validate:"min=0,max=23"`
+	Preferences       []NotificationPreference `json:"preferences" validate:"omitempty,dive"`
+}
+
+type UpdateUserSettingsResponse struct {
+	Status int    `json:"status"`
+	Info   string `json:"info"`
+	// Set only when webhook url was set or changed, it can't be got later
+	WebhookSecret string `json:"webhook_secret,omitempty"`
 }
 
 type GetUserSettings struct {
 	UserId string `json:"user_id" validate:"required"`
 }
+
+type GetUserInbox struct {
+	UserId     string `json:"user_id" validate:"required"`
+	UnreadOnly bool   `json:"unread_only"`
+}
+
+type ReadInboxMessage struct {
+	UserId    uuid.UUID `json:"user_id" validate:"required"`
+	MessageId uuid.UUID `json:"message_id" validate:"required"`
+}
+
+type GetDeliveries struct {
+	UserId    string `json:"user_id" validate:"omitempty,uuid"`
+	MessageId string `json:"message_id" validate:"omitempty,uuid"`
+	Channel   string `json:"channel"`
+	Status    string `json:"status" validate:"omitempty,oneof=queued sent failed bounced"`
+	Template  string `json:"template"`
+	Begin     string `json:"begin" validate:"omitempty,datetime=02-01-2006 15:04:05"`
+	End       string `json:"end" validate:"omitempty,datetime=02-01-2006 15:04:05"`
+	Limit     int    `json:"limit" validate:"omitempty,min=1,max=500"`
+	Offset    int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendEmailVerification struct {
+	UserId uuid.UUID `json:"user_id" validate:"required"`
+}
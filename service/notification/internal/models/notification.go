@@ -1,9 +1,117 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"time"
+)
 
 type UserNotificationInfo struct {
 	UserUuid   uuid.UUID `json:"user_uuid" db:"user_uuid"`
 	EmailUsage bool      `json:"email_usage" db:"email_usage"`
 	Email      string    `json:"email" db:"email"`
+	// Set after user has opened verification link, email is not used before it
+	EmailVerified bool   `json:"email_verified" db:"email_verified"`
+	Locale        string `json:"locale" db:"locale"`
+	Phone         string `json:"phone" db:"phone"`
+	WebhookUrl    string `json:"webhook_url" db:"webhook_url"`
+	// Key of webhook request signature, shown to user only once when webhook url is set
+	WebhookSecret string `json:"-" db:"webhook_secret"`
+	// Non-security notifications are collected into digest: off, daily or weekly
+	DigestMode string `json:"digest_mode" db:"digest_mode"`
+	// IANA time zone of digest and quiet hours
+	Timezone string `json:"timezone" db:"timezone"`
+	// Local hours when only security notifications are sent, equal hours turn quiet hours off
+	QuietHoursStart int                      `json:"quiet_hours_start" db:"quiet_hours_start"`
+	QuietHoursEnd   int                      `json:"quiet_hours_end" db:"quiet_hours_end"`
+	Preferences     []NotificationPreference `json:"preferences" db:"-"`
+}
+
+type NotificationPreference struct {
+	Category  string `json:"category" db:"category" validate:"required"`
+	Channel   string `json:"channel" db:"channel" validate:"required"`
+	Enabled   bool   `json:"enabled" db:"enabled"`
+	Mandatory bool   `json:"mandatory" db:"-"`
+}
+
+// Rendered notification passed to delivery channels
+type ChannelMessage struct {
+	Id       uuid.UUID              `json:"id"`
+	Category string                 `json:"category"`
+	Template string                 `json:"template"`
+	Subject  string                 `json:"subject"`
+	Text     string                 `json:"text"`
+	HTML     string                 `json:"-"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+type InboxMessage struct {
+	MessageUuid uuid.UUID `json:"message_uuid" db:"message_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid" db:"user_uuid"`
+	Category    string    `json:"category" db:"category"`
+	Subject     string    `json:"subject" db:"subject"`
+	Body        string    `json:"body" db:"body"`
+	IsRead      bool      `json:"is_read" db:"is_read"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type UserInbox struct {
+	Messages []InboxMessage `json:"messages"`
+}
+
+// Delivery of notification to one channel
+type Delivery struct {
+	DeliveryUuid uuid.UUID  `json:"delivery_uuid" db:"delivery_uuid"`
+	MessageUuid  uuid.UUID  `json:"message_uuid" db:"message_uuid"`
+	UserUuid     uuid.UUID  `json:"user_uuid" db:"user_uuid"`
+	Channel      string     `json:"channel" db:"channel"`
+	Category     string     `json:"category" db:"category"`
+	Template     string     `json:"template" db:"template"`
+	Subject      string     `json:"subject" db:"subject"`
+	Status       string     `json:"status" db:"status"`
+	Attempts     int        `json:"attempts" db:"attempts"`
+	LastError    string     `json:"last_error" db:"last_error"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	SentAt       *time.Time `json:"sent_at" db:"sent_at"`
+}
+
+// Delivery log search. Nil and empty fields are not used
+type DeliveryFilter struct {
+	UserUuid    *uuid.UUID
+	MessageUuid *uuid.UUID
+	Channel     string
+	Status      string
+	Template    string
+	Begin       time.Time
+	End         time.Time
+	Limit       int
+	Offset      int
+}
+
+type DeliveryLog struct {
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// Verification emails sent to user, used for resend limits
+type EmailVerification struct {
+	UserUuid    uuid.UUID `json:"user_uuid" db:"user_uuid"`
+	Email       string    `json:"email" db:"email"`
+	LastSentAt  time.Time `json:"last_sent_at" db:"last_sent_at"`
+	WindowStart time.Time `json:"window_start" db:"window_start"`
+	SentCount   int       `json:"sent_count" db:"sent_count"`
+}
+
+// Notification waiting for digest or end of quiet hours
+type PendingNotification struct {
+	MessageUuid uuid.UUID `json:"message_uuid" db:"message_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid" db:"user_uuid"`
+	Category    string    `json:"category" db:"category"`
+	Template    string    `json:"template" db:"template"`
+	// Template variables as they came from producer
+	Body   string `json:"body" db:"body"`
+	Digest bool   `json:"digest" db:"digest"`
+	// Set when item is taken into digest
+	DigestUuid *uuid.UUID `json:"digest_uuid" db:"digest_uuid"`
+	DeliverAt  time.Time  `json:"deliver_at" db:"deliver_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,14 @@
+package notification
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+)
+
+// Channel delivers rendered notification to user
+type Channel interface {
+	Name() string
+	// Available reports whether user has an address for the channel
+	Available(user *models.UserNotificationInfo) bool
+	Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error
+}
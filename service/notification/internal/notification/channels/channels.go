@@ -0,0 +1,11 @@
+package channels
+
+// Channel names, used in user preferences and metrics
+const (
+	ChannelEmail   string = "email"
+	ChannelWebhook string = "webhook"
+	ChannelSms     string = "sms"
+	ChannelInbox   string = "inbox"
+)
+
+const defaultTimeout = 10
@@ -0,0 +1,252 @@
+package channels
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/google/uuid"
+)
+
+func testMessage() *models.ChannelMessage {
+	return &models.ChannelMessage{
+		Id:       uuid.New(),
+		Category: "transactions",
+		Template: "deposit",
+		Subject:  "Deposit",
+		Text:     "Account was refilled",
+	}
+}
+
+func TestWebhookChannel_Send(t *testing.T) {
+	secret := "webhook-secret"
+	message := testMessage()
+	user := &models.UserNotificationInfo{UserUuid: uuid.New(), WebhookSecret: secret}
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Test server listens on loopback, so its client is used instead of one refusing private addresses
+	ch := &WebhookChannel{client: server.Client()}
+	user.WebhookUrl = server.URL + "/hook"
+
+	if err := ch.Send(context.Background(), user, message); err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Header.Get(HeaderWebhookId) != message.Id.String() {
+		t.Fatalf("message id header = %s", received.Header.Get(HeaderWebhookId))
+	}
+
+	timestamp := received.Header.Get(HeaderWebhookTimestamp)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(receivedBody)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); received.Header.Get(HeaderWebhookSignature) != want {
+		t.Fatalf("signature = %s, want %s", received.Header.Get(HeaderWebhookSignature), want)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(receivedBody, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["user_uuid"] != user.UserUuid.String() || body["text"] != message.Text {
+		t.Fatalf("body = %v", body)
+	}
+}
+
+func TestNewWebhookSecret(t *testing.T) {
+	secret, err := NewWebhookSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewWebhookSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 2*webhookSecretSize || secret == other {
+		t.Fatalf("secrets = %s, %s", secret, other)
+	}
+}
+
+func TestWebhookChannel_SendRejected(t *testing.T) {
+	redirected := false
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/internal", http.StatusFound)
+		case "/internal":
+			redirected = true
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	user := &models.UserNotificationInfo{UserUuid: uuid.New(), WebhookSecret: "secret"}
+
+	t.Run("Error empty secret", func(t *testing.T) {
+		ch := &WebhookChannel{client: server.Client()}
+		noSecret := &models.UserNotificationInfo{UserUuid: uuid.New(), WebhookUrl: server.URL + "/internal"}
+
+		err := ch.Send(context.Background(), noSecret, testMessage())
+		if !errors.Is(err, ErrorWebhookSecret) || redirected {
+			t.Fatalf("error = %v, request sent = %v", err, redirected)
+		}
+	})
+
+	t.Run("Error http scheme", func(t *testing.T) {
+		user.WebhookUrl = "http://example.com/hook"
+		err := NewWebhookChannel(config.Webhook{}).Send(context.Background(), user, testMessage())
+		if !errors.Is(err, ErrorWebhookScheme) {
+			t.Fatalf("error = %v", err)
+		}
+	})
+
+	t.Run("Error loopback address", func(t *testing.T) {
+		user.WebhookUrl = server.URL
+		err := NewWebhookChannel(config.Webhook{}).Send(context.Background(), user, testMessage())
+		if !errors.Is(err, ErrorWebhookAddress) {
+			t.Fatalf("error = %v", err)
+		}
+	})
+
+	t.Run("Error redirect", func(t *testing.T) {
+		client := newWebhookClient(time.Second)
+		client.Transport = server.Client().Transport
+		ch := &WebhookChannel{client: client}
+
+		user.WebhookUrl = server.URL + "/redirect"
+		err := ch.Send(context.Background(), user, testMessage())
+		if !errors.Is(err, ErrorUnexpectedStatus) || redirected {
+			t.Fatalf("error = %v, redirect followed = %v", err, redirected)
+		}
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		ch := &WebhookChannel{client: server.Client()}
+
+		user.WebhookUrl = server.URL + "/fail"
+		err := ch.Send(context.Background(), user, testMessage())
+		if !errors.Is(err, ErrorUnexpectedStatus) {
+			t.Fatalf("error = %v", err)
+		}
+	})
+}
+
+func TestCheckWebhookAddress(t *testing.T) {
+	forbidden := []string{
+		"127.0.0.1:443",
+		"10.1.2.3:443",
+		"172.16.0.1:443",
+		"192.168.1.1:443",
+		"169.254.169.254:80",
+		"0.0.0.0:443",
+		"[::1]:443",
+		"[fe80::1]:443",
+		"[fd00::1]:443",
+		"[::ffff:127.0.0.1]:443",
+	}
+	for _, address := range forbidden {
+		if err := checkWebhookAddress("tcp", address, nil); !errors.Is(err, ErrorWebhookAddress) {
+			t.Fatalf("address %s error = %v", address, err)
+		}
+	}
+
+	for _, address := range []string{"93.184.216.34:443", "[2606:4700::1111]:443"} {
+		if err := checkWebhookAddress("tcp", address, nil); err != nil {
+			t.Fatalf("address %s error = %v", address, err)
+		}
+	}
+}
+
+func TestSmsChannel_Send(t *testing.T) {
+	var received smsBody
+	var authorization string
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cfg := config.Sms{Url: server.URL, ApiKey: "key", Sender: "Bank"}
+	ch := NewSmsChannel(cfg)
+	user := &models.UserNotificationInfo{UserUuid: uuid.New(), Phone: "+79990000000"}
+	message := testMessage()
+	message.Text = strings.Repeat("я", smsMaxLength+10)
+
+	if !ch.Available(user) || NewSmsChannel(config.Sms{}).Available(user) {
+		t.Fatal("sms channel must be available only with gateway url and user phone")
+	}
+
+	if err := ch.Send(context.Background(), user, message); err != nil {
+		t.Fatal(err)
+	}
+	if authorization != "Bearer key" {
+		t.Fatalf("authorization = %s", authorization)
+	}
+	if received.Id != message.Id.String() || received.From != cfg.Sender || received.To != user.Phone {
+		t.Fatalf("body = %+v", received)
+	}
+	if len([]rune(received.Text)) != smsMaxLength {
+		t.Fatalf("text length = %d, want %d", len([]rune(received.Text)), smsMaxLength)
+	}
+
+	status = http.StatusBadGateway
+	if err := ch.Send(context.Background(), user, message); !errors.Is(err, ErrorUnexpectedStatus) {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+// inboxRepo keeps inbox messages, other repository methods aren't used by inbox channel
+type inboxRepo struct {
+	notification.Repository
+	messages []*models.InboxMessage
+}
+
+func (r *inboxRepo) AddInboxMessage(ctx context.Context, message *models.InboxMessage) error {
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestInboxChannel_Send(t *testing.T) {
+	repo := &inboxRepo{}
+	ch := NewInboxChannel(repo)
+	user := &models.UserNotificationInfo{UserUuid: uuid.New()}
+	message := testMessage()
+
+	if !ch.Available(user) {
+		t.Fatal("inbox must be available for every user")
+	}
+	if err := ch.Send(context.Background(), user, message); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(repo.messages) != 1 {
+		t.Fatalf("saved %d messages", len(repo.messages))
+	}
+	saved := repo.messages[0]
+	if saved.MessageUuid != message.Id || saved.UserUuid != user.UserUuid || saved.Subject != message.Subject || saved.Body != message.Text {
+		t.Fatalf("saved message = %+v", saved)
+	}
+}
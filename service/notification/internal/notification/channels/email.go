@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/mail"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"net/textproto"
+)
+
+type EmailChannel struct {
+	sender  mail.Sender
+	smtpCfg config.Smtp
+}
+
+func (ch EmailChannel) Name() string {
+	return ChannelEmail
+}
+
+func (ch EmailChannel) Available(user *models.UserNotificationInfo) bool {
+	return user.Email != "" && user.EmailVerified
+}
+
+func (ch EmailChannel) Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+	local_ctx, span := tracing.StartSpan(ctx, "EmailChannel.Send")
+	defer span.End()
+
+	msg, err := mail.BuildMessage(mail.Content{
+		From:     ch.smtpCfg.From,
+		FromName: ch.smtpCfg.NickName,
+		To:       []string{user.Email},
+		Subject:  message.Subject,
+		Text:     message.Text,
+		HTML:     message.HTML,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ch.sender.Send(local_ctx, msg)
+	// 5xx reply is permanent rejection, retry will not help
+	var replyErr *textproto.Error
+	if errors.As(err, &replyErr) && replyErr.Code >= 500 {
+		return fmt.Errorf("%w: %v", ErrorBounced, err)
+	}
+
+	return err
+}
+
+func NewEmailChannel(sender mail.Sender, smtpCfg config.Smtp) notification.Channel {
+	return &EmailChannel{sender: sender, smtpCfg: smtpCfg}
+}
@@ -0,0 +1,11 @@
+package channels
+
+import "errors"
+
+var (
+	ErrorUnexpectedStatus = errors.New("Unexpected response status")
+	ErrorBounced          = errors.New("Recipient rejected message")
+	ErrorWebhookScheme    = errors.New("Webhook url must use https")
+	ErrorWebhookAddress   = errors.New("Webhook address is not public")
+	ErrorWebhookSecret    = errors.New("Webhook has no signature secret, set webhook url again")
+)
@@ -0,0 +1,38 @@
+package channels
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+)
+
+// InboxChannel stores message in user's in-app inbox. Message id makes redelivery idempotent.
+type InboxChannel struct {
+	repo notification.Repository
+}
+
+func (ch InboxChannel) Name() string {
+	return ChannelInbox
+}
+
+func (ch InboxChannel) Available(user *models.UserNotificationInfo) bool {
+	return true
+}
+
+func (ch InboxChannel) Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+	local_ctx, span := tracing.StartSpan(ctx, "InboxChannel.Send")
+	defer span.End()
+
+	return ch.repo.AddInboxMessage(local_ctx, &models.InboxMessage{
+		MessageUuid: message.Id,
+		UserUuid:    user.UserUuid,
+		Category:    message.Category,
+		Subject:     message.Subject,
+		Body:        message.Text,
+	})
+}
+
+func NewInboxChannel(repo notification.Repository) notification.Channel {
+	return &InboxChannel{repo: repo}
+}
@@ -0,0 +1,93 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Max SMS text length, longer texts are cut
+const smsMaxLength = 480
+
+// SmsChannel posts message to generic HTTP SMS gateway:
+// POST cfg.Url with bearer api key and body {"from", "to", "text", "id"}, any 2xx status means accepted.
+type SmsChannel struct {
+	client *http.Client
+	cfg    config.Sms
+}
+
+type smsBody struct {
+	Id   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	Text string `json:"text"`
+}
+
+func (ch SmsChannel) Name() string {
+	return ChannelSms
+}
+
+func (ch SmsChannel) Available(user *models.UserNotificationInfo) bool {
+	return user.Phone != "" && ch.cfg.Url != ""
+}
+
+func (ch SmsChannel) Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+	local_ctx, span := tracing.StartSpan(ctx, "SmsChannel.Send")
+	defer span.End()
+
+	text := []rune(message.Text)
+	if len(text) > smsMaxLength {
+		text = text[:smsMaxLength]
+	}
+
+	body, err := json.Marshal(&smsBody{
+		Id:   message.Id.String(),
+		From: ch.cfg.Sender,
+		To:   user.Phone,
+		Text: string(text),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(local_ctx, http.MethodPost, ch.cfg.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if ch.cfg.ApiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+ch.cfg.ApiKey)
+	}
+
+	resp, err := ch.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrorUnexpectedStatus, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func NewSmsChannel(cfg config.Sms) notification.Channel {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &SmsChannel{
+		client: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		cfg:    cfg,
+	}
+}
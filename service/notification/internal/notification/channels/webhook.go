@@ -0,0 +1,164 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Webhook request headers. Signature is hex HMAC-SHA256 of "<timestamp>.<body>" with secret of user endpoint,
+// receiver should also reject old timestamps to prevent replays.
+const (
+	HeaderWebhookSignature = "X-Dbo-Signature"
+	HeaderWebhookTimestamp = "X-Dbo-Timestamp"
+	HeaderWebhookId        = "X-Dbo-Message-Id"
+)
+
+// Length of generated webhook secret in bytes
+const webhookSecretSize = 32
+
+type WebhookChannel struct {
+	client *http.Client
+}
+
+type webhookBody struct {
+	*models.ChannelMessage
+	UserUuid string `json:"user_uuid"`
+}
+
+func (ch WebhookChannel) Name() string {
+	return ChannelWebhook
+}
+
+func (ch WebhookChannel) Available(user *models.UserNotificationInfo) bool {
+	return user.WebhookUrl != ""
+}
+
+func (ch WebhookChannel) Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+	local_ctx, span := tracing.StartSpan(ctx, "WebhookChannel.Send")
+	defer span.End()
+
+	// HMAC with empty key lets anyone forge requests, endpoint without secret must be registered again
+	if user.WebhookSecret == "" {
+		return ErrorWebhookSecret
+	}
+
+	body, err := json.Marshal(&webhookBody{ChannelMessage: message, UserUuid: user.UserUuid.String()})
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	webhookUrl, err := url.Parse(user.WebhookUrl)
+	if err != nil {
+		return err
+	}
+	if webhookUrl.Scheme != "https" {
+		return ErrorWebhookScheme
+	}
+
+	req, err := http.NewRequestWithContext(local_ctx, http.MethodPost, webhookUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookId, message.Id.String())
+	req.Header.Set(HeaderWebhookSignature, "sha256="+Sign(user.WebhookSecret, timestamp, body))
+
+	resp, err := ch.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", ErrorUnexpectedStatus, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns hex HMAC-SHA256 of timestamp and body
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewWebhookSecret returns random hex key for signature of user webhook endpoint
+func NewWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Webhook url is set by user, so requests must not reach loopback, private or link-local addresses
+// of the cluster. Address is checked after name resolution, right before connect, so resolving to
+// other address on request doesn't bypass the check
+func checkWebhookAddress(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return fmt.Errorf("%w: %s", ErrorWebhookAddress, addr)
+	}
+	return nil
+}
+
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: checkWebhookAddress,
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// Proxy would connect to user url instead of checked dialer
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			ForceAttemptHTTP2:   true,
+		},
+		// Redirect may point to internal address, so redirect response is returned as is and rejected by status
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func NewWebhookChannel(cfg config.Webhook) notification.Channel {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &WebhookChannel{
+		client: newWebhookClient(time.Duration(timeout) * time.Second),
+	}
+}
@@ -5,4 +5,9 @@ import "github.com/labstack/echo/v4"
 type HttpHandlers interface {
 	UpdateUserSettings() echo.HandlerFunc
 	GetUserSettings() echo.HandlerFunc
+	GetUserInbox() echo.HandlerFunc
+	ReadInboxMessage() echo.HandlerFunc
+	GetDeliveries() echo.HandlerFunc
+	VerifyEmail() echo.HandlerFunc
+	ResendEmailVerification() echo.HandlerFunc
 }
@@ -2,20 +2,26 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/usecase"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"reflect"
 	"strconv"
+	"time"
 )
 
+// Time format of delivery log filters, same as admin page
+const DeliveryTimeFormat = "02-01-2006 15:04:05"
+
 type ApiGatewayHandlers struct {
 	cfg     *config.Config
 	useCase notification.UseCase
@@ -107,7 +113,7 @@ func (h ApiGatewayHandlers) safeReadQueryParamsRequest(c echo.Context, v interfa
 func (h ApiGatewayHandlers) UpdateUserSettings() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		result := &models.DefaultHttpResponse{
+		result := &models.UpdateUserSettingsResponse{
 			Status: http.StatusOK,
 			Info:   "",
 		}
@@ -122,11 +128,20 @@ func (h ApiGatewayHandlers) UpdateUserSettings() echo.HandlerFunc {
 		}
 
 		userSettings := &models.UserNotificationInfo{
-			UserUuid:   operationInfo.UserId,
-			EmailUsage: operationInfo.EmailNotification,
-			Email:      operationInfo.Email,
+			UserUuid:        operationInfo.UserId,
+			EmailUsage:      operationInfo.EmailNotification,
+			Email:           operationInfo.Email,
+			Locale:          operationInfo.Locale,
+			Phone:           operationInfo.Phone,
+			WebhookUrl:      operationInfo.WebhookUrl,
+			DigestMode:      operationInfo.DigestMode,
+			Timezone:        operationInfo.Timezone,
+			QuietHoursStart: operationInfo.QuietHoursStart,
+			QuietHoursEnd:   operationInfo.QuietHoursEnd,
+			Preferences:     operationInfo.Preferences,
 		}
-		err = h.useCase.UpdateUserSettings(context.Background(), userSettings)
+		// Settings may be saved before error of verification email, so new secret is returned anyway
+		result.WebhookSecret, err = h.useCase.UpdateUserSettings(context.Background(), userSettings)
 		if err != nil {
 			utils.LogResponseError(c, h.logger, err)
 			result.Status = http.StatusBadRequest
@@ -176,6 +191,187 @@ func (h ApiGatewayHandlers) GetUserSettings() echo.HandlerFunc {
 	}
 }
 
+func (h ApiGatewayHandlers) GetUserInbox() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.GetUserInbox{}
+		err := h.safeReadQueryParamsRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		userId, err := uuid.Parse(operationInfo.UserId)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		messages, err := h.useCase.GetInbox(context.Background(), userId, operationInfo.UnreadOnly)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusNotFound
+			result.Info = err.Error()
+			return c.JSON(http.StatusNotFound, result)
+		}
+
+		return c.JSON(http.StatusOK, &models.UserInbox{Messages: messages})
+	}
+}
+
+func (h ApiGatewayHandlers) ReadInboxMessage() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.ReadInboxMessage{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		err = h.useCase.ReadInboxMessage(context.Background(), operationInfo.UserId, operationInfo.MessageId)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusNotFound
+			result.Info = err.Error()
+			return c.JSON(http.StatusNotFound, result)
+		}
+
+		result.Info = "Success"
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+func (h ApiGatewayHandlers) GetDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.GetDeliveries{}
+		err := h.safeReadQueryParamsRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		filter := &models.DeliveryFilter{
+			Channel:  operationInfo.Channel,
+			Status:   operationInfo.Status,
+			Template: operationInfo.Template,
+			Limit:    operationInfo.Limit,
+			Offset:   operationInfo.Offset,
+		}
+		if operationInfo.UserId != "" {
+			userId := uuid.MustParse(operationInfo.UserId)
+			filter.UserUuid = &userId
+		}
+		if operationInfo.MessageId != "" {
+			messageId := uuid.MustParse(operationInfo.MessageId)
+			filter.MessageUuid = &messageId
+		}
+		if operationInfo.Begin != "" {
+			filter.Begin, _ = time.Parse(DeliveryTimeFormat, operationInfo.Begin)
+		}
+		if operationInfo.End != "" {
+			filter.End, _ = time.Parse(DeliveryTimeFormat, operationInfo.End)
+		}
+
+		deliveries, err := h.useCase.GetDeliveries(context.Background(), filter)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusInternalServerError
+			result.Info = err.Error()
+			return c.JSON(http.StatusInternalServerError, result)
+		}
+
+		return c.JSON(http.StatusOK, &models.DeliveryLog{Deliveries: deliveries})
+	}
+}
+
+func (h ApiGatewayHandlers) VerifyEmail() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.VerifyEmail{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		err = h.useCase.VerifyEmail(context.Background(), operationInfo.Token)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		result.Info = "Success"
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+func (h ApiGatewayHandlers) ResendEmailVerification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		result := &models.DefaultHttpResponse{
+			Status: http.StatusOK,
+			Info:   "",
+		}
+
+		operationInfo := &models.ResendEmailVerification{}
+		err := h.safeReadBodyRequest(c, operationInfo)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			result.Info = err.Error()
+			return c.JSON(http.StatusBadRequest, result)
+		}
+
+		err = h.useCase.ResendEmailVerification(context.Background(), operationInfo.UserId)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			result.Status = http.StatusBadRequest
+			if errors.Is(err, usecase.ErrorVerificationRateLimit) {
+				result.Status = http.StatusTooManyRequests
+			}
+			result.Info = err.Error()
+			return c.JSON(result.Status, result)
+		}
+
+		result.Info = "Success"
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
 func NewNotificationHandlers(cfg *config.Config, useCase notification.UseCase, logger logger.Logger) notification.HttpHandlers {
 	return &ApiGatewayHandlers{cfg: cfg, logger: logger, useCase: useCase}
 }
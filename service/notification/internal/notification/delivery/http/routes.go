@@ -9,4 +9,9 @@ import (
 func MapNotificationRoutes(notificationGroup *echo.Group, h notification.HttpHandlers, mw *middleware.MiddlewareManager) {
 	notificationGroup.POST("/update_user_notification_settings", h.UpdateUserSettings())
 	notificationGroup.GET("/get_user_notification_settings", h.GetUserSettings())
+	notificationGroup.GET("/get_user_inbox", h.GetUserInbox())
+	notificationGroup.POST("/read_inbox_message", h.ReadInboxMessage())
+	notificationGroup.GET("/get_deliveries", h.GetDeliveries())
+	notificationGroup.POST("/verify_email", h.VerifyEmail())
+	notificationGroup.POST("/resend_email_verification", h.ResendEmailVerification())
 }
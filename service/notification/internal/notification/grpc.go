@@ -1,8 +1,8 @@
 package notification
 
 import (
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	api "github.com/GCFactory/dbo-system/service/notification/gen_proto/proto/notification_api"
-	"github.com/GCFactory/dbo-system/service/notification/pkg/kafka"
 	"golang.org/x/net/context"
 )
 
@@ -18,8 +18,10 @@ var GRPCErrors = map[error]uint32{
 	usecase.ErrorUserNotFound:             101,
 	usecase.ErrorUserSettingsAlreadyExist: 110,
 	usecase.ErrorNoUserIdHeader:           120,
-	usecase.ErrorNoNotificationLvlHeader:  130,
-	usecase.ErrorInvalidNotificationLvl:   140,
+	usecase.ErrorNoCategoryHeader:         130,
+	usecase.ErrorInvalidCategory:          140,
+	usecase.ErrorInvalidChannel:           150,
+	usecase.ErrorMandatoryCategory:        160,
 	ErrorNoUserEmail:                      201,
 	ErrorUnknownOperationType:             210,
 	ErrorInvalidInputData:                 220,
@@ -3,15 +3,15 @@ package grpc
 import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	api "github.com/GCFactory/dbo-system/service/notification/gen_proto/proto/notification_api"
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
-	"github.com/GCFactory/dbo-system/service/notification/pkg/kafka"
-	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 )
 
 type NotificationGrpcHandlers struct {
@@ -19,16 +19,17 @@ type NotificationGrpcHandlers struct {
 	kProducer *kafka.ProducerProvider
 	useCase   notification.UseCase
 	accLog    logger.Logger
+	metrics   metric.SagaMetrics
 }
 
-func NewNotificationGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, useCase notification.UseCase, accLog logger.Logger) notification.GRPCHandlers {
-	return &NotificationGrpcHandlers{cfg: cfg, kProducer: kProducer, useCase: useCase, accLog: accLog}
+func NewNotificationGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, useCase notification.UseCase, accLog logger.Logger, metrics metric.SagaMetrics) notification.GRPCHandlers {
+	return &NotificationGrpcHandlers{cfg: cfg, kProducer: kProducer, useCase: useCase, accLog: accLog, metrics: metrics}
 }
 
 func (gh NotificationGrpcHandlers) AddUserSettings(ctx context.Context, saga_uuid string, event_uuid string, users_data *api.AdditionalInfo, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "NotificationGrpcHandlers.AddUserSettings")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "NotificationGrpcHandlers.AddUserSettings")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -72,8 +73,10 @@ func (gh NotificationGrpcHandlers) AddUserSettings(ctx context.Context, saga_uui
 	}
 
 	if flag_error {
+		gh.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		gh.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -82,7 +85,7 @@ func (gh NotificationGrpcHandlers) AddUserSettings(ctx context.Context, saga_uui
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		gh.accLog.Error(err)
 		return err
@@ -94,8 +97,8 @@ func (gh NotificationGrpcHandlers) AddUserSettings(ctx context.Context, saga_uui
 
 func (gh NotificationGrpcHandlers) RemoveUserSettings(ctx context.Context, saga_uuid string, event_uuid string, users_data *api.AdditionalInfo, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "NotificationGrpcHandlers.AddUserSettings")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "NotificationGrpcHandlers.AddUserSettings")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -130,8 +133,10 @@ func (gh NotificationGrpcHandlers) RemoveUserSettings(ctx context.Context, saga_
 	}
 
 	if flag_error {
+		gh.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		gh.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -140,7 +145,7 @@ func (gh NotificationGrpcHandlers) RemoveUserSettings(ctx context.Context, saga_
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		gh.accLog.Error(err)
 		return err
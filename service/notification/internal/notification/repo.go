@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Repository interface {
@@ -11,4 +12,23 @@ type Repository interface {
 	UpdateUserNotificationSettings(ctx context.Context, user *models.UserNotificationInfo) error
 	GetUserNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error)
 	DeleteUserNotificationSettings(ctx context.Context, userId uuid.UUID) error
+	GetUserPreferences(ctx context.Context, userId uuid.UUID) ([]models.NotificationPreference, error)
+	UpdateUserPreferences(ctx context.Context, userId uuid.UUID, preferences []models.NotificationPreference) error
+	AddInboxMessage(ctx context.Context, message *models.InboxMessage) error
+	GetInboxMessages(ctx context.Context, userId uuid.UUID, unreadOnly bool) ([]models.InboxMessage, error)
+	ReadInboxMessage(ctx context.Context, userId uuid.UUID, messageId uuid.UUID) error
+	GetDelivery(ctx context.Context, messageId uuid.UUID, channel string) (*models.Delivery, error)
+	QueueDelivery(ctx context.Context, delivery *models.Delivery) (*models.Delivery, error)
+	UpdateDeliveryStatus(ctx context.Context, deliveryId uuid.UUID, status string, lastError string, sent bool) error
+	GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, error)
+	VerifyUserEmail(ctx context.Context, userId uuid.UUID, email string) error
+	GetEmailVerification(ctx context.Context, userId uuid.UUID) (*models.EmailVerification, error)
+	UpsertEmailVerification(ctx context.Context, verification *models.EmailVerification) error
+	AddPendingNotification(ctx context.Context, pending *models.PendingNotification) error
+	GetPendingUsers(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+	ClaimDuePendingNotifications(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error)
+	ClaimDigest(ctx context.Context, userId uuid.UUID, digestId uuid.UUID, before time.Time) error
+	ClaimDigests(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error)
+	DeletePendingNotification(ctx context.Context, messageId uuid.UUID) error
+	DeleteDigest(ctx context.Context, digestId uuid.UUID) error
 }
@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrorNoUserSettingsFound = errors.New("No user's settings found")
+	ErrorNoInboxMessageFound = errors.New("No inbox message found")
+	ErrorNoDeliveryFound     = errors.New("No delivery found")
 )
@@ -5,16 +5,177 @@ const (
 						(
 						 user_uuid,
 						 email_usage,
-						 email
+						 email,
+						 locale,
+						 phone,
+						 webhook_url,
+						 email_verified,
+						 digest_mode,
+						 timezone,
+						 quiet_hours_start,
+						 quiet_hours_end,
+						 webhook_secret
 						)
-						VALUES ($1, $2, $3);`
-	GetUserSettings = `SELECT *
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`
+	GetUserSettings = `SELECT user_uuid, email_usage, email, locale, phone, webhook_url, email_verified,
+							digest_mode, timezone, quiet_hours_start, quiet_hours_end, webhook_secret
 						FROM ONLY notification
 						WHERE user_uuid = $1;`
 	UpdateUserSettings = `UPDATE ONLY notification
 						SET email_usage = $2,
-						    email = $3
+						    email = $3,
+						    locale = $4,
+						    phone = $5,
+						    webhook_url = $6,
+						    email_verified = $7,
+						    digest_mode = $8,
+						    timezone = $9,
+						    quiet_hours_start = $10,
+						    quiet_hours_end = $11,
+						    webhook_secret = $12
 						WHERE user_uuid = $1;`
+	VerifyUserEmail = `UPDATE ONLY notification
+						SET email_verified = true,
+						    email_usage = true
+						WHERE user_uuid = $1 AND email = $2;`
+	GetEmailVerification = `SELECT user_uuid, email, last_sent_at, window_start, sent_count
+						FROM ONLY notification_email_verification
+						WHERE user_uuid = $1;`
+	UpsertEmailVerification = `INSERT INTO notification_email_verification
+						(
+						 user_uuid,
+						 email,
+						 last_sent_at,
+						 window_start,
+						 sent_count
+						)
+						VALUES ($1, $2, $3, $4, $5)
+						ON CONFLICT (user_uuid)
+						DO UPDATE SET email = EXCLUDED.email,
+						              last_sent_at = EXCLUDED.last_sent_at,
+						              window_start = EXCLUDED.window_start,
+						              sent_count = EXCLUDED.sent_count;`
 	DeleteUserSettings = `DELETE FROM ONLY notification
 							WHERE user_uuid = $1;`
+	AddInboxMessage = `INSERT INTO notification_inbox
+						(
+						 message_uuid,
+						 user_uuid,
+						 category,
+						 subject,
+						 body
+						)
+						VALUES ($1, $2, $3, $4, $5)
+						ON CONFLICT (message_uuid) DO NOTHING;`
+	GetInboxMessages = `SELECT message_uuid, user_uuid, category, subject, body, is_read, created_at
+						FROM ONLY notification_inbox
+						WHERE user_uuid = $1 AND (NOT $2 OR NOT is_read)
+						ORDER BY created_at DESC;`
+	ReadInboxMessage = `UPDATE ONLY notification_inbox
+						SET is_read = true
+						WHERE user_uuid = $1 AND message_uuid = $2;`
+	GetUserPreferences = `SELECT category, channel, enabled
+						FROM ONLY notification_preference
+						WHERE user_uuid = $1;`
+	UpsertUserPreference = `INSERT INTO notification_preference
+						(
+						 user_uuid,
+						 category,
+						 channel,
+						 enabled
+						)
+						VALUES ($1, $2, $3, $4)
+						ON CONFLICT (user_uuid, category, channel)
+						DO UPDATE SET enabled = EXCLUDED.enabled;`
+	GetDelivery = `SELECT delivery_uuid, message_uuid, user_uuid, channel, category, template, subject,
+							status, attempts, last_error, created_at, updated_at, sent_at
+						FROM ONLY notification_delivery
+						WHERE message_uuid = $1 AND channel = $2;`
+	QueueDelivery = `INSERT INTO notification_delivery
+						(
+						 delivery_uuid,
+						 message_uuid,
+						 user_uuid,
+						 channel,
+						 category,
+						 template,
+						 subject,
+						 status,
+						 attempts
+						)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)
+						ON CONFLICT (message_uuid, channel)
+						DO UPDATE SET status = EXCLUDED.status,
+						              subject = EXCLUDED.subject,
+						              attempts = notification_delivery.attempts + 1,
+						              updated_at = now()
+						RETURNING delivery_uuid, message_uuid, user_uuid, channel, category, template, subject,
+							status, attempts, last_error, created_at, updated_at, sent_at;`
+	UpdateDeliveryStatus = `UPDATE ONLY notification_delivery
+						SET status = $2,
+						    last_error = $3,
+						    updated_at = now(),
+						    sent_at = CASE WHEN $4 THEN now() ELSE sent_at END
+						WHERE delivery_uuid = $1;`
+	GetDeliveries = `SELECT delivery_uuid, message_uuid, user_uuid, channel, category, template, subject,
+							status, attempts, last_error, created_at, updated_at, sent_at
+						FROM ONLY notification_delivery
+						WHERE ($1::uuid IS NULL OR user_uuid = $1)
+						  AND ($2::uuid IS NULL OR message_uuid = $2)
+						  AND ($3 = '' OR channel = $3)
+						  AND ($4 = '' OR status = $4)
+						  AND ($5 = '' OR template = $5)
+						  AND created_at BETWEEN $6 AND $7
+						ORDER BY created_at DESC
+						LIMIT $8 OFFSET $9;`
+	AddPendingNotification = `INSERT INTO notification_pending
+						(
+						 message_uuid,
+						 user_uuid,
+						 category,
+						 template,
+						 body,
+						 digest,
+						 deliver_at
+						)
+						VALUES ($1, $2, $3, $4, $5, $6, $7)
+						ON CONFLICT (message_uuid) DO NOTHING;`
+	GetPendingUsers = `SELECT DISTINCT user_uuid
+						FROM ONLY notification_pending
+						WHERE deliver_at <= $1 OR digest_uuid IS NOT NULL;`
+	ClaimDuePendingNotifications = `WITH claimed AS (
+							UPDATE ONLY notification_pending
+							SET claimed_until = $3
+							WHERE message_uuid IN (
+								SELECT message_uuid
+								FROM ONLY notification_pending
+								WHERE user_uuid = $1 AND NOT digest AND deliver_at <= $2
+								  AND (claimed_until IS NULL OR claimed_until <= $2)
+								FOR UPDATE SKIP LOCKED
+							)
+							RETURNING message_uuid, user_uuid, category, template, body, digest, digest_uuid, deliver_at, created_at
+						)
+						SELECT * FROM claimed
+						ORDER BY created_at;`
+	ClaimDigest = `UPDATE ONLY notification_pending
+						SET digest_uuid = $2
+						WHERE user_uuid = $1 AND digest AND digest_uuid IS NULL AND deliver_at <= $3;`
+	ClaimDigests = `WITH claimed AS (
+							UPDATE ONLY notification_pending
+							SET claimed_until = $3
+							WHERE message_uuid IN (
+								SELECT message_uuid
+								FROM ONLY notification_pending
+								WHERE user_uuid = $1 AND digest_uuid IS NOT NULL
+								  AND (claimed_until IS NULL OR claimed_until <= $2)
+								FOR UPDATE SKIP LOCKED
+							)
+							RETURNING message_uuid, user_uuid, category, template, body, digest, digest_uuid, deliver_at, created_at
+						)
+						SELECT * FROM claimed
+						ORDER BY digest_uuid, created_at;`
+	DeletePendingNotification = `DELETE FROM ONLY notification_pending
+						WHERE message_uuid = $1;`
+	DeleteDigest = `DELETE FROM ONLY notification_pending
+						WHERE digest_uuid = $1;`
 )
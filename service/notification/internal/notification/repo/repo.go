@@ -2,11 +2,12 @@ package repo
 
 import (
 	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/opentracing/opentracing-go"
+	"time"
 )
 
 type NotificationRepository struct {
@@ -15,14 +16,23 @@ type NotificationRepository struct {
 
 func (repo NotificationRepository) AddUserNotificationSettings(ctx context.Context, user *models.UserNotificationInfo) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationRepository.AddUserNotificationSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.AddUserNotificationSettings")
+	defer span.End()
 
 	if _, err := repo.db.ExecContext(local_ctx,
 		AddUserSettings,
 		user.UserUuid,
 		user.EmailUsage,
 		user.Email,
+		user.Locale,
+		user.Phone,
+		user.WebhookUrl,
+		user.EmailVerified,
+		user.DigestMode,
+		user.Timezone,
+		user.QuietHoursStart,
+		user.QuietHoursEnd,
+		user.WebhookSecret,
 	); err != nil {
 		return err
 	}
@@ -32,14 +42,23 @@ func (repo NotificationRepository) AddUserNotificationSettings(ctx context.Conte
 
 func (repo NotificationRepository) UpdateUserNotificationSettings(ctx context.Context, user *models.UserNotificationInfo) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationRepository.UpdateUserNotificationSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.UpdateUserNotificationSettings")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		UpdateUserSettings,
 		user.UserUuid,
 		user.EmailUsage,
 		user.Email,
+		user.Locale,
+		user.Phone,
+		user.WebhookUrl,
+		user.EmailVerified,
+		user.DigestMode,
+		user.Timezone,
+		user.QuietHoursStart,
+		user.QuietHoursEnd,
+		user.WebhookSecret,
 	)
 
 	if err != nil {
@@ -53,15 +72,16 @@ func (repo NotificationRepository) UpdateUserNotificationSettings(ctx context.Co
 
 func (repo NotificationRepository) GetUserNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error) {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationRepository.GetUserNotificationSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetUserNotificationSettings")
+	defer span.End()
 
 	var userSettings = &models.UserNotificationInfo{}
 
 	if err := repo.db.QueryRowxContext(local_ctx,
 		GetUserSettings,
 		&userId,
-	).Scan(&userSettings.UserUuid, &userSettings.EmailUsage, &userSettings.Email); err != nil {
+	).Scan(&userSettings.UserUuid, &userSettings.EmailUsage, &userSettings.Email, &userSettings.Locale, &userSettings.Phone, &userSettings.WebhookUrl, &userSettings.EmailVerified,
+		&userSettings.DigestMode, &userSettings.Timezone, &userSettings.QuietHoursStart, &userSettings.QuietHoursEnd, &userSettings.WebhookSecret); err != nil {
 		return nil, err
 	}
 
@@ -70,8 +90,8 @@ func (repo NotificationRepository) GetUserNotificationSettings(ctx context.Conte
 
 func (repo NotificationRepository) DeleteUserNotificationSettings(ctx context.Context, userId uuid.UUID) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationRepository.DeleteUserNotificationSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.DeleteUserNotificationSettings")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		DeleteUserSettings,
@@ -87,6 +107,380 @@ func (repo NotificationRepository) DeleteUserNotificationSettings(ctx context.Co
 	return nil
 }
 
+func (repo NotificationRepository) GetUserPreferences(ctx context.Context, userId uuid.UUID) ([]models.NotificationPreference, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetUserPreferences")
+	defer span.End()
+
+	var preferences []models.NotificationPreference
+
+	if err := repo.db.SelectContext(local_ctx,
+		&preferences,
+		GetUserPreferences,
+		userId,
+	); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+func (repo NotificationRepository) UpdateUserPreferences(ctx context.Context, userId uuid.UUID, preferences []models.NotificationPreference) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.UpdateUserPreferences")
+	defer span.End()
+
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, preference := range preferences {
+		if _, err = tx.ExecContext(local_ctx,
+			UpsertUserPreference,
+			userId,
+			preference.Category,
+			preference.Channel,
+			preference.Enabled,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo NotificationRepository) AddInboxMessage(ctx context.Context, message *models.InboxMessage) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.AddInboxMessage")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		AddInboxMessage,
+		message.MessageUuid,
+		message.UserUuid,
+		message.Category,
+		message.Subject,
+		message.Body,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) GetInboxMessages(ctx context.Context, userId uuid.UUID, unreadOnly bool) ([]models.InboxMessage, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetInboxMessages")
+	defer span.End()
+
+	messages := []models.InboxMessage{}
+
+	if err := repo.db.SelectContext(local_ctx,
+		&messages,
+		GetInboxMessages,
+		userId,
+		unreadOnly,
+	); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (repo NotificationRepository) ReadInboxMessage(ctx context.Context, userId uuid.UUID, messageId uuid.UUID) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.ReadInboxMessage")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(local_ctx,
+		ReadInboxMessage,
+		userId,
+		messageId,
+	)
+
+	if err != nil {
+		return err
+	} else if count, err := res.RowsAffected(); err != nil || count == 0 {
+		return ErrorNoInboxMessageFound
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) GetDelivery(ctx context.Context, messageId uuid.UUID, channel string) (*models.Delivery, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetDelivery")
+	defer span.End()
+
+	var delivery = &models.Delivery{}
+
+	if err := repo.db.QueryRowxContext(local_ctx,
+		GetDelivery,
+		messageId,
+		channel,
+	).StructScan(delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (repo NotificationRepository) QueueDelivery(ctx context.Context, delivery *models.Delivery) (*models.Delivery, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.QueueDelivery")
+	defer span.End()
+
+	var result = &models.Delivery{}
+
+	if err := repo.db.QueryRowxContext(local_ctx,
+		QueueDelivery,
+		delivery.DeliveryUuid,
+		delivery.MessageUuid,
+		delivery.UserUuid,
+		delivery.Channel,
+		delivery.Category,
+		delivery.Template,
+		delivery.Subject,
+		delivery.Status,
+	).StructScan(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (repo NotificationRepository) UpdateDeliveryStatus(ctx context.Context, deliveryId uuid.UUID, status string, lastError string, sent bool) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.UpdateDeliveryStatus")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(local_ctx,
+		UpdateDeliveryStatus,
+		deliveryId,
+		status,
+		lastError,
+		sent,
+	)
+
+	if err != nil {
+		return err
+	} else if count, err := res.RowsAffected(); err != nil || count == 0 {
+		return ErrorNoDeliveryFound
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetDeliveries")
+	defer span.End()
+
+	deliveries := []models.Delivery{}
+
+	if err := repo.db.SelectContext(local_ctx,
+		&deliveries,
+		GetDeliveries,
+		filter.UserUuid,
+		filter.MessageUuid,
+		filter.Channel,
+		filter.Status,
+		filter.Template,
+		filter.Begin,
+		filter.End,
+		filter.Limit,
+		filter.Offset,
+	); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (repo NotificationRepository) VerifyUserEmail(ctx context.Context, userId uuid.UUID, email string) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.VerifyUserEmail")
+	defer span.End()
+
+	res, err := repo.db.ExecContext(local_ctx,
+		VerifyUserEmail,
+		userId,
+		email,
+	)
+
+	if err != nil {
+		return err
+	} else if count, err := res.RowsAffected(); err != nil || count == 0 {
+		return ErrorNoUserSettingsFound
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) GetEmailVerification(ctx context.Context, userId uuid.UUID) (*models.EmailVerification, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetEmailVerification")
+	defer span.End()
+
+	var verification = &models.EmailVerification{}
+
+	if err := repo.db.QueryRowxContext(local_ctx,
+		GetEmailVerification,
+		userId,
+	).StructScan(verification); err != nil {
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+func (repo NotificationRepository) UpsertEmailVerification(ctx context.Context, verification *models.EmailVerification) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.UpsertEmailVerification")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		UpsertEmailVerification,
+		verification.UserUuid,
+		verification.Email,
+		verification.LastSentAt,
+		verification.WindowStart,
+		verification.SentCount,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) AddPendingNotification(ctx context.Context, pending *models.PendingNotification) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.AddPendingNotification")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		AddPendingNotification,
+		pending.MessageUuid,
+		pending.UserUuid,
+		pending.Category,
+		pending.Template,
+		pending.Body,
+		pending.Digest,
+		pending.DeliverAt,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) GetPendingUsers(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.GetPendingUsers")
+	defer span.End()
+
+	users := []uuid.UUID{}
+
+	if err := repo.db.SelectContext(local_ctx,
+		&users,
+		GetPendingUsers,
+		before,
+	); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (repo NotificationRepository) ClaimDuePendingNotifications(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.ClaimDuePendingNotifications")
+	defer span.End()
+
+	pending := []models.PendingNotification{}
+
+	if err := repo.db.SelectContext(local_ctx,
+		&pending,
+		ClaimDuePendingNotifications,
+		userId,
+		before,
+		claimUntil,
+	); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (repo NotificationRepository) ClaimDigest(ctx context.Context, userId uuid.UUID, digestId uuid.UUID, before time.Time) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.ClaimDigest")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		ClaimDigest,
+		userId,
+		digestId,
+		before,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) ClaimDigests(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.ClaimDigests")
+	defer span.End()
+
+	pending := []models.PendingNotification{}
+
+	if err := repo.db.SelectContext(local_ctx,
+		&pending,
+		ClaimDigests,
+		userId,
+		before,
+		claimUntil,
+	); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (repo NotificationRepository) DeletePendingNotification(ctx context.Context, messageId uuid.UUID) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.DeletePendingNotification")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		DeletePendingNotification,
+		messageId,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo NotificationRepository) DeleteDigest(ctx context.Context, digestId uuid.UUID) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationRepository.DeleteDigest")
+	defer span.End()
+
+	if _, err := repo.db.ExecContext(local_ctx,
+		DeleteDigest,
+		digestId,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func NewNotificationRepository(db *sqlx.DB) notification.Repository {
 	return &NotificationRepository{db: db}
 }
@@ -0,0 +1,7 @@
+package templates
+
+import "errors"
+
+var (
+	ErrorUnknownTemplate = errors.New("Unknown notification template")
+)
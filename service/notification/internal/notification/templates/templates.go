@@ -0,0 +1,102 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+)
+
+// Template names passed by producers in rmq message header
+const (
+	TemplateWelcome string = "welcome"
+	TemplateSignIn  string = "sign_in"
+	TemplateTotpOn  string = "totp_on"
+	TemplateTotpOff string = "totp_off"
+	// Sent by registration service after account operations
+	TemplateDeposit        string = "deposit"
+	TemplateWithdrawal     string = "withdrawal"
+	TemplateAccountClosed  string = "account_closed"
+	TemplateAccountBlocked string = "account_blocked"
+	// Sent by notification service itself on email add or change
+	TemplateVerifyEmail string = "verify_email"
+	// Sent by notification service itself, collects deferred notifications
+	TemplateDigest string = "digest"
+)
+
+const (
+	LocaleRu      string = "ru"
+	LocaleEn      string = "en"
+	DefaultLocale        = LocaleRu
+)
+
+const (
+	subjectSuffix    = ".subject"
+	textSuffix       = ".text"
+	htmlSuffix       = ".html"
+	missingKeyOption = "missingkey=error"
+)
+
+var PossibleLocales = []string{
+	LocaleRu,
+	LocaleEn,
+}
+
+// Every <name>.<locale>.txt.tmpl defines "<name>.<locale>.subject" and "<name>.<locale>.text",
+// every <name>.<locale>.html.tmpl defines "<name>.<locale>.html"
+//
+//go:embed *.tmpl
+var files embed.FS
+
+// Rendered message parts
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Renderer struct {
+	text *textTemplate.Template
+	html *htmlTemplate.Template
+}
+
+// NewRenderer parses embedded templates
+func NewRenderer() (*Renderer, error) {
+	text, err := textTemplate.New("text").Option(missingKeyOption).ParseFS(files, "*.txt.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmlTemplate.New("html").Option(missingKeyOption).ParseFS(files, "*.html.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{text: text, html: html}, nil
+}
+
+// Render template in locale. Default locale is used when template has no translation.
+func (r *Renderer) Render(name string, locale string, data interface{}) (*Rendered, error) {
+	prefix := name + "." + locale
+	if r.text.Lookup(prefix+subjectSuffix) == nil {
+		prefix = name + "." + DefaultLocale
+		if r.text.Lookup(prefix+subjectSuffix) == nil {
+			return nil, ErrorUnknownTemplate
+		}
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := r.text.ExecuteTemplate(&subject, prefix+subjectSuffix, data); err != nil {
+		return nil, err
+	}
+	if err := r.text.ExecuteTemplate(&text, prefix+textSuffix, data); err != nil {
+		return nil, err
+	}
+	if err := r.html.ExecuteTemplate(&html, prefix+htmlSuffix, data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
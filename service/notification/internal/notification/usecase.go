@@ -5,12 +5,19 @@ import (
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/google/uuid"
 	"github.com/rabbitmq/amqp091-go"
+	"time"
 )
 
 type UseCase interface {
 	AddUserSettings(ctx context.Context, user *models.UserNotificationInfo) error
 	GetUserSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error)
-	UpdateUserSettings(ctx context.Context, user *models.UserNotificationInfo) error
+	UpdateUserSettings(ctx context.Context, user *models.UserNotificationInfo) (string, error)
 	DeleteUserSettings(ctx context.Context, userId uuid.UUID) error
 	SendMessage(ctx context.Context, message amqp091.Delivery) error
+	GetInbox(ctx context.Context, userId uuid.UUID, unreadOnly bool) ([]models.InboxMessage, error)
+	ReadInboxMessage(ctx context.Context, userId uuid.UUID, messageId uuid.UUID) error
+	GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, error)
+	ResendEmailVerification(ctx context.Context, userId uuid.UUID) error
+	VerifyEmail(ctx context.Context, token string) error
+	ProcessPending(ctx context.Context, now time.Time) error
 }
@@ -0,0 +1,22 @@
+package usecase
+
+import "time"
+
+// Delivery log statuses
+const (
+	DeliveryStatusQueued  string = "queued"
+	DeliveryStatusSent    string = "sent"
+	DeliveryStatusFailed  string = "failed"
+	DeliveryStatusBounced string = "bounced"
+)
+
+// Statuses after which channel is not tried again
+var FinalDeliveryStatuses = []string{
+	DeliveryStatusSent,
+	DeliveryStatusBounced,
+}
+
+const (
+	DefaultDeliveriesLimit  = 50
+	DefaultDeliveriesPeriod = 24 * time.Hour
+)
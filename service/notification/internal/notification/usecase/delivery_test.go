@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
+	"github.com/google/uuid"
+)
+
+func findDelivery(t *testing.T, repo *pendingRepo, messageId uuid.UUID, channel string) models.Delivery {
+	delivery, err := repo.GetDelivery(context.Background(), messageId, channel)
+	if err != nil {
+		t.Fatalf("no delivery of %s", channel)
+	}
+	return *delivery
+}
+
+func TestNotificationUseCase_SendMessageDeliveryLog(t *testing.T) {
+	user := newMessageUser(templates.LocaleEn)
+	repo := &pendingRepo{
+		user: user,
+		preferences: []models.NotificationPreference{
+			{Category: CategoryTransactions, Channel: channels.ChannelSms, Enabled: true},
+		},
+	}
+	email := &testChannel{name: channels.ChannelEmail}
+	inbox := &testChannel{name: channels.ChannelInbox, err: fmt.Errorf("%w: %v", channels.ErrorBounced, errors.New("550 mailbox unavailable"))}
+	sms := &testChannel{name: channels.ChannelSms, err: errors.New("sms gateway timeout")}
+	uc := newDeliveryUseCase(t, repo, email, inbox, sms)
+	metrics := uc.metrics.(*testMetrics)
+
+	message := newMessage(t, user.UserUuid, CategoryTransactions, templates.TemplateDeposit, depositData("Main"))
+	messageId := uuid.MustParse(message.MessageId)
+
+	// Only failed channel is returned as error, bounced one will not be retried
+	err := uc.SendMessage(context.Background(), message)
+	if err == nil || !strings.Contains(err.Error(), "sms gateway timeout") || strings.Contains(err.Error(), "550") {
+		t.Fatalf("error = %v", err)
+	}
+	if len(repo.deliveries) != 3 {
+		t.Fatalf("deliveries = %d", len(repo.deliveries))
+	}
+
+	cases := []struct {
+		channel    string
+		wantStatus string
+		wantError  string
+		wantSent   bool
+	}{
+		{channels.ChannelEmail, DeliveryStatusSent, "", true},
+		{channels.ChannelInbox, DeliveryStatusBounced, "550 mailbox unavailable", false},
+		{channels.ChannelSms, DeliveryStatusFailed, "sms gateway timeout", false},
+	}
+	for _, test := range cases {
+		delivery := findDelivery(t, repo, messageId, test.channel)
+		if delivery.Status != test.wantStatus || delivery.Attempts != 1 {
+			t.Errorf("%s status = %s, attempts = %d, want %s", test.channel, delivery.Status, delivery.Attempts, test.wantStatus)
+		}
+		if !strings.Contains(delivery.LastError, test.wantError) || (test.wantError == "") != (delivery.LastError == "") {
+			t.Errorf("%s last error = %q, want %q", test.channel, delivery.LastError, test.wantError)
+		}
+		if (delivery.SentAt != nil) != test.wantSent {
+			t.Errorf("%s sent at = %v", test.channel, delivery.SentAt)
+		}
+		if delivery.UserUuid != user.UserUuid || delivery.Category != CategoryTransactions ||
+			delivery.Template != templates.TemplateDeposit || delivery.Subject != "Deposit to account Main" {
+			t.Errorf("%s delivery = %+v", test.channel, delivery)
+		}
+	}
+	if metrics.sent[channels.ChannelEmail] != 1 || metrics.failed[channels.ChannelInbox] != 1 || metrics.failed[channels.ChannelSms] != 1 {
+		t.Fatalf("sent = %v, failed = %v", metrics.sent, metrics.failed)
+	}
+
+	// Redelivered message goes only to channel which failed before
+	sms.err = nil
+	if err = uc.SendMessage(context.Background(), message); err != nil {
+		t.Fatal(err)
+	}
+	if len(email.sent) != 1 || len(inbox.sent) != 0 || len(sms.sent) != 1 {
+		t.Fatalf("email = %d, inbox = %d, sms = %d", len(email.sent), len(inbox.sent), len(sms.sent))
+	}
+	if len(repo.deliveries) != 3 {
+		t.Fatalf("deliveries = %d", len(repo.deliveries))
+	}
+	delivery := findDelivery(t, repo, messageId, channels.ChannelSms)
+	if delivery.Status != DeliveryStatusSent || delivery.Attempts != 2 || delivery.LastError != "" || delivery.SentAt == nil {
+		t.Fatalf("sms delivery = %+v", delivery)
+	}
+	if delivery = findDelivery(t, repo, messageId, channels.ChannelEmail); delivery.Attempts != 1 {
+		t.Fatalf("email attempts = %d", delivery.Attempts)
+	}
+}
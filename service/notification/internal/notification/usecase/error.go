@@ -6,6 +6,19 @@ var (
 	ErrorUserNotFound             = errors.New("User not found")
 	ErrorUserSettingsAlreadyExist = errors.New("User settings already exist")
 	ErrorNoUserIdHeader           = errors.New("No user id into rmq message headers")
-	ErrorNoNotificationLvlHeader  = errors.New("No notification lvl into rmq message headers")
-	ErrorInvalidNotificationLvl   = errors.New("Unknown notification lvl")
+	ErrorNoCategoryHeader         = errors.New("No category into rmq message headers")
+	ErrorInvalidCategory          = errors.New("Unknown notification category")
+	ErrorInvalidChannel           = errors.New("Unknown notification channel")
+	ErrorMandatoryCategory        = errors.New("Mandatory notification category can't be turned off")
+	ErrorInvalidUserIdHeader      = errors.New("Invalid user id into rmq message headers")
+	ErrorNoTemplateHeader         = errors.New("No template into rmq message headers")
+	ErrorInvalidTemplateHeader    = errors.New("Invalid template into rmq message headers")
+	ErrorNoEmail                  = errors.New("User has no email")
+	ErrorEmailNotVerified         = errors.New("Email is not verified")
+	ErrorEmailAlreadyVerified     = errors.New("Email is already verified")
+	ErrorVerificationEmailChanged = errors.New("Email was changed after verification link was sent")
+	ErrorVerificationRateLimit    = errors.New("Too many verification emails, try later")
+	ErrorInvalidDigestMode        = errors.New("Unknown digest mode")
+	ErrorInvalidTimezone          = errors.New("Unknown time zone")
+	ErrorInvalidQuietHours        = errors.New("Quiet hours must be between 0 and 23")
 )
@@ -1,6 +1,7 @@
 package usecase
 
 const (
-	HeaderUserId          string = "user_id"
-	HeaderNotificationLvl string = "notification_level"
+	HeaderUserId   string = "user_id"
+	HeaderCategory string = "category"
+	HeaderTemplate string = "template"
 )
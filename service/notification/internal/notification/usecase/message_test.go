@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"strings"
+	"testing"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
+	"github.com/GCFactory/dbo-system/platform/pkg/mail"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
+	"github.com/google/uuid"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+func newMessageUser(locale string) *models.UserNotificationInfo {
+	return &models.UserNotificationInfo{
+		UserUuid:      uuid.New(),
+		EmailUsage:    true,
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Locale:        locale,
+		DigestMode:    DigestModeOff,
+		Timezone:      DefaultTimezone,
+	}
+}
+
+func newMessage(t *testing.T, userId uuid.UUID, category string, template string, data map[string]interface{}) amqp091.Delivery {
+	body, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amqp091.Delivery{
+		MessageId: uuid.New().String(),
+		Headers: amqp091.Table{
+			HeaderUserId:   userId.String(),
+			HeaderCategory: category,
+			HeaderTemplate: template,
+		},
+		Body: body,
+	}
+}
+
+func depositData(accountName string) map[string]interface{} {
+	return map[string]interface{}{
+		"AccountName":  accountName,
+		"Amount":       100.5,
+		"BalanceAfter": 250,
+		"OperationId":  "op-1",
+		"Time":         "10:00",
+	}
+}
+
+// readMimeParts checks multipart/alternative message and returns its decoded parts by content type
+func readMimeParts(t *testing.T, msg mail.Message) (*netmail.Message, map[string]string) {
+	parsed, err := netmail.ReadMessage(strings.NewReader(string(msg.Data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" || parsed.Header.Get("MIME-Version") != "1.0" {
+		t.Fatalf("content type = %s, mime version = %s", mediaType, parsed.Header.Get("MIME-Version"))
+	}
+
+	parts := make(map[string]string)
+	var order []string
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		partType, partParams, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if partParams["charset"] != "utf-8" {
+			t.Fatalf("%s charset = %s", partType, partParams["charset"])
+		}
+		// Quoted-printable is decoded by multipart reader
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[partType] = string(content)
+		order = append(order, partType)
+	}
+
+	// Preferred alternative goes last
+	if len(order) != 2 || order[0] != "text/plain" || order[1] != "text/html" {
+		t.Fatalf("parts = %v", order)
+	}
+	return parsed, parts
+}
+
+func TestNotificationUseCase_SendMessageTemplates(t *testing.T) {
+	cases := map[string]struct {
+		locale      string
+		wantSubject string
+		wantText    string
+	}{
+		"en":               {templates.LocaleEn, "Deposit to account Main", "Account Main was credited with 100.50."},
+		"ru":               {templates.LocaleRu, "Пополнение счёта Main", "Счёт Main пополнен на 100.50."},
+		"unknown locale":   {"de", "Пополнение счёта Main", "Счёт Main пополнен на 100.50."},
+		"locale not given": {"", "Пополнение счёта Main", "Баланс после операции: 250.00."},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			user := newMessageUser(test.locale)
+			inbox := &testChannel{name: channels.ChannelInbox}
+			uc := newDeliveryUseCase(t, &pendingRepo{user: user}, inbox)
+
+			err := uc.SendMessage(context.Background(), newMessage(t, user.UserUuid, CategoryTransactions, templates.TemplateDeposit, depositData("Main")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(inbox.sent) != 1 {
+				t.Fatalf("sent = %d", len(inbox.sent))
+			}
+			sent := inbox.sent[0]
+			if sent.Template != templates.TemplateDeposit || sent.Category != CategoryTransactions {
+				t.Fatalf("template = %s, category = %s", sent.Template, sent.Category)
+			}
+			if sent.Subject != test.wantSubject {
+				t.Fatalf("subject = %q, want %q", sent.Subject, test.wantSubject)
+			}
+			if !strings.Contains(sent.Text, test.wantText) {
+				t.Fatalf("text = %q, want %q", sent.Text, test.wantText)
+			}
+		})
+	}
+}
+
+func TestNotificationUseCase_SendMessageVariables(t *testing.T) {
+	user := newMessageUser(templates.LocaleEn)
+	inbox := &testChannel{name: channels.ChannelInbox}
+	uc := newDeliveryUseCase(t, &pendingRepo{user: user}, inbox)
+
+	data := depositData(`<script>"Main"</script>`)
+	if err := uc.SendMessage(context.Background(), newMessage(t, user.UserUuid, CategoryTransactions, templates.TemplateDeposit, data)); err != nil {
+		t.Fatal(err)
+	}
+
+	sent := inbox.sent[0]
+	for _, want := range []string{`<script>"Main"</script>`, "100.50", "250.00", "op-1", "10:00"} {
+		if !strings.Contains(sent.Text, want) {
+			t.Errorf("text has no %q: %s", want, sent.Text)
+		}
+	}
+	// Values are escaped in html part only
+	if strings.Contains(sent.HTML, "<script>") || !strings.Contains(sent.HTML, "&lt;script&gt;") {
+		t.Errorf("html is not escaped: %s", sent.HTML)
+	}
+	if sent.Data["OperationId"] != "op-1" {
+		t.Errorf("message data = %v", sent.Data)
+	}
+}
+
+func TestNotificationUseCase_SendMessageTemplateErrors(t *testing.T) {
+	missing := depositData("Main")
+	delete(missing, "Amount")
+
+	cases := map[string]struct {
+		template string
+		data     map[string]interface{}
+	}{
+		"unknown template": {"unknown", depositData("Main")},
+		"missing variable": {templates.TemplateDeposit, missing},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			user := newMessageUser(templates.LocaleEn)
+			repo := &pendingRepo{user: user}
+			inbox := &testChannel{name: channels.ChannelInbox}
+			uc := newDeliveryUseCase(t, repo, inbox)
+
+			// Retry will not fix template, message goes to dead-letter queue at once
+			err := uc.SendMessage(context.Background(), newMessage(t, user.UserUuid, CategoryTransactions, test.template, test.data))
+			if !rmq.IsPermanent(err) {
+				t.Fatalf("error = %v, permanent error is expected", err)
+			}
+			if len(inbox.sent) != 0 || len(repo.deliveries) != 0 {
+				t.Fatalf("sent = %d, deliveries = %d", len(inbox.sent), len(repo.deliveries))
+			}
+		})
+	}
+}
+
+func TestNotificationUseCase_SendMessageMime(t *testing.T) {
+	user := newMessageUser(templates.LocaleRu)
+	sender := mail.NewMemorySender()
+	email := channels.NewEmailChannel(sender, config.Smtp{From: "noreply@dbo.local", NickName: "dbo-system"})
+	uc := newDeliveryUseCase(t, &pendingRepo{user: user}, email)
+
+	if err := uc.SendMessage(context.Background(), newMessage(t, user.UserUuid, CategoryTransactions, templates.TemplateDeposit, depositData("Main"))); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := sender.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("sent = %d", len(messages))
+	}
+	if messages[0].From != "noreply@dbo.local" || len(messages[0].To) != 1 || messages[0].To[0] != user.Email {
+		t.Fatalf("envelope from = %s, to = %v", messages[0].From, messages[0].To)
+	}
+
+	parsed, parts := readMimeParts(t, messages[0])
+
+	// Non-ascii subject is encoded word
+	rawSubject := parsed.Header.Get("Subject")
+	subject, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawSubject == subject || subject != "Пополнение счёта Main" {
+		t.Fatalf("raw subject = %q, subject = %q", rawSubject, subject)
+	}
+	if to := parsed.Header.Get("To"); to != "<user@example.com>" {
+		t.Fatalf("to = %s", to)
+	}
+	if parsed.Header.Get("Message-ID") == "" {
+		t.Fatal("message has no id")
+	}
+
+	if !strings.Contains(parts["text/plain"], "Счёт Main пополнен на 100.50.") {
+		t.Errorf("text part = %q", parts["text/plain"])
+	}
+	if !strings.Contains(parts["text/html"], "Счёт <b>Main</b> пополнен на <b>100.50</b>.") {
+		t.Errorf("html part = %q", parts["text/html"])
+	}
+}
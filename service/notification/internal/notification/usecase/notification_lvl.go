@@ -1,11 +0,0 @@
-package usecase
-
-const (
-	NotificationLvlEmail string = "email"
-	NotificationLvlAll   string = "all"
-)
-
-var PossibleNotificationLvl = []string{
-	NotificationLvlEmail,
-	NotificationLvlAll,
-}
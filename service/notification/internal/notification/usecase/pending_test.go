@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
+	"github.com/google/uuid"
+)
+
+type pendingItem struct {
+	models.PendingNotification
+	claimedUntil time.Time
+}
+
+// pendingRepo keeps pending items and deliveries of one user in memory, claims work like
+// claimed_until lease of notification_pending
+type pendingRepo struct {
+	notification.Repository
+	mu          sync.Mutex
+	user        *models.UserNotificationInfo
+	preferences []models.NotificationPreference
+	items       []*pendingItem
+	deliveries  []models.Delivery
+}
+
+func (r *pendingRepo) GetUserNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error) {
+	user := *r.user
+	return &user, nil
+}
+
+func (r *pendingRepo) GetUserPreferences(ctx context.Context, userId uuid.UUID) ([]models.NotificationPreference, error) {
+	return r.preferences, nil
+}
+
+func (r *pendingRepo) claim(before time.Time, claimUntil time.Time, match func(item *pendingItem) bool) []models.PendingNotification {
+	var claimed []models.PendingNotification
+	for _, item := range r.items {
+		if match(item) && !item.claimedUntil.After(before) {
+			item.claimedUntil = claimUntil
+			claimed = append(claimed, item.PendingNotification)
+		}
+	}
+	return claimed
+}
+
+func (r *pendingRepo) ClaimDuePendingNotifications(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.claim(before, claimUntil, func(item *pendingItem) bool {
+		return !item.Digest && !item.DeliverAt.After(before)
+	}), nil
+}
+
+func (r *pendingRepo) ClaimDigest(ctx context.Context, userId uuid.UUID, digestId uuid.UUID, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, item := range r.items {
+		if item.Digest && item.DigestUuid == nil && !item.DeliverAt.After(before) {
+			item.DigestUuid = &digestId
+		}
+	}
+	return nil
+}
+
+func (r *pendingRepo) ClaimDigests(ctx context.Context, userId uuid.UUID, before time.Time, claimUntil time.Time) ([]models.PendingNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.claim(before, claimUntil, func(item *pendingItem) bool {
+		return item.DigestUuid != nil
+	}), nil
+}
+
+func (r *pendingRepo) DeletePendingNotification(ctx context.Context, messageId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = slices.DeleteFunc(r.items, func(item *pendingItem) bool { return item.MessageUuid == messageId })
+	return nil
+}
+
+func (r *pendingRepo) DeleteDigest(ctx context.Context, digestId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = slices.DeleteFunc(r.items, func(item *pendingItem) bool {
+		return item.DigestUuid != nil && *item.DigestUuid == digestId
+	})
+	return nil
+}
+
+func (r *pendingRepo) GetDelivery(ctx context.Context, messageId uuid.UUID, channel string) (*models.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].MessageUuid == messageId && r.deliveries[i].Channel == channel {
+			delivery := r.deliveries[i]
+			return &delivery, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// QueueDelivery keeps one record per message and channel like ON CONFLICT of notification_delivery
+func (r *pendingRepo) QueueDelivery(ctx context.Context, delivery *models.Delivery) (*models.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].MessageUuid == delivery.MessageUuid && r.deliveries[i].Channel == delivery.Channel {
+			r.deliveries[i].Status = delivery.Status
+			r.deliveries[i].Subject = delivery.Subject
+			r.deliveries[i].Attempts++
+			result := r.deliveries[i]
+			return &result, nil
+		}
+	}
+	result := *delivery
+	result.Attempts = 1
+	r.deliveries = append(r.deliveries, result)
+	return &result, nil
+}
+
+func (r *pendingRepo) UpdateDeliveryStatus(ctx context.Context, deliveryId uuid.UUID, status string, lastError string, sent bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.deliveries {
+		if r.deliveries[i].DeliveryUuid == deliveryId {
+			r.deliveries[i].Status = status
+			r.deliveries[i].LastError = lastError
+			if sent {
+				sentAt := time.Now()
+				r.deliveries[i].SentAt = &sentAt
+			}
+		}
+	}
+	return nil
+}
+
+// testChannel records sent messages, onSend is called before message is recorded
+type testChannel struct {
+	name   string
+	err    error
+	onSend func()
+	sent   []*models.ChannelMessage
+}
+
+func (ch *testChannel) Name() string {
+	return ch.name
+}
+
+func (ch *testChannel) Available(user *models.UserNotificationInfo) bool {
+	return true
+}
+
+func (ch *testChannel) Send(ctx context.Context, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+	if ch.onSend != nil {
+		ch.onSend()
+	}
+	if ch.err != nil {
+		return ch.err
+	}
+	ch.sent = append(ch.sent, message)
+	return nil
+}
+
+type testMetrics struct {
+	sent   map[string]int
+	failed map[string]int
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{sent: map[string]int{}, failed: map[string]int{}}
+}
+
+func (m *testMetrics) IncNotificationSent(channel string) {
+	m.sent[channel]++
+}
+
+func (m *testMetrics) IncNotificationFailed(channel string) {
+	m.failed[channel]++
+}
+
+func newDeliveryUseCase(t *testing.T, repo notification.Repository, notificationChannels ...notification.Channel) *NotificationUseCase {
+	renderer, err := templates.NewRenderer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &NotificationUseCase{repo: repo, channels: notificationChannels, renderer: renderer, metrics: newTestMetrics()}
+}
+
+func depositItem(userId uuid.UUID, deliverAt time.Time) *pendingItem {
+	return &pendingItem{PendingNotification: models.PendingNotification{
+		MessageUuid: uuid.New(),
+		UserUuid:    userId,
+		Category:    CategoryTransactions,
+		Template:    templates.TemplateDeposit,
+		Body:        `{"AccountName":"main","Amount":100,"BalanceAfter":200,"OperationId":"op","Time":"10:00"}`,
+		Digest:      true,
+		DeliverAt:   deliverAt,
+		CreatedAt:   deliverAt,
+	}}
+}
+
+func TestNotificationUseCase_ProcessUserPendingOverlappingClaims(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	user := &models.UserNotificationInfo{
+		UserUuid:   uuid.New(),
+		Locale:     templates.LocaleEn,
+		DigestMode: DigestModeDaily,
+		Timezone:   DefaultTimezone,
+	}
+	repo := &pendingRepo{
+		user:  user,
+		items: []*pendingItem{depositItem(user.UserUuid, now), depositItem(user.UserUuid, now)},
+	}
+	inbox := &testChannel{name: channels.ChannelInbox}
+	uc := newDeliveryUseCase(t, repo, inbox)
+
+	// Second check starts while first one is sending the digest
+	overlapped := false
+	inbox.onSend = func() {
+		if overlapped {
+			return
+		}
+		overlapped = true
+		if err := uc.processUserPending(context.Background(), user.UserUuid, now.Add(time.Second)); err != nil {
+			t.Errorf("overlapping check error = %v", err)
+		}
+	}
+
+	if err := uc.processUserPending(context.Background(), user.UserUuid, now); err != nil {
+		t.Fatal(err)
+	}
+	if !overlapped || len(inbox.sent) != 1 {
+		t.Fatalf("overlapped = %v, sent digests = %d", overlapped, len(inbox.sent))
+	}
+	if inbox.sent[0].Template != templates.TemplateDigest || inbox.sent[0].Data["Count"] != 2 {
+		t.Fatalf("sent message = %+v", inbox.sent[0])
+	}
+	if len(repo.items) != 0 {
+		t.Fatalf("pending items left = %d", len(repo.items))
+	}
+}
+
+func TestNotificationUseCase_ProcessUserPendingClaimExpired(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	user := &models.UserNotificationInfo{
+		UserUuid:   uuid.New(),
+		Locale:     templates.LocaleEn,
+		DigestMode: DigestModeDaily,
+		Timezone:   DefaultTimezone,
+	}
+	repo := &pendingRepo{user: user, items: []*pendingItem{depositItem(user.UserUuid, now)}}
+	inbox := &testChannel{name: channels.ChannelInbox, err: errors.New("inbox is down")}
+	uc := newDeliveryUseCase(t, repo, inbox)
+
+	if err := uc.processUserPending(context.Background(), user.UserUuid, now); err == nil {
+		t.Fatal("send error is expected")
+	}
+
+	// Digest stays claimed after failed send and isn't taken before claim expires
+	inbox.err = nil
+	if err := uc.processUserPending(context.Background(), user.UserUuid, now.Add(PendingClaimTimeout/2)); err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox.sent) != 0 {
+		t.Fatalf("digest sent while claimed: %d", len(inbox.sent))
+	}
+
+	if err := uc.processUserPending(context.Background(), user.UserUuid, now.Add(PendingClaimTimeout)); err != nil {
+		t.Fatal(err)
+	}
+	if len(inbox.sent) != 1 || len(repo.items) != 0 {
+		t.Fatalf("sent digests = %d, pending items left = %d", len(inbox.sent), len(repo.items))
+	}
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"slices"
+)
+
+// Event categories passed by producers in rmq message header
+const (
+	CategorySecurity     string = "security"
+	CategoryTransactions string = "transactions"
+	CategoryMarketing    string = "marketing"
+	CategorySystem       string = "system"
+)
+
+var PossibleCategories = []string{
+	CategorySecurity,
+	CategoryTransactions,
+	CategoryMarketing,
+	CategorySystem,
+}
+
+// Categories which user can't turn off
+var MandatoryCategories = []string{
+	CategorySecurity,
+}
+
+var PossibleChannels = []string{
+	channels.ChannelEmail,
+	channels.ChannelInbox,
+	channels.ChannelSms,
+	channels.ChannelWebhook,
+}
+
+// Channels enabled when user has not set preference. Mandatory categories can't be turned off on them
+var DefaultChannelEnabled = map[string]bool{
+	channels.ChannelEmail:   true,
+	channels.ChannelInbox:   true,
+	channels.ChannelSms:     false,
+	channels.ChannelWebhook: false,
+}
+
+// Preference value when user has not set it
+var DefaultCategoryEnabled = map[string]bool{
+	CategorySecurity:     true,
+	CategoryTransactions: true,
+	CategoryMarketing:    false,
+	CategorySystem:       true,
+}
+
+// isMandatory checks whether user can't turn off category on channel
+func isMandatory(category string, channel string) bool {
+	return slices.Contains(MandatoryCategories, category) && DefaultChannelEnabled[channel]
+}
+
+// mergePreferences builds full category x channel matrix from stored preferences and defaults.
+// Mandatory preferences are always enabled.
+func mergePreferences(stored []models.NotificationPreference) []models.NotificationPreference {
+	enabled := make(map[string]bool, len(stored))
+	for _, preference := range stored {
+		enabled[preference.Category+"."+preference.Channel] = preference.Enabled
+	}
+
+	result := make([]models.NotificationPreference, 0, len(PossibleCategories)*len(PossibleChannels))
+	for _, category := range PossibleCategories {
+		for _, channel := range PossibleChannels {
+			value, ok := enabled[category+"."+channel]
+			if !ok {
+				value = DefaultCategoryEnabled[category] && DefaultChannelEnabled[channel]
+			}
+			mandatory := isMandatory(category, channel)
+			result = append(result, models.NotificationPreference{
+				Category:  category,
+				Channel:   channel,
+				Enabled:   value || mandatory,
+				Mandatory: mandatory,
+			})
+		}
+	}
+
+	return result
+}
+
+// isChannelEnabled checks merged preferences
+func isChannelEnabled(preferences []models.NotificationPreference, category string, channel string) bool {
+	for _, preference := range preferences {
+		if preference.Category == category && preference.Channel == channel {
+			return preference.Enabled
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
+	"github.com/google/uuid"
+)
+
+// preferencesRepo keeps stored preferences of settingsRepo user
+type preferencesRepo struct {
+	settingsRepo
+	preferences []models.NotificationPreference
+}
+
+func (r *preferencesRepo) GetUserPreferences(ctx context.Context, userId uuid.UUID) ([]models.NotificationPreference, error) {
+	return r.preferences, nil
+}
+
+func (r *preferencesRepo) UpdateUserPreferences(ctx context.Context, userId uuid.UUID, preferences []models.NotificationPreference) error {
+	r.preferences = preferences
+	return nil
+}
+
+func findPreference(t *testing.T, preferences []models.NotificationPreference, category string, channel string) models.NotificationPreference {
+	for _, preference := range preferences {
+		if preference.Category == category && preference.Channel == channel {
+			return preference
+		}
+	}
+	t.Fatalf("no preference %s.%s", category, channel)
+	return models.NotificationPreference{}
+}
+
+func TestMergePreferences(t *testing.T) {
+	merged := mergePreferences([]models.NotificationPreference{
+		{Category: CategorySecurity, Channel: channels.ChannelEmail, Enabled: false},
+		{Category: CategorySecurity, Channel: channels.ChannelSms, Enabled: false},
+		{Category: CategoryMarketing, Channel: channels.ChannelEmail, Enabled: true},
+		{Category: CategoryTransactions, Channel: channels.ChannelInbox, Enabled: false},
+		{Category: CategoryTransactions, Channel: channels.ChannelSms, Enabled: true},
+	})
+
+	if len(merged) != len(PossibleCategories)*len(PossibleChannels) {
+		t.Fatalf("merged preferences = %d", len(merged))
+	}
+
+	cases := []struct {
+		category      string
+		channel       string
+		wantEnabled   bool
+		wantMandatory bool
+	}{
+		// Mandatory category stays on even if turned off in storage
+		{CategorySecurity, channels.ChannelEmail, true, true},
+		{CategorySecurity, channels.ChannelInbox, true, true},
+		// Channel off by default isn't forced for mandatory category
+		{CategorySecurity, channels.ChannelSms, false, false},
+		// Stored settings override category and channel defaults
+		{CategoryMarketing, channels.ChannelEmail, true, false},
+		{CategoryTransactions, channels.ChannelInbox, false, false},
+		{CategoryTransactions, channels.ChannelSms, true, false},
+		// Defaults are used when nothing is stored
+		{CategoryMarketing, channels.ChannelInbox, false, false},
+		{CategoryTransactions, channels.ChannelEmail, true, false},
+		{CategorySystem, channels.ChannelWebhook, false, false},
+	}
+	for _, test := range cases {
+		preference := findPreference(t, merged, test.category, test.channel)
+		if preference.Enabled != test.wantEnabled || preference.Mandatory != test.wantMandatory {
+			t.Errorf("%s.%s enabled = %v, mandatory = %v, want %v, %v", test.category, test.channel,
+				preference.Enabled, preference.Mandatory, test.wantEnabled, test.wantMandatory)
+		}
+	}
+}
+
+func TestNotificationUseCase_UpdateUserSettingsPreferences(t *testing.T) {
+	user := &models.UserNotificationInfo{
+		UserUuid:   uuid.New(),
+		Locale:     templates.LocaleRu,
+		DigestMode: DigestModeOff,
+		Timezone:   DefaultTimezone,
+	}
+
+	t.Run("Error mandatory category", func(t *testing.T) {
+		for _, channel := range []string{channels.ChannelEmail, channels.ChannelInbox} {
+			repo := &preferencesRepo{settingsRepo: settingsRepo{user: user}}
+			uc := newTestUseCase(t, repo)
+
+			update := *user
+			update.Preferences = []models.NotificationPreference{
+				{Category: CategoryMarketing, Channel: channels.ChannelEmail, Enabled: true},
+				{Category: CategorySecurity, Channel: channel, Enabled: false},
+			}
+			if _, err := uc.UpdateUserSettings(context.Background(), &update); !errors.Is(err, ErrorMandatoryCategory) {
+				t.Fatalf("%s error = %v", channel, err)
+			}
+			// Nothing is applied, including valid preferences of the same request
+			if repo.updated || repo.preferences != nil {
+				t.Fatalf("%s settings saved = %v, preferences = %v", channel, repo.updated, repo.preferences)
+			}
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &preferencesRepo{settingsRepo: settingsRepo{user: user}}
+		uc := newTestUseCase(t, repo)
+
+		update := *user
+		update.Preferences = []models.NotificationPreference{
+			{Category: CategorySecurity, Channel: channels.ChannelSms, Enabled: false},
+			{Category: CategoryTransactions, Channel: channels.ChannelEmail, Enabled: false},
+			{Category: CategoryMarketing, Channel: channels.ChannelInbox, Enabled: true},
+		}
+		if _, err := uc.UpdateUserSettings(context.Background(), &update); err != nil {
+			t.Fatal(err)
+		}
+
+		settings, err := uc.GetUserSettings(context.Background(), user.UserUuid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if findPreference(t, settings.Preferences, CategoryTransactions, channels.ChannelEmail).Enabled {
+			t.Error("transactions.email must be turned off")
+		}
+		if !findPreference(t, settings.Preferences, CategoryMarketing, channels.ChannelInbox).Enabled {
+			t.Error("marketing.inbox must be turned on")
+		}
+		if !findPreference(t, settings.Preferences, CategoryTransactions, channels.ChannelInbox).Enabled {
+			t.Error("transactions.inbox must keep default")
+		}
+	})
+}
+
+func TestNotificationUseCase_SendMessagePreferences(t *testing.T) {
+	cases := map[string]struct {
+		category    string
+		emailUsage  bool
+		preferences []models.NotificationPreference
+		wantEmail   bool
+		wantInbox   bool
+		wantSms     bool
+	}{
+		"defaults": {
+			category:   CategoryTransactions,
+			emailUsage: true,
+			wantEmail:  true,
+			wantInbox:  true,
+		},
+		"channel turned off": {
+			category:   CategoryTransactions,
+			emailUsage: true,
+			preferences: []models.NotificationPreference{
+				{Category: CategoryTransactions, Channel: channels.ChannelInbox, Enabled: false},
+				{Category: CategoryTransactions, Channel: channels.ChannelSms, Enabled: true},
+			},
+			wantEmail: true,
+			wantSms:   true,
+		},
+		"category off by default turned on": {
+			category:   CategoryMarketing,
+			emailUsage: true,
+			preferences: []models.NotificationPreference{
+				{Category: CategoryMarketing, Channel: channels.ChannelEmail, Enabled: true},
+			},
+			wantEmail: true,
+		},
+		"email switched off": {
+			category:   CategoryTransactions,
+			emailUsage: false,
+			wantInbox:  true,
+		},
+		// Security goes through email even if user has turned it and email usage off
+		"mandatory category": {
+			category:   CategorySecurity,
+			emailUsage: false,
+			preferences: []models.NotificationPreference{
+				{Category: CategorySecurity, Channel: channels.ChannelEmail, Enabled: false},
+				{Category: CategorySecurity, Channel: channels.ChannelInbox, Enabled: false},
+			},
+			wantEmail: true,
+			wantInbox: true,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			user := newMessageUser(templates.LocaleEn)
+			user.EmailUsage = test.emailUsage
+			repo := &pendingRepo{user: user, preferences: test.preferences}
+			email := &testChannel{name: channels.ChannelEmail}
+			inbox := &testChannel{name: channels.ChannelInbox}
+			sms := &testChannel{name: channels.ChannelSms}
+			uc := newDeliveryUseCase(t, repo, email, inbox, sms)
+
+			data := map[string]interface{}{"Login": "user", "Time": "10:00", "Ip": "127.0.0.1", "UserAgent": "test"}
+			template := templates.TemplateSignIn
+			if test.category != CategorySecurity {
+				data = depositData("Main")
+				template = templates.TemplateDeposit
+			}
+			if err := uc.SendMessage(context.Background(), newMessage(t, user.UserUuid, test.category, template, data)); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := len(email.sent) == 1; got != test.wantEmail {
+				t.Errorf("email sent = %v, want %v", got, test.wantEmail)
+			}
+			if got := len(inbox.sent) == 1; got != test.wantInbox {
+				t.Errorf("inbox sent = %v, want %v", got, test.wantInbox)
+			}
+			if got := len(sms.sent) == 1; got != test.wantSms {
+				t.Errorf("sms sent = %v, want %v", got, test.wantSms)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"slices"
+	"time"
+)
+
+// Digest modes of user settings
+const (
+	DigestModeOff    string = "off"
+	DigestModeDaily  string = "daily"
+	DigestModeWeekly string = "weekly"
+)
+
+var PossibleDigestModes = []string{
+	DigestModeOff,
+	DigestModeDaily,
+	DigestModeWeekly,
+}
+
+// Categories sent immediately regardless of digest and quiet hours
+var ImmediateCategories = []string{
+	CategorySecurity,
+}
+
+// Category of digest message into delivery log and inbox
+const CategoryDigest string = "digest"
+
+// Time format of digest items
+const DigestItemTimeFormat = "02-01-2006 15:04"
+
+const (
+	DefaultTimezone          = "UTC"
+	DefaultDigestHour        = 9
+	DefaultDigestWeekday     = time.Monday
+	DefaultSchedulerInterval = time.Minute
+)
+
+// Time due pending notification is claimed by one check for sending
+const PendingClaimTimeout = 5 * time.Minute
+
+// userLocation of digest and quiet hours, UTC is used when user's time zone is unknown
+func userLocation(user *models.UserNotificationInfo) *time.Location {
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil || user.Timezone == "" {
+		return time.UTC
+	}
+	return location
+}
+
+// inQuietHours checks user's local hour. Quiet hours may pass midnight, e.g. from 22 to 7.
+func inQuietHours(user *models.UserNotificationInfo, t time.Time) bool {
+	if user.QuietHoursStart == user.QuietHoursEnd {
+		return false
+	}
+	hour := t.In(userLocation(user)).Hour()
+	if user.QuietHoursStart < user.QuietHoursEnd {
+		return hour >= user.QuietHoursStart && hour < user.QuietHoursEnd
+	}
+	return hour >= user.QuietHoursStart || hour < user.QuietHoursEnd
+}
+
+// nextLocalHour returns first moment after t when local clock shows hour:00
+func nextLocalHour(t time.Time, location *time.Location, hour int) time.Time {
+	local := t.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), hour, 0, 0, 0, location)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func quietHoursEnd(user *models.UserNotificationInfo, t time.Time) time.Time {
+	return nextLocalHour(t, userLocation(user), user.QuietHoursEnd)
+}
+
+func (uc NotificationUseCase) digestHour() int {
+	if uc.schedulerCfg.DigestHour <= 0 || uc.schedulerCfg.DigestHour > 23 {
+		return DefaultDigestHour
+	}
+	return uc.schedulerCfg.DigestHour
+}
+
+func (uc NotificationUseCase) digestWeekday() time.Weekday {
+	if uc.schedulerCfg.DigestWeekday < 0 || uc.schedulerCfg.DigestWeekday > 6 {
+		return DefaultDigestWeekday
+	}
+	return time.Weekday(uc.schedulerCfg.DigestWeekday)
+}
+
+// nextDigest returns time of user's next digest. Digest falling into quiet hours waits for their end.
+func (uc NotificationUseCase) nextDigest(user *models.UserNotificationInfo, t time.Time) time.Time {
+	location := userLocation(user)
+	next := nextLocalHour(t, location, uc.digestHour())
+	if user.DigestMode == DigestModeWeekly {
+		for next.Weekday() != uc.digestWeekday() {
+			next = next.AddDate(0, 0, 1)
+		}
+	}
+	if inQuietHours(user, next) {
+		next = quietHoursEnd(user, next)
+	}
+	return next
+}
+
+// deferUntil returns when message must be sent and whether it goes to digest.
+// ok is false for message which is sent immediately.
+func (uc NotificationUseCase) deferUntil(user *models.UserNotificationInfo, category string, now time.Time) (deliverAt time.Time, digest bool, ok bool) {
+	if slices.Contains(ImmediateCategories, category) {
+		return now, false, false
+	}
+	if user.DigestMode == DigestModeDaily || user.DigestMode == DigestModeWeekly {
+		return uc.nextDigest(user, now), true, true
+	}
+	if inQuietHours(user, now) {
+		return quietHoursEnd(user, now), false, true
+	}
+	return now, false, false
+}
+
+func validateSchedule(user *models.UserNotificationInfo) error {
+	if !slices.Contains(PossibleDigestModes, user.DigestMode) {
+		return ErrorInvalidDigestMode
+	}
+	if _, err := time.LoadLocation(user.Timezone); err != nil {
+		return ErrorInvalidTimezone
+	}
+	if user.QuietHoursStart < 0 || user.QuietHoursStart > 23 ||
+		user.QuietHoursEnd < 0 || user.QuietHoursEnd > 23 {
+		return ErrorInvalidQuietHours
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s is unavailable: %s", name, err)
+	}
+	return location
+}
+
+func TestInQuietHours(t *testing.T) {
+	cases := map[string]struct {
+		start, end int
+		hour       int
+		want       bool
+	}{
+		"same day inside":         {9, 18, 12, true},
+		"same day start":          {9, 18, 9, true},
+		"same day end":            {9, 18, 18, false},
+		"same day before":         {9, 18, 8, false},
+		"wraps midnight evening":  {22, 7, 23, true},
+		"wraps midnight at night": {22, 7, 0, true},
+		"wraps midnight morning":  {22, 7, 6, true},
+		"wraps midnight end":      {22, 7, 7, false},
+		"wraps midnight day":      {22, 7, 21, false},
+		"disabled":                {0, 0, 3, false},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			user := &models.UserNotificationInfo{Timezone: DefaultTimezone, QuietHoursStart: test.start, QuietHoursEnd: test.end}
+			now := time.Date(2026, 10, 19, test.hour, 30, 0, 0, time.UTC)
+			if got := inQuietHours(user, now); got != test.want {
+				t.Fatalf("inQuietHours(%d-%d, %02d:30) = %v, want %v", test.start, test.end, test.hour, got, test.want)
+			}
+		})
+	}
+
+	t.Run("user time zone", func(t *testing.T) {
+		user := &models.UserNotificationInfo{Timezone: "Europe/Moscow", QuietHoursStart: 22, QuietHoursEnd: 7}
+		loadLocation(t, user.Timezone)
+		// 20:00 UTC is 23:00 in Moscow
+		if !inQuietHours(user, time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)) {
+			t.Fatal("quiet hours must be checked by user's local time")
+		}
+	})
+}
+
+func TestQuietHoursEnd(t *testing.T) {
+	user := &models.UserNotificationInfo{Timezone: DefaultTimezone, QuietHoursStart: 22, QuietHoursEnd: 7}
+
+	// Before midnight end of quiet hours is next day
+	if got, want := quietHoursEnd(user, time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC)), time.Date(2027, 1, 1, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("quietHoursEnd() = %s, want %s", got, want)
+	}
+	// After midnight it's the same day
+	if got, want := quietHoursEnd(user, time.Date(2027, 1, 1, 2, 0, 0, 0, time.UTC)), time.Date(2027, 1, 1, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("quietHoursEnd() = %s, want %s", got, want)
+	}
+}
+
+func TestNextLocalHourDST(t *testing.T) {
+	location := loadLocation(t, "America/New_York")
+
+	cases := map[string]struct {
+		now      time.Time
+		want     time.Time
+		duration time.Duration
+	}{
+		// Clocks go forward on 2026-03-08, day is 23 hours long
+		"spring forward": {
+			now:      time.Date(2026, 3, 7, 10, 0, 0, 0, location),
+			want:     time.Date(2026, 3, 8, 9, 0, 0, 0, location),
+			duration: 22 * time.Hour,
+		},
+		// Clocks go back on 2026-11-01, day is 25 hours long
+		"fall back": {
+			now:      time.Date(2026, 10, 31, 10, 0, 0, 0, location),
+			want:     time.Date(2026, 11, 1, 9, 0, 0, 0, location),
+			duration: 24 * time.Hour,
+		},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := nextLocalHour(test.now, location, 9)
+			if !got.Equal(test.want) || got.In(location).Hour() != 9 {
+				t.Fatalf("nextLocalHour() = %s, want %s", got.In(location), test.want)
+			}
+			if got.Sub(test.now) != test.duration {
+				t.Fatalf("next hour is %s later, want %s", got.Sub(test.now), test.duration)
+			}
+		})
+	}
+}
+
+func TestNextDigest(t *testing.T) {
+	uc := NotificationUseCase{schedulerCfg: config.NotificationScheduler{DigestHour: 9, DigestWeekday: int(time.Monday)}}
+	daily := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeDaily}
+	weekly := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeWeekly}
+
+	cases := map[string]struct {
+		user *models.UserNotificationInfo
+		now  time.Time
+		want time.Time
+	}{
+		"daily before digest hour": {daily, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		"daily after digest hour":  {daily, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)},
+		// 2026-10-19 is Monday
+		"weekly on digest day before hour": {weekly, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		"weekly on digest day after hour":  {weekly, time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC)},
+		"weekly on sunday":                 {weekly, time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC)},
+		"weekly over new year":             {weekly, time.Date(2026, 12, 31, 12, 0, 0, 0, time.UTC), time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC)},
+	}
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := uc.nextDigest(test.user, test.now); !got.Equal(test.want) {
+				t.Fatalf("nextDigest() = %s, want %s", got, test.want)
+			}
+		})
+	}
+
+	t.Run("digest in quiet hours", func(t *testing.T) {
+		user := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeDaily, QuietHoursStart: 22, QuietHoursEnd: 10}
+		got := uc.nextDigest(user, time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+		if want := time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Fatalf("nextDigest() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("weekly in user time zone", func(t *testing.T) {
+		location := loadLocation(t, "Asia/Tokyo")
+		user := &models.UserNotificationInfo{Timezone: "Asia/Tokyo", DigestMode: DigestModeWeekly}
+		// Sunday 22:00 UTC is already Monday 07:00 in Tokyo
+		got := uc.nextDigest(user, time.Date(2026, 10, 25, 22, 0, 0, 0, time.UTC))
+		if want := time.Date(2026, 10, 26, 9, 0, 0, 0, location); !got.Equal(want) {
+			t.Fatalf("nextDigest() = %s, want %s", got, want)
+		}
+	})
+}
+
+func TestDeferUntil(t *testing.T) {
+	uc := NotificationUseCase{schedulerCfg: config.NotificationScheduler{DigestHour: 9}}
+	now := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+
+	quiet := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeOff, QuietHoursStart: 22, QuietHoursEnd: 7}
+	if _, _, ok := uc.deferUntil(quiet, CategorySecurity, now); ok {
+		t.Fatal("security notification must be sent immediately")
+	}
+
+	deliverAt, digest, ok := uc.deferUntil(quiet, CategoryTransactions, now)
+	if !ok || digest || !deliverAt.Equal(time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC)) {
+		t.Fatalf("quiet hours deferUntil() = %s, %v, %v", deliverAt, digest, ok)
+	}
+
+	daily := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeDaily}
+	deliverAt, digest, ok = uc.deferUntil(daily, CategoryTransactions, now)
+	if !ok || !digest || !deliverAt.Equal(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("digest deferUntil() = %s, %v, %v", deliverAt, digest, ok)
+	}
+
+	active := &models.UserNotificationInfo{Timezone: DefaultTimezone, DigestMode: DigestModeOff}
+	if _, _, ok = uc.deferUntil(active, CategoryTransactions, now); ok {
+		t.Fatal("notification out of quiet hours must be sent immediately")
+	}
+}
@@ -2,74 +2,338 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/notification/internal/models"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/verification"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/rabbitmq/amqp091-go"
-	"net/smtp"
+	"net/url"
 	"slices"
+	"time"
 )
 
 type NotificationUseCase struct {
-	repo       notification.Repository
-	smtpClient *smtp.Client
-	smtpCfg    config.Smtp
+	repo            notification.Repository
+	channels        []notification.Channel
+	renderer        *templates.Renderer
+	signer          *verification.Signer
+	verificationCfg config.EmailVerification
+	schedulerCfg    config.NotificationScheduler
+	metrics         metric.NotificationMetrics
+	logger          logger.Logger
 }
 
 func (uc NotificationUseCase) AddUserSettings(ctx context.Context, user *models.UserNotificationInfo) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.AddUserSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.AddUserSettings")
+	defer span.End()
 
 	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, user.UserUuid)
 	if err == nil && userD != nil {
 		return ErrorUserSettingsAlreadyExist
 	}
 
+	if user.Locale == "" {
+		user.Locale = templates.DefaultLocale
+	}
+	if user.DigestMode == "" {
+		user.DigestMode = DigestModeOff
+	}
+	if user.Timezone == "" {
+		user.Timezone = DefaultTimezone
+	}
+	if err = validateSchedule(user); err != nil {
+		return err
+	}
+
+	// Email is used only after user has confirmed it
+	user.EmailUsage = false
+	user.EmailVerified = false
+
 	err = uc.repo.AddUserNotificationSettings(local_ctx, user)
 	if err != nil {
 		return err
 	}
 
+	if user.Email != "" {
+		// Registration must not fail because of mail server, user can resend verification later
+		if err = uc.requestEmailVerification(local_ctx, user); err != nil {
+			uc.logger.Warnf("Send email verification to user %s error: %s", user.UserUuid, err)
+		}
+	}
+
 	return nil
 }
 
 func (uc NotificationUseCase) GetUserSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error) {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.GetUserSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.GetUserSettings")
+	defer span.End()
 
 	result, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
 	if err != nil {
 		return nil, err
 	}
 
+	preferences, err := uc.repo.GetUserPreferences(local_ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	result.Preferences = mergePreferences(preferences)
+
 	return result, nil
 }
 
-func (uc NotificationUseCase) UpdateUserSettings(ctx context.Context, user *models.UserNotificationInfo) error {
+// UpdateUserSettings returns new webhook secret when webhook url was set or changed, secret isn't returned
+// by settings later
+func (uc NotificationUseCase) UpdateUserSettings(ctx context.Context, user *models.UserNotificationInfo) (string, error) {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.UpdateUserSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.UpdateUserSettings")
+	defer span.End()
 
 	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, user.UserUuid)
 	if err != nil && userD == nil {
-		return ErrorUserNotFound
+		return "", ErrorUserNotFound
+	}
+
+	if user.Locale == "" {
+		user.Locale = userD.Locale
+	}
+	if user.DigestMode == "" {
+		user.DigestMode = userD.DigestMode
+	}
+	if user.Timezone == "" {
+		user.Timezone = userD.Timezone
+	}
+	if err = validateSchedule(user); err != nil {
+		return "", err
+	}
+
+	ok, err := uc.validatePreferences(local_ctx, user.Preferences)
+	if !ok {
+		return "", err
+	}
+
+	// New address needs verification again, email is turned on after it
+	emailChanged := user.Email != userD.Email
+	var attempt *models.EmailVerification
+	if emailChanged {
+		user.EmailVerified = false
+		user.EmailUsage = false
+		if user.Email != "" {
+			// Limits are checked before saving, so rejected change isn't applied
+			attempt, err = uc.verificationAttempt(local_ctx, user.UserUuid)
+			if err != nil {
+				return "", err
+			}
+		}
+	} else {
+		user.EmailVerified = userD.EmailVerified
+		if user.EmailUsage && !user.EmailVerified {
+			return "", ErrorEmailNotVerified
+		}
+	}
+
+	// Secret is generated for every new endpoint, so old receiver can't verify requests to new one
+	webhookSecret := ""
+	if user.WebhookUrl == "" {
+		user.WebhookSecret = ""
+	} else if user.WebhookUrl != userD.WebhookUrl || userD.WebhookSecret == "" {
+		webhookSecret, err = channels.NewWebhookSecret()
+		if err != nil {
+			return "", err
+		}
+		user.WebhookSecret = webhookSecret
+	} else {
+		user.WebhookSecret = userD.WebhookSecret
 	}
 
 	err = uc.repo.UpdateUserNotificationSettings(local_ctx, user)
 	if err != nil {
+		return "", err
+	}
+
+	if len(user.Preferences) > 0 {
+		err = uc.repo.UpdateUserPreferences(local_ctx, user.UserUuid, user.Preferences)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if attempt != nil {
+		return webhookSecret, uc.sendEmailVerification(local_ctx, user, attempt)
+	}
+
+	return webhookSecret, nil
+}
+
+func (uc NotificationUseCase) ResendEmailVerification(ctx context.Context, userId uuid.UUID) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.ResendEmailVerification")
+	defer span.End()
+
+	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
+	if err != nil && userD == nil {
+		return ErrorUserNotFound
+	}
+
+	if userD.Email == "" {
+		return ErrorNoEmail
+	}
+	if userD.EmailVerified {
+		return ErrorEmailAlreadyVerified
+	}
+
+	return uc.requestEmailVerification(local_ctx, userD)
+}
+
+func (uc NotificationUseCase) VerifyEmail(ctx context.Context, token string) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.VerifyEmail")
+	defer span.End()
+
+	claims, err := uc.signer.Parse(token)
+	if err != nil {
+		return err
+	}
+
+	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, claims.UserId)
+	if err != nil && userD == nil {
+		return ErrorUserNotFound
+	}
+
+	// Token was issued for address that has been changed since
+	if userD.Email != claims.Email {
+		return ErrorVerificationEmailChanged
+	}
+	if userD.EmailVerified {
 		return nil
 	}
 
+	return uc.repo.VerifyUserEmail(local_ctx, claims.UserId, claims.Email)
+}
+
+// verificationAttempt checks resend limits and returns state with the next attempt counted.
+// Nothing is saved, so callers can check limits before changing user settings.
+func (uc NotificationUseCase) verificationAttempt(ctx context.Context, userId uuid.UUID) (*models.EmailVerification, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.verificationAttempt")
+	defer span.End()
+
+	now := time.Now()
+	state, err := uc.repo.GetEmailVerification(local_ctx, userId)
+	if err != nil {
+		return &models.EmailVerification{
+			UserUuid:    userId,
+			WindowStart: now,
+			SentCount:   1,
+			LastSentAt:  now,
+		}, nil
+	}
+
+	if now.Sub(state.LastSentAt) < uc.resendInterval() {
+		return nil, ErrorVerificationRateLimit
+	}
+	if now.Sub(state.WindowStart) < VerificationWindow {
+		if state.SentCount >= uc.maxVerificationsPerDay() {
+			return nil, ErrorVerificationRateLimit
+		}
+		state.SentCount++
+	} else {
+		state.WindowStart = now
+		state.SentCount = 1
+	}
+	state.LastSentAt = now
+
+	return state, nil
+}
+
+func (uc NotificationUseCase) requestEmailVerification(ctx context.Context, user *models.UserNotificationInfo) error {
+	attempt, err := uc.verificationAttempt(ctx, user.UserUuid)
+	if err != nil {
+		return err
+	}
+	return uc.sendEmailVerification(ctx, user, attempt)
+}
+
+// sendEmailVerification mails signed link to user's address.
+// Attempt is counted before sending, so failing mail server can't be used to bypass limits.
+func (uc NotificationUseCase) sendEmailVerification(ctx context.Context, user *models.UserNotificationInfo, state *models.EmailVerification) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.sendEmailVerification")
+	defer span.End()
+
+	state.Email = user.Email
+	err := uc.repo.UpsertEmailVerification(local_ctx, state)
+	if err != nil {
+		return err
+	}
+
+	email := uc.channel(channels.ChannelEmail)
+	if email == nil {
+		return ErrorInvalidChannel
+	}
+
+	token, claims := uc.signer.Sign(user.UserUuid, user.Email)
+	data := map[string]interface{}{
+		"Email":     user.Email,
+		"Link":      uc.verificationCfg.Url + "?" + url.Values{"token": []string{token}}.Encode(),
+		"ExpiresAt": claims.ExpiresAt.Format("02-01-2006 15:04"),
+	}
+
+	rendered, err := uc.renderer.Render(templates.TemplateVerifyEmail, user.Locale, data)
+	if err != nil {
+		return err
+	}
+
+	// Sent directly: channel skips unverified addresses
+	return uc.deliver(local_ctx, email, user, &models.ChannelMessage{
+		Id:       uuid.New(),
+		Category: CategorySecurity,
+		Template: templates.TemplateVerifyEmail,
+		Subject:  rendered.Subject,
+		Text:     rendered.Text,
+		HTML:     rendered.HTML,
+		Data:     data,
+	})
+}
+
+func (uc NotificationUseCase) channel(name string) notification.Channel {
+	for _, channel := range uc.channels {
+		if channel.Name() == name {
+			return channel
+		}
+	}
 	return nil
 }
 
+func (uc NotificationUseCase) resendInterval() time.Duration {
+	if uc.verificationCfg.ResendInterval <= 0 {
+		return DefaultVerificationResendInterval
+	}
+	return time.Duration(uc.verificationCfg.ResendInterval) * time.Second
+}
+
+func (uc NotificationUseCase) maxVerificationsPerDay() int {
+	if uc.verificationCfg.MaxPerDay <= 0 {
+		return DefaultVerificationsPerDay
+	}
+	return uc.verificationCfg.MaxPerDay
+}
+
 func (uc NotificationUseCase) DeleteUserSettings(ctx context.Context, userId uuid.UUID) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.DeleteUserSettings")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.DeleteUserSettings")
+	defer span.End()
 
 	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
 	if err != nil && userD == nil {
@@ -86,112 +350,452 @@ func (uc NotificationUseCase) DeleteUserSettings(ctx context.Context, userId uui
 
 func (uc NotificationUseCase) SendMessage(ctx context.Context, message amqp091.Delivery) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.SendMessage")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.SendMessage")
+	defer span.End()
 
 	msgHeaders := message.Headers
+	// Malformed message will not be fixed by retry
 	ok, err := uc.checkRuquiredHeaders(local_ctx, msgHeaders)
 	if !ok {
+		return rmq.Permanent(err)
+	}
+
+	userIdStr, ok := msgHeaders[HeaderUserId].(string)
+	if !ok {
+		return rmq.Permanent(ErrorInvalidUserIdHeader)
+	}
+	userId, err := uuid.Parse(userIdStr)
+	if err != nil {
+		return rmq.Permanent(err)
+	}
+
+	category, ok := msgHeaders[HeaderCategory].(string)
+	if !ok {
+		return rmq.Permanent(ErrorInvalidCategory)
+	}
+	ok, err = uc.validateCategory(local_ctx, category)
+	if !ok {
+		return rmq.Permanent(err)
+	}
+
+	templateName, ok := msgHeaders[HeaderTemplate].(string)
+	if !ok {
+		return rmq.Permanent(ErrorInvalidTemplateHeader)
+	}
+
+	templateData := make(map[string]interface{})
+	if err = json.Unmarshal(message.Body, &templateData); err != nil {
+		return rmq.Permanent(err)
+	}
+
+	userNotificationSettings, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
+	if err != nil {
 		return err
 	}
 
-	userIdStr := msgHeaders[HeaderUserId]
-	userId, err := uuid.Parse(userIdStr.(string))
+	storedPreferences, err := uc.repo.GetUserPreferences(local_ctx, userId)
 	if err != nil {
 		return err
 	}
+	preferences := mergePreferences(storedPreferences)
 
-	notificationLvl := msgHeaders[HeaderNotificationLvl].(string)
-	ok, err = uc.validateNotificationLvl(local_ctx, notificationLvl)
-	if !ok {
+	messageId, err := uuid.Parse(message.MessageId)
+	if err != nil {
+		// Producer without message id, redelivery can't be deduplicated
+		messageId = uuid.New()
+	}
+
+	// Non-security message waits for digest or end of quiet hours, redelivered message is saved once
+	if deliverAt, digest, ok := uc.deferUntil(userNotificationSettings, category, time.Now()); ok {
+		return uc.repo.AddPendingNotification(local_ctx, &models.PendingNotification{
+			MessageUuid: messageId,
+			UserUuid:    userId,
+			Category:    category,
+			Template:    templateName,
+			Body:        string(message.Body),
+			Digest:      digest,
+			DeliverAt:   deliverAt,
+		})
+	}
+
+	return uc.sendToChannels(local_ctx, userNotificationSettings, preferences, messageId, category, templateName, templateData)
+}
+
+// sendToChannels renders message once and sends it to every channel allowed by user preferences.
+// Channels which got message on previous attempt are skipped.
+func (uc NotificationUseCase) sendToChannels(ctx context.Context, userNotificationSettings *models.UserNotificationInfo, preferences []models.NotificationPreference, messageId uuid.UUID, category string, templateName string, templateData map[string]interface{}) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.sendToChannels")
+	defer span.End()
+
+	var rendered *templates.Rendered
+	var sendErrors []error
+	for _, channel := range uc.channels {
+		if !uc.useChannel(userNotificationSettings, preferences, category, channel) {
+			continue
+		}
+
+		// Channel already done on previous attempt of this message
+		delivery, err := uc.repo.GetDelivery(local_ctx, messageId, channel.Name())
+		if err == nil && slices.Contains(FinalDeliveryStatuses, delivery.Status) {
+			continue
+		}
+
+		if rendered == nil {
+			rendered, err = uc.renderer.Render(templateName, userNotificationSettings.Locale, templateData)
+			if err != nil {
+				return rmq.Permanent(err)
+			}
+		}
+
+		err = uc.deliver(local_ctx, channel, userNotificationSettings, &models.ChannelMessage{
+			Id:       messageId,
+			Category: category,
+			Template: templateName,
+			Subject:  rendered.Subject,
+			Text:     rendered.Text,
+			HTML:     rendered.HTML,
+			Data:     templateData,
+		})
+		if err != nil {
+			sendErrors = append(sendErrors, fmt.Errorf("%s: %w", channel.Name(), err))
+		}
+	}
+
+	return errors.Join(sendErrors...)
+}
+
+// deliver sends message to channel and keeps its delivery log record.
+// Bounced message is final and is not returned as error, so it will not be retried.
+func (uc NotificationUseCase) deliver(ctx context.Context, channel notification.Channel, user *models.UserNotificationInfo, message *models.ChannelMessage) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.deliver")
+	defer span.End()
+
+	delivery, err := uc.repo.QueueDelivery(local_ctx, &models.Delivery{
+		DeliveryUuid: uuid.New(),
+		MessageUuid:  message.Id,
+		UserUuid:     user.UserUuid,
+		Channel:      channel.Name(),
+		Category:     message.Category,
+		Template:     message.Template,
+		Subject:      message.Subject,
+		Status:       DeliveryStatusQueued,
+	})
+	if err != nil {
 		return err
 	}
 
-	userNotificationSettings, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
+	sendErr := channel.Send(local_ctx, user, message)
+	if sendErr == nil {
+		uc.metrics.IncNotificationSent(channel.Name())
+		return uc.repo.UpdateDeliveryStatus(local_ctx, delivery.DeliveryUuid, DeliveryStatusSent, "", true)
+	}
+
+	uc.metrics.IncNotificationFailed(channel.Name())
+	if errors.Is(sendErr, channels.ErrorBounced) {
+		return uc.repo.UpdateDeliveryStatus(local_ctx, delivery.DeliveryUuid, DeliveryStatusBounced, sendErr.Error(), false)
+	}
+
+	if err = uc.repo.UpdateDeliveryStatus(local_ctx, delivery.DeliveryUuid, DeliveryStatusFailed, sendErr.Error(), false); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	return sendErr
+}
+
+func (uc NotificationUseCase) GetDeliveries(ctx context.Context, filter *models.DeliveryFilter) ([]models.Delivery, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.GetDeliveries")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultDeliveriesLimit
+	}
+	if filter.End.IsZero() {
+		filter.End = time.Now()
+	}
+	if filter.Begin.IsZero() {
+		filter.Begin = filter.End.Add(-DefaultDeliveriesPeriod)
+	}
+
+	return uc.repo.GetDeliveries(local_ctx, filter)
+}
+
+// ProcessPending sends quiet hours notifications and digests which time has come.
+// Item is deleted only after sending, so nothing is lost on restart, and delivery log skips channels done before it.
+func (uc NotificationUseCase) ProcessPending(ctx context.Context, now time.Time) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.ProcessPending")
+	defer span.End()
+
+	users, err := uc.repo.GetPendingUsers(local_ctx, now)
 	if err != nil {
 		return err
 	}
 
-	sendEmail := false
+	var processErrors []error
+	for _, userId := range users {
+		if err = uc.processUserPending(local_ctx, userId, now); err != nil {
+			processErrors = append(processErrors, fmt.Errorf("%s: %w", userId, err))
+		}
+	}
+
+	return errors.Join(processErrors...)
+}
 
-	if notificationLvl == NotificationLvlEmail {
-		sendEmail = userNotificationSettings.EmailUsage
-	} else if notificationLvl == NotificationLvlAll {
-		sendEmail = userNotificationSettings.EmailUsage
+func (uc NotificationUseCase) processUserPending(ctx context.Context, userId uuid.UUID, now time.Time) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.processUserPending")
+	defer span.End()
+
+	user, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
+	if err != nil {
+		return err
 	}
 
-	if sendEmail {
-		// 1. Указываем отправителя
-		if err = uc.smtpClient.Mail(uc.smtpCfg.From); err != nil {
-			return err
+	storedPreferences, err := uc.repo.GetUserPreferences(local_ctx, userId)
+	if err != nil {
+		return err
+	}
+	preferences := mergePreferences(storedPreferences)
+
+	// Claim keeps other replicas and overlapping checks from sending the same items
+	due, err := uc.repo.ClaimDuePendingNotifications(local_ctx, userId, now, now.Add(PendingClaimTimeout))
+	if err != nil {
+		return err
+	}
+
+	var sendErrors []error
+	for _, item := range due {
+		templateData := make(map[string]interface{})
+		err = json.Unmarshal([]byte(item.Body), &templateData)
+		if err == nil {
+			err = uc.sendToChannels(local_ctx, user, preferences, item.MessageUuid, item.Category, item.Template, templateData)
+		} else {
+			err = rmq.Permanent(err)
+		}
+		if err != nil {
+			sendErrors = append(sendErrors, err)
+			// Item stays pending and is retried after its claim expires
+			if !rmq.IsPermanent(err) {
+				continue
+			}
 		}
+		if err = uc.repo.DeletePendingNotification(local_ctx, item.MessageUuid); err != nil {
+			sendErrors = append(sendErrors, err)
+		}
+	}
 
-		// 2. Указываем получателя
-		if err = uc.smtpClient.Rcpt(userNotificationSettings.Email); err != nil {
-			return err
+	// Digest id is saved before sending, so digest interrupted by restart is sent again with the same id
+	err = uc.repo.ClaimDigest(local_ctx, userId, uuid.New(), now)
+	if err != nil {
+		return errors.Join(append(sendErrors, err)...)
+	}
+
+	// Digests are claimed like other pending items, digest which wasn't sent is taken again after claim expires
+	claimed, err := uc.repo.ClaimDigests(local_ctx, userId, now, now.Add(PendingClaimTimeout))
+	if err != nil {
+		return errors.Join(append(sendErrors, err)...)
+	}
+
+	digests := make(map[uuid.UUID][]models.PendingNotification)
+	var digestIds []uuid.UUID
+	for _, item := range claimed {
+		if _, ok := digests[*item.DigestUuid]; !ok {
+			digestIds = append(digestIds, *item.DigestUuid)
+		}
+		digests[*item.DigestUuid] = append(digests[*item.DigestUuid], item)
+	}
+
+	for _, digestId := range digestIds {
+		if err = uc.sendDigest(local_ctx, user, preferences, digestId, digests[digestId]); err != nil {
+			sendErrors = append(sendErrors, err)
+			continue
 		}
+		if err = uc.repo.DeleteDigest(local_ctx, digestId); err != nil {
+			sendErrors = append(sendErrors, err)
+		}
+	}
+
+	return errors.Join(sendErrors...)
+}
 
-		// 3. Отправляем тело письма
-		dataWriter, err := uc.smtpClient.Data()
+// sendDigest renders every item with its own template and sends them as one message.
+// Each channel gets only items which categories are enabled on it. Items that can't be rendered are skipped.
+func (uc NotificationUseCase) sendDigest(ctx context.Context, user *models.UserNotificationInfo, preferences []models.NotificationPreference, digestId uuid.UUID, items []models.PendingNotification) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.sendDigest")
+	defer span.End()
+
+	location := userLocation(user)
+	categories := make([]string, 0, len(items))
+	entries := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		templateData := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(item.Body), &templateData); err != nil {
+			continue
+		}
+		rendered, err := uc.renderer.Render(item.Template, user.Locale, templateData)
 		if err != nil {
-			return err
+			continue
 		}
+		categories = append(categories, item.Category)
+		entries = append(entries, map[string]interface{}{
+			"Subject": rendered.Subject,
+			"Text":    rendered.Text,
+			"Time":    item.CreatedAt.In(location).Format(DigestItemTimeFormat),
+		})
+	}
 
-		// Формируем письмо (заголовки + тело)
-		messageBytes := []byte(
-			"Subject: Notification message\r\n" +
-				"From: " + uc.smtpCfg.User + "\r\n" +
-				"To: " + userNotificationSettings.Email + "\r\n" +
-				"\r\n" + // Пустая строка — разделитель заголовков и тела
-				string(message.Body) + "\r\n",
-		)
+	var sendErrors []error
+	for _, channel := range uc.channels {
+		var channelEntries []map[string]interface{}
+		for i, entry := range entries {
+			if uc.useChannel(user, preferences, categories[i], channel) {
+				channelEntries = append(channelEntries, entry)
+			}
+		}
+		if len(channelEntries) == 0 {
+			continue
+		}
 
-		_, err = dataWriter.Write(messageBytes)
+		delivery, err := uc.repo.GetDelivery(local_ctx, digestId, channel.Name())
+		if err == nil && slices.Contains(FinalDeliveryStatuses, delivery.Status) {
+			continue
+		}
+
+		data := map[string]interface{}{
+			"Count": len(channelEntries),
+			"Items": channelEntries,
+		}
+		rendered, err := uc.renderer.Render(templates.TemplateDigest, user.Locale, data)
 		if err != nil {
 			return err
 		}
 
-		// Закрываем writer
-		err = dataWriter.Close()
+		err = uc.deliver(local_ctx, channel, user, &models.ChannelMessage{
+			Id:       digestId,
+			Category: CategoryDigest,
+			Template: templates.TemplateDigest,
+			Subject:  rendered.Subject,
+			Text:     rendered.Text,
+			HTML:     rendered.HTML,
+			Data:     data,
+		})
 		if err != nil {
-			return err
+			sendErrors = append(sendErrors, fmt.Errorf("%s: %w", channel.Name(), err))
 		}
 	}
 
-	return nil
+	return errors.Join(sendErrors...)
+}
+
+// useChannel checks user preference and channel address.
+// email_usage is the user's master switch for email, mandatory categories ignore it.
+func (uc NotificationUseCase) useChannel(user *models.UserNotificationInfo, preferences []models.NotificationPreference, category string, channel notification.Channel) bool {
+	if !isChannelEnabled(preferences, category, channel.Name()) || !channel.Available(user) {
+		return false
+	}
+	if channel.Name() == channels.ChannelEmail {
+		return user.EmailUsage || isMandatory(category, channel.Name())
+	}
+	return true
+}
+
+func (uc NotificationUseCase) GetInbox(ctx context.Context, userId uuid.UUID, unreadOnly bool) ([]models.InboxMessage, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.GetInbox")
+	defer span.End()
+
+	userD, err := uc.repo.GetUserNotificationSettings(local_ctx, userId)
+	if err != nil && userD == nil {
+		return nil, ErrorUserNotFound
+	}
+
+	return uc.repo.GetInboxMessages(local_ctx, userId, unreadOnly)
+}
+
+func (uc NotificationUseCase) ReadInboxMessage(ctx context.Context, userId uuid.UUID, messageId uuid.UUID) error {
+
+	local_ctx, span := tracing.StartSpan(ctx, "NotificationUseCase.ReadInboxMessage")
+	defer span.End()
+
+	return uc.repo.ReadInboxMessage(local_ctx, userId, messageId)
 }
 
 func (uc NotificationUseCase) checkRuquiredHeaders(ctx context.Context, headers map[string]interface{}) (bool, error) {
-	span, _ := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.checkRuquiredHeaders")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "NotificationUseCase.checkRuquiredHeaders")
+	defer span.End()
 
 	_, ok := headers[HeaderUserId]
 	if !ok {
 		return false, ErrorNoUserIdHeader
 	}
 
-	_, ok = headers[HeaderNotificationLvl]
+	_, ok = headers[HeaderCategory]
+	if !ok {
+		return false, ErrorNoCategoryHeader
+	}
+
+	_, ok = headers[HeaderTemplate]
 	if !ok {
-		return false, ErrorNoNotificationLvlHeader
+		return false, ErrorNoTemplateHeader
 	}
 
 	return true, nil
 
 }
 
-func (uc NotificationUseCase) validateNotificationLvl(ctx context.Context, notificationLvl string) (bool, error) {
-	span, _ := opentracing.StartSpanFromContext(ctx, "NotificationUseCase.validateNotificationLvl")
-	defer span.Finish()
+func (uc NotificationUseCase) validateCategory(ctx context.Context, category string) (bool, error) {
+	_, span := tracing.StartSpan(ctx, "NotificationUseCase.validateCategory")
+	defer span.End()
 
-	exists := slices.Contains(PossibleNotificationLvl, notificationLvl)
+	exists := slices.Contains(PossibleCategories, category)
 	if !exists {
-		return exists, ErrorInvalidNotificationLvl
+		return exists, ErrorInvalidCategory
 	}
 
 	return exists, nil
 
 }
 
-func NewNotificationUseCase(repo notification.Repository, smtpClient *smtp.Client, smtpCfg config.Smtp) notification.UseCase {
-	return &NotificationUseCase{repo: repo, smtpClient: smtpClient, smtpCfg: smtpCfg}
+func (uc NotificationUseCase) validatePreferences(ctx context.Context, preferences []models.NotificationPreference) (bool, error) {
+	_, span := tracing.StartSpan(ctx, "NotificationUseCase.validatePreferences")
+	defer span.End()
+
+	for _, preference := range preferences {
+		if !slices.Contains(PossibleCategories, preference.Category) {
+			return false, ErrorInvalidCategory
+		}
+		if !slices.Contains(PossibleChannels, preference.Channel) {
+			return false, ErrorInvalidChannel
+		}
+		if !preference.Enabled && isMandatory(preference.Category, preference.Channel) {
+			return false, ErrorMandatoryCategory
+		}
+	}
+
+	return true, nil
+
+}
+
+func NewNotificationUseCase(repo notification.Repository, channels []notification.Channel, renderer *templates.Renderer, verificationCfg config.EmailVerification, schedulerCfg config.NotificationScheduler, metrics metric.NotificationMetrics, log logger.Logger) (notification.UseCase, error) {
+	tokenTTL := DefaultVerificationTokenTTL
+	if verificationCfg.TokenTTL > 0 {
+		tokenTTL = time.Duration(verificationCfg.TokenTTL) * time.Minute
+	}
+	signer, err := verification.NewSigner(verificationCfg.Secret, tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationUseCase{
+		repo:            repo,
+		channels:        channels,
+		renderer:        renderer,
+		signer:          signer,
+		verificationCfg: verificationCfg,
+		schedulerCfg:    schedulerCfg,
+		metrics:         metrics,
+		logger:          log,
+	}, nil
 }
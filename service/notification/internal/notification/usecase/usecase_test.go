@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GCFactory/dbo-system/service/notification/internal/models"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/verification"
+	"github.com/google/uuid"
+)
+
+// settingsRepo keeps settings of one user, methods not used by tests panic through nil interface
+type settingsRepo struct {
+	notification.Repository
+	user         *models.UserNotificationInfo
+	verification *models.EmailVerification
+	updated      bool
+	saved        *models.UserNotificationInfo
+	verified     string
+}
+
+func (r *settingsRepo) GetUserNotificationSettings(ctx context.Context, userId uuid.UUID) (*models.UserNotificationInfo, error) {
+	if r.user == nil || r.user.UserUuid != userId {
+		return nil, ErrorUserNotFound
+	}
+	user := *r.user
+	return &user, nil
+}
+
+func (r *settingsRepo) UpdateUserNotificationSettings(ctx context.Context, user *models.UserNotificationInfo) error {
+	r.updated = true
+	saved := *user
+	r.saved = &saved
+	return nil
+}
+
+func (r *settingsRepo) GetEmailVerification(ctx context.Context, userId uuid.UUID) (*models.EmailVerification, error) {
+	if r.verification == nil {
+		return nil, errors.New("not found")
+	}
+	return r.verification, nil
+}
+
+func (r *settingsRepo) VerifyUserEmail(ctx context.Context, userId uuid.UUID, email string) error {
+	r.verified = email
+	return nil
+}
+
+func newTestUseCase(t *testing.T, repo notification.Repository) *NotificationUseCase {
+	signer, err := verification.NewSigner("verification-secret", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &NotificationUseCase{repo: repo, signer: signer}
+}
+
+func TestNotificationUseCase_VerifyEmail(t *testing.T) {
+	user := &models.UserNotificationInfo{
+		UserUuid:   uuid.New(),
+		Email:      "user@example.com",
+		Locale:     "ru",
+		DigestMode: DigestModeOff,
+		Timezone:   DefaultTimezone,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo := &settingsRepo{user: user}
+		uc := newTestUseCase(t, repo)
+		token, _ := uc.signer.Sign(user.UserUuid, user.Email)
+
+		if err := uc.VerifyEmail(context.Background(), token); err != nil {
+			t.Fatal(err)
+		}
+		if repo.verified != user.Email {
+			t.Fatalf("verified email = %q", repo.verified)
+		}
+	})
+
+	t.Run("Error email changed", func(t *testing.T) {
+		repo := &settingsRepo{user: user}
+		uc := newTestUseCase(t, repo)
+		token, _ := uc.signer.Sign(user.UserUuid, "old@example.com")
+
+		if err := uc.VerifyEmail(context.Background(), token); !errors.Is(err, ErrorVerificationEmailChanged) {
+			t.Fatalf("error = %v", err)
+		}
+		if repo.verified != "" {
+			t.Fatalf("email %q must not be verified", repo.verified)
+		}
+	})
+
+	t.Run("Error invalid token", func(t *testing.T) {
+		uc := newTestUseCase(t, &settingsRepo{user: user})
+
+		if err := uc.VerifyEmail(context.Background(), "token"); !errors.Is(err, verification.ErrorInvalidToken) {
+			t.Fatalf("error = %v", err)
+		}
+	})
+}
+
+func TestNotificationUseCase_UpdateUserSettingsRateLimit(t *testing.T) {
+	user := &models.UserNotificationInfo{
+		UserUuid:   uuid.New(),
+		Email:      "user@example.com",
+		Locale:     "ru",
+		DigestMode: DigestModeOff,
+		Timezone:   DefaultTimezone,
+	}
+	repo := &settingsRepo{
+		user: user,
+		verification: &models.EmailVerification{
+			UserUuid:    user.UserUuid,
+			Email:       user.Email,
+			LastSentAt:  time.Now(),
+			WindowStart: time.Now(),
+			SentCount:   1,
+		},
+	}
+	uc := newTestUseCase(t, repo)
+
+	update := *user
+	update.Email = "new@example.com"
+	if _, err := uc.UpdateUserSettings(context.Background(), &update); !errors.Is(err, ErrorVerificationRateLimit) {
+		t.Fatalf("error = %v", err)
+	}
+	if repo.updated {
+		t.Fatal("settings must not be saved when verification can't be sent")
+	}
+}
+
+func TestNotificationUseCase_UpdateUserSettingsWebhookSecret(t *testing.T) {
+	user := &models.UserNotificationInfo{
+		UserUuid:      uuid.New(),
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Locale:        "ru",
+		WebhookUrl:    "https://example.com/hook",
+		WebhookSecret: "old-secret",
+		DigestMode:    DigestModeOff,
+		Timezone:      DefaultTimezone,
+	}
+
+	t.Run("New url", func(t *testing.T) {
+		repo := &settingsRepo{user: user}
+		uc := newTestUseCase(t, repo)
+
+		update := *user
+		update.WebhookUrl = "https://example.com/new"
+		update.WebhookSecret = ""
+		secret, err := uc.UpdateUserSettings(context.Background(), &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret == "" || secret == user.WebhookSecret || repo.saved.WebhookSecret != secret {
+			t.Fatalf("returned secret = %q, saved secret = %q", secret, repo.saved.WebhookSecret)
+		}
+	})
+
+	t.Run("Same url", func(t *testing.T) {
+		repo := &settingsRepo{user: user}
+		uc := newTestUseCase(t, repo)
+
+		update := *user
+		update.WebhookSecret = ""
+		secret, err := uc.UpdateUserSettings(context.Background(), &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret != "" || repo.saved.WebhookSecret != user.WebhookSecret {
+			t.Fatalf("returned secret = %q, saved secret = %q", secret, repo.saved.WebhookSecret)
+		}
+	})
+
+	t.Run("Registered without secret", func(t *testing.T) {
+		legacy := *user
+		legacy.WebhookSecret = ""
+		repo := &settingsRepo{user: &legacy}
+		uc := newTestUseCase(t, repo)
+
+		update := legacy
+		secret, err := uc.UpdateUserSettings(context.Background(), &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret == "" || repo.saved.WebhookSecret != secret {
+			t.Fatalf("returned secret = %q, saved secret = %q", secret, repo.saved.WebhookSecret)
+		}
+	})
+
+	t.Run("Url removed", func(t *testing.T) {
+		repo := &settingsRepo{user: user}
+		uc := newTestUseCase(t, repo)
+
+		update := *user
+		update.WebhookUrl = ""
+		secret, err := uc.UpdateUserSettings(context.Background(), &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if secret != "" || repo.saved.WebhookSecret != "" {
+			t.Fatalf("returned secret = %q, saved secret = %q", secret, repo.saved.WebhookSecret)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import "time"
+
+// Email verification defaults, used when config has no value
+const (
+	DefaultVerificationTokenTTL       = 24 * time.Hour
+	DefaultVerificationResendInterval = time.Minute
+	DefaultVerificationsPerDay        = 5
+)
+
+// Period of MaxPerDay verification limit
+const VerificationWindow = 24 * time.Hour
@@ -0,0 +1,9 @@
+package verification
+
+import "errors"
+
+var (
+	ErrorInvalidToken = errors.New("Invalid verification token")
+	ErrorTokenExpired = errors.New("Verification token expired")
+	ErrorEmptySecret  = errors.New("Verification secret is empty")
+)
@@ -0,0 +1,112 @@
+package verification
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	claimsSeparator    = "|"
+	signatureSeparator = "."
+)
+
+// Data signed into email verification token
+type Claims struct {
+	UserId    uuid.UUID
+	Email     string
+	ExpiresAt time.Time
+}
+
+// Signer issues and checks tokens of form base64url("<user>|<email>|<expires unix>").base64url(HMAC-SHA256)
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func (s *Signer) Sign(userId uuid.UUID, email string) (string, *Claims) {
+	claims := &Claims{
+		UserId:    userId,
+		Email:     email,
+		ExpiresAt: time.Now().Add(s.ttl).Truncate(time.Second),
+	}
+
+	payload := strings.Join([]string{
+		userId.String(),
+		email,
+		strconv.FormatInt(claims.ExpiresAt.Unix(), 10),
+	}, claimsSeparator)
+
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) +
+		signatureSeparator +
+		base64.RawURLEncoding.EncodeToString(s.signature([]byte(payload)))
+
+	return token, claims
+}
+
+func (s *Signer) Parse(token string) (*Claims, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, signatureSeparator)
+	if !ok {
+		return nil, ErrorInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrorInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, ErrorInvalidToken
+	}
+	if !hmac.Equal(signature, s.signature(payload)) {
+		return nil, ErrorInvalidToken
+	}
+
+	// Email may contain separator, so user and expiration are cut from the edges
+	userPart, rest, ok := strings.Cut(string(payload), claimsSeparator)
+	if !ok {
+		return nil, ErrorInvalidToken
+	}
+	idx := strings.LastIndex(rest, claimsSeparator)
+	if idx < 0 {
+		return nil, ErrorInvalidToken
+	}
+
+	userId, err := uuid.Parse(userPart)
+	if err != nil {
+		return nil, ErrorInvalidToken
+	}
+	expires, err := strconv.ParseInt(rest[idx+1:], 10, 64)
+	if err != nil {
+		return nil, ErrorInvalidToken
+	}
+
+	claims := &Claims{
+		UserId:    userId,
+		Email:     rest[:idx],
+		ExpiresAt: time.Unix(expires, 0),
+	}
+	if time.Now().After(claims.ExpiresAt) {
+		return nil, ErrorTokenExpired
+	}
+
+	return claims, nil
+}
+
+func (s *Signer) signature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// NewSigner refuses empty secret: HMAC with empty key lets anyone forge tokens
+func NewSigner(secret string, ttl time.Duration) (*Signer, error) {
+	if secret == "" {
+		return nil, ErrorEmptySecret
+	}
+	return &Signer{secret: []byte(secret), ttl: ttl}, nil
+}
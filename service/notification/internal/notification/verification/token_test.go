@@ -0,0 +1,77 @@
+package verification
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestSigner(t *testing.T, ttl time.Duration) *Signer {
+	signer, err := NewSigner("verification-secret", ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestNewSigner(t *testing.T) {
+	if _, err := NewSigner("", time.Hour); !errors.Is(err, ErrorEmptySecret) {
+		t.Fatalf("empty secret error = %v", err)
+	}
+}
+
+func TestSigner_SignParse(t *testing.T) {
+	signer := newTestSigner(t, time.Hour)
+	userId := uuid.New()
+	// Separator inside email must not break parsing
+	email := "user|name@example.com"
+
+	token, claims := signer.Sign(userId, email)
+	parsed, err := signer.Parse(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.UserId != userId || parsed.Email != email || !parsed.ExpiresAt.Equal(claims.ExpiresAt) {
+		t.Fatalf("parsed claims = %+v, signed claims = %+v", parsed, claims)
+	}
+}
+
+func TestSigner_ParseExpired(t *testing.T) {
+	signer := newTestSigner(t, -time.Minute)
+	token, _ := signer.Sign(uuid.New(), "user@example.com")
+
+	if _, err := signer.Parse(token); !errors.Is(err, ErrorTokenExpired) {
+		t.Fatalf("expired token error = %v", err)
+	}
+}
+
+func TestSigner_ParseInvalid(t *testing.T) {
+	signer := newTestSigner(t, time.Hour)
+	token, _ := signer.Sign(uuid.New(), "user@example.com")
+	payload, signature, _ := strings.Cut(token, signatureSeparator)
+
+	decoded, _ := base64.RawURLEncoding.DecodeString(payload)
+	forged := strings.Replace(string(decoded), "user@", "attacker@", 1)
+
+	otherSigner, _ := NewSigner("other-secret", time.Hour)
+	otherToken, _ := otherSigner.Sign(uuid.New(), "user@example.com")
+
+	cases := map[string]string{
+		"no signature":     payload,
+		"tampered payload": base64.RawURLEncoding.EncodeToString([]byte(forged)) + signatureSeparator + signature,
+		"tampered sign":    payload + signatureSeparator + base64.RawURLEncoding.EncodeToString([]byte("signature")),
+		"broken encoding":  payload + signatureSeparator + "!!!",
+		"other secret":     otherToken,
+	}
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := signer.Parse(token); !errors.Is(err, ErrorInvalidToken) {
+				t.Fatalf("error = %v", err)
+			}
+		})
+	}
+}
@@ -3,14 +3,14 @@ package server
 import (
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	notificationMiddleware "github.com/GCFactory/dbo-system/service/notification/internal/middleware"
 	delivery "github.com/GCFactory/dbo-system/service/notification/internal/notification/delivery/http"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	"net/http"
 	"strings"
 )
 
@@ -48,6 +48,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -64,15 +65,18 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
 	apiGatewayGroup := v1.Group("/notification")
 
 	delivery.MapNotificationRoutes(apiGatewayGroup, notificationHandlers, mw)
 
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+		health.KafkaChecker("kafka", s.cfg.KafkaConsumer.Brokers),
+		health.AMQPChecker(s.rmqCh),
+		health.SmtpChecker(s.mailSender),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	return nil
 }
@@ -2,20 +2,23 @@ package server
 
 import (
 	"context"
-	"errors"
 	"github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/mail"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
 	api "github.com/GCFactory/dbo-system/service/notification/gen_proto/proto/notification_api"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/channels"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification/grpc"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification/repo"
+	"github.com/GCFactory/dbo-system/service/notification/internal/notification/templates"
 	"github.com/GCFactory/dbo-system/service/notification/internal/notification/usecase"
-	"github.com/GCFactory/dbo-system/service/notification/pkg/kafka"
 	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/labstack/echo/v4"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"net/smtp"
 	"os"
 	"os/signal"
 	"syscall"
@@ -39,36 +42,48 @@ type Server struct {
 	cfg           *config.Config
 	db            *sqlx.DB
 	logger        logger.Logger
-	msg           <-chan amqp.Delivery
+	rmqCh         *amqp.Channel
+	rmqConsumer   *rmq.Consumer
 	useCase       notification.UseCase
-	smtpClient    *smtp.Client
+	mailSender    mail.Sender
 	kafkaProducer *kafka.ProducerProvider
 	kafkaConsumer *kafka.ConsumerGroup
-	// Channel to control goroutines
-	kafkaConsumerChan chan int
-	grpcHandlers      notification.GRPCHandlers
+	grpcHandlers  notification.GRPCHandlers
 }
 
-func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, msgChan <-chan amqp.Delivery, smtpClient *smtp.Client, logger logger.Logger) *Server {
+func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, metrics metric.BusinessMetrics, rmqCh *amqp.Channel, rmqConsumer *rmq.Consumer, mailSender mail.Sender, renderer *templates.Renderer, logger logger.Logger) (*Server, error) {
 	server := Server{
-		echo:              echo.New(),
-		cfg:               cfg,
-		db:                db,
-		msg:               msgChan,
-		logger:            logger,
-		smtpClient:        smtpClient,
-		kafkaConsumer:     kConsumer,
-		kafkaProducer:     kProducer,
-		kafkaConsumerChan: make(chan int, 3),
+		echo:          echo.New(),
+		cfg:           cfg,
+		db:            db,
+		rmqCh:         rmqCh,
+		rmqConsumer:   rmqConsumer,
+		logger:        logger,
+		mailSender:    mailSender,
+		kafkaConsumer: kConsumer,
+		kafkaProducer: kProducer,
 	}
 	server.echo.HidePort = true
 	server.echo.HideBanner = true
 
 	serverRepo := repo.NewNotificationRepository(server.db)
-	server.useCase = usecase.NewNotificationUseCase(serverRepo, server.smtpClient, server.cfg.NotificationSmtp)
-	server.grpcHandlers = grpc.NewNotificationGRPCHandlers(cfg, kProducer, server.useCase, server.logger)
+	notificationChannels := []notification.Channel{
+		channels.NewEmailChannel(server.mailSender, server.cfg.NotificationSmtp),
+		channels.NewInboxChannel(serverRepo),
+		channels.NewSmsChannel(server.cfg.NotificationSms),
+		channels.NewWebhookChannel(server.cfg.NotificationWebhook),
+	}
+	useCase, err := usecase.NewNotificationUseCase(serverRepo, notificationChannels, renderer, server.cfg.NotificationEmailVerification, server.cfg.NotificationScheduler, metrics, server.logger)
+	if err != nil {
+		return nil, err
+	}
+	server.useCase = useCase
+	server.grpcHandlers = grpc.NewNotificationGRPCHandlers(cfg, kProducer, server.useCase, server.logger, metrics)
 
-	return &server
+	for _, topic := range cfg.KafkaConsumer.Topics {
+		kConsumer.Handle(topic, server.handleData)
+	}
+	return &server, nil
 }
 
 func (s *Server) Run() error {
@@ -109,65 +124,72 @@ func (s *Server) Run() error {
 	}
 
 	go func() {
-		for d := range s.msg {
-			err := s.useCase.SendMessage(context.Background(), d)
-			if err != nil {
-				s.logger.Errorf("Error send msg: %s", err)
-			}
+		if err := s.rmqConsumer.Run(ctxWithCancel, s.handleNotification); err != nil {
+			s.logger.Errorf("RMQ consumer stopped: %v", err)
 		}
 	}()
 
+	go s.runScheduler(ctxWithCancel)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
-	go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-
-	for {
-		select {
-		// If goroutine exit for any reason - restart
-		case <-s.kafkaConsumerChan:
-			s.logger.Warn("Received end of kafka consumer goroutine")
-			go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-		// Handle system interrupts
-		case <-quit:
-			ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-			s.kafkaConsumer.Consumer.PauseAll()
-			s.kafkaConsumer.Consumer.Close()
-			time.Sleep(time.Second * 5)
-			close(quit)
-			close(s.kafkaConsumerChan)
-			defer shutdown()
-			defer cancel()
-
-			s.logger.Info("Server Exited Properly")
-			return s.echo.Server.Shutdown(ctx)
+	go func() {
+		if err := s.kafkaConsumer.Run(ctxWithCancel); err != nil {
+			s.logger.Errorf("Kafka consumer stopped: %v", err)
 		}
+	}()
+
+	<-quit
+	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+	defer shutdown()
+
+	if err := s.kafkaConsumer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka consumer: %v", err)
+	}
+	cancel()
+	<-s.kafkaConsumer.Done()
+	if err := s.kafkaProducer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka producer: %v", err)
+	}
+
+	s.logger.Info("Server Exited Properly")
+	return s.echo.Server.Shutdown(ctx)
+}
+
+// handleNotification sends message from RMQ. Returned error makes consumer retry the message.
+func (s *Server) handleNotification(ctx context.Context, delivery amqp.Delivery) error {
+	err := s.useCase.SendMessage(ctx, delivery)
+	if err != nil {
+		s.logger.Errorf("Error send msg: %s", err)
 	}
+	return err
 }
 
-func (s *Server) RunKafkaConsumer(ctx context.Context, quitChan chan<- int) {
-	consumer := kafka.Consumer{
-		Ready:       make(chan bool),
-		HandlerFunc: s.handleData,
+// runScheduler periodically sends digests and notifications deferred by quiet hours.
+// Pending items are kept in db, so the ones missed while service was down are sent on start.
+func (s *Server) runScheduler(ctx context.Context) {
+	interval := usecase.DefaultSchedulerInterval
+	if s.cfg.NotificationScheduler.Interval > 0 {
+		interval = time.Duration(s.cfg.NotificationScheduler.Interval) * time.Second
 	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
 	for {
-		if err := s.kafkaConsumer.Consumer.Consume(ctx, s.cfg.KafkaConsumer.Topics, &consumer); err != nil {
-			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
-				return
-			}
-			quitChan <- 1
-			s.logger.Errorf("Error from consumer: %v", err)
+		if err := s.useCase.ProcessPending(ctx, time.Now()); err != nil {
+			s.logger.Errorf("Error send pending notifications: %s", err)
 		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			s.logger.Infof("Stopping kafka consumer: context close: %v", ctx.Err())
+
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
 		}
-		s.logger.Info("Stopping kafka consumer...")
-		consumer.Ready = make(chan bool)
 	}
 }
 
-func (s *Server) handleData(message *sarama.ConsumerMessage) error {
+func (s *Server) handleData(ctx context.Context, message *sarama.ConsumerMessage) error {
 
 	data := &api.EventData{}
 	err := proto.Unmarshal(message.Value, data)
@@ -185,7 +207,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -199,7 +221,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.AddUserSettings(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.AddUserSettings(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -211,7 +233,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.RemoveUserSettings(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.RemoveUserSettings(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -232,7 +254,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 				s.logger.Error(err)
 				return err
 			}
-			err = s.kafkaProducer.ProduceRecord(grpc.TopicError, sarama.ByteEncoder(answer_data))
+			err = s.kafkaProducer.ProduceRecord(ctx, grpc.TopicError, answer_data)
 			if err != nil {
 				s.logger.Error(err)
 				return err
@@ -254,7 +276,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -0,0 +1 @@
+ALTER TABLE notification ADD COLUMN locale varchar(2) NOT NULL DEFAULT 'ru';
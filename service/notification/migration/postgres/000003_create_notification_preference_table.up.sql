@@ -0,0 +1,8 @@
+CREATE TABLE notification_preference
+(
+    user_uuid           UUID                NOT NULL        REFERENCES notification (user_uuid) ON DELETE CASCADE,
+    category            varchar(32)         NOT NULL,
+    channel             varchar(32)         NOT NULL,
+    enabled             bool                NOT NULL        DEFAULT true,
+    PRIMARY KEY (user_uuid, category, channel)
+);
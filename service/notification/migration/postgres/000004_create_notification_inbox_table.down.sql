@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_inbox CASCADE;
+
+ALTER TABLE notification DROP COLUMN IF EXISTS webhook_url;
+ALTER TABLE notification DROP COLUMN IF EXISTS phone;
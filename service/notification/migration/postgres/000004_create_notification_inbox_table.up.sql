@@ -0,0 +1,15 @@
+ALTER TABLE notification ADD COLUMN phone varchar(32) NOT NULL DEFAULT '';
+ALTER TABLE notification ADD COLUMN webhook_url varchar(512) NOT NULL DEFAULT '';
+
+CREATE TABLE notification_inbox
+(
+    message_uuid        UUID                PRIMARY KEY,
+    user_uuid           UUID                NOT NULL        REFERENCES notification (user_uuid) ON DELETE CASCADE,
+    category            varchar(32)         NOT NULL,
+    subject             varchar(256)        NOT NULL        DEFAULT '',
+    body                text                NOT NULL        DEFAULT '',
+    is_read             bool                NOT NULL        DEFAULT false,
+    created_at          timestamp           NOT NULL        DEFAULT now()
+);
+
+CREATE INDEX notification_inbox_user_idx ON notification_inbox (user_uuid, created_at DESC);
@@ -0,0 +1,20 @@
+CREATE TABLE notification_delivery
+(
+    delivery_uuid       UUID                PRIMARY KEY,
+    message_uuid        UUID                NOT NULL,
+    user_uuid           UUID                NOT NULL        REFERENCES notification (user_uuid) ON DELETE CASCADE,
+    channel             varchar(32)         NOT NULL,
+    category            varchar(32)         NOT NULL,
+    template            varchar(64)         NOT NULL,
+    subject             varchar(256)        NOT NULL        DEFAULT '',
+    status              varchar(16)         NOT NULL,
+    attempts            int                 NOT NULL        DEFAULT 0,
+    last_error          text                NOT NULL        DEFAULT '',
+    created_at          timestamp           NOT NULL        DEFAULT now(),
+    updated_at          timestamp           NOT NULL        DEFAULT now(),
+    sent_at             timestamp,
+    UNIQUE (message_uuid, channel)
+);
+
+CREATE INDEX notification_delivery_user_idx ON notification_delivery (user_uuid, created_at DESC);
+CREATE INDEX notification_delivery_created_idx ON notification_delivery (created_at DESC);
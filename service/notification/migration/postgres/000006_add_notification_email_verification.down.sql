@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS notification_email_verification CASCADE;
+
+ALTER TABLE notification DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,13 @@
+ALTER TABLE notification ADD COLUMN email_verified bool NOT NULL DEFAULT false;
+
+-- Addresses added before double opt-in keep receiving notifications
+UPDATE notification SET email_verified = true;
+
+CREATE TABLE notification_email_verification
+(
+    user_uuid           UUID                PRIMARY KEY     REFERENCES notification (user_uuid) ON DELETE CASCADE,
+    email               varchar(128)        NOT NULL,
+    last_sent_at        timestamp           NOT NULL,
+    window_start        timestamp           NOT NULL,
+    sent_count          int                 NOT NULL        DEFAULT 0
+);
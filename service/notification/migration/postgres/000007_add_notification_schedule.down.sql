@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notification_pending CASCADE;
+
+ALTER TABLE notification DROP COLUMN IF EXISTS digest_mode;
+ALTER TABLE notification DROP COLUMN IF EXISTS timezone;
+ALTER TABLE notification DROP COLUMN IF EXISTS quiet_hours_start;
+ALTER TABLE notification DROP COLUMN IF EXISTS quiet_hours_end;
@@ -0,0 +1,22 @@
+ALTER TABLE notification ADD COLUMN digest_mode varchar(8) NOT NULL DEFAULT 'off';
+ALTER TABLE notification ADD COLUMN timezone varchar(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE notification ADD COLUMN quiet_hours_start smallint NOT NULL DEFAULT 0;
+ALTER TABLE notification ADD COLUMN quiet_hours_end smallint NOT NULL DEFAULT 0;
+
+-- Notifications waiting for digest or end of quiet hours.
+-- Digest items are claimed by digest_uuid before sending, so digest is resent with the same id after restart.
+CREATE TABLE notification_pending
+(
+    message_uuid        UUID                PRIMARY KEY,
+    user_uuid           UUID                NOT NULL        REFERENCES notification (user_uuid) ON DELETE CASCADE,
+    category            varchar(32)         NOT NULL,
+    template            varchar(64)         NOT NULL,
+    body                text                NOT NULL        DEFAULT '{}',
+    digest              bool                NOT NULL        DEFAULT false,
+    digest_uuid         UUID,
+    deliver_at          timestamptz         NOT NULL,
+    created_at          timestamptz         NOT NULL        DEFAULT now()
+);
+
+CREATE INDEX notification_pending_deliver_idx ON notification_pending (deliver_at);
+CREATE INDEX notification_pending_digest_idx ON notification_pending (user_uuid, digest_uuid);
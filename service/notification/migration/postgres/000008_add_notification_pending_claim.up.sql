@@ -0,0 +1,3 @@
+-- Due notification is claimed by one service replica until claimed_until, so it isn't sent twice.
+-- Item which wasn't sent or deleted before claim expired is taken again.
+ALTER TABLE notification_pending ADD COLUMN claimed_until timestamptz;
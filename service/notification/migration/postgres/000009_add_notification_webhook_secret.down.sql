@@ -0,0 +1 @@
+ALTER TABLE notification DROP COLUMN IF EXISTS webhook_secret;
@@ -0,0 +1,3 @@
+-- Webhook requests are signed with key of user endpoint. Key is generated when webhook url is set,
+-- webhooks registered before have empty key and aren't sent until url is saved again.
+ALTER TABLE notification ADD COLUMN webhook_secret varchar(64) NOT NULL DEFAULT '';
@@ -1,24 +1,23 @@
 package main
 
 import (
+	"context"
 	platformConfig "github.com/GCFactory/dbo-system/platform/config"
+	rmq "github.com/GCFactory/dbo-system/platform/pkg/amqp"
 	"github.com/GCFactory/dbo-system/platform/pkg/db/postgres"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/registration/config"
 	"github.com/GCFactory/dbo-system/service/registration/internal/server"
-	"github.com/GCFactory/dbo-system/service/registration/pkg/kafka"
 	"github.com/golang-migrate/migrate/v4"
 	migratePostgres "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/opentracing/opentracing-go"
-	"github.com/uber/jaeger-client-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"log"
 	"os"
-	"strings"
 )
 
 //	@Title			Registration Service
@@ -86,41 +85,66 @@ func main() {
 	}
 	appLogger.Info("Migration completed")
 
-	jaegerCfgInstance := jaegercfg.Configuration{
-		ServiceName: cfg.Jaeger.ServiceName,
-		Sampler: &jaegercfg.SamplerConfig{
-			Type:  jaeger.SamplerTypeConst,
-			Param: 1,
-		},
-		Reporter: &jaegercfg.ReporterConfig{
-			LogSpans:           cfg.Jaeger.LogSpans,
-			LocalAgentHostPort: cfg.Jaeger.Host,
-		},
+	appLogger.Info("Connecting to RMQ")
+	rmqUrl := "amqp://" +
+		cfg.RabbitMQ.User + ":" +
+		cfg.RabbitMQ.Password + "@" +
+		cfg.RabbitMQ.Host + ":" +
+		cfg.RabbitMQ.Port
+	rmqConn, err := amqp.Dial(rmqUrl)
+	if err != nil {
+		appLogger.Fatalf("Connecting error to RMQ: %s", err)
+		return
 	}
+	defer rmqConn.Close()
+	appLogger.Info("Connecting to RMQ success")
 
-	tracer, closer, err := jaegerCfgInstance.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
-	)
+	appLogger.Info("Open RMQ channel")
+	rmqCh, err := rmqConn.Channel()
 	if err != nil {
-		log.Fatal("cannot create tracer", err)
+		appLogger.Fatalf("Open RMQ channel error: %s", err)
+		return
 	}
-	appLogger.Info("Jaeger connected")
+	defer rmqCh.Close()
+	appLogger.Info("Open RMQ channel success")
 
-	opentracing.SetGlobalTracer(tracer)
-	defer closer.Close()
-	appLogger.Info("Opentracing connected")
+	appLogger.Info("Creating RMQ queue")
+	rmqQueue, err := rmq.DeclareQueue(rmqCh, cfg.RabbitMQ)
+	if err != nil {
+		appLogger.Fatalf("Create RMQ queue error: %s", err)
+		return
+	}
+	appLogger.Info("Create RMQ queue success")
+
+	tp, err := tracing.NewTracerProvider(context.Background(), &platformConfig.Config{
+		Env:     cfg.Env,
+		Version: cfg.Version,
+		Tracing: cfg.Tracing,
+	})
+	if err != nil {
+		log.Fatal("cannot create tracer provider", err)
+	}
+	defer tp.Shutdown(context.Background())
+	appLogger.Info("OpenTelemetry tracing connected")
 
-	kc, err := kafka.NewKafkaConsumer(strings.Split(cfg.KafkaConsumer.Brokers, ";"), cfg.KafkaConsumer.GroupID)
+	kc, err := kafka.NewKafkaConsumer(platformConfig.KafkaConsumer(cfg.KafkaConsumer), appLogger)
 	if err != nil {
 		appLogger.Fatal(err)
 	}
 	appLogger.Infof("Kafka consumer with group '%s' connected", cfg.KafkaConsumer.GroupID)
 
-	kp := kafka.NewKafkaProducer(cfg, appLogger)
+	kp := kafka.NewKafkaProducer(platformConfig.KafkaProducer(cfg.KafkaProducer), appLogger)
+
+	businessMetrics, err := metric.CreateBusinessMetrics(cfg.Metrics.ServiceName)
+	if err != nil {
+		appLogger.Fatalf("CreateBusinessMetrics Error: %s", err)
+	}
+	kc.SetMetrics(businessMetrics)
+	kp.SetMetrics(businessMetrics)
+	kc.SetDeadLetterProducer(kp)
 
 	//Run server
-	s := server.NewServer(cfg, kc, kp, psqlDB, appLogger)
+	s := server.NewServer(cfg, kc, kp, psqlDB, rmqCh, *rmqQueue, businessMetrics, appLogger)
 	if err = s.Run(); err != nil {
 		appLogger.Fatal(err)
 	}
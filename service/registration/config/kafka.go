@@ -4,9 +4,14 @@ type KafkaConsumer struct {
 	Brokers string   `yaml:"brokers"`
 	GroupID string   `yaml:"groupID"`
 	Topics  []string `yaml:"topics"`
+	// Handler attempts for one message before it goes to dead-letter topic
+	MaxAttempts int `yaml:"maxAttempts"`
+	// Topic for messages which handler failed to process, <topic>_dlq by default
+	DeadLetterTopic string `yaml:"deadLetterTopic"`
 }
 
 type KafkaProducer struct {
-	Brokers string   `yaml:"brokers"`
-	Topics  []string `yaml:"topics"`
+	Brokers       string   `yaml:"brokers"`
+	Topics        []string `yaml:"topics"`
+	TransactionID string   `yaml:"transactionID"`
 }
@@ -28,6 +28,10 @@ type RegistrationUserInfo struct {
 	UserEmail string   `json:"user_email" validate:"required,email"`
 	Passport  Passport `json:"passport" validate:"required"`
 	User      UserData `json:"user_data" validate:"required"`
+	// Passport scans uploaded to file-api
+	Passport_files []string `json:"passport_files" validate:"max=10,dive,uuid4"`
+	// UUID of new user set by caller when passport scans are uploaded with it as owner
+	User_uuid string `json:"user_uuid" validate:"omitempty,uuid4"`
 }
 
 type OperationID struct {
@@ -42,6 +46,8 @@ type OpenAccountInfo struct {
 	BIC            string `json:"bic" validate:"required,number,len=9"`
 	CIO            string `json:"cio" validate:"required,number,min=9,max=11"`
 	Reserve_reason string `json:"reserve_reason"`
+	// ISO-4217 code, it's checked against currency digits of culc number when it's set
+	Currency string `json:"currency" validate:"omitempty,len=3,alpha,uppercase"`
 }
 
 type AddAccountCache struct {
@@ -56,11 +62,46 @@ type WidthAccountCache struct {
 	Cache_diff float32 `json:"cache_diff" validate:"required,number,min=0"`
 }
 
+// Transfer to account of other currency is converted by account service exchange rate
+type TransferAccountCache struct {
+	User_ID       string  `json:"user_id" validate:"required,uuid4"`
+	Account_ID    string  `json:"account_id" validate:"required,uuid4"`
+	To_Account_ID string  `json:"to_account_id" validate:"required,uuid4,nefield=Account_ID"`
+	Cache_diff    float64 `json:"cache_diff" validate:"required,number,gt=0"`
+}
+
+type CreateAccountHold struct {
+	User_ID     string  `json:"user_id" validate:"required,uuid4"`
+	Account_ID  string  `json:"account_id" validate:"required,uuid4"`
+	Hold_amount float64 `json:"hold_amount" validate:"required,number,gt=0"`
+	// Hold lifetime in seconds, account default lifetime is used when it's 0
+	Expires_in uint64 `json:"expires_in"`
+}
+
+type CaptureAccountHold struct {
+	User_ID    string `json:"user_id" validate:"required,uuid4"`
+	Account_ID string `json:"account_id" validate:"required,uuid4"`
+	Hold_ID    string `json:"hold_id" validate:"required,uuid4"`
+	// Captured amount, whole hold is captured when it's 0
+	Hold_amount float64 `json:"hold_amount" validate:"number,min=0"`
+}
+
+type ReleaseAccountHold struct {
+	User_ID    string `json:"user_id" validate:"required,uuid4"`
+	Account_ID string `json:"account_id" validate:"required,uuid4"`
+	Hold_ID    string `json:"hold_id" validate:"required,uuid4"`
+}
+
 type CloseAccount struct {
 	User_ID    string `json:"user_id" validate:"required,uuid4"`
 	Account_ID string `json:"account_id" validate:"required,uuid4"`
 }
 
+type BlockAccount struct {
+	User_ID    string `json:"user_id" validate:"required,uuid4"`
+	Account_ID string `json:"account_id" validate:"required,uuid4"`
+}
+
 type GetUserData struct {
 	User_ID string `json:"user_id" validate:"required,uuid4"`
 }
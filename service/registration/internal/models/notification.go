@@ -0,0 +1,22 @@
+package models
+
+import "github.com/google/uuid"
+
+// Notification published to notification service after completed event
+type Notification struct {
+	MessageId uuid.UUID
+	UserId    uuid.UUID
+	Category  string
+	Template  string
+	Data      interface{}
+}
+
+// Template variables of account operation notification
+type TransactionMessage struct {
+	OperationId  string
+	AccountId    string
+	AccountName  string
+	Amount       float64
+	BalanceAfter float64
+	Time         string
+}
@@ -8,7 +8,12 @@ type Handlers interface {
 	OpenAccount() echo.HandlerFunc
 	AddAccountCache() echo.HandlerFunc
 	WidthAccountCache() echo.HandlerFunc
+	TransferAccountCache() echo.HandlerFunc
+	CreateAccountHold() echo.HandlerFunc
+	CaptureAccountHold() echo.HandlerFunc
+	ReleaseAccountHold() echo.HandlerFunc
 	CloseAccount() echo.HandlerFunc
+	BlockAccount() echo.HandlerFunc
 	GetUserData() echo.HandlerFunc
 	GetAccountData() echo.HandlerFunc
 	UpdateUserPassword() echo.HandlerFunc
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/pkg/httpErrors"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/registration/config"
 	"github.com/GCFactory/dbo-system/service/registration/internal/models"
@@ -12,7 +13,6 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/opentracing/opentracing-go"
 	"net/http"
 	"time"
 )
@@ -55,8 +55,8 @@ func (h RegistrationHandlers) safeReadRequest(c echo.Context, v interface{}) err
 
 func (h RegistrationHandlers) CreateUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.CreateUser")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.CreateUser")
+		defer span.End()
 
 		user_info := &models.RegistrationUserInfo{}
 		if err := h.safeReadRequest(c, user_info); err != nil {
@@ -78,6 +78,8 @@ func (h RegistrationHandlers) CreateUser() echo.HandlerFunc {
 		data["authority"] = user_info.Passport.Authority
 		data["authority_date"] = user_info.Passport.Authority_date
 		data["registration_adress"] = user_info.Passport.Registration_address
+		data["passport_files"] = append([]string{}, user_info.Passport_files...)
+		data["user_uuid"] = user_info.User_uuid
 		data["login"] = user_info.User.Login
 		data["password"] = user_info.User.Password
 		data["email"] = user_info.UserEmail
@@ -98,8 +100,8 @@ func (h RegistrationHandlers) CreateUser() echo.HandlerFunc {
 
 func (h RegistrationHandlers) GetOperationStatus() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationStatus")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationStatus")
+		defer span.End()
 
 		operation_info := &models.OperationID{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -131,8 +133,8 @@ func (h RegistrationHandlers) GetOperationStatus() echo.HandlerFunc {
 func (h RegistrationHandlers) OpenAccount() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.OpenAccount")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.OpenAccount")
+		defer span.End()
 
 		operation_info := &models.OpenAccountInfo{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -149,6 +151,7 @@ func (h RegistrationHandlers) OpenAccount() echo.HandlerFunc {
 		data["bic"] = operation_info.BIC
 		data["cio"] = operation_info.CIO
 		data["reserve_reason"] = operation_info.Reserve_reason
+		data["currency"] = operation_info.Currency
 
 		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationAddAccount, data)
 		if err != nil {
@@ -166,8 +169,8 @@ func (h RegistrationHandlers) OpenAccount() echo.HandlerFunc {
 func (h RegistrationHandlers) AddAccountCache() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.AddAccountCache")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.AddAccountCache")
+		defer span.End()
 
 		operation_info := &models.AddAccountCache{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -197,8 +200,8 @@ func (h RegistrationHandlers) AddAccountCache() echo.HandlerFunc {
 func (h RegistrationHandlers) WidthAccountCache() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
+		defer span.End()
 
 		operation_info := &models.WidthAccountCache{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -226,11 +229,142 @@ func (h RegistrationHandlers) WidthAccountCache() echo.HandlerFunc {
 
 }
 
+func (h RegistrationHandlers) TransferAccountCache() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.TransferAccountCache")
+		defer span.End()
+
+		operation_info := &models.TransferAccountCache{}
+		if err := h.safeReadRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusBadRequest, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		data := make(map[string]interface{})
+
+		data["user_id"] = operation_info.User_ID
+		data["acc_id"] = operation_info.Account_ID
+		data["to_acc_id"] = operation_info.To_Account_ID
+		data["cache_diff"] = operation_info.Cache_diff
+
+		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationTransferAccountCache, data)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+		}
+
+		response := make(map[string]interface{})
+		response["info"] = operation_uuid.String()
+
+		return c.JSON(http.StatusAccepted, response)
+	}
+
+}
+
+func (h RegistrationHandlers) CreateAccountHold() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.CreateAccountHold")
+		defer span.End()
+
+		operation_info := &models.CreateAccountHold{}
+		if err := h.safeReadRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusBadRequest, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		data := make(map[string]interface{})
+
+		data["user_id"] = operation_info.User_ID
+		data["acc_id"] = operation_info.Account_ID
+		data["hold_amount"] = operation_info.Hold_amount
+		data["hold_expires_in"] = operation_info.Expires_in
+
+		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationCreateAccountHold, data)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+		}
+
+		response := make(map[string]interface{})
+		response["info"] = operation_uuid.String()
+
+		return c.JSON(http.StatusAccepted, response)
+	}
+
+}
+
+func (h RegistrationHandlers) CaptureAccountHold() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.CaptureAccountHold")
+		defer span.End()
+
+		operation_info := &models.CaptureAccountHold{}
+		if err := h.safeReadRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusBadRequest, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		data := make(map[string]interface{})
+
+		data["user_id"] = operation_info.User_ID
+		data["acc_id"] = operation_info.Account_ID
+		data["hold_id"] = operation_info.Hold_ID
+		data["hold_amount"] = operation_info.Hold_amount
+
+		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationCaptureAccountHold, data)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+		}
+
+		response := make(map[string]interface{})
+		response["info"] = operation_uuid.String()
+
+		return c.JSON(http.StatusAccepted, response)
+	}
+
+}
+
+func (h RegistrationHandlers) ReleaseAccountHold() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.ReleaseAccountHold")
+		defer span.End()
+
+		operation_info := &models.ReleaseAccountHold{}
+		if err := h.safeReadRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusBadRequest, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		data := make(map[string]interface{})
+
+		data["user_id"] = operation_info.User_ID
+		data["acc_id"] = operation_info.Account_ID
+		data["hold_id"] = operation_info.Hold_ID
+
+		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationReleaseAccountHold, data)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+		}
+
+		response := make(map[string]interface{})
+		response["info"] = operation_uuid.String()
+
+		return c.JSON(http.StatusAccepted, response)
+	}
+
+}
+
 func (h RegistrationHandlers) CloseAccount() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
+		defer span.End()
 
 		operation_info := &models.CloseAccount{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -257,11 +391,42 @@ func (h RegistrationHandlers) CloseAccount() echo.HandlerFunc {
 
 }
 
+func (h RegistrationHandlers) BlockAccount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.BlockAccount")
+		defer span.End()
+
+		operation_info := &models.BlockAccount{}
+		if err := h.safeReadRequest(c, operation_info); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusBadRequest, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		data := make(map[string]interface{})
+
+		data["user_id"] = operation_info.User_ID
+		data["acc_id"] = operation_info.Account_ID
+
+		operation_uuid, err := h.registrationGRPC.StartOperation(ctxWithTrace, usecase.OperationBlockAccount, data)
+		if err != nil {
+			utils.LogResponseError(c, h.logger, err)
+			return c.JSON(http.StatusInternalServerError, httpErrors.NewRestError(http.StatusInternalServerError, err.Error(), nil))
+		}
+
+		response := make(map[string]interface{})
+		response["info"] = operation_uuid.String()
+
+		return c.JSON(http.StatusAccepted, response)
+	}
+
+}
+
 func (h RegistrationHandlers) GetUserData() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
+		defer span.End()
 
 		operation_info := &models.GetUserData{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -290,8 +455,8 @@ func (h RegistrationHandlers) GetUserData() echo.HandlerFunc {
 func (h RegistrationHandlers) GetAccountData() echo.HandlerFunc {
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.WidthAccountCache")
+		defer span.End()
 
 		operation_info := &models.GetAccountData{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -322,8 +487,8 @@ func (h RegistrationHandlers) UpdateUserPassword() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
+		defer span.End()
 
 		operation_info := &models.UpdateUserPassword{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -354,8 +519,8 @@ func (h RegistrationHandlers) GetUserDataByLogin() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
+		defer span.End()
 
 		operation_info := &models.GetUserDataByLogin{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -385,8 +550,8 @@ func (h RegistrationHandlers) CheckUserPassword() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.UpdateUserPassword")
+		defer span.End()
 
 		operation_info := &models.CheckUserPassword{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -417,8 +582,8 @@ func (h RegistrationHandlers) GetOperationTree() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationTree")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationTree")
+		defer span.End()
 
 		operation_info := &models.OperationID{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
@@ -450,8 +615,8 @@ func (h RegistrationHandlers) GetOperationListBetween() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationTree")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(utils.GetRequestCtx(c), "RegistrationHandlers.GetOperationTree")
+		defer span.End()
 
 		operation_info := &models.TimeBetween{}
 		if err := h.safeReadRequest(c, operation_info); err != nil {
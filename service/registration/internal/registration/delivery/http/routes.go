@@ -11,7 +11,12 @@ func MapRegistrationRoutes(RegistrationGroup *echo.Group, h registration.Handler
 	RegistrationGroup.POST("/open_account", h.OpenAccount())
 	RegistrationGroup.POST("/add_account_cache", h.AddAccountCache())
 	RegistrationGroup.POST("/width_account_cache", h.WidthAccountCache())
+	RegistrationGroup.POST("/transfer_account_cache", h.TransferAccountCache())
+	RegistrationGroup.POST("/create_account_hold", h.CreateAccountHold())
+	RegistrationGroup.POST("/capture_account_hold", h.CaptureAccountHold())
+	RegistrationGroup.POST("/release_account_hold", h.ReleaseAccountHold())
 	RegistrationGroup.POST("/close_acc", h.CloseAccount())
+	RegistrationGroup.POST("/block_acc", h.BlockAccount())
 	RegistrationGroup.POST("/get_user_data", h.GetUserData())
 	RegistrationGroup.POST("/get_account_data", h.GetAccountData())
 	RegistrationGroup.POST("/update_password", h.UpdateUserPassword())
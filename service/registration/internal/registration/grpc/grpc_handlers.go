@@ -2,7 +2,9 @@ package grpc
 
 import (
 	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/registration/config"
 	accounts_api "github.com/GCFactory/dbo-system/service/registration/gen_proto/proto/api/account"
 	notification_api "github.com/GCFactory/dbo-system/service/registration/gen_proto/proto/api/notification_api"
@@ -11,11 +13,9 @@ import (
 	"github.com/GCFactory/dbo-system/service/registration/internal/models"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/usecase"
-	"github.com/GCFactory/dbo-system/service/registration/pkg/kafka"
-	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"time"
 )
@@ -23,14 +23,16 @@ import (
 type GRPCRegistrationHandlers struct {
 	cfg            *config.Config
 	kProducer      *kafka.ProducerProvider
+	rmqChan        *amqp.Channel
+	rmqQueue       amqp.Queue
 	registrationUC registration.UseCase
 	regLog         logger.Logger
 }
 
 func (h *GRPCRegistrationHandlers) StartOperation(ctx context.Context, operation_type uint8, operation_data map[string]interface{}) (operation_uuid uuid.UUID, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "GRPCRegistrationHandlers.StartOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "GRPCRegistrationHandlers.StartOperation")
+	defer span.End()
 
 	operation_uuid = uuid.Nil
 
@@ -45,7 +47,12 @@ func (h *GRPCRegistrationHandlers) StartOperation(ctx context.Context, operation
 		usecase.OperationGetAccountData,
 		usecase.OperationGroupUpdateUserPassword,
 		usecase.OperationGetUserDataByLogin,
-		usecase.OperationCheckUserPassword:
+		usecase.OperationCheckUserPassword,
+		usecase.OperationCreateAccountHold,
+		usecase.OperationCaptureAccountHold,
+		usecase.OperationReleaseAccountHold,
+		usecase.OperationTransferAccountCache,
+		usecase.OperationBlockAccount:
 		{
 			list_of_events, operation_uuid, err = h.registrationUC.StartOperation(ctxWithTrace, operation_type, operation_data)
 			if err != nil {
@@ -77,8 +84,8 @@ func (h *GRPCRegistrationHandlers) StartOperation(ctx context.Context, operation
 
 func (h *GRPCRegistrationHandlers) Process(ctx context.Context, saga_uuid uuid.UUID, saga *models.Saga, event_uuid uuid.UUID, event *models.Event, data map[string]interface{}, is_success bool) (err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "GRPCRegistrationHandlers.Process")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "GRPCRegistrationHandlers.Process")
+	defer span.End()
 
 	if uuid.Nil == saga_uuid || uuid.Nil == event_uuid {
 		return ErrorInvalidUUIDs
@@ -107,6 +114,9 @@ func (h *GRPCRegistrationHandlers) Process(ctx context.Context, saga_uuid uuid.U
 			h.regLog.Error(local_err)
 			return local_err
 		}
+		if is_success {
+			h.sendEventNotification(ctxWithTrace, event_uuid)
+		}
 		for _, new_event := range list_of_events {
 			if new_event != nil {
 				data_for_event, err := h.registrationUC.GetEventData(ctxWithTrace, new_event.Event_uuid)
@@ -132,8 +142,8 @@ func (h *GRPCRegistrationHandlers) Process(ctx context.Context, saga_uuid uuid.U
 
 func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8, operation_name string, saga_uuid uuid.UUID, event_uuid uuid.UUID, data map[string]interface{}) (err error) {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "GRPCRegistrationHandlers.SendRequest")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "GRPCRegistrationHandlers.SendRequest")
+	defer span.End()
 
 	if !ValidateServer(server) {
 		return ErrorInvalidServer
@@ -196,6 +206,8 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 									AuthorityDate:      authority_date_proto,
 									RegistrationAdress: data["registration_adress"].(string),
 								},
+								PassportFiles: stringList(data["passport_files"]),
+								UserUuid:      stringValue(data["user_uuid"]),
 							},
 						},
 					}
@@ -205,7 +217,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -240,7 +252,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -277,7 +289,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -315,7 +327,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -352,7 +364,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -389,7 +401,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicUsersConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicUsersConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -416,6 +428,9 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						return ErrorInvalidServersTopic
 					}
 
+					// Currency is optional, account service derives it from culc number
+					currency, _ := data["currency"].(string)
+
 					account_data := &accounts_api.EventData{
 						SagaUuid:      saga_uuid.String(),
 						EventUuid:     event_uuid.String(),
@@ -428,6 +443,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 								CorrNumber:    data["corr_number"].(string),
 								CulcNumber:    data["culc_number"].(string),
 								ReserveReason: data["reserve_reason"].(string),
+								Currency:      currency,
 							},
 						},
 					}
@@ -437,7 +453,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicAccountsConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -448,6 +464,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 			case OperationOpenAcc,
 				OperationCreateAcc,
 				OperationCloseAccount,
+				OperationBlockAccount,
 				OperationRemoveAccount:
 				{
 					if !ValidateOperationsData(operation_name, data) {
@@ -474,7 +491,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicAccountsConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -508,7 +525,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicAccountsConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -527,6 +544,9 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						return ErrorInvalidServersTopic
 					}
 
+					// Withdrawal is counted in limits of user who made it
+					user_id, _ := data["user_id"].(string)
+
 					account_data := &accounts_api.EventData{
 						SagaUuid:      saga_uuid.String(),
 						EventUuid:     event_uuid.String(),
@@ -535,6 +555,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 							AdditionalInfo: &accounts_api.OperationDetails{
 								AccUuid:        data["acc_id"].(string),
 								AdditionalData: float32(data["cache_diff"].(float64)),
+								UserUuid:       user_id,
 							},
 						},
 					}
@@ -544,7 +565,95 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicAccountsConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
+					if err != nil {
+						h.regLog.Error(err)
+						return err
+					}
+					break
+				}
+			case OperationTransferAccountCache:
+				{
+
+					if !ValidateOperationsData(operation_name, data) {
+						return ErrorInvalidOperationsData
+					}
+
+					if !ValidateServerTopic(server, ServerTopicAccountsConsumer) {
+						return ErrorInvalidServersTopic
+					}
+
+					// Transfer is withdrawal of user from source account
+					user_id, _ := data["user_id"].(string)
+
+					account_data := &accounts_api.EventData{
+						SagaUuid:      saga_uuid.String(),
+						EventUuid:     event_uuid.String(),
+						OperationName: operation_name,
+						Data: &accounts_api.EventData_TransferDetails{
+							TransferDetails: &accounts_api.TransferDetails{
+								FromAccUuid: data["acc_id"].(string),
+								ToAccUuid:   data["to_acc_id"].(string),
+								Amount:      data["cache_diff"].(float64),
+								UserUuid:    user_id,
+							},
+						},
+					}
+
+					msg, err := proto.Marshal(account_data)
+					if err != nil {
+						h.regLog.Error(err)
+						return err
+					}
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
+					if err != nil {
+						h.regLog.Error(err)
+						return err
+					}
+					break
+				}
+			case OperationCreateAccountHold,
+				OperationCaptureAccountHold,
+				OperationReleaseAccountHold:
+				{
+
+					if !ValidateOperationsData(operation_name, data) {
+						return ErrorInvalidOperationsData
+					}
+
+					if !ValidateServerTopic(server, ServerTopicAccountsConsumer) {
+						return ErrorInvalidServersTopic
+					}
+
+					// Hold is created without hold_id, release doesn't need amount
+					hold_details := &accounts_api.HoldDetails{
+						AccUuid: data["acc_id"].(string),
+					}
+					if hold_id, ok := data["hold_id"].(string); ok {
+						hold_details.HoldUuid = hold_id
+					}
+					if hold_amount, ok := data["hold_amount"].(float64); ok {
+						hold_details.Amount = hold_amount
+					}
+					if expires_in, ok := data["hold_expires_in"].(float64); ok {
+						hold_details.ExpiresIn = uint64(expires_in)
+					}
+
+					account_data := &accounts_api.EventData{
+						SagaUuid:      saga_uuid.String(),
+						EventUuid:     event_uuid.String(),
+						OperationName: operation_name,
+						Data: &accounts_api.EventData_HoldDetails{
+							HoldDetails: hold_details,
+						},
+					}
+
+					msg, err := proto.Marshal(account_data)
+					if err != nil {
+						h.regLog.Error(err)
+						return err
+					}
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicAccountsConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -590,7 +699,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicNotificationConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicNotificationConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -623,7 +732,7 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 						h.regLog.Error(err)
 						return err
 					}
-					err = h.kProducer.ProduceRecord(ServerTopicNotificationConsumer, sarama.ByteEncoder(msg))
+					err = h.kProducer.ProduceRecord(ctxWithTrace, ServerTopicNotificationConsumer, msg)
 					if err != nil {
 						h.regLog.Error(err)
 						return err
@@ -643,6 +752,29 @@ func (h *GRPCRegistrationHandlers) SendRequest(ctx context.Context, server uint8
 	return nil
 }
 
-func NewRegistrationGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, registrationUC registration.UseCase, regLog logger.Logger) registration.RegistrationGRPCHandlers {
-	return &GRPCRegistrationHandlers{cfg: cfg, kProducer: kProducer, registrationUC: registrationUC, regLog: regLog}
+// stringValue returns optional string of operation data, empty for missing one
+func stringValue(value interface{}) string {
+	str, _ := value.(string)
+	return str
+}
+
+// stringList converts list from operation data, it's []interface{} after saga data is stored
+func stringList(value interface{}) []string {
+	switch list := value.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func NewRegistrationGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, rmqChan *amqp.Channel, rmqQueue amqp.Queue, registrationUC registration.UseCase, regLog logger.Logger) registration.RegistrationGRPCHandlers {
+	return &GRPCRegistrationHandlers{cfg: cfg, kProducer: kProducer, rmqChan: rmqChan, rmqQueue: rmqQueue, registrationUC: registrationUC, regLog: regLog}
 }
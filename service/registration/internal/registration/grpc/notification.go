@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers of rmq message read by notification service
+const (
+	HeaderUserId   string = "user_id"
+	HeaderCategory string = "category"
+	HeaderTemplate string = "template"
+)
+
+// sendEventNotification publishes notification about completed event, message is rendered by notification service.
+// Saga doesn't depend on notification, so errors are only logged.
+func (h *GRPCRegistrationHandlers) sendEventNotification(ctx context.Context, event_uuid uuid.UUID) {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "GRPCRegistrationHandlers.sendEventNotification")
+	defer span.End()
+
+	notification, err := h.registrationUC.GetEventNotification(ctxWithTrace, event_uuid)
+	if err != nil {
+		h.regLog.Error(err)
+		return
+	}
+	if notification == nil {
+		return
+	}
+
+	headers := make(amqp.Table)
+	headers[HeaderUserId] = notification.UserId.String()
+	headers[HeaderCategory] = notification.Category
+	headers[HeaderTemplate] = notification.Template
+
+	body, err := json.Marshal(notification.Data)
+	if err != nil {
+		h.regLog.Error(err)
+		return
+	}
+
+	// Message id is event uuid, so notification service skips redelivered event result
+	err = h.rmqChan.PublishWithContext(ctxWithTrace,
+		"",              // exchange
+		h.rmqQueue.Name, // routing key
+		false,           // mandatory
+		false,           // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    notification.MessageId.String(),
+			Body:         body,
+			Headers:      headers,
+		})
+	if err != nil {
+		h.regLog.Error(err)
+		return
+	}
+
+	h.regLog.Debug("Notification sent: ", notification.Template, "|", event_uuid)
+}
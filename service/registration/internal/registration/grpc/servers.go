@@ -89,6 +89,11 @@ const (
 	OperationCreateUserNotificationSettings string = "add_user_notification_settings"
 	OperationDeleteUserNotificationSettings string = "remove_user_notification_settings"
 	OperationDeleteUser                     string = "remove_user"
+	OperationCreateAccountHold              string = "create_hold"
+	OperationCaptureAccountHold             string = "capture_hold"
+	OperationReleaseAccountHold             string = "release_hold"
+	OperationTransferAccountCache           string = "transfer_acc"
+	OperationBlockAccount                   string = "block_acc"
 )
 
 var PossibleServersOperations = map[uint8][]string{
@@ -111,6 +116,11 @@ var PossibleServersOperations = map[uint8][]string{
 		OperationWidthAccountCache,
 		OperationCloseAccount,
 		OperationRemoveAccount,
+		OperationCreateAccountHold,
+		OperationCaptureAccountHold,
+		OperationReleaseAccountHold,
+		OperationTransferAccountCache,
+		OperationBlockAccount,
 	},
 	ServerTypeNotification: {
 		OperationCreateUserNotificationSettings,
@@ -162,6 +172,8 @@ var RequiredOperationsFields = map[string][]string{
 		"authority",
 		"authority_date",
 		"registration_adress",
+		"passport_files",
+		"user_uuid",
 		"login",
 		"password",
 	},
@@ -200,6 +212,28 @@ var RequiredOperationsFields = map[string][]string{
 	OperationCloseAccount: {
 		"acc_id",
 	},
+	OperationBlockAccount: {
+		"acc_id",
+	},
+	OperationCreateAccountHold: {
+		"acc_id",
+		"hold_amount",
+		"hold_expires_in",
+	},
+	OperationCaptureAccountHold: {
+		"acc_id",
+		"hold_id",
+		"hold_amount",
+	},
+	OperationReleaseAccountHold: {
+		"acc_id",
+		"hold_id",
+	},
+	OperationTransferAccountCache: {
+		"acc_id",
+		"to_acc_id",
+		"cache_diff",
+	},
 	OperationRemoveAccount: {
 		"acc_id",
 	},
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/GCFactory/dbo-system/service/registration/internal/models"
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,20 @@ func (mr *MockRepositoryMockRecorder) CreateEvent(ctx, event interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockRepository)(nil).CreateEvent), ctx, event)
 }
 
+// CreateOperation mocks base method.
+func (m *MockRepository) CreateOperation(ctx context.Context, operation *models.Operation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperation", ctx, operation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOperation indicates an expected call of CreateOperation.
+func (mr *MockRepositoryMockRecorder) CreateOperation(ctx, operation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperation", reflect.TypeOf((*MockRepository)(nil).CreateOperation), ctx, operation)
+}
+
 // CreateSaga mocks base method.
 func (m *MockRepository) CreateSaga(ctx context.Context, saga *models.Saga) error {
 	m.ctrl.T.Helper()
@@ -92,6 +107,20 @@ func (mr *MockRepositoryMockRecorder) DeleteEvent(ctx, event_uuid interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockRepository)(nil).DeleteEvent), ctx, event_uuid)
 }
 
+// DeleteOperation mocks base method.
+func (m *MockRepository) DeleteOperation(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOperation", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOperation indicates an expected call of DeleteOperation.
+func (mr *MockRepositoryMockRecorder) DeleteOperation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOperation", reflect.TypeOf((*MockRepository)(nil).DeleteOperation), ctx, id)
+}
+
 // DeleteSaga mocks base method.
 func (m *MockRepository) DeleteSaga(ctx context.Context, saga_uuid uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -150,6 +179,66 @@ func (mr *MockRepositoryMockRecorder) GetListOfSagaEvents(ctx, saga_uuid interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListOfSagaEvents", reflect.TypeOf((*MockRepository)(nil).GetListOfSagaEvents), ctx, saga_uuid)
 }
 
+// GetOperation mocks base method.
+func (m *MockRepository) GetOperation(ctx context.Context, id uuid.UUID) (*models.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperation", ctx, id)
+	ret0, _ := ret[0].(*models.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperation indicates an expected call of GetOperation.
+func (mr *MockRepositoryMockRecorder) GetOperation(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperation", reflect.TypeOf((*MockRepository)(nil).GetOperation), ctx, id)
+}
+
+// GetOperationBetweenInterval mocks base method.
+func (m *MockRepository) GetOperationBetweenInterval(ctx context.Context, begin, end time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationBetweenInterval", ctx, begin, end)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationBetweenInterval indicates an expected call of GetOperationBetweenInterval.
+func (mr *MockRepositoryMockRecorder) GetOperationBetweenInterval(ctx, begin, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationBetweenInterval", reflect.TypeOf((*MockRepository)(nil).GetOperationBetweenInterval), ctx, begin, end)
+}
+
+// GetOperationSaga mocks base method.
+func (m *MockRepository) GetOperationSaga(ctx context.Context, operation_uuid uuid.UUID) (*models.ListOfSaga, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationSaga", ctx, operation_uuid)
+	ret0, _ := ret[0].(*models.ListOfSaga)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationSaga indicates an expected call of GetOperationSaga.
+func (mr *MockRepositoryMockRecorder) GetOperationSaga(ctx, operation_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationSaga", reflect.TypeOf((*MockRepository)(nil).GetOperationSaga), ctx, operation_uuid)
+}
+
+// GetRevertEvent mocks base method.
+func (m *MockRepository) GetRevertEvent(ctx context.Context, event_uuid uuid.UUID) (*models.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevertEvent", ctx, event_uuid)
+	ret0, _ := ret[0].(*models.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevertEvent indicates an expected call of GetRevertEvent.
+func (mr *MockRepositoryMockRecorder) GetRevertEvent(ctx, event_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevertEvent", reflect.TypeOf((*MockRepository)(nil).GetRevertEvent), ctx, event_uuid)
+}
+
 // GetSaga mocks base method.
 func (m *MockRepository) GetSaga(ctx context.Context, id uuid.UUID) (*models.Saga, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +298,20 @@ func (mr *MockRepositoryMockRecorder) UpdateEvent(ctx, event interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockRepository)(nil).UpdateEvent), ctx, event)
 }
 
+// UpdateOperation mocks base method.
+func (m *MockRepository) UpdateOperation(ctx context.Context, operation *models.Operation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOperation", ctx, operation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOperation indicates an expected call of UpdateOperation.
+func (mr *MockRepositoryMockRecorder) UpdateOperation(ctx, operation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOperation", reflect.TypeOf((*MockRepository)(nil).UpdateOperation), ctx, operation)
+}
+
 // UpdateSaga mocks base method.
 func (m *MockRepository) UpdateSaga(ctx context.Context, saga *models.Saga) error {
 	m.ctrl.T.Helper()
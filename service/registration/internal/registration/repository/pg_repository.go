@@ -2,11 +2,11 @@ package repository
 
 import (
 	"encoding/json"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/registration/internal/models"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/opentracing/opentracing-go"
 	"golang.org/x/net/context"
 	"time"
 )
@@ -17,8 +17,8 @@ type registrationRepo struct {
 
 func (repo registrationRepo) CreateOperation(ctx context.Context, operation *models.Operation) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.CreateOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.CreateOperation")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -47,8 +47,8 @@ func (repo registrationRepo) CreateOperation(ctx context.Context, operation *mod
 
 func (repo registrationRepo) GetOperation(ctx context.Context, id uuid.UUID) (*models.Operation, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetOperation")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -74,8 +74,8 @@ func (repo registrationRepo) GetOperation(ctx context.Context, id uuid.UUID) (*m
 
 func (repo registrationRepo) UpdateOperation(ctx context.Context, operation *models.Operation) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.UpdateOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.UpdateOperation")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -105,8 +105,8 @@ func (repo registrationRepo) UpdateOperation(ctx context.Context, operation *mod
 
 func (repo registrationRepo) DeleteOperation(ctx context.Context, id uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.DeleteOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.DeleteOperation")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -135,8 +135,8 @@ func (repo registrationRepo) DeleteOperation(ctx context.Context, id uuid.UUID)
 
 func (repo registrationRepo) CreateSaga(ctx context.Context, saga *models.Saga) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.CreateSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.CreateSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -171,8 +171,8 @@ func (repo registrationRepo) CreateSaga(ctx context.Context, saga *models.Saga)
 }
 
 func (repo registrationRepo) DeleteSaga(ctx context.Context, saga_uuid uuid.UUID) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.DeleteSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.DeleteSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -200,8 +200,8 @@ func (repo registrationRepo) DeleteSaga(ctx context.Context, saga_uuid uuid.UUID
 }
 
 func (repo registrationRepo) GetSaga(ctx context.Context, id uuid.UUID) (*models.Saga, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -241,8 +241,8 @@ func (repo registrationRepo) GetSaga(ctx context.Context, id uuid.UUID) (*models
 }
 
 func (repo registrationRepo) UpdateSaga(ctx context.Context, saga *models.Saga) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.UpdateSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.UpdateSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -280,8 +280,8 @@ func (repo registrationRepo) UpdateSaga(ctx context.Context, saga *models.Saga)
 
 func (repo registrationRepo) CreateSagaConnection(ctx context.Context, sagaConnection *models.SagaConnection) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.CreateSagaConnection")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.CreateSagaConnection")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -310,8 +310,8 @@ func (repo registrationRepo) CreateSagaConnection(ctx context.Context, sagaConne
 
 func (repo registrationRepo) GetSagaConnectionsCurrentSaga(ctx context.Context, current_saga_uuid uuid.UUID) (*models.ListOfSagaConnections, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetSagaConnectionsCurrentSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetSagaConnectionsCurrentSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -353,8 +353,8 @@ func (repo registrationRepo) GetSagaConnectionsCurrentSaga(ctx context.Context,
 
 func (repo registrationRepo) GetSagaConnectionsNextSaga(ctx context.Context, next_saga_uuid uuid.UUID) (*models.ListOfSagaConnections, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetSagaConnectionsNextSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetSagaConnectionsNextSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -396,8 +396,8 @@ func (repo registrationRepo) GetSagaConnectionsNextSaga(ctx context.Context, nex
 
 func (repo registrationRepo) DeleteSagaConnection(ctx context.Context, sagaConnection *models.SagaConnection) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.DeleteSagaConnection")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.DeleteSagaConnection")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -426,8 +426,8 @@ func (repo registrationRepo) DeleteSagaConnection(ctx context.Context, sagaConne
 
 func (repo registrationRepo) UpdateSagaConnection(ctx context.Context, sagaConnection *models.SagaConnection) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.UpdateSagaConnection")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.UpdateSagaConnection")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -459,8 +459,8 @@ func (repo registrationRepo) UpdateSagaConnection(ctx context.Context, sagaConne
 
 func (repo registrationRepo) CreateEvent(ctx context.Context, event *models.Event) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.CreateEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.CreateEvent")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -497,8 +497,8 @@ func (repo registrationRepo) CreateEvent(ctx context.Context, event *models.Even
 
 func (repo registrationRepo) GetEvent(ctx context.Context, id uuid.UUID) (*models.Event, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetEvent")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -540,8 +540,8 @@ func (repo registrationRepo) GetEvent(ctx context.Context, id uuid.UUID) (*model
 
 func (repo registrationRepo) DeleteEvent(ctx context.Context, event_uuid uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.DeleteEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.DeleteEvent")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -569,8 +569,8 @@ func (repo registrationRepo) DeleteEvent(ctx context.Context, event_uuid uuid.UU
 
 func (repo registrationRepo) UpdateEvent(ctx context.Context, event *models.Event) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.UpdateEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.UpdateEvent")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -608,8 +608,8 @@ func (repo registrationRepo) UpdateEvent(ctx context.Context, event *models.Even
 
 func (repo registrationRepo) GetListOfSagaEvents(ctx context.Context, saga_uuid uuid.UUID) (*models.SagaListEvents, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetListOfSagaEvents")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetListOfSagaEvents")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -649,8 +649,8 @@ func (repo registrationRepo) GetListOfSagaEvents(ctx context.Context, saga_uuid
 
 func (repo registrationRepo) GetRevertEvent(ctx context.Context, event_uuid uuid.UUID) (*models.Event, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetRevertEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetRevertEvent")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -692,8 +692,8 @@ func (repo registrationRepo) GetRevertEvent(ctx context.Context, event_uuid uuid
 
 func (repo registrationRepo) GetOperationSaga(ctx context.Context, operation_uuid uuid.UUID) (*models.ListOfSaga, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetOperationSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetOperationSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -733,8 +733,8 @@ func (repo registrationRepo) GetOperationSaga(ctx context.Context, operation_uui
 
 func (repo registrationRepo) GetOperationBetweenInterval(ctx context.Context, begin time.Time, end time.Time) ([]uuid.UUID, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationRepo.GetOperationSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationRepo.GetOperationSaga")
+	defer span.End()
 
 	tx, err := repo.db.BeginTx(ctxWithTrace, nil)
 	if err != nil {
@@ -742,7 +742,7 @@ func (repo registrationRepo) GetOperationBetweenInterval(ctx context.Context, be
 	}
 
 	defer tx.Rollback()
-	
+
 	rows, err := repo.db.QueryxContext(
 		ctxWithTrace,
 		GetListOperationsBetweenInterval,
@@ -0,0 +1,105 @@
+package test
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/service/registration/internal/models"
+	"github.com/GCFactory/dbo-system/service/registration/internal/registration/mock"
+	"github.com/GCFactory/dbo-system/service/registration/internal/registration/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRegistrationUC_SagaMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	metrics := metric.NewBusinessMetrics("registration_test")
+	regUC := usecase.NewRegistrationUseCase(testRegCfgUC, mockRepo, apiLogger, metrics)
+
+	operation := &models.Operation{
+		Operation_uuid: uuid.New(),
+		Operation_name: usecase.SagaTypeBlockAccount,
+		Create_time:    time.Now().Add(-3 * time.Second),
+	}
+	mockRepo.EXPECT().GetOperation(gomock.Any(), gomock.Eq(operation.Operation_uuid)).Return(operation, nil).AnyTimes()
+	mockRepo.EXPECT().UpdateOperation(gomock.Any(), gomock.Eq(operation)).Return(nil).AnyTimes()
+
+	t.Run("Event error", func(t *testing.T) {
+		saga := &models.Saga{
+			Saga_uuid:      uuid.New(),
+			Saga_status:    usecase.SagaStatusInProcess,
+			Saga_type:      usecase.SagaGroupCreateUser,
+			Saga_name:      usecase.SagaTypeCreateUser,
+			Operation_uuid: operation.Operation_uuid,
+		}
+		event := &models.Event{
+			Event_uuid:   uuid.New(),
+			Saga_uuid:    saga.Saga_uuid,
+			Event_status: usecase.EventStatusInProgress,
+			Event_name:   usecase.EventTypeCreateUser,
+		}
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().UpdateEvent(gomock.Any(), gomock.Eq(event)).Return(nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(saga.Saga_uuid)).Return(saga, nil)
+
+		_, err := regUC.ProcessingSagaAndEvents(context.Background(), uuid.Nil, event.Event_uuid, false, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, float64(1), testutil.ToFloat64(metrics.Events.WithLabelValues(usecase.EventTypeCreateUser, "error")))
+		// Saga is not finished by event
+		require.Equal(t, 0, testutil.CollectAndCount(metrics.Sagas))
+	})
+
+	t.Run("Saga error", func(t *testing.T) {
+		saga := &models.Saga{
+			Saga_uuid:      uuid.New(),
+			Saga_status:    usecase.SagaStatusInProcess,
+			Saga_type:      usecase.SagaGroupBlockAccount,
+			Saga_name:      usecase.SagaTypeBlockAccount,
+			Saga_data:      map[string]interface{}{"acc_id": uuid.New().String(), "acc_status": float64(40)},
+			Operation_uuid: operation.Operation_uuid,
+		}
+		event := &models.Event{
+			Event_uuid:          uuid.New(),
+			Saga_uuid:           saga.Saga_uuid,
+			Event_status:        usecase.EventStatusCreated,
+			Event_name:          usecase.EventTypeBlockAccount,
+			Event_required_data: []string{"acc_id"},
+		}
+
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(saga.Saga_uuid)).Return(saga, nil).AnyTimes()
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil).Times(4)
+		mockRepo.EXPECT().UpdateEvent(gomock.Any(), gomock.Eq(event)).Return(nil)
+		mockRepo.EXPECT().GetListOfSagaEvents(gomock.Any(), gomock.Eq(saga.Saga_uuid)).
+			Return(&models.SagaListEvents{EventList: []uuid.UUID{event.Event_uuid}}, nil).Times(2)
+		mockRepo.EXPECT().UpdateSaga(gomock.Any(), gomock.Eq(saga)).Return(nil)
+		mockRepo.EXPECT().GetSagaConnectionsNextSaga(gomock.Any(), gomock.Eq(saga.Saga_uuid)).Return(&models.ListOfSagaConnections{}, nil)
+
+		_, err := regUC.ProcessingSagaAndEvents(context.Background(), uuid.Nil, event.Event_uuid, true, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, float64(1), testutil.ToFloat64(metrics.Events.WithLabelValues(usecase.EventTypeBlockAccount, "error")))
+		require.Equal(t, float64(1), testutil.ToFloat64(metrics.Sagas.WithLabelValues(usecase.SagaTypeBlockAccount, "error")))
+
+		// Duration is counted from operation start
+		var m dto.Metric
+		histogram := metrics.SagaDuration.WithLabelValues(usecase.SagaTypeBlockAccount, "error").(prometheus.Histogram)
+		require.NoError(t, histogram.Write(&m))
+		require.Equal(t, uint64(1), m.GetHistogram().GetSampleCount())
+		require.GreaterOrEqual(t, m.GetHistogram().GetSampleSum(), float64(3))
+		require.Less(t, m.GetHistogram().GetSampleSum(), float64(10))
+	})
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/service/registration/internal/models"
+	"github.com/GCFactory/dbo-system/service/registration/internal/registration/mock"
+	"github.com/GCFactory/dbo-system/service/registration/internal/registration/usecase"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRegistrationUC_GetEventNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(testCfgUC)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	regUC := usecase.NewRegistrationUseCase(testRegCfgUC, mockRepo, apiLogger, testSagaMetrics{})
+
+	user_id := uuid.New()
+	acc_id := uuid.New()
+
+	newEvent := func(event_name string, status uint8, result string) *models.Event {
+		return &models.Event{
+			Event_uuid:   uuid.New(),
+			Saga_uuid:    uuid.New(),
+			Event_status: status,
+			Event_name:   event_name,
+			Event_result: result,
+		}
+	}
+	newSaga := func(event *models.Event, data map[string]interface{}) *models.Saga {
+		return &models.Saga{
+			Saga_uuid:      event.Saga_uuid,
+			Saga_data:      data,
+			Operation_uuid: uuid.New(),
+		}
+	}
+
+	t.Run("Completed deposit", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeAddAccountCache, usecase.EventStatusCompleted, `{"acc_name":"Main","acc_cache":150.5}`)
+		saga := newSaga(event, map[string]interface{}{
+			"user_id":    user_id.String(),
+			"acc_id":     acc_id.String(),
+			"cache_diff": 50.5,
+		})
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(event.Saga_uuid)).Return(saga, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.NoError(t, err)
+		require.NotNil(t, notification)
+		require.Equal(t, event.Event_uuid, notification.MessageId)
+		require.Equal(t, user_id, notification.UserId)
+		require.Equal(t, usecase.NotificationCategoryTransactions, notification.Category)
+		require.Equal(t, usecase.NotificationTemplateDeposit, notification.Template)
+
+		message, ok := notification.Data.(*models.TransactionMessage)
+		require.True(t, ok)
+		require.Equal(t, saga.Operation_uuid.String(), message.OperationId)
+		require.Equal(t, acc_id.String(), message.AccountId)
+		require.Equal(t, "Main", message.AccountName)
+		require.Equal(t, 50.5, message.Amount)
+		require.Equal(t, 150.5, message.BalanceAfter)
+	})
+
+	t.Run("Completed block without amount", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeBlockAccount, usecase.EventStatusCompleted, `{"acc_name":"Main","acc_cache":10}`)
+		saga := newSaga(event, map[string]interface{}{
+			"user_id": user_id.String(),
+			"acc_id":  acc_id.String(),
+		})
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(event.Saga_uuid)).Return(saga, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.NoError(t, err)
+		require.Equal(t, usecase.NotificationTemplateAccountBlocked, notification.Template)
+		require.Equal(t, float64(10), notification.Data.(*models.TransactionMessage).BalanceAfter)
+	})
+
+	t.Run("Error no amount", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeWidthAccountCache, usecase.EventStatusCompleted, `{"acc_name":"Main","acc_cache":10}`)
+		saga := newSaga(event, map[string]interface{}{
+			"user_id": user_id.String(),
+			"acc_id":  acc_id.String(),
+		})
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(event.Saga_uuid)).Return(saga, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.Nil(t, notification)
+		require.Equal(t, usecase.ErrorNoNotificationAmount, err)
+	})
+
+	t.Run("Error no balance", func(t *testing.T) {
+		// Account service answers "Success" when account state can't be read
+		event := newEvent(usecase.EventTypeWidthAccountCache, usecase.EventStatusCompleted, `{}`)
+		saga := newSaga(event, map[string]interface{}{
+			"user_id":    user_id.String(),
+			"acc_id":     acc_id.String(),
+			"cache_diff": 5.0,
+		})
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(event.Saga_uuid)).Return(saga, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.Nil(t, notification)
+		require.Equal(t, usecase.ErrorNoNotificationBalance, err)
+	})
+
+	t.Run("Rolled back", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeAddAccountCache, usecase.EventStatusCompleted, `{"acc_cache":10}`)
+		event.Event_is_roll_back = true
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.NoError(t, err)
+		require.Nil(t, notification)
+	})
+
+	t.Run("Not completed", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeAddAccountCache, usecase.EventStatusFallBackCompleted, `{"acc_cache":10}`)
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.NoError(t, err)
+		require.Nil(t, notification)
+	})
+
+	t.Run("Unmapped event", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeGetAccountData, usecase.EventStatusCompleted, `{}`)
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.NoError(t, err)
+		require.Nil(t, notification)
+	})
+
+	t.Run("Error no user", func(t *testing.T) {
+		event := newEvent(usecase.EventTypeCloseAccount, usecase.EventStatusCompleted, `{"acc_cache":0}`)
+		saga := newSaga(event, map[string]interface{}{
+			"acc_id": acc_id.String(),
+		})
+
+		mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(event.Event_uuid)).Return(event, nil)
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(event.Saga_uuid)).Return(saga, nil)
+
+		notification, err := regUC.GetEventNotification(context.Background(), event.Event_uuid)
+		require.Nil(t, notification)
+		require.Equal(t, usecase.ErrorNoNotificationUser, err)
+	})
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/GCFactory/dbo-system/platform/config"
@@ -35,12 +36,17 @@ func TestRepository_CreateSaga(t *testing.T) {
 	regRepo := repository.NewRegistrationRepository(sqlxDB)
 
 	saga := &models.Saga{
-		Saga_uuid:   uuid.New(),
-		Saga_name:   "test_name",
-		Saga_status: 0,
-		Saga_type:   1,
+		Saga_uuid:      uuid.New(),
+		Saga_name:      "test_name",
+		Saga_status:    0,
+		Saga_type:      1,
+		Saga_data:      map[string]interface{}{"user_id": "test"},
+		Operation_uuid: uuid.New(),
 	}
 
+	sagaData, err := json.Marshal(saga.Saga_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
@@ -50,6 +56,8 @@ func TestRepository_CreateSaga(t *testing.T) {
 				&saga.Saga_status,
 				&saga.Saga_type,
 				&saga.Saga_name,
+				sagaData,
+				&saga.Operation_uuid,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -70,6 +78,8 @@ func TestRepository_CreateSaga(t *testing.T) {
 			&saga.Saga_status,
 			&saga.Saga_type,
 			&saga.Saga_name,
+			sagaData,
+			&saga.Operation_uuid,
 		).WillReturnError(errors.New("test error"))
 
 		mock.ExpectRollback()
@@ -95,10 +105,12 @@ func TestRepository_DeleteSaga(t *testing.T) {
 	regRepo := repository.NewRegistrationRepository(sqlxDB)
 
 	saga := &models.Saga{
-		Saga_uuid:   uuid.New(),
-		Saga_name:   "test_name",
-		Saga_status: 0,
-		Saga_type:   1,
+		Saga_uuid:      uuid.New(),
+		Saga_name:      "test_name",
+		Saga_status:    0,
+		Saga_type:      1,
+		Saga_data:      map[string]interface{}{"user_id": "test"},
+		Operation_uuid: uuid.New(),
 	}
 
 	t.Run("Success", func(t *testing.T) {
@@ -167,21 +179,28 @@ func TestRepository_GetSaga(t *testing.T) {
 	regRepo := repository.NewRegistrationRepository(sqlxDB)
 
 	saga := &models.Saga{
-		Saga_uuid:   uuid.New(),
-		Saga_name:   "test_name",
-		Saga_status: 0,
-		Saga_type:   1,
+		Saga_uuid:      uuid.New(),
+		Saga_name:      "test_name",
+		Saga_status:    0,
+		Saga_type:      1,
+		Saga_data:      map[string]interface{}{"user_id": "test"},
+		Operation_uuid: uuid.New(),
 	}
 
+	sagaData, err := json.Marshal(saga.Saga_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
 
-		rows := mock.NewRows([]string{"saga_uuid", "saga_status", "saga_type", "saga_name"}).AddRow(
+		rows := mock.NewRows([]string{"saga_uuid", "saga_status", "saga_type", "saga_name", "saga_data", "operation_uuid"}).AddRow(
 			&saga.Saga_uuid,
 			&saga.Saga_status,
 			&saga.Saga_type,
 			saga.Saga_name,
+			sagaData,
+			&saga.Operation_uuid,
 		)
 
 		mock.ExpectQuery(repository.GetSaga).
@@ -231,12 +250,17 @@ func TestRepository_UpdateSaga(t *testing.T) {
 	regRepo := repository.NewRegistrationRepository(sqlxDB)
 
 	saga := &models.Saga{
-		Saga_uuid:   uuid.New(),
-		Saga_name:   "test_name",
-		Saga_status: 0,
-		Saga_type:   1,
+		Saga_uuid:      uuid.New(),
+		Saga_name:      "test_name",
+		Saga_status:    0,
+		Saga_type:      1,
+		Saga_data:      map[string]interface{}{"user_id": "test"},
+		Operation_uuid: uuid.New(),
 	}
 
+	sagaData, err := json.Marshal(saga.Saga_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
@@ -247,6 +271,7 @@ func TestRepository_UpdateSaga(t *testing.T) {
 				&saga.Saga_status,
 				&saga.Saga_type,
 				&saga.Saga_name,
+				sagaData,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -664,9 +689,13 @@ func TestRepository_CreateEvent(t *testing.T) {
 		Event_name:          "test_name",
 		Event_is_roll_back:  false,
 		Event_result:        "{}",
+		Event_required_data: []string{"user_id"},
 		Event_rollback_uuid: uuid.Nil,
 	}
 
+	eventData, err := json.Marshal(event.Event_required_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
@@ -678,6 +707,7 @@ func TestRepository_CreateEvent(t *testing.T) {
 				&event.Event_status,
 				&event.Event_name,
 				&event.Event_is_roll_back,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -703,6 +733,7 @@ func TestRepository_CreateEvent(t *testing.T) {
 				&event.Event_status,
 				&event.Event_name,
 				&event.Event_is_roll_back,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			).WillReturnError(errors.New("test error"))
@@ -736,20 +767,25 @@ func TestRepository_GetEvent(t *testing.T) {
 		Event_name:          "test_name",
 		Event_is_roll_back:  false,
 		Event_result:        "{}",
+		Event_required_data: []string{"user_id"},
 		Event_rollback_uuid: uuid.Nil,
 	}
 
+	eventData, err := json.Marshal(event.Event_required_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
 
-		rows := mock.NewRows([]string{"event_uuid", "saga_uuid", "event_status", "event_name", "event_is_roll_back", "event_result", "event_rollback_uuid"}).
+		rows := mock.NewRows([]string{"event_uuid", "saga_uuid", "event_status", "event_name", "event_is_roll_back", "event_required_data", "event_result", "event_rollback_uuid"}).
 			AddRow(
 				&event.Event_uuid,
 				&event.Saga_uuid,
 				&event.Event_status,
 				&event.Event_name,
 				&event.Event_is_roll_back,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			)
@@ -809,6 +845,7 @@ func TestRepository_DeleteEvent(t *testing.T) {
 		Event_name:          "test_name",
 		Event_is_roll_back:  false,
 		Event_result:        "{}",
+		Event_required_data: []string{"user_id"},
 		Event_rollback_uuid: uuid.Nil,
 	}
 
@@ -888,9 +925,13 @@ func TestRepository_UpdateEvent(t *testing.T) {
 		Event_name:          "test_name",
 		Event_is_roll_back:  false,
 		Event_result:        "{}",
+		Event_required_data: []string{"user_id"},
 		Event_rollback_uuid: uuid.Nil,
 	}
 
+	eventData, err := json.Marshal(event.Event_required_data)
+	require.NoError(t, err)
+
 	t.Run("Success", func(t *testing.T) {
 
 		mock.ExpectBegin()
@@ -899,6 +940,7 @@ func TestRepository_UpdateEvent(t *testing.T) {
 			WithArgs(
 				&event.Event_uuid,
 				&event.Event_status,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -921,6 +963,7 @@ func TestRepository_UpdateEvent(t *testing.T) {
 			WithArgs(
 				&event.Event_uuid,
 				&event.Event_status,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			).WillReturnError(errors.New("test error"))
@@ -943,6 +986,7 @@ func TestRepository_UpdateEvent(t *testing.T) {
 			WithArgs(
 				&event.Event_uuid,
 				&event.Event_status,
+				eventData,
 				&event.Event_result,
 				&event.Event_rollback_uuid,
 			).WillReturnResult(sqlmock.NewResult(0, 0))
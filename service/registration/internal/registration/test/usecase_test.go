@@ -4,14 +4,16 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	regConfig "github.com/GCFactory/dbo-system/service/registration/config"
 	"github.com/GCFactory/dbo-system/service/registration/internal/models"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/mock"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 var (
@@ -22,8 +24,20 @@ var (
 			Level:       "Debug",
 		},
 	}
+	testRegCfgUC = &regConfig.Config{
+		Env:    testCfgUC.Env,
+		Logger: testCfgUC.Logger,
+	}
 )
 
+// testSagaMetrics drops saga metrics, they aren't checked by usecase tests
+type testSagaMetrics struct{}
+
+func (testSagaMetrics) IncSaga(sagaType, status string)                              {}
+func (testSagaMetrics) ObserveSagaDuration(sagaType, status string, seconds float64) {}
+func (testSagaMetrics) IncEvent(eventType, status string)                            {}
+func (testSagaMetrics) IncCompensation(sagaType, eventType string)                   {}
+
 func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -32,35 +46,56 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	regUC := usecase.NewRegistrationUseCase(testCfgUC, mockRepo, apiLogger)
+	regUC := usecase.NewRegistrationUseCase(testRegCfgUC, mockRepo, apiLogger, testSagaMetrics{})
+
+	test_saga := &models.Saga{
+		Saga_uuid:      uuid.New(),
+		Saga_status:    usecase.SagaStatusInProcess,
+		Saga_type:      usecase.SagaGroupCreateUser,
+		Saga_name:      usecase.SagaTypeCreateUser,
+		Saga_data:      map[string]interface{}{"user_id": uuid.New().String()},
+		Operation_uuid: uuid.New(),
+	}
+
+	test_operation := &models.Operation{
+		Operation_uuid: test_saga.Operation_uuid,
+		Operation_name: usecase.SagaTypeCreateUser,
+		Create_time:    time.Now(),
+	}
 
 	test_event := &models.Event{
 		Event_uuid:          uuid.New(),
-		Saga_uuid:           uuid.Nil,
+		Saga_uuid:           test_saga.Saga_uuid,
 		Event_status:        usecase.EventStatusUndefined,
 		Event_is_roll_back:  false,
 		Event_rollback_uuid: uuid.Nil,
 		Event_name:          usecase.EventTypeCreateUser,
 		Event_result:        "",
+		Event_required_data: []string{"user_id"},
 	}
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.ProcessingSagaAndEvents")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.ProcessingSagaAndEvents")
+	defer span.End()
 
-	//span, ctxWithTrace_2 := opentracing.StartSpanFromContext(ctxWithTrace, "registrationUC.ProcessingSagaAndEvents")
-	//defer span.Finish()
+	//ctxWithTrace_2, span := tracing.StartSpan(ctxWithTrace, "registrationUC.ProcessingSagaAndEvents")
+	//defer span.End()
 
 	t.Parallel()
 
 	t.Run("Events", func(t *testing.T) {
 
+		// Every processed event updates last edit time of saga's operation
+		mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(test_saga.Saga_uuid)).Return(test_saga, nil).AnyTimes()
+		mockRepo.EXPECT().GetOperation(gomock.Any(), gomock.Eq(test_operation.Operation_uuid)).Return(test_operation, nil).AnyTimes()
+		mockRepo.EXPECT().UpdateOperation(gomock.Any(), gomock.Eq(test_operation)).Return(nil).AnyTimes()
+
 		t.Run("Event wrong status", func(t *testing.T) {
 
 			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, result)
 			require.Error(t, err)
 			require.Equal(t, err, usecase.ErrorInvalidEventStatus)
@@ -72,13 +107,14 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 			old_status := test_event.Event_status
 			test_event.Event_status = usecase.EventStatusCreated
 
-			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
+			// Second call checks that saga has all event's required data
+			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil).Times(2)
 
 			test_event.Event_status = usecase.EventStatusInProgress
 			mockRepo.EXPECT().UpdateEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event)).Return(nil)
 
 			test_event.Event_status = usecase.EventStatusCreated
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, err)
 			require.Equal(t, []*models.Event{test_event}, result)
 
@@ -94,9 +130,11 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 				mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 				test_event.Event_status = usecase.EventStatusCompleted
 				mockRepo.EXPECT().UpdateEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event)).Return(nil)
+				mockRepo.EXPECT().GetSaga(gomock.Eq(ctxWithTrace), gomock.Eq(uuid.Nil)).Return(test_saga, nil)
+				mockRepo.EXPECT().UpdateSaga(gomock.Eq(ctxWithTrace), gomock.Eq(test_saga)).Return(nil)
 
 				test_event.Event_status = usecase.EventStatusInProgress
-				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 				require.Nil(t, err)
 				require.Nil(t, result)
 
@@ -112,7 +150,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 				mockRepo.EXPECT().UpdateEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event)).Return(nil)
 
 				test_event.Event_status = usecase.EventStatusInProgress
-				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, false)
+				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, false, nil)
 				require.Nil(t, err)
 				require.Nil(t, result)
 
@@ -127,7 +165,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 
 			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, err)
 			require.Nil(t, result)
 
@@ -145,7 +183,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 				mockRepo.EXPECT().UpdateEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event)).Return(nil)
 
 				test_event.Event_status = usecase.EventStatusFallBackInProcess
-				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 				require.Nil(t, err)
 				require.Nil(t, result)
 
@@ -161,7 +199,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 				mockRepo.EXPECT().UpdateEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event)).Return(nil)
 
 				test_event.Event_status = usecase.EventStatusFallBackInProcess
-				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, false)
+				result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, false, nil)
 				require.Nil(t, err)
 				require.Nil(t, result)
 
@@ -176,7 +214,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 
 			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, err)
 			require.Nil(t, result)
 
@@ -191,7 +229,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 
 			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, err)
 			require.Nil(t, result)
 
@@ -206,13 +244,53 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 
 			mockRepo.EXPECT().GetEvent(gomock.Eq(ctxWithTrace), gomock.Eq(test_event.Event_uuid)).Return(test_event, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, test_event.Event_uuid, true, nil)
 			require.Nil(t, err)
 			require.Nil(t, result)
 
 			test_event.Event_status = old_status
 
 		})
+
+		t.Run("Block not opened account", func(t *testing.T) {
+
+			block_saga := &models.Saga{
+				Saga_uuid:      uuid.New(),
+				Saga_status:    usecase.SagaStatusInProcess,
+				Saga_type:      usecase.SagaGroupBlockAccount,
+				Saga_name:      usecase.SagaTypeBlockAccount,
+				Saga_data:      map[string]interface{}{"acc_id": uuid.New().String(), "acc_status": float64(40)},
+				Operation_uuid: test_operation.Operation_uuid,
+			}
+
+			block_event := &models.Event{
+				Event_uuid:          uuid.New(),
+				Saga_uuid:           block_saga.Saga_uuid,
+				Event_status:        usecase.EventStatusCreated,
+				Event_name:          usecase.EventTypeBlockAccount,
+				Event_rollback_uuid: uuid.Nil,
+				Event_required_data: []string{"acc_id"},
+			}
+
+			list_of_events := &models.SagaListEvents{
+				EventList: []uuid.UUID{block_event.Event_uuid},
+			}
+
+			// Failed validation moves saga to error, saga without connections is finished there
+			mockRepo.EXPECT().GetSaga(gomock.Any(), gomock.Eq(block_saga.Saga_uuid)).Return(block_saga, nil).AnyTimes()
+			mockRepo.EXPECT().GetEvent(gomock.Any(), gomock.Eq(block_event.Event_uuid)).Return(block_event, nil).Times(4)
+			mockRepo.EXPECT().UpdateEvent(gomock.Any(), gomock.Eq(block_event)).Return(nil)
+			mockRepo.EXPECT().GetListOfSagaEvents(gomock.Any(), gomock.Eq(block_saga.Saga_uuid)).Return(list_of_events, nil).Times(2)
+			mockRepo.EXPECT().UpdateSaga(gomock.Any(), gomock.Eq(block_saga)).Return(nil)
+			mockRepo.EXPECT().GetSagaConnectionsNextSaga(gomock.Any(), gomock.Eq(block_saga.Saga_uuid)).Return(&models.ListOfSagaConnections{}, nil)
+
+			result, err := regUC.ProcessingSagaAndEvents(ctx, uuid.Nil, block_event.Event_uuid, true, nil)
+			require.Nil(t, err)
+			require.Nil(t, result)
+			require.Equal(t, usecase.EventStatusError, block_event.Event_status)
+			require.Contains(t, block_event.Event_result, usecase.ErrorCheckAccountIsOpen.Error())
+			require.Equal(t, usecase.SagaStatusError, block_saga.Saga_status)
+		})
 	})
 
 	t.Run("Sagas", func(t *testing.T) {
@@ -227,7 +305,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 			mockRepo.EXPECT().GetSaga(gomock.Eq(ctxWithTrace), gomock.Eq(saga.Saga_uuid)).Return(saga, nil)
 			mockRepo.EXPECT().GetListOfSagaEvents(gomock.Eq(ctxWithTrace), gomock.Eq(saga.Saga_uuid)).Return(nil, nil)
 
-			result, err := regUC.ProcessingSagaAndEvents(ctx, saga.Saga_uuid, uuid.Nil, true)
+			result, err := regUC.ProcessingSagaAndEvents(ctx, saga.Saga_uuid, uuid.Nil, true, nil)
 			require.Nil(t, result)
 			require.Equal(t, usecase.ErrorInvalidSagaStatus, err)
 		})
@@ -286,7 +364,7 @@ func TestRegistrationUC_ProcessingSagaAndEvents(t *testing.T) {
 			//mockRepo.EXPECT().UpdateSaga(gomock.Eq(ctxWithTrace), gomock.Eq(saga)).Return(nil)
 			//
 			//saga.Saga_status = usecase.SagaStatusCreated
-			//result, err := regUC.ProcessingSagaAndEvents(ctx, saga.Saga_uuid, uuid.Nil, true)
+			//result, err := regUC.ProcessingSagaAndEvents(ctx, saga.Saga_uuid, uuid.Nil, true, nil)
 			//require.Nil(t, err)
 			//require.Equal(t, result, list_of_events)
 
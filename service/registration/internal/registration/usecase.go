@@ -16,4 +16,5 @@ type UseCase interface {
 	GetOperationResultData(ctx context.Context, operation_uuid uuid.UUID) (map[string]interface{}, error)
 	GetOperationTree(ctx context.Context, operation_uuid uuid.UUID) (map[string]interface{}, error)
 	GerOperationListBetween(ctx context.Context, begin time.Time, end time.Time) ([]uuid.UUID, error)
+	GetEventNotification(ctx context.Context, event_uuid uuid.UUID) (*models.Notification, error)
 }
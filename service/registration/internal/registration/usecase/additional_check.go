@@ -109,6 +109,46 @@ var ListOfAdditionalSagaEventsChecks = map[uint8]map[string][]string{
 			AdditionalCheckUserHasAccount,
 		},
 	},
+	SagaGroupCreateAccountHold: map[string][]string{
+		EventTypeGetAccountData: []string{
+			AdditionalCheckUserHasAccount,
+		},
+		EventTypeCreateAccountHold: []string{
+			AdditionalCheckAccountStatusIsOpen,
+		},
+	},
+	SagaGroupCaptureAccountHold: map[string][]string{
+		EventTypeGetAccountData: []string{
+			AdditionalCheckUserHasAccount,
+		},
+		EventTypeCaptureAccountHold: []string{
+			AdditionalCheckAccountStatusIsOpen,
+		},
+	},
+	SagaGroupReleaseAccountHold: map[string][]string{
+		EventTypeGetAccountData: []string{
+			AdditionalCheckUserHasAccount,
+		},
+		EventTypeReleaseAccountHold: []string{
+			AdditionalCheckAccountStatusIsOpen,
+		},
+	},
+	SagaGroupTransferAccountCache: map[string][]string{
+		EventTypeGetAccountData: []string{
+			AdditionalCheckUserHasAccount,
+		},
+		EventTypeTransferAccountCache: []string{
+			AdditionalCheckAccountStatusIsOpen,
+		},
+	},
+	SagaGroupBlockAccount: map[string][]string{
+		EventTypeGetAccountData: []string{
+			AdditionalCheckUserHasAccount,
+		},
+		EventTypeBlockAccount: []string{
+			AdditionalCheckAccountStatusIsOpen,
+		},
+	},
 }
 
 func AdditionalValidation(saga_group uint8, event_type string, data map[string]interface{}) (err error) {
@@ -28,5 +28,8 @@ var (
 	ErrorCheckAccountEmptyCache              = errors.New("Account's cache is not empty")
 	ErrorRevertErrorIsExists                 = errors.New("Revert event is exists")
 	ErrorNotAllChildReverted                 = errors.New("Not all children reverted!")
+	ErrorNoNotificationUser                  = errors.New("No user of notification into saga data")
+	ErrorNoNotificationAmount                = errors.New("No amount of notification into saga data")
+	ErrorNoNotificationBalance               = errors.New("No account balance of notification into event result")
 	ErrorNoOperationFound                    = errors.New("No operation found!")
 )
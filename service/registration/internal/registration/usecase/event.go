@@ -19,6 +19,11 @@ const (
 	EventTypeCreateUserNotificationSettings string = "add_user_notification_settings"
 	EventTypeDeleteUserNotificationSettings string = "remove_user_notification_settings"
 	EventTypeRemoveUser                     string = "remove_user"
+	EventTypeCreateAccountHold              string = "create_hold"
+	EventTypeCaptureAccountHold             string = "capture_hold"
+	EventTypeReleaseAccountHold             string = "release_hold"
+	EventTypeTransferAccountCache           string = "transfer_acc"
+	EventTypeBlockAccount                   string = "block_acc"
 )
 
 var PossibleEventsList = [...]string{
@@ -40,6 +45,11 @@ var PossibleEventsList = [...]string{
 	EventTypeCreateUserNotificationSettings,
 	EventTypeDeleteUserNotificationSettings,
 	EventTypeRemoveUser,
+	EventTypeCreateAccountHold,
+	EventTypeCaptureAccountHold,
+	EventTypeReleaseAccountHold,
+	EventTypeTransferAccountCache,
+	EventTypeBlockAccount,
 }
 
 func ValidateEventType(eventType string) bool {
@@ -101,6 +111,8 @@ var RequiredEventListOfData = map[string][]string{
 		"authority",
 		"authority_date",
 		"registration_adress",
+		"passport_files",
+		"user_uuid",
 		"login",
 		"password",
 	},
@@ -168,6 +180,28 @@ var RequiredEventListOfData = map[string][]string{
 	EventTypeRemoveUser: {
 		"user_id",
 	},
+	EventTypeCreateAccountHold: {
+		"acc_id",
+		"hold_amount",
+		"hold_expires_in",
+	},
+	EventTypeCaptureAccountHold: {
+		"acc_id",
+		"hold_id",
+		"hold_amount",
+	},
+	EventTypeReleaseAccountHold: {
+		"acc_id",
+		"hold_id",
+	},
+	EventTypeTransferAccountCache: {
+		"acc_id",
+		"to_acc_id",
+		"cache_diff",
+	},
+	EventTypeBlockAccount: {
+		"acc_id",
+	},
 }
 
 // Обратные события
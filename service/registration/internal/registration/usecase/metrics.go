@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/registration/internal/models"
+	"time"
+)
+
+// Terminal saga statuses as metric label values
+var SagaTerminalStatusLabel = map[uint]string{
+	SagaStatusCompleted:       "completed",
+	SagaStatusFallBackSuccess: "fallback_success",
+	SagaStatusFallBackError:   "fallback_error",
+	SagaStatusError:           "error",
+}
+
+// Terminal event statuses as metric label values
+var EventTerminalStatusLabel = map[uint8]string{
+	EventStatusCompleted:         "completed",
+	EventStatusFallBackCompleted: "fallback_completed",
+	EventStatusFallBackError:     "fallback_error",
+	EventStatusError:             "error",
+}
+
+// observeSaga records saga in terminal status. Duration is counted from operation start.
+func (regUC registrationUC) observeSaga(ctx context.Context, saga *models.Saga) {
+	status, ok := SagaTerminalStatusLabel[saga.Saga_status]
+	if !ok {
+		return
+	}
+
+	regUC.metrics.IncSaga(saga.Saga_name, status)
+
+	operation, err := regUC.registrationRepo.GetOperation(ctx, saga.Operation_uuid)
+	if err != nil {
+		regUC.logger.Warnf("Unable to observe saga duration: %v", err)
+		return
+	}
+	regUC.metrics.ObserveSagaDuration(saga.Saga_name, status, time.Since(operation.Create_time).Seconds())
+}
+
+// observeEvent records event in terminal status
+func (regUC registrationUC) observeEvent(event *models.Event) {
+	status, ok := EventTerminalStatusLabel[event.Event_status]
+	if !ok {
+		return
+	}
+
+	regUC.metrics.IncEvent(event.Event_name, status)
+}
@@ -0,0 +1,32 @@
+package usecase
+
+// Event categories, notification service routes messages by user preferences of category
+const (
+	NotificationCategoryTransactions string = "transactions"
+)
+
+// Templates rendered by notification service
+const (
+	NotificationTemplateDeposit        string = "deposit"
+	NotificationTemplateWithdrawal     string = "withdrawal"
+	NotificationTemplateAccountClosed  string = "account_closed"
+	NotificationTemplateAccountBlocked string = "account_blocked"
+)
+
+// Time format of operation into notification
+const NotificationTimeFormat string = "02-01-2006 15:04:05"
+
+// Templates of events which user is notified about after their completion
+var NotificationTemplateForEvent = map[string]string{
+	EventTypeAddAccountCache:      NotificationTemplateDeposit,
+	EventTypeWidthAccountCache:    NotificationTemplateWithdrawal,
+	EventTypeTransferAccountCache: NotificationTemplateWithdrawal,
+	EventTypeCloseAccount:         NotificationTemplateAccountClosed,
+	EventTypeBlockAccount:         NotificationTemplateAccountBlocked,
+}
+
+// Templates of money movements, their notification must have operation amount
+var NotificationTemplatesWithAmount = []string{
+	NotificationTemplateDeposit,
+	NotificationTemplateWithdrawal,
+}
@@ -17,6 +17,11 @@ const (
 	OperationGroupUpdateUserPassword uint8 = 8
 	OperationGetUserDataByLogin      uint8 = 9
 	OperationCheckUserPassword       uint8 = 10
+	OperationCreateAccountHold       uint8 = 11
+	OperationCaptureAccountHold      uint8 = 12
+	OperationReleaseAccountHold      uint8 = 13
+	OperationTransferAccountCache    uint8 = 14
+	OperationBlockAccount            uint8 = 15
 	OperationError                   uint8 = 255
 )
 
@@ -32,6 +37,11 @@ var PossibleOperations = []uint8{
 	OperationGroupUpdateUserPassword,
 	OperationGetUserDataByLogin,
 	OperationCheckUserPassword,
+	OperationCreateAccountHold,
+	OperationCaptureAccountHold,
+	OperationReleaseAccountHold,
+	OperationTransferAccountCache,
+	OperationBlockAccount,
 	OperationError,
 }
 
@@ -76,6 +86,21 @@ var OperationsRootsSagas = map[uint8][]string{
 	OperationCheckUserPassword: {
 		SagaTypeCheckUserPassword,
 	},
+	OperationCreateAccountHold: {
+		SagaTypeCheckUser,
+	},
+	OperationCaptureAccountHold: {
+		SagaTypeCheckUser,
+	},
+	OperationReleaseAccountHold: {
+		SagaTypeCheckUser,
+	},
+	OperationTransferAccountCache: {
+		SagaTypeCheckUser,
+	},
+	OperationBlockAccount: {
+		SagaTypeCheckUser,
+	},
 }
 
 // Имена операций
@@ -90,6 +115,11 @@ var OperationName = map[uint8]string{
 	OperationGroupUpdateUserPassword: "update_user_password",
 	OperationGetUserDataByLogin:      "get_user_data_by_login",
 	OperationCheckUserPassword:       "check_user_password",
+	OperationCreateAccountHold:       "create_account_hold",
+	OperationCaptureAccountHold:      "capture_account_hold",
+	OperationReleaseAccountHold:      "release_account_hold",
+	OperationTransferAccountCache:    "transfer_account_cache",
+	OperationBlockAccount:            "block_account",
 }
 
 func OperationNameFromCode(operation_code uint8) (string, error) {
@@ -27,6 +27,11 @@ const (
 	SagaTypeGetUserDataByLogin             string = "get_user_data_by_login"
 	SagaTypeCheckUserPassword              string = "check_user_password"
 	SagaTypeCreateUserNotificationSettings string = "add_user_notification_settings"
+	SagaTypeCreateAccountHold              string = "create_account_hold"
+	SagaTypeCaptureAccountHold             string = "capture_account_hold"
+	SagaTypeReleaseAccountHold             string = "release_account_hold"
+	SagaTypeTransferAccountCache           string = "transfer_account_cache"
+	SagaTypeBlockAccount                   string = "block_account"
 )
 
 var PossibleSagaTypes = []string{
@@ -43,6 +48,11 @@ var PossibleSagaTypes = []string{
 	SagaTypeGetUserDataByLogin,
 	SagaTypeCheckUserPassword,
 	SagaTypeCreateUserNotificationSettings,
+	SagaTypeCreateAccountHold,
+	SagaTypeCaptureAccountHold,
+	SagaTypeReleaseAccountHold,
+	SagaTypeTransferAccountCache,
+	SagaTypeBlockAccount,
 }
 
 func ValidateSagaType(saga_type string) bool {
@@ -105,6 +115,21 @@ var PossibleEventsListForSagaType = map[string][]string{
 		EventTypeCreateUserNotificationSettings,
 		EventTypeDeleteUserNotificationSettings,
 	},
+	SagaTypeCreateAccountHold: {
+		EventTypeCreateAccountHold,
+	},
+	SagaTypeCaptureAccountHold: {
+		EventTypeCaptureAccountHold,
+	},
+	SagaTypeReleaseAccountHold: {
+		EventTypeReleaseAccountHold,
+	},
+	SagaTypeTransferAccountCache: {
+		EventTypeTransferAccountCache,
+	},
+	SagaTypeBlockAccount: {
+		EventTypeBlockAccount,
+	},
 }
 
 // Список операций, входящих в SAG-у
@@ -149,6 +174,21 @@ var StartEventsListForSagaType = map[string][]string{
 	SagaTypeCreateUserNotificationSettings: {
 		EventTypeCreateUserNotificationSettings,
 	},
+	SagaTypeCreateAccountHold: {
+		EventTypeCreateAccountHold,
+	},
+	SagaTypeCaptureAccountHold: {
+		EventTypeCaptureAccountHold,
+	},
+	SagaTypeReleaseAccountHold: {
+		EventTypeReleaseAccountHold,
+	},
+	SagaTypeTransferAccountCache: {
+		EventTypeTransferAccountCache,
+	},
+	SagaTypeBlockAccount: {
+		EventTypeBlockAccount,
+	},
 }
 
 func CheckExistingEventTypeIntoSagaType(saga_type string, event_type string) (result bool) {
@@ -179,16 +219,21 @@ func CheckExistingEventTypeIntoSagaType(saga_type string, event_type string) (re
 }
 
 const (
-	SagaGroupCreateUser         uint8 = 1
-	SagaGroupCreateAccount      uint8 = 2
-	SagaGroupAddAccountCache    uint8 = 3
-	SagaGroupWidthAccountCache  uint8 = 4
-	SagaGroupCloseAccount       uint8 = 5
-	SagaGroupGetUserData        uint8 = 6
-	SagaGroupGetAccountData     uint8 = 7
-	SagaGroupUpdateUserPassword uint8 = 8
-	SagaGroupGetUserDataByLogin uint8 = 9
-	SagaGroupCheckUserPassword  uint8 = 10
+	SagaGroupCreateUser           uint8 = 1
+	SagaGroupCreateAccount        uint8 = 2
+	SagaGroupAddAccountCache      uint8 = 3
+	SagaGroupWidthAccountCache    uint8 = 4
+	SagaGroupCloseAccount         uint8 = 5
+	SagaGroupGetUserData          uint8 = 6
+	SagaGroupGetAccountData       uint8 = 7
+	SagaGroupUpdateUserPassword   uint8 = 8
+	SagaGroupGetUserDataByLogin   uint8 = 9
+	SagaGroupCheckUserPassword    uint8 = 10
+	SagaGroupCreateAccountHold    uint8 = 11
+	SagaGroupCaptureAccountHold   uint8 = 12
+	SagaGroupReleaseAccountHold   uint8 = 13
+	SagaGroupTransferAccountCache uint8 = 14
+	SagaGroupBlockAccount         uint8 = 15
 )
 
 var PossibleSagaGroups = []uint8{
@@ -202,6 +247,11 @@ var PossibleSagaGroups = []uint8{
 	SagaGroupUpdateUserPassword,
 	SagaGroupGetUserDataByLogin,
 	SagaGroupCheckUserPassword,
+	SagaGroupCreateAccountHold,
+	SagaGroupCaptureAccountHold,
+	SagaGroupReleaseAccountHold,
+	SagaGroupTransferAccountCache,
+	SagaGroupBlockAccount,
 }
 
 func ValidateSagaGroup(saga_group uint8) bool {
@@ -364,6 +414,116 @@ var ListOfSagaDepend = map[uint8]map[string]models.SagaDepend{
 			Children: nil,
 		},
 	},
+	SagaGroupCreateAccountHold: map[string]models.SagaDepend{
+		SagaTypeCheckUser: models.SagaDepend{
+			Parents: nil,
+			Children: []string{
+				SagaTypeGetAccountData,
+			},
+		},
+		SagaTypeGetAccountData: models.SagaDepend{
+			Parents: []string{
+				SagaTypeCheckUser,
+			},
+			Children: []string{
+				SagaTypeCreateAccountHold,
+			},
+		},
+		SagaTypeCreateAccountHold: models.SagaDepend{
+			Parents: []string{
+				SagaTypeGetAccountData,
+			},
+			Children: nil,
+		},
+	},
+	SagaGroupCaptureAccountHold: map[string]models.SagaDepend{
+		SagaTypeCheckUser: models.SagaDepend{
+			Parents: nil,
+			Children: []string{
+				SagaTypeGetAccountData,
+			},
+		},
+		SagaTypeGetAccountData: models.SagaDepend{
+			Parents: []string{
+				SagaTypeCheckUser,
+			},
+			Children: []string{
+				SagaTypeCaptureAccountHold,
+			},
+		},
+		SagaTypeCaptureAccountHold: models.SagaDepend{
+			Parents: []string{
+				SagaTypeGetAccountData,
+			},
+			Children: nil,
+		},
+	},
+	SagaGroupReleaseAccountHold: map[string]models.SagaDepend{
+		SagaTypeCheckUser: models.SagaDepend{
+			Parents: nil,
+			Children: []string{
+				SagaTypeGetAccountData,
+			},
+		},
+		SagaTypeGetAccountData: models.SagaDepend{
+			Parents: []string{
+				SagaTypeCheckUser,
+			},
+			Children: []string{
+				SagaTypeReleaseAccountHold,
+			},
+		},
+		SagaTypeReleaseAccountHold: models.SagaDepend{
+			Parents: []string{
+				SagaTypeGetAccountData,
+			},
+			Children: nil,
+		},
+	},
+	SagaGroupTransferAccountCache: map[string]models.SagaDepend{
+		SagaTypeCheckUser: models.SagaDepend{
+			Parents: nil,
+			Children: []string{
+				SagaTypeGetAccountData,
+			},
+		},
+		SagaTypeGetAccountData: models.SagaDepend{
+			Parents: []string{
+				SagaTypeCheckUser,
+			},
+			Children: []string{
+				SagaTypeTransferAccountCache,
+			},
+		},
+		SagaTypeTransferAccountCache: models.SagaDepend{
+			Parents: []string{
+				SagaTypeGetAccountData,
+			},
+			Children: nil,
+		},
+	},
+	SagaGroupBlockAccount: map[string]models.SagaDepend{
+		SagaTypeCheckUser: models.SagaDepend{
+			Parents: nil,
+			Children: []string{
+				SagaTypeGetAccountData,
+			},
+		},
+		SagaTypeGetAccountData: models.SagaDepend{
+			Parents: []string{
+				SagaTypeCheckUser,
+			},
+			Children: []string{
+				SagaTypeBlockAccount,
+			},
+		},
+		SagaTypeBlockAccount: models.SagaDepend{
+			Parents: []string{
+				SagaTypeGetAccountData,
+			},
+			Children: nil,
+		},
+	},
 }
 
 const (
@@ -447,6 +607,7 @@ var SagaGroupResultDataUpdate = map[uint8]map[string][]string{
 			"passport_authority",
 			"passport_authority_date",
 			"passport_registration_address",
+			"passport_files",
 			"user_id",
 			"user_login",
 		},
@@ -459,6 +620,7 @@ var SagaGroupResultDataUpdate = map[uint8]map[string][]string{
 			"acc_name",
 			"acc_status",
 			"acc_cache",
+			"acc_available_cache",
 			"acc_cache_value",
 			"acc_culc_number",
 			"acc_corr_number",
@@ -475,6 +637,46 @@ var SagaGroupResultDataUpdate = map[uint8]map[string][]string{
 			"accounts",
 		},
 	},
+	SagaGroupCreateAccountHold: map[string][]string{
+		SagaTypeCheckUser: []string{
+			"accounts",
+		},
+		SagaTypeGetAccountData: []string{
+			"acc_status",
+		},
+	},
+	SagaGroupCaptureAccountHold: map[string][]string{
+		SagaTypeCheckUser: []string{
+			"accounts",
+		},
+		SagaTypeGetAccountData: []string{
+			"acc_status",
+		},
+	},
+	SagaGroupReleaseAccountHold: map[string][]string{
+		SagaTypeCheckUser: []string{
+			"accounts",
+		},
+		SagaTypeGetAccountData: []string{
+			"acc_status",
+		},
+	},
+	SagaGroupTransferAccountCache: map[string][]string{
+		SagaTypeCheckUser: []string{
+			"accounts",
+		},
+		SagaTypeGetAccountData: []string{
+			"acc_status",
+		},
+	},
+	SagaGroupBlockAccount: map[string][]string{
+		SagaTypeCheckUser: []string{
+			"accounts",
+		},
+		SagaTypeGetAccountData: []string{
+			"acc_status",
+		},
+	},
 }
 
 // Возвращаемые данные при получении статуса операции
@@ -512,6 +714,7 @@ var SagaGroupDataIsResult = map[uint8]map[string]map[string][]string{
 				"passport_authority",
 				"passport_authority_date",
 				"passport_registration_address",
+				"passport_files",
 				"user_id",
 				"user_login",
 			},
@@ -523,6 +726,7 @@ var SagaGroupDataIsResult = map[uint8]map[string]map[string][]string{
 				"acc_name",
 				"acc_status",
 				"acc_cache",
+				"acc_available_cache",
 				"acc_cache_value",
 				"acc_culc_number",
 				"acc_corr_number",
@@ -543,4 +747,15 @@ var SagaGroupDataIsResult = map[uint8]map[string]map[string][]string{
 		},
 	},
 	SagaGroupCheckUserPassword: nil,
+	SagaGroupCreateAccountHold: map[string]map[string][]string{
+		SagaTypeCreateAccountHold: map[string][]string{
+			EventTypeCreateAccountHold: []string{
+				"hold_id",
+			},
+		},
+	},
+	SagaGroupCaptureAccountHold:   nil,
+	SagaGroupReleaseAccountHold:   nil,
+	SagaGroupTransferAccountCache: nil,
+	SagaGroupBlockAccount:         nil,
 }
@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/registration/config"
 	"github.com/GCFactory/dbo-system/service/registration/internal/models"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/repository"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"slices"
 	"sync"
 	"time"
@@ -19,14 +20,15 @@ type registrationUC struct {
 	cfg              *config.Config
 	registrationRepo registration.Repository
 	logger           logger.Logger
+	metrics          metric.SagaMetrics
 }
 
 var mutex sync.Mutex
 
 func (regUC registrationUC) CreateEvent(ctx context.Context, event_type string, saga_uuid uuid.UUID, is_fall_back bool, revet_event_uuid uuid.UUID) (event *models.Event, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.CreateEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.CreateEvent")
+	defer span.End()
 
 	if !ValidateEventType(event_type) {
 		return nil, ErrorInvalidEventType
@@ -92,8 +94,8 @@ func (regUC registrationUC) CreateEvent(ctx context.Context, event_type string,
 
 func (regUC registrationUC) RevertEvent(ctx context.Context, event_uuid uuid.UUID) (*models.Event, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.RevertEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.RevertEvent")
+	defer span.End()
 
 	if event_uuid == uuid.Nil {
 		return nil, ErrorInvalidEventType
@@ -138,6 +140,7 @@ func (regUC registrationUC) RevertEvent(ctx context.Context, event_uuid uuid.UUI
 				if err != nil {
 					return nil, err
 				}
+				regUC.metrics.IncCompensation(saga.Saga_name, event.Event_name)
 				return revert_event, nil
 			}
 		}
@@ -148,8 +151,8 @@ func (regUC registrationUC) RevertEvent(ctx context.Context, event_uuid uuid.UUI
 
 func (regUC registrationUC) DeleteEvent(ctx context.Context, event_uuid uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.DeleteEvent")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.DeleteEvent")
+	defer span.End()
 
 	if event_uuid == uuid.Nil {
 		return ErrorInvalidEventType
@@ -181,8 +184,8 @@ func (regUC registrationUC) DeleteEvent(ctx context.Context, event_uuid uuid.UUI
 
 func (regUC registrationUC) CreateOperation(ctx context.Context, operation_type uint8) (*models.Operation, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.CreateOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.CreateOperation")
+	defer span.End()
 
 	operation_name, err := OperationNameFromCode(operation_type)
 	if err != nil {
@@ -207,8 +210,8 @@ func (regUC registrationUC) CreateOperation(ctx context.Context, operation_type
 
 func (regUC registrationUC) UpdateOperationTimeEdit(ctx context.Context, operation_id uuid.UUID, new_time time.Time) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.UpdateOperationTimeEdit")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.UpdateOperationTimeEdit")
+	defer span.End()
 
 	operation, err := regUC.registrationRepo.GetOperation(ctxWithTrace, operation_id)
 	if err != nil {
@@ -227,8 +230,8 @@ func (regUC registrationUC) UpdateOperationTimeEdit(ctx context.Context, operati
 
 func (regUC registrationUC) DeleteOperation(ctx context.Context, operation_id uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.DeleteOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.DeleteOperation")
+	defer span.End()
 
 	_, err := regUC.registrationRepo.GetOperation(ctxWithTrace, operation_id)
 	if err != nil {
@@ -246,8 +249,8 @@ func (regUC registrationUC) DeleteOperation(ctx context.Context, operation_id uu
 
 func (regUC registrationUC) CreateSaga(ctx context.Context, operation_id uuid.UUID, saga_type string, saga_group uint8, saga_data map[string]interface{}) (saga *models.Saga, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.CreateSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.CreateSaga")
+	defer span.End()
 
 	if !ValidateSagaType(saga_type) {
 		return nil, ErrorInvalidSagaType
@@ -315,8 +318,8 @@ func (regUC registrationUC) RevertSaga(ctx context.Context, saga_uuid uuid.UUID)
 	result = nil
 	err = nil
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.CreateSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.CreateSaga")
+	defer span.End()
 
 	saga, err := regUC.registrationRepo.GetSaga(ctxWithTrace, saga_uuid)
 	if err != nil {
@@ -393,6 +396,7 @@ func (regUC registrationUC) RevertSaga(ctx context.Context, saga_uuid uuid.UUID)
 	if err != nil {
 		return result, err
 	}
+	regUC.observeSaga(ctxWithTrace, saga)
 
 	return result, nil
 
@@ -400,8 +404,8 @@ func (regUC registrationUC) RevertSaga(ctx context.Context, saga_uuid uuid.UUID)
 
 func (regUC registrationUC) DeleteSaga(ctx context.Context, saga_uuid uuid.UUID) (err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.DeleteSaga")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.DeleteSaga")
+	defer span.End()
 
 	_, err = regUC.registrationRepo.GetSaga(ctxWithTrace, saga_uuid)
 	if err != nil {
@@ -432,8 +436,8 @@ func (regUC registrationUC) DeleteSaga(ctx context.Context, saga_uuid uuid.UUID)
 
 func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uuid uuid.UUID, event_uuid uuid.UUID, success bool, data map[string]interface{}) (result []*models.Event, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.ProcessingSagaAndEvents")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.ProcessingSagaAndEvents")
+	defer span.End()
 
 	result = nil
 
@@ -476,6 +480,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 						if err != nil {
 							return nil, err
 						}
+						regUC.observeEvent(event)
 
 						new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, event.Saga_uuid, uuid.Nil, false, nil)
 						if err != nil {
@@ -498,6 +503,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeEvent(event)
 					new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, event.Saga_uuid, uuid.Nil, false, nil)
 					if err != nil {
 						return result, err
@@ -519,12 +525,13 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeEvent(event)
 					if event.Event_is_roll_back {
 						new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, uuid.Nil, event.Event_rollback_uuid, true, nil)
 						if err != nil {
 							return result, err
 						}
-						result = slices.Concat(result, new_events)
+						result = append(result, new_events...)
 					} else {
 						saga, local_err := regUC.registrationRepo.GetSaga(ctxWithTrace, saga_uuid)
 						if local_err != nil {
@@ -562,12 +569,13 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeEvent(event)
 					if event.Event_is_roll_back {
 						new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, event.Saga_uuid, event.Event_rollback_uuid, false, nil)
 						if err != nil {
 							return result, err
 						}
-						result = slices.Concat(result, new_events)
+						result = append(result, new_events...)
 					}
 				}
 			}
@@ -586,6 +594,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 						if err != nil {
 							return result, err
 						}
+						regUC.observeEvent(event)
 						new_events, err := regUC.ProcessingSagaAndEvents(
 							ctxWithTrace,
 							event.Saga_uuid,
@@ -626,12 +635,14 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeEvent(event)
 				} else {
 					event.Event_status = EventStatusFallBackError
 					err = regUC.registrationRepo.UpdateEvent(ctxWithTrace, event)
 					if err != nil {
 						return result, err
 					}
+					regUC.observeEvent(event)
 				}
 			}
 		case EventStatusFallBackCompleted:
@@ -749,6 +760,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeSaga(ctxWithTrace, saga)
 					//new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, saga.Saga_uuid, uuid.Nil, false, nil)
 					//if err != nil {
 					//	return result, err
@@ -775,6 +787,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeSaga(ctxWithTrace, saga)
 					new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, saga_uuid, uuid.Nil, true, nil)
 					if err != nil {
 						return result, err
@@ -788,6 +801,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeSaga(ctxWithTrace, saga)
 					new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, saga_uuid, uuid.Nil, true, nil)
 					if err != nil {
 						return result, err
@@ -886,6 +900,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeSaga(ctxWithTrace, saga)
 					new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, saga_uuid, uuid.Nil, true, nil)
 					if err != nil {
 						return result, err
@@ -899,6 +914,7 @@ func (regUC registrationUC) ProcessingSagaAndEvents(ctx context.Context, saga_uu
 					if err != nil {
 						return result, err
 					}
+					regUC.observeSaga(ctxWithTrace, saga)
 
 					new_events, err := regUC.ProcessingSagaAndEvents(ctxWithTrace, saga_uuid, uuid.Nil, true, nil)
 					if err != nil {
@@ -1078,8 +1094,8 @@ func (regUC registrationUC) SetOrUpdateSagaData(ctx context.Context, saga_uuid u
 
 func (regUC registrationUC) CreateSagaTree(ctx context.Context, operation_type uint8, list_root_saga_types []string, saga_group uint8, start_data map[string]interface{}) (list_root_saga []*models.Saga, operation_id uuid.UUID, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.CreateSagaTree")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.CreateSagaTree")
+	defer span.End()
 
 	operation_id = uuid.Nil
 
@@ -1231,8 +1247,8 @@ func (regUC registrationUC) CreateSagaTree(ctx context.Context, operation_type u
 
 func (regUC registrationUC) ClearSagaTree(ctx context.Context, saga_tree map[uint8][]*models.Saga) (err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.ClearSagaTree")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.ClearSagaTree")
+	defer span.End()
 
 	for _, list_of_saga := range saga_tree {
 		for _, saga := range list_of_saga {
@@ -1248,8 +1264,8 @@ func (regUC registrationUC) ClearSagaTree(ctx context.Context, saga_tree map[uin
 
 func (regUC registrationUC) FillNewSagaTreeLayer(ctx context.Context, saga_tree map[uint8][]*models.Saga, layer_number uint8, new_layer map[string]*models.Saga) map[uint8][]*models.Saga {
 
-	span, _ := opentracing.StartSpanFromContext(ctx, "registrationUC.FillNewSagaTreeLayer")
-	defer span.Finish()
+	_, span := tracing.StartSpan(ctx, "registrationUC.FillNewSagaTreeLayer")
+	defer span.End()
 
 	result := saga_tree
 
@@ -1266,8 +1282,8 @@ func (regUC registrationUC) FillNewSagaTreeLayer(ctx context.Context, saga_tree
 
 func (regUC registrationUC) StartOperation(ctx context.Context, operation_type uint8, start_data map[string]interface{}) (result []*models.Event, operation_uuid uuid.UUID, err error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.StartOperation")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.StartOperation")
+	defer span.End()
 
 	operation_id := uuid.Nil
 
@@ -1287,7 +1303,12 @@ func (regUC registrationUC) StartOperation(ctx context.Context, operation_type u
 		OperationGetAccountData,
 		OperationGroupUpdateUserPassword,
 		OperationGetUserDataByLogin,
-		OperationCheckUserPassword:
+		OperationCheckUserPassword,
+		OperationCreateAccountHold,
+		OperationCaptureAccountHold,
+		OperationReleaseAccountHold,
+		OperationTransferAccountCache,
+		OperationBlockAccount:
 		{
 			list_of_root_saga_types, is_exist := OperationsRootsSagas[operation_type]
 			if !is_exist {
@@ -1345,8 +1366,8 @@ func (regUC registrationUC) GetOperationStatus(ctx context.Context, operation_id
 	err = nil
 	status = OperationStatusUnknown
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.GetOperationStatus")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GetOperationStatus")
+	defer span.End()
 
 	_, err = regUC.registrationRepo.GetOperation(ctxWithTrace, operation_id)
 	if err != nil {
@@ -1390,8 +1411,8 @@ func (regUC registrationUC) GetSagaChildrenWithoutChildren(ctx context.Context,
 	children = nil
 	err = nil
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.GetSagaChildrenWithoutChildren")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GetSagaChildrenWithoutChildren")
+	defer span.End()
 
 	saga, err := regUC.registrationRepo.GetSaga(ctxWithTrace, saga_uuid)
 	if err != nil {
@@ -1436,8 +1457,8 @@ func (regUC registrationUC) GetOperationResultData(ctx context.Context, operatio
 	data = make(map[string]interface{})
 	err = nil
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.GetOperationResultData")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GetOperationResultData")
+	defer span.End()
 
 	operation, err := regUC.registrationRepo.GetOperation(ctxWithTrace, operation_uuid)
 	if err != nil {
@@ -1560,8 +1581,8 @@ func (regUC registrationUC) GetOperationTree(ctx context.Context, operation_uuid
 	data = make(map[string]interface{})
 	err = nil
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.GetOperationTree")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GetOperationTree")
+	defer span.End()
 
 	operation, err := regUC.registrationRepo.GetOperation(ctxWithTrace, operation_uuid)
 	if err != nil {
@@ -1643,8 +1664,8 @@ func (regUC registrationUC) GetOperationTree(ctx context.Context, operation_uuid
 
 func (regUC registrationUC) GerOperationListBetween(ctx context.Context, begin time.Time, end time.Time) ([]uuid.UUID, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "registrationUC.GerOperationListBetween")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GerOperationListBetween")
+	defer span.End()
 
 	result, err := regUC.registrationRepo.GetOperationBetweenInterval(ctxWithTrace, begin, end)
 	if err != nil {
@@ -1655,6 +1676,68 @@ func (regUC registrationUC) GerOperationListBetween(ctx context.Context, begin t
 
 }
 
-func NewRegistrationUseCase(cfg *config.Config, registration_repo registration.Repository, log logger.Logger) registration.UseCase {
-	return &registrationUC{cfg: cfg, registrationRepo: registration_repo, logger: log}
+// GetEventNotification builds notification about completed event.
+// Nil is returned when user is not notified about such event.
+func (regUC registrationUC) GetEventNotification(ctx context.Context, event_uuid uuid.UUID) (*models.Notification, error) {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "registrationUC.GetEventNotification")
+	defer span.End()
+
+	event, err := regUC.registrationRepo.GetEvent(ctxWithTrace, event_uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	template, ok := NotificationTemplateForEvent[event.Event_name]
+	if !ok || event.Event_is_roll_back || event.Event_status != EventStatusCompleted {
+		return nil, nil
+	}
+
+	saga, err := regUC.registrationRepo.GetSaga(ctxWithTrace, event.Saga_uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	user_id_str, _ := saga.Saga_data["user_id"].(string)
+	user_id, err := uuid.Parse(user_id_str)
+	if err != nil {
+		return nil, ErrorNoNotificationUser
+	}
+
+	var event_result map[string]interface{}
+	err = json.Unmarshal([]byte(event.Event_result), &event_result)
+	if err != nil {
+		return nil, err
+	}
+
+	message := &models.TransactionMessage{
+		OperationId: saga.Operation_uuid.String(),
+		Time:        time.Now().Format(NotificationTimeFormat),
+	}
+	message.AccountId, _ = saga.Saga_data["acc_id"].(string)
+	message.AccountName, _ = event_result["acc_name"].(string)
+
+	// Zero amount or balance would be shown to user as real value, so notification isn't built without them
+	if slices.Contains(NotificationTemplatesWithAmount, template) {
+		message.Amount, ok = saga.Saga_data["cache_diff"].(float64)
+		if !ok {
+			return nil, ErrorNoNotificationAmount
+		}
+	}
+	message.BalanceAfter, ok = event_result["acc_cache"].(float64)
+	if !ok {
+		return nil, ErrorNoNotificationBalance
+	}
+
+	return &models.Notification{
+		MessageId: event.Event_uuid,
+		UserId:    user_id,
+		Category:  NotificationCategoryTransactions,
+		Template:  template,
+		Data:      message,
+	}, nil
+}
+
+func NewRegistrationUseCase(cfg *config.Config, registration_repo registration.Repository, log logger.Logger, metrics metric.SagaMetrics) registration.UseCase {
+	return &registrationUC{cfg: cfg, registrationRepo: registration_repo, logger: log, metrics: metrics}
 }
@@ -2,13 +2,13 @@ package server
 
 import (
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	apiMiddlewares "github.com/GCFactory/dbo-system/service/registration/internal/middleware"
 	registrationHttp "github.com/GCFactory/dbo-system/service/registration/internal/registration/delivery/http"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"net/http"
 	"strings"
 )
 
@@ -56,6 +56,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -72,11 +73,12 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+		health.KafkaChecker("kafka", s.cfg.KafkaConsumer.Brokers),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	rGroup := v1.Group("/registration")
 	rHandlers := registrationHttp.NewRegistrationHandlers(s.cfg, s.logger, s.useCase, s.grpcH)
@@ -3,7 +3,9 @@ package server
 import (
 	"context"
 	"errors"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
 	"github.com/GCFactory/dbo-system/service/registration/config"
 	accounts_api "github.com/GCFactory/dbo-system/service/registration/gen_proto/proto/api/account"
 	"github.com/GCFactory/dbo-system/service/registration/gen_proto/proto/api/notification_api"
@@ -12,12 +14,10 @@ import (
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/grpc"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/repository"
 	"github.com/GCFactory/dbo-system/service/registration/internal/registration/usecase"
-	"github.com/GCFactory/dbo-system/service/registration/pkg/kafka"
-	"github.com/IBM/sarama"
-	"github.com/golang/protobuf/proto"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	amqp "github.com/rabbitmq/amqp091-go"
 	"net/http"
 	"net/http/pprof"
 	_ "net/http/pprof"
@@ -45,22 +45,20 @@ type Server struct {
 	grpcH         registration.RegistrationGRPCHandlers
 	useCase       registration.UseCase
 	// Channel to control goroutines
-	kafkaConsumerChan  chan int
 	sagaSyncChan       chan bool
-	sagaProcessingSaga map[uuid.UUID][]*sarama.ConsumerMessage
+	sagaProcessingSaga map[uuid.UUID][]*incomingEvent
 }
 
-func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, logger logger.Logger) *Server {
+func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, rmqChan *amqp.Channel, rmqQueue amqp.Queue, metrics metric.BusinessMetrics, logger logger.Logger) *Server {
 	server := Server{
 		echo:               echo.New(),
 		cfg:                cfg,
 		db:                 db,
 		logger:             logger,
 		kafkaConsumer:      kConsumer,
-		kafkaConsumerChan:  make(chan int, 3),
 		kafkaProducer:      kProducer,
 		sagaSyncChan:       make(chan bool, 1),
-		sagaProcessingSaga: make(map[uuid.UUID][]*sarama.ConsumerMessage),
+		sagaProcessingSaga: make(map[uuid.UUID][]*incomingEvent),
 	}
 	RepoRegistration := repository.NewRegistrationRepository(
 		server.db,
@@ -69,10 +67,13 @@ func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *ka
 		cfg,
 		RepoRegistration,
 		server.logger,
+		metrics,
 	)
 	grpcHandlers := grpc.NewRegistrationGRPCHandlers(
 		cfg,
 		kProducer,
+		rmqChan,
+		rmqQueue,
 		UCHandlers,
 		server.logger,
 	)
@@ -81,6 +82,7 @@ func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *ka
 	server.echo.HidePort = true
 	server.echo.HideBanner = true
 	server.sagaSyncChan <- true
+	server.registerKafkaHandlers()
 	return &server
 }
 
@@ -124,386 +126,325 @@ func (s *Server) Run() error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-
-	for {
-		select {
-		// If goroutine exit for any reason - restart
-		case <-s.kafkaConsumerChan:
-			s.logger.Warn("Received end of kafka consumer goroutine")
-			go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-		// Handle system interrupts
-		case <-quit:
-			ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-			s.kafkaConsumer.Consumer.PauseAll()
-			s.kafkaConsumer.Consumer.Close()
-			time.Sleep(time.Second * 5)
-			close(quit)
-			close(s.kafkaConsumerChan)
-			defer shutdown()
-			defer cancel()
-
-			s.logger.Info("Server Exited Properly")
-			return s.echo.Server.Shutdown(ctx)
+	go func() {
+		if err := s.kafkaConsumer.Run(ctxWithCancel); err != nil {
+			s.logger.Errorf("Kafka consumer stopped: %v", err)
 		}
-	}
-}
+	}()
 
-func (s *Server) RunKafkaConsumer(ctx context.Context, quitChan chan<- int) {
-	consumer := kafka.Consumer{
-		Ready:       make(chan bool),
-		HandlerFunc: s.handleData,
-	}
+	<-quit
+	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+	defer shutdown()
 
-	for {
-		if err := s.kafkaConsumer.Consumer.Consume(ctx, s.cfg.KafkaConsumer.Topics, &consumer); err != nil {
-			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
-				return
-			}
-			quitChan <- 1
-			s.logger.Errorf("Error from consumer: %v", err)
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			s.logger.Infof("Stopping kafka consumer: context close: %v", ctx.Err())
-			return
-		}
-		s.logger.Info("Stopping kafka consumer...")
-		consumer.Ready = make(chan bool)
+	if err := s.kafkaConsumer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka consumer: %v", err)
+	}
+	cancel()
+	<-s.kafkaConsumer.Done()
+	if err := s.kafkaProducer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka producer: %v", err)
 	}
+
+	s.logger.Info("Server Exited Properly")
+	return s.echo.Server.Shutdown(ctx)
 }
 
-func (s *Server) handleData(message *sarama.ConsumerMessage) (err error) {
+// Result of saga event sent back by one of the services
+type incomingEvent struct {
+	sagaUuid  uuid.UUID
+	eventUuid uuid.UUID
+	data      map[string]interface{}
+	success   bool
+}
 
-	err = nil
+// Error answer common for all services
+type eventError interface {
+	GetSagaUuid() string
+	GetEventUuid() string
+	GetOperationName() string
+	GetStatus() uint32
+	GetInfo() string
+}
 
-	var saga_uuid uuid.UUID = uuid.Nil
-	var event_uuid uuid.UUID = uuid.Nil
-	var success bool = false
-	var data map[string]interface{} = make(map[string]interface{})
+func (s *Server) registerKafkaHandlers() {
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicUsersProducerRes, s.handleUsersResult)
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicUsersProducerErr, handleEventError[*users_api.EventError](s, grpc.ServerTopicUsersProducerErr))
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicAccountsProducerRes, s.handleAccountsResult)
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicAccountsProducerErr, handleEventError[*accounts_api.EventError](s, grpc.ServerTopicAccountsProducerErr))
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicNotificationRes, s.handleNotificationResult)
+	kafka.HandleProto(s.kafkaConsumer, grpc.ServerTopicNotificationErr, handleEventError[*notification_api.EventError](s, grpc.ServerTopicNotificationErr))
+}
 
-	switch message.Topic {
-	case grpc.ServerTopicUsersProducerRes:
-		{
-			event_success := &users_api.EventSuccess{}
-			err := proto.Unmarshal(message.Value, event_success)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
+func (s *Server) parseEventUuids(saga string, event string) (saga_uuid uuid.UUID, event_uuid uuid.UUID) {
+	saga_uuid, err := uuid.Parse(saga)
+	if err != nil {
+		s.logger.Errorf("Error parsing saga uuid: %v", err)
+	}
+	event_uuid, err = uuid.Parse(event)
+	if err != nil {
+		s.logger.Errorf("Error parsing event uuid: %v", err)
+	}
+	return saga_uuid, event_uuid
+}
 
-			operation_name := event_success.OperationName
+func (s *Server) handleUsersResult(ctx context.Context, event_success *users_api.EventSuccess) error {
 
-			saga_uuid, err = uuid.Parse(event_success.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_success.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
-			}
+	event := &incomingEvent{
+		data:    make(map[string]interface{}),
+		success: true,
+	}
+	event.sagaUuid, event.eventUuid = s.parseEventUuids(event_success.SagaUuid, event_success.EventUuid)
 
-			switch operation_name {
-			case grpc.OperationCreateUser:
-				{
-					result := event_success.GetInfo()
+	data := event.data
+	operation_name := event_success.OperationName
 
-					data["user_id"] = result
+	switch operation_name {
+	case grpc.OperationCreateUser:
+		{
+			result := event_success.GetInfo()
 
-					break
-				}
-			case grpc.OperationGetUserData:
-				{
-
-					result := event_success.GetFullData()
-					data["inn"] = result.GetUserInn()
-					data["user_id"] = result.GetUserId()
-					data["user_login"] = result.GetUserLogin()
-
-					accounts := result.GetAccounts()
-					if accounts != nil {
-						data["accounts"] = accounts.Accounts
-					}
-
-					passport := result.GetPassport()
-					if passport != nil {
-						data["passport_series"] = passport.GetSeries()
-						data["passport_number"] = passport.GetNumber()
-
-						fcs := passport.GetFcs()
-						if fcs != nil {
-							data["passport_first_name"] = fcs.GetName()
-							data["passport_first_surname"] = fcs.GetSurname()
-							data["passport_first_patronimic"] = fcs.GetPatronymic()
-						}
-
-						data["passport_birth_date"] = passport.GetBirthDate().AsTime().Format("2006-01-02 15:04:05 -07:00:00")
-						data["passport_birth_location"] = passport.GetBirthLocation()
-						data["passport_pick_up_point"] = passport.GetPickUpPoint()
-						data["passport_authority"] = passport.GetAuthority()
-						data["passport_authority_date"] = passport.GetAuthorityDate().AsTime().Format("2006-01-02 15:04:05 -07:00:00")
-						data["passport_registration_address"] = passport.GetRegistrationAdress()
-
-					}
-
-					break
-				}
-			case grpc.OperationGetUserDataByLogin:
-				{
+			data["user_id"] = result
 
-					result := event_success.GetFullData()
-					data["inn"] = result.GetUserInn()
-					data["user_id"] = result.GetUserId()
-					data["user_login"] = result.GetUserLogin()
+			break
+		}
+	case grpc.OperationGetUserData:
+		{
 
-					accounts := result.GetAccounts()
-					if accounts != nil {
-						data["accounts"] = accounts.Accounts
-					}
+			result := event_success.GetFullData()
+			data["inn"] = result.GetUserInn()
+			data["user_id"] = result.GetUserId()
+			data["user_login"] = result.GetUserLogin()
 
-				}
-			case grpc.OperationAddAccountToUser,
-				grpc.OperationRemoveUserAccount,
-				grpc.OperationCheckUSerPassword,
-				grpc.OperationDeleteUser:
-				{
-					break
-				}
-			default:
-				{
-					s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", message.Topic, operation_name)
-				}
+			accounts := result.GetAccounts()
+			if accounts != nil {
+				data["accounts"] = accounts.Accounts
 			}
 
-			success = true
+			passport := result.GetPassport()
+			if passport != nil {
+				data["passport_series"] = passport.GetSeries()
+				data["passport_number"] = passport.GetNumber()
 
-			break
+				fcs := passport.GetFcs()
+				if fcs != nil {
+					data["passport_first_name"] = fcs.GetName()
+					data["passport_first_surname"] = fcs.GetSurname()
+					data["passport_first_patronimic"] = fcs.GetPatronymic()
+				}
 
-		}
-	case grpc.ServerTopicUsersProducerErr:
-		{
-			event_error := &users_api.EventError{}
-			err := proto.Unmarshal(message.Value, event_error)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
+				data["passport_birth_date"] = passport.GetBirthDate().AsTime().Format("2006-01-02 15:04:05 -07:00:00")
+				data["passport_birth_location"] = passport.GetBirthLocation()
+				data["passport_pick_up_point"] = passport.GetPickUpPoint()
+				data["passport_authority"] = passport.GetAuthority()
+				data["passport_authority_date"] = passport.GetAuthorityDate().AsTime().Format("2006-01-02 15:04:05 -07:00:00")
+				data["passport_registration_address"] = passport.GetRegistrationAdress()
 
-			saga_uuid, err = uuid.Parse(event_error.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_error.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
 			}
 
-			data["info"] = event_error.Info
-			data["operation_name"] = event_error.OperationName
-			data["status"] = event_error.Status
-
-			success = false
+			data["passport_files"] = result.GetPassportFiles()
 
 			break
 		}
-	case grpc.ServerTopicAccountsProducerRes:
+	case grpc.OperationGetUserDataByLogin:
 		{
 
-			event_success := &accounts_api.EventStatus{}
-			err := proto.Unmarshal(message.Value, event_success)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
-
-			operation_name := event_success.OperationName
+			result := event_success.GetFullData()
+			data["inn"] = result.GetUserInn()
+			data["user_id"] = result.GetUserId()
+			data["user_login"] = result.GetUserLogin()
 
-			saga_uuid, err = uuid.Parse(event_success.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_success.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
+			accounts := result.GetAccounts()
+			if accounts != nil {
+				data["accounts"] = accounts.Accounts
 			}
 
-			switch operation_name {
-			case grpc.OperationReserveAcc:
-				{
-					data["acc_id"] = event_success.GetInfo()
-
-					break
-				}
-			case grpc.OperationGetAccountData:
-				{
-					acc_data := event_success.GetAccData()
-					if acc_data != nil {
-
-						data["acc_status"] = acc_data.GetAccStatus()
-						data["acc_cache"] = acc_data.GetAccMoneyAmount()
-						data["acc_cache_value"] = acc_data.GetAccMoneyValue()
+		}
+	case grpc.OperationAddAccountToUser,
+		grpc.OperationRemoveUserAccount,
+		grpc.OperationCheckUSerPassword,
+		grpc.OperationDeleteUser:
+		{
+			break
+		}
+	default:
+		{
+			s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", grpc.ServerTopicUsersProducerRes, operation_name)
+		}
+	}
 
-						account_details := acc_data.GetAccDetails()
+	return s.processEvent(ctx, grpc.ServerTopicUsersProducerRes, event)
+}
 
-						if account_details != nil {
-							data["acc_name"] = account_details.GetAccountName()
-							data["acc_culc_number"] = account_details.GetCulcNumber()
-							data["acc_corr_number"] = account_details.GetCorrNumber()
-							data["acc_bic"] = account_details.GetBic()
-							data["acc_cio"] = account_details.GetCio()
-							data["acc_reserve_reason"] = account_details.GetReserveReason()
-						}
+func (s *Server) handleAccountsResult(ctx context.Context, event_success *accounts_api.EventStatus) error {
 
-					}
+	event := &incomingEvent{
+		data:    make(map[string]interface{}),
+		success: true,
+	}
+	event.sagaUuid, event.eventUuid = s.parseEventUuids(event_success.SagaUuid, event_success.EventUuid)
 
-					break
-				}
-			case grpc.OperationOpenAcc,
-				grpc.OperationCreateAcc,
-				grpc.OperationCloseAccount,
-				grpc.OperationAddAccountCache,
-				grpc.OperationWidthAccountCache,
-				grpc.OperationRemoveAccount:
-				{
-					break
-				}
-			default:
-				{
-					s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", message.Topic, operation_name)
-				}
-			}
+	data := event.data
+	operation_name := event_success.OperationName
 
-			success = true
+	switch operation_name {
+	case grpc.OperationReserveAcc:
+		{
+			data["acc_id"] = event_success.GetInfo()
 
 			break
 		}
-	case grpc.ServerTopicAccountsProducerErr:
+	case grpc.OperationGetAccountData:
 		{
-			event_error := &accounts_api.EventError{}
-			err := proto.Unmarshal(message.Value, event_error)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
+			acc_data := event_success.GetAccData()
+			if acc_data != nil {
+
+				data["acc_status"] = acc_data.GetAccStatus()
+				data["acc_cache"] = acc_data.GetAccMoneyAmount()
+				data["acc_available_cache"] = acc_data.GetAccAvailableAmount()
+				data["acc_cache_value"] = acc_data.GetAccCurrency()
+
+				account_details := acc_data.GetAccDetails()
+
+				if account_details != nil {
+					data["acc_name"] = account_details.GetAccountName()
+					data["acc_culc_number"] = account_details.GetCulcNumber()
+					data["acc_corr_number"] = account_details.GetCorrNumber()
+					data["acc_bic"] = account_details.GetBic()
+					data["acc_cio"] = account_details.GetCio()
+					data["acc_reserve_reason"] = account_details.GetReserveReason()
+				}
 
-			saga_uuid, err = uuid.Parse(event_error.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_error.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
 			}
 
-			data["info"] = event_error.Info
-			data["operation_name"] = event_error.OperationName
-			data["status"] = event_error.Status
-
-			success = false
-
 			break
 		}
-	case grpc.ServerTopicNotificationRes:
+	case grpc.OperationCreateAccountHold:
 		{
-			event_success := &notification_api.EventSuccess{}
-			err := proto.Unmarshal(message.Value, event_success)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
+			data["hold_id"] = event_success.GetHold().GetHoldUuid()
 
-			operation_name := event_success.OperationName
-
-			saga_uuid, err = uuid.Parse(event_success.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_success.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
-			}
+			break
+		}
+	case grpc.OperationCloseAccount,
+		grpc.OperationBlockAccount,
+		grpc.OperationAddAccountCache,
+		grpc.OperationWidthAccountCache,
+		grpc.OperationCaptureAccountHold,
+		grpc.OperationReleaseAccountHold,
+		grpc.OperationTransferAccountCache:
+		{
+			// Account state after operation, used into notification
+			acc_data := event_success.GetAccData()
+			if acc_data != nil {
 
-			switch operation_name {
-			case grpc.OperationCreateUserNotificationSettings,
-				grpc.OperationDeleteUserNotificationSettings:
-				{
+				data["acc_status"] = acc_data.GetAccStatus()
+				data["acc_cache"] = acc_data.GetAccMoneyAmount()
 
+				account_details := acc_data.GetAccDetails()
+				if account_details != nil {
+					data["acc_name"] = account_details.GetAccountName()
 				}
-			default:
-				{
-					s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", message.Topic, operation_name)
-				}
-			}
 
-			success = true
+			}
 
 			break
-
 		}
-	case grpc.ServerTopicNotificationErr:
+	case grpc.OperationOpenAcc,
+		grpc.OperationCreateAcc,
+		grpc.OperationRemoveAccount:
 		{
-			event_error := &notification_api.EventError{}
-			err := proto.Unmarshal(message.Value, event_error)
-			if err != nil {
-				s.logger.Errorf("Error unmarshalling event error: %v", err)
-			}
+			break
+		}
+	default:
+		{
+			s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", grpc.ServerTopicAccountsProducerRes, operation_name)
+		}
+	}
 
-			saga_uuid, err = uuid.Parse(event_error.SagaUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing saga uuid: %v", err)
-			}
-			event_uuid, err = uuid.Parse(event_error.EventUuid)
-			if err != nil {
-				s.logger.Errorf("Error parsing event uuid: %v", err)
-			}
+	return s.processEvent(ctx, grpc.ServerTopicAccountsProducerRes, event)
+}
+
+func (s *Server) handleNotificationResult(ctx context.Context, event_success *notification_api.EventSuccess) error {
 
-			data["info"] = event_error.Info
-			data["operation_name"] = event_error.OperationName
-			data["status"] = event_error.Status
+	event := &incomingEvent{
+		data:    make(map[string]interface{}),
+		success: true,
+	}
+	event.sagaUuid, event.eventUuid = s.parseEventUuids(event_success.SagaUuid, event_success.EventUuid)
 
-			success = false
+	operation_name := event_success.OperationName
+
+	switch operation_name {
+	case grpc.OperationCreateUserNotificationSettings,
+		grpc.OperationDeleteUserNotificationSettings:
+		{
 
-			break
 		}
 	default:
 		{
-			s.logger.Errorf("Message was gotten from wrong topic <%v>!", message.Topic)
+			s.logger.Errorf("Message was gotten from <%v> topic with unknown operation <%v>!", grpc.ServerTopicNotificationRes, operation_name)
+		}
+	}
+
+	return s.processEvent(ctx, grpc.ServerTopicNotificationRes, event)
+}
+
+func handleEventError[T eventError](s *Server, topic string) func(ctx context.Context, event_error T) error {
+	return func(ctx context.Context, event_error T) error {
+
+		event := &incomingEvent{
+			data:    make(map[string]interface{}),
+			success: false,
 		}
+		event.sagaUuid, event.eventUuid = s.parseEventUuids(event_error.GetSagaUuid(), event_error.GetEventUuid())
+
+		event.data["info"] = event_error.GetInfo()
+		event.data["operation_name"] = event_error.GetOperationName()
+		event.data["status"] = event_error.GetStatus()
+
+		return s.processEvent(ctx, topic, event)
 	}
+}
+
+func (s *Server) processEvent(ctx context.Context, topic string, event *incomingEvent) error {
 
-	s.logger.Debug("INCOMING:", message.Topic, "|", event_uuid, "|", success)
+	s.logger.Debug("INCOMING:", topic, "|", event.eventUuid, "|", event.success)
 
 	<-s.sagaSyncChan
 
-	if s.checkSagaProcessing(saga_uuid) {
-		s.logger.Errorf("Error processing event: %v", errors.New("Saga already in process, event uuid:"), event_uuid.String())
-		s.addSagaToProcess(saga_uuid, message)
+	if s.checkSagaProcessing(event.sagaUuid) {
+		s.logger.Errorf("Error processing event: %v", errors.New("Saga already in process, event uuid:"), event.eventUuid.String())
+		s.addSagaToProcess(event.sagaUuid, event)
 		s.sagaSyncChan <- true
-		return
+		return nil
 	}
 
 	s.sagaSyncChan <- true
 
-	s.logger.Debug("Processing event:", event_uuid)
-	err = s.grpcH.Process(context.Background(), saga_uuid, nil, event_uuid, nil, data, success)
+	s.logger.Debug("Processing event:", event.eventUuid)
+	err := s.grpcH.Process(ctx, event.sagaUuid, nil, event.eventUuid, nil, event.data, event.success)
 	if err != nil {
 		s.logger.Errorf("Error processing event: %v", err)
 	}
 
 	<-s.sagaSyncChan
 
-	s.removeSagaFromProcess(saga_uuid)
+	s.removeSagaFromProcess(event.sagaUuid)
 
 	s.sagaSyncChan <- true
 
-	s.startSagaProcessAgain(saga_uuid)
+	s.startSagaProcessAgain(ctx, topic, event.sagaUuid)
 
-	s.logger.Debug("End processing:", event_uuid)
+	s.logger.Debug("End processing:", event.eventUuid)
 
 	return nil
 
 }
 
-func (s *Server) addSagaToProcess(saga_uuid uuid.UUID, message *sarama.ConsumerMessage) {
+func (s *Server) addSagaToProcess(saga_uuid uuid.UUID, event *incomingEvent) {
 
 	if !s.checkSagaProcessing(saga_uuid) {
 
-		s.sagaProcessingSaga[saga_uuid] = append(s.sagaProcessingSaga[saga_uuid], message)
+		s.sagaProcessingSaga[saga_uuid] = append(s.sagaProcessingSaga[saga_uuid], event)
 
 	}
 
@@ -527,13 +468,13 @@ func (s *Server) removeSagaFromProcess(saga_uuid uuid.UUID) {
 
 }
 
-func (s *Server) startSagaProcessAgain(saga_uuid uuid.UUID) {
+func (s *Server) startSagaProcessAgain(ctx context.Context, topic string, saga_uuid uuid.UUID) {
 
 	if s.checkSagaProcessing(saga_uuid) {
 
-		messages, ok := s.sagaProcessingSaga[saga_uuid]
+		events, ok := s.sagaProcessingSaga[saga_uuid]
 		if ok {
-			_ = s.handleData(messages[0])
+			_ = s.processEvent(ctx, topic, events[0])
 		}
 
 	}
@@ -3,8 +3,9 @@ package server
 import (
 	"fmt"
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/totp/pkg/otp"
 
 	//"github.com/GCFactory/dbo-system/service/totp/docs"
@@ -16,7 +17,6 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
-	"net/http"
 	"strings"
 )
 
@@ -69,6 +69,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -85,17 +86,17 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
 	tGroup := v1.Group("/totp")
 	//authGroup := v1.Group("/auth")
 
 	//authHttp.MapAuthRoutes(authGroup, authHandlers, mw)
 	totpHttp.MapTOTPRoutes(tGroup, tHandlers, mw)
 
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/httpErrors"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/totp/internal/models"
 	"github.com/GCFactory/dbo-system/service/totp/internal/totp"
@@ -12,7 +13,6 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"net/http"
 	"time"
@@ -48,8 +48,8 @@ func (t totpHandlers) Enroll() echo.HandlerFunc {
 		UserId   string `json:"user_id"`
 	}
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpH.Enroll")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpH.Enroll")
+		defer span.End()
 
 		user := &User{}
 		result := &models.DefaultHttpRequest{
@@ -125,8 +125,8 @@ func (t totpHandlers) Verify() echo.HandlerFunc {
 		TotpUrl string `json:"totp_url"`
 	}
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Verify")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Verify")
+		defer span.End()
 
 		input := &Url{}
 		result := &models.DefaultHttpRequest{
@@ -187,8 +187,8 @@ func (t totpHandlers) Validate() echo.HandlerFunc {
 		TotpCode string `json:"totp_code"`
 	}
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Validate")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Validate")
+		defer span.End()
 
 		input := &Input{}
 		result := &models.DefaultHttpRequest{
@@ -261,8 +261,8 @@ func (t totpHandlers) Enable() echo.HandlerFunc {
 		TotpId string `json:"totp_id"`
 	}
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Enable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Enable")
+		defer span.End()
 
 		input := &Input{}
 		result := &models.DefaultHttpRequest{
@@ -344,8 +344,8 @@ func (t totpHandlers) Disable() echo.HandlerFunc {
 		TotpId string `json:"totp_id"`
 	}
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Disable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Disable")
+		defer span.End()
 
 		input := &Input{}
 		result := &models.DefaultHttpRequest{
@@ -414,8 +414,8 @@ func (t totpHandlers) Disable() echo.HandlerFunc {
 func (t totpHandlers) Url() echo.HandlerFunc {
 
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Url")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Url")
+		defer span.End()
 
 		operation_result := &models.DefaultHttpRequest{}
 		operation_info := &models.GetTotpUrlBody{}
@@ -5,6 +5,7 @@ import (
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/converter"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/platform/pkg/utils"
 	"github.com/GCFactory/dbo-system/service/totp/internal/models"
 	"github.com/GCFactory/dbo-system/service/totp/internal/totp/mock"
@@ -14,7 +15,6 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -171,8 +171,8 @@ func TestTOTPHandlers_Enroll(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Enroll()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpH.Enroll")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpH.Enroll")
+		defer span.End()
 
 		userId, err := uuid.Parse(inputBody.UserId)
 		require.NoError(t, err)
@@ -211,8 +211,8 @@ func TestTOTPHandlers_Enroll(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Enroll()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpH.Enroll")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpH.Enroll")
+		defer span.End()
 
 		userId, err := uuid.Parse(inputBody.UserId)
 		require.NoError(t, err)
@@ -260,8 +260,8 @@ func TestTOTPHandlers_Enroll(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Enroll()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpH.Enroll")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpH.Enroll")
+		defer span.End()
 
 		userId, err := uuid.Parse(inputBody.UserId)
 		require.NoError(t, err)
@@ -297,8 +297,8 @@ func TestTOTPHandlers_Enroll(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Enroll()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpH.Enroll")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpH.Enroll")
+		defer span.End()
 
 		userId, err := uuid.Parse(inputBody.UserId)
 		require.NoError(t, err)
@@ -405,8 +405,8 @@ func TestTotpHandlers_Verify(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Verify()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Verify")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Verify")
+		defer span.End()
 
 		verifyResult := models.TOTPVerify{
 			Status: "OK",
@@ -438,8 +438,8 @@ func TestTotpHandlers_Verify(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Verify()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Verify")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Verify")
+		defer span.End()
 
 		verifyResult := models.TOTPVerify{
 			Status: totpErrors.NoSecretField.Error(),
@@ -471,8 +471,8 @@ func TestTotpHandlers_Verify(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handleFunc := tHandlers.Verify()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Verify")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Verify")
+		defer span.End()
 
 		mockUC.EXPECT().Verify(ctx, gomock.Eq(inputBody.TotpUrl)).Return(nil, totpUseCase.ErrorRegexCompile)
 
@@ -620,8 +620,8 @@ func TestTotpHandlers_Validate(t *testing.T) {
 		require.NotNil(t, userId)
 		require.NotEqual(t, userId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Validate")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Validate")
+		defer span.End()
 
 		validateResult := models.TOTPValidate{
 			Status: "OK",
@@ -657,8 +657,8 @@ func TestTotpHandlers_Validate(t *testing.T) {
 		require.NotNil(t, userId)
 		require.NotEqual(t, userId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Validate")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Validate")
+		defer span.End()
 
 		validateResult := models.TOTPValidate{
 			Status: totpErrors.NoUserId.Error(),
@@ -694,8 +694,8 @@ func TestTotpHandlers_Validate(t *testing.T) {
 		require.NotNil(t, userId)
 		require.NotEqual(t, userId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Validate")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Validate")
+		defer span.End()
 
 		validateResult := models.TOTPValidate{
 			Status: totpErrors.WrongTotpCode.Error(),
@@ -731,8 +731,8 @@ func TestTotpHandlers_Validate(t *testing.T) {
 		require.NotNil(t, userId)
 		require.NotEqual(t, userId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Validate")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Validate")
+		defer span.End()
 
 		mockUC.EXPECT().Validate(ctx, gomock.Eq(userId), gomock.Eq(inputBody.TotpCode), gomock.Any()).Return(nil, totpUseCase.ErrorRegexCompile)
 
@@ -895,8 +895,8 @@ func TestTotpHandlers_Enable(t *testing.T) {
 		require.NotNil(t, totpId)
 		require.NotEqual(t, totpId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Enable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Enable")
+		defer span.End()
 
 		enableResult := models.TOTPEnable{
 			Status: "OK",
@@ -934,8 +934,8 @@ func TestTotpHandlers_Enable(t *testing.T) {
 		require.NotNil(t, totpId)
 		require.NotEqual(t, totpId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Enable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Enable")
+		defer span.End()
 
 		enableResult := models.TOTPEnable{
 			Status: totpErrors.NoTotpId.Error(),
@@ -973,8 +973,8 @@ func TestTotpHandlers_Enable(t *testing.T) {
 		require.NotNil(t, totpId)
 		require.NotEqual(t, totpId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Enable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Enable")
+		defer span.End()
 
 		enableResult := models.TOTPEnable{
 			Status: totpErrors.TotpIsActive.Error(),
@@ -1012,8 +1012,8 @@ func TestTotpHandlers_Enable(t *testing.T) {
 		require.NotNil(t, totpId)
 		require.NotEqual(t, totpId, uuid.Nil)
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Enable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Enable")
+		defer span.End()
 
 		mockUC.EXPECT().Enable(ctx, gomock.Eq(totpId), gomock.Eq(uuid.Nil)).Return(nil, totpUseCase.ErrorUpdateActivityByTotpId)
 
@@ -1134,8 +1134,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 		require.Nil(t, err)
 		require.NotNil(t, totpId)
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+		defer span.End()
 		mockUC.EXPECT().Disable(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(userId)).Return(&totpDisable, nil)
 
 		expectedBody, _ := json.Marshal(totpDisable)
@@ -1178,8 +1178,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 		require.Nil(t, err)
 		require.NotNil(t, userId)
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+		defer span.End()
 		mockUC.EXPECT().Disable(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(userId)).Return(&totpDisable, nil)
 
 		expectedBody, _ := json.Marshal(totpDisable)
@@ -1222,8 +1222,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 
 		userId := uuid.Nil
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+		defer span.End()
 		mockUC.EXPECT().Disable(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(userId)).Return(&totpDisable, nil)
 
 		expectedBody, _ := json.Marshal(totpDisable)
@@ -1269,8 +1269,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 		require.Nil(t, err)
 		require.NotNil(t, totpId)
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+		defer span.End()
 		mockUC.EXPECT().Disable(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(userId)).Return(&totpDisable, totpErrors.NoTotpId)
 
 		expectedBody, _ := json.Marshal(totpDisable)
@@ -1316,8 +1316,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 		require.Nil(t, err)
 		require.NotNil(t, totpId)
 
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+		defer span.End()
 		mockUC.EXPECT().Disable(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(userId)).Return(&totpDisable, totpErrors.TotpIsDisabled)
 
 		expectedBody, _ := json.Marshal(totpDisable)
@@ -1398,8 +1398,8 @@ func TestTotpHandlers_Disable(t *testing.T) {
 		c := e.NewContext(req, rec)
 		handlerFunc := tHandlers.Disable()
 
-		span, ctx := opentracing.StartSpanFromContext(utils.GetRequestCtx(c), "totpUC.Disable")
-		defer span.Finish()
+		ctx, span := tracing.StartSpan(utils.GetRequestCtx(c), "totpUC.Disable")
+		defer span.End()
 
 		totpId, err := uuid.Parse(inputBody.TotpId)
 		require.Nil(t, err)
@@ -2,11 +2,11 @@ package repository
 
 import (
 	"context"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/totp/internal/models"
 	"github.com/GCFactory/dbo-system/service/totp/internal/totp"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/opentracing/opentracing-go"
 )
 
 type totpRepo struct {
@@ -14,8 +14,8 @@ type totpRepo struct {
 }
 
 func (t totpRepo) CreateConfig(ctx context.Context, totpConfig models.TOTPConfig) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.CreateConfig")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.CreateConfig")
+	defer span.End()
 
 	var s models.TOTPConfig
 
@@ -35,8 +35,8 @@ func (t totpRepo) CreateConfig(ctx context.Context, totpConfig models.TOTPConfig
 }
 
 func (t totpRepo) GetActiveConfig(ctx context.Context, userId uuid.UUID) (*models.TOTPConfig, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.GetActiveConfig")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.GetActiveConfig")
+	defer span.End()
 
 	var s models.TOTPConfig
 
@@ -50,8 +50,8 @@ func (t totpRepo) GetActiveConfig(ctx context.Context, userId uuid.UUID) (*model
 }
 
 func (t totpRepo) GetConfigByTotpId(ctx context.Context, totpId uuid.UUID) (*models.TOTPConfig, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.GetConfigByTotpId")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.GetConfigByTotpId")
+	defer span.End()
 
 	var s models.TOTPConfig
 
@@ -65,8 +65,8 @@ func (t totpRepo) GetConfigByTotpId(ctx context.Context, totpId uuid.UUID) (*mod
 }
 
 func (t totpRepo) UpdateTotpActivityByTotpId(ctx context.Context, totpId uuid.UUID, status bool) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.UpdateActive")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.UpdateActive")
+	defer span.End()
 
 	_, err := t.db.ExecContext(ctx,
 		updateTotpActivityByTotpId,
@@ -80,8 +80,8 @@ func (t totpRepo) UpdateTotpActivityByTotpId(ctx context.Context, totpId uuid.UU
 }
 
 func (t totpRepo) GetConfigByUserId(ctx context.Context, userId uuid.UUID) (*models.TOTPConfig, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.GetConfigByUserId")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.GetConfigByUserId")
+	defer span.End()
 
 	var s models.TOTPConfig
 
@@ -95,8 +95,8 @@ func (t totpRepo) GetConfigByUserId(ctx context.Context, userId uuid.UUID) (*mod
 }
 
 func (t totpRepo) GetLastDisabledConfig(ctx context.Context, userId uuid.UUID) (*models.TOTPConfig, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "totpRepo.GetConfigByUserId")
-	defer span.Finish()
+	ctx, span := tracing.StartSpan(ctx, "totpRepo.GetConfigByUserId")
+	defer span.End()
 
 	var s models.TOTPConfig
 
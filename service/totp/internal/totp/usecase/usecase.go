@@ -4,13 +4,13 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/totp/internal/models"
 	"github.com/GCFactory/dbo-system/service/totp/internal/totp"
 	totpPkg "github.com/GCFactory/dbo-system/service/totp/pkg"
 	totpErrors "github.com/GCFactory/dbo-system/service/totp/pkg/errors"
 	totpPkgConfig "github.com/GCFactory/dbo-system/service/totp/pkg/otp/config"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"regexp"
 	"strconv"
 	"strings"
@@ -25,8 +25,8 @@ type totpUC struct {
 }
 
 func (t totpUC) Enroll(ctx context.Context, totpConfig models.TOTPConfig) (*models.TOTPEnroll, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enroll")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enroll")
+	defer span.End()
 
 	if totpConfig.AccountName == "" {
 		return nil, totpErrors.NoUserName
@@ -96,8 +96,8 @@ func (t totpUC) Verify(ctx context.Context, url string) (*models.TOTPVerify, err
 }
 
 func (t totpUC) Validate(ctx context.Context, id uuid.UUID, code string, time time.Time) (*models.TOTPValidate, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+	defer span.End()
 
 	activeConfig, err := t.totpRepo.GetActiveConfig(ctxWithTrace, id)
 	if err != nil {
@@ -198,8 +198,8 @@ func (t totpUC) Validate(ctx context.Context, id uuid.UUID, code string, time ti
 }
 
 func (t totpUC) Enable(ctx context.Context, totpId uuid.UUID, userId uuid.UUID) (*models.TOTPEnable, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+	defer span.End()
 
 	totpCfg := &models.TOTPConfig{
 		Id: uuid.Nil,
@@ -256,8 +256,8 @@ func (t totpUC) Enable(ctx context.Context, totpId uuid.UUID, userId uuid.UUID)
 }
 
 func (t totpUC) Disable(ctx context.Context, totpId uuid.UUID, userId uuid.UUID) (*models.TOTPDisable, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+	defer span.End()
 
 	totpCfg := &models.TOTPConfig{
 		Id: uuid.Nil,
@@ -308,8 +308,8 @@ func (t totpUC) Disable(ctx context.Context, totpId uuid.UUID, userId uuid.UUID)
 }
 
 func (t totpUC) Url(ctx context.Context, userId uuid.UUID) (*models.TOTPEnroll, error) {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+	defer span.End()
 
 	totpInfo, err := t.totpRepo.GetActiveConfig(ctxWithTrace, userId)
 	if err != nil {
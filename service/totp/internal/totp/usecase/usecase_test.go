@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/totp/internal/models"
 	"github.com/GCFactory/dbo-system/service/totp/internal/totp/mock"
 	totpRepo "github.com/GCFactory/dbo-system/service/totp/internal/totp/repository"
@@ -12,7 +13,6 @@ import (
 	totpPkgConfig "github.com/GCFactory/dbo-system/service/totp/pkg/otp/config"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
 	"os"
 	"testing"
@@ -73,8 +73,8 @@ func TestTotpUC_Enroll(t *testing.T) {
 		require.NotNil(t, url)
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enroll")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enroll")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
 		mockTotp.EXPECT().Generate(gomock.Eq(genOpts)).Return(secret, url, nil)
@@ -96,8 +96,8 @@ func TestTotpUC_Enroll(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enroll")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enroll")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 
@@ -142,8 +142,8 @@ func TestTotpUC_Enroll(t *testing.T) {
 		require.NotNil(t, url)
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enroll")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enroll")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
 		mockTotp.EXPECT().Generate(gomock.Eq(genOpts)).Return(nil, nil, totpPkgConfig.ErrGenerateMissingIssuer)
@@ -173,8 +173,8 @@ func TestTotpUC_Enroll(t *testing.T) {
 		require.NotNil(t, url)
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enroll")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enroll")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
 		mockTotp.EXPECT().Generate(gomock.Eq(genOpts)).Return(secret, url, nil)
@@ -313,8 +313,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -327,8 +327,8 @@ func TestTotpUC_Validate(t *testing.T) {
 	})
 	t.Run("NotFound", func(t *testing.T) {
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
 
@@ -354,8 +354,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return("", totpPkgConfig.ErrValidateSecretInvalidBase32)
@@ -390,8 +390,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -422,8 +422,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -453,8 +453,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -484,8 +484,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -515,8 +515,8 @@ func TestTotpUC_Validate(t *testing.T) {
 		}
 
 		ctx := context.Background()
-		span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Validate")
-		defer span.Finish()
+		ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Validate")
+		defer span.End()
 
 		mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 		mockTotp.EXPECT().GenerateCodeCustom(gomock.Eq(secret), gomock.Eq(time), gomock.Eq(validOpts)).Return(code, nil)
@@ -561,8 +561,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(totpCfg.UserId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -576,8 +576,8 @@ func TestTotpUC_Enable(t *testing.T) {
 		})
 		t.Run("Not found", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(nil, totpRepo.ErrorGetConfigByTotpId)
 
@@ -594,8 +594,8 @@ func TestTotpUC_Enable(t *testing.T) {
 				IsActive: true,
 			}
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 
@@ -619,8 +619,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(totpCfg.UserId)).Return(&activeCfg, nil)
@@ -640,8 +640,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(totpCfg.UserId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -667,8 +667,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -683,8 +683,8 @@ func TestTotpUC_Enable(t *testing.T) {
 		})
 		t.Run("Not found", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetConfiByUserId)
 
@@ -705,8 +705,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&activeCfg, nil)
@@ -724,8 +724,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -749,8 +749,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -775,8 +775,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -790,8 +790,8 @@ func TestTotpUC_Enable(t *testing.T) {
 		})
 		t.Run("Not found", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(nil, totpRepo.ErrorGetConfigByTotpId)
 
@@ -809,8 +809,8 @@ func TestTotpUC_Enable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Enable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Enable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 
@@ -863,8 +863,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&configTOTP, nil)
 			mockRepo.EXPECT().UpdateTotpActivityByTotpId(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(false)).Return(nil)
@@ -877,8 +877,8 @@ func TestTotpUC_Disable(t *testing.T) {
 		})
 		t.Run("NotFound", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(nil, totpRepo.ErrorGetConfigByTotpId)
 
@@ -896,8 +896,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&configTOTP, nil)
 
@@ -914,8 +914,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&configTOTP, nil)
 			mockRepo.EXPECT().UpdateTotpActivityByTotpId(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(false)).Return(totpRepo.ErrorUpdateTotpActivityByTotpId)
@@ -941,8 +941,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&userConfigTOTP, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&userActiveCfg, nil)
@@ -956,8 +956,8 @@ func TestTotpUC_Disable(t *testing.T) {
 		})
 		t.Run("NotFound", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetConfiByUserId)
 
@@ -975,8 +975,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&userConfigTOTP, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(nil, totpRepo.ErrorGetActiveConfig)
@@ -999,8 +999,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByUserId(ctxWithTrace, gomock.Eq(userId)).Return(&userConfigTOTP, nil)
 			mockRepo.EXPECT().GetActiveConfig(ctxWithTrace, gomock.Eq(userId)).Return(&userActiveCfg, nil)
@@ -1036,8 +1036,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().UpdateTotpActivityByTotpId(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(false)).Return(nil)
@@ -1050,8 +1050,8 @@ func TestTotpUC_Disable(t *testing.T) {
 		})
 		t.Run("Not found", func(t *testing.T) {
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(nil, totpRepo.ErrorGetConfigByTotpId)
 
@@ -1070,8 +1070,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 
@@ -1090,8 +1090,8 @@ func TestTotpUC_Disable(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "totpUC.Disable")
-			defer span.Finish()
+			ctxWithTrace, span := tracing.StartSpan(ctx, "totpUC.Disable")
+			defer span.End()
 
 			mockRepo.EXPECT().GetConfigByTotpId(ctxWithTrace, gomock.Eq(totpId)).Return(&totpCfg, nil)
 			mockRepo.EXPECT().UpdateTotpActivityByTotpId(ctxWithTrace, gomock.Eq(totpId), gomock.Eq(false)).Return(ErrorUpdateActivityByTotpId)
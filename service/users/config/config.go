@@ -15,9 +15,9 @@ type Config struct {
 	Version    string                      `yaml:"version"`
 	HTTPServer HTTPServerConfig            `yaml:"http-server,omitempty" mapstructure:"http-server"`
 
-	Logger  platformConfig.Logger `yaml:"logger"`
-	Jaeger  Jaeger                `yaml:"jaeger"`
-	Metrics Metrics               `yaml:"metrics"`
+	Logger  platformConfig.Logger  `yaml:"logger"`
+	Tracing platformConfig.Tracing `yaml:"tracing"`
+	Metrics Metrics                `yaml:"metrics"`
 	Docs    Docs
 
 	KafkaConsumer KafkaConsumer `yaml:"kafkaConsumer"`
@@ -17,6 +17,8 @@ type Passport struct {
 	Authority           string    `json:"authority" db:"authority" validate:"required,len=7"`
 	Authority_date      string    `json:"authority_date" db:"authority_date" validate:"required,datetime"`
 	Registration_adress string    `json:"registration_adress" db:"registration_adress" validate:"required"`
+	// Passport scans in file-api, kept in passport_file table
+	Files []uuid.UUID `json:"files" db:"-"`
 }
 
 type ListOfAccounts struct {
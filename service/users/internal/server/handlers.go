@@ -2,15 +2,15 @@ package server
 
 import (
 	"github.com/GCFactory/dbo-system/platform/pkg/csrf"
+	"github.com/GCFactory/dbo-system/platform/pkg/health"
 	"github.com/GCFactory/dbo-system/platform/pkg/metric"
-	"github.com/GCFactory/dbo-system/platform/pkg/utils"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	usersMiddleware "github.com/GCFactory/dbo-system/service/users/internal/middleware"
 	delivery "github.com/GCFactory/dbo-system/service/users/internal/users/delivery/http"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/repository"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/usecase"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
-	"net/http"
 	"strings"
 )
 
@@ -27,7 +27,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	usersRepo := repository.NewUserRepository(s.db)
 
-	usersUC := usecase.NewUsersUseCase(s.cfg, usersRepo, s.logger)
+	usersUC := usecase.NewUsersUseCase(s.cfg, usersRepo, s.files, s.logger)
 
 	usersHandlers := delivery.NewUsersHandlers(s.cfg, usersUC, s.logger)
 
@@ -53,6 +53,7 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(tracing.EchoMiddleware(s.cfg.Tracing.ServiceName))
 	e.Use(mw.MetricsMiddleware(metrics))
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
@@ -69,15 +70,16 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	v1 := e.Group("/api/v1")
 
-	health := e.Group("/health/ready")
 	usersGroup := v1.Group("/users")
 
 	delivery.MapNotificationRoutes(usersGroup, usersHandlers, mw)
 
-	health.GET("", func(c echo.Context) error {
-		s.logger.Infof("Health check RequestID: %s", utils.GetRequestID(c))
-		return c.JSON(http.StatusOK, map[string]string{"status": "OK"})
-	})
+	healthChecks := health.NewHealth(
+		s.cfg.Health,
+		health.PostgresChecker(s.db),
+		health.KafkaChecker("kafka", s.cfg.KafkaConsumer.Brokers),
+	)
+	healthChecks.MapRoutes(e.Group("/health"))
 
 	return nil
 }
@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
-	"errors"
 	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
 	"github.com/GCFactory/dbo-system/service/users/internal/users"
+	usersFiles "github.com/GCFactory/dbo-system/service/users/internal/users/files"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/repository"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/usecase"
 	"github.com/golang/protobuf/proto"
@@ -13,7 +15,6 @@ import (
 
 	api "github.com/GCFactory/dbo-system/service/users/gen_proto/proto/user_api"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/grpc_handlers"
-	"github.com/GCFactory/dbo-system/service/users/pkg/kafka"
 	"github.com/IBM/sarama"
 	"github.com/jmoiron/sqlx"
 	"net/http"
@@ -40,23 +41,27 @@ type Server struct {
 	cfg           *config.Config
 	db            *sqlx.DB
 	logger        logger.Logger
-	// Channel to control goroutines
-	kafkaConsumerChan chan int
-	grpcHandlers      users.GRPCHandlers
+	files         users.Files
+	grpcHandlers  users.GRPCHandlers
 }
 
-func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, logger logger.Logger) *Server {
+func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *kafka.ProducerProvider, db *sqlx.DB, metrics metric.BusinessMetrics, logger logger.Logger) *Server {
 	server := Server{
-		echo:              echo.New(),
-		cfg:               cfg,
-		db:                db,
-		logger:            logger,
-		kafkaConsumer:     kConsumer,
-		kafkaConsumerChan: make(chan int, 3),
-		kafkaProducer:     kProducer,
+		echo:          echo.New(),
+		cfg:           cfg,
+		db:            db,
+		logger:        logger,
+		kafkaConsumer: kConsumer,
+		kafkaProducer: kProducer,
 	}
 	server.echo.HidePort = true
 	server.echo.HideBanner = true
+	if cfg.FileApi.Address != "" {
+		server.files = usersFiles.NewFileApiClient(cfg.FileApi.Address, time.Second*cfg.FileApi.Timeout)
+	} else {
+		logger.Warn("file-api address isn't set, passport files of removed users are kept")
+		server.files = usersFiles.NewNoopFiles()
+	}
 	usersRepo := repository.NewUserRepository(db)
 	usersUC := usecase.NewUsersUseCase(&config.Config{
 		Env:      cfg.Env,
@@ -64,8 +69,11 @@ func NewServer(cfg *config.Config, kConsumer *kafka.ConsumerGroup, kProducer *ka
 		App:      cfg.App,
 		Postgres: cfg.Postgres,
 		Version:  cfg.Version,
-	}, usersRepo, logger)
-	server.grpcHandlers = grpc_handlers.NewUsersGRPCHandlers(cfg, kProducer, usersUC, logger)
+	}, usersRepo, server.files, logger)
+	server.grpcHandlers = grpc_handlers.NewUsersGRPCHandlers(cfg, kProducer, usersUC, logger, metrics)
+	for _, topic := range cfg.KafkaConsumer.Topics {
+		kConsumer.Handle(topic, server.handleData)
+	}
 	return &server
 }
 
@@ -109,55 +117,30 @@ func (s *Server) Run() error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-
-	for {
-		select {
-		// If goroutine exit for any reason - restart
-		case <-s.kafkaConsumerChan:
-			s.logger.Warn("Received end of kafka consumer goroutine")
-			go s.RunKafkaConsumer(ctxWithCancel, s.kafkaConsumerChan)
-		// Handle system interrupts
-		case <-quit:
-			ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-			s.kafkaConsumer.Consumer.PauseAll()
-			s.kafkaConsumer.Consumer.Close()
-			time.Sleep(time.Second * 5)
-			close(quit)
-			close(s.kafkaConsumerChan)
-			defer shutdown()
-			defer cancel()
-
-			s.logger.Info("Server Exited Properly")
-			return s.echo.Server.Shutdown(ctx)
+	go func() {
+		if err := s.kafkaConsumer.Run(ctxWithCancel); err != nil {
+			s.logger.Errorf("Kafka consumer stopped: %v", err)
 		}
-	}
-}
+	}()
+
+	<-quit
+	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+	defer shutdown()
 
-func (s *Server) RunKafkaConsumer(ctx context.Context, quitChan chan<- int) {
-	consumer := kafka.Consumer{
-		Ready:       make(chan bool),
-		HandlerFunc: s.handleData,
+	if err := s.kafkaConsumer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka consumer: %v", err)
 	}
-	for {
-		if err := s.kafkaConsumer.Consumer.Consume(ctx, s.cfg.KafkaConsumer.Topics, &consumer); err != nil {
-			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
-				return
-			}
-			quitChan <- 1
-			s.logger.Errorf("Error from consumer: %v", err)
-		}
-		// check if context was cancelled, signaling that the consumer should stop
-		if ctx.Err() != nil {
-			s.logger.Infof("Stopping kafka consumer: context close: %v", ctx.Err())
-			return
-		}
-		s.logger.Info("Stopping kafka consumer...")
-		consumer.Ready = make(chan bool)
+	cancel()
+	<-s.kafkaConsumer.Done()
+	if err := s.kafkaProducer.Close(); err != nil {
+		s.logger.Errorf("Error on close kafka producer: %v", err)
 	}
+
+	s.logger.Info("Server Exited Properly")
+	return s.echo.Server.Shutdown(ctx)
 }
 
-func (s *Server) handleData(message *sarama.ConsumerMessage) error {
+func (s *Server) handleData(ctx context.Context, message *sarama.ConsumerMessage) error {
 
 	data := &api.EventData{}
 	err := proto.Unmarshal(message.Value, data)
@@ -175,7 +158,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -188,7 +171,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetUserInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.AddUser(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.AddUser(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -199,7 +182,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.GetUserData(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.GetUserData(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -210,7 +193,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.UpdateUsersPassport(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.UpdateUsersPassport(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -221,7 +204,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.AddUserAccount(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.AddUserAccount(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -232,7 +215,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.RemoveUserAccount(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.RemoveUserAccount(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -243,7 +226,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.GetUsersAccounts(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.GetUsersAccounts(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -254,7 +237,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.UpdateUserPassword(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.UpdateUserPassword(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -265,7 +248,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.GetUserDataByLogin(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.GetUserDataByLogin(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -276,7 +259,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.CheckUserPassword(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.CheckUserPassword(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -287,7 +270,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 		{
 			// Unpack data and handle func
 			if extracted_data := data.GetAdditionalInfo(); extracted_data != nil {
-				if err = s.grpcHandlers.DeleteUser(context.Background(), data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
+				if err = s.grpcHandlers.DeleteUser(ctx, data.GetSagaUuid(), data.GetEventUuid(), extracted_data, s.kafkaProducer); err != nil {
 					return err
 				}
 			} else {
@@ -308,7 +291,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 				s.logger.Error(err)
 				return err
 			}
-			err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+			err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 			if err != nil {
 				s.logger.Error(err)
 				return err
@@ -329,7 +312,7 @@ func (s *Server) handleData(message *sarama.ConsumerMessage) error {
 			s.logger.Error(err)
 			return err
 		}
-		err = s.kafkaProducer.ProduceRecord(grpc_handlers.TopicError, sarama.ByteEncoder(answer_data))
+		err = s.kafkaProducer.ProduceRecord(ctx, grpc_handlers.TopicError, answer_data)
 		if err != nil {
 			s.logger.Error(err)
 			return err
@@ -0,0 +1,13 @@
+//go:generate mockgen -source files.go -destination mock/files_mock.go -package mock
+
+package users
+
+import (
+	"context"
+	"github.com/google/uuid"
+)
+
+// Storage of passport scans (file-api)
+type Files interface {
+	DeleteFile(ctx context.Context, fileId uuid.UUID) error
+}
@@ -0,0 +1,7 @@
+package files
+
+import "errors"
+
+var (
+	ErrorDeleteFile = errors.New("Error deleting file")
+)
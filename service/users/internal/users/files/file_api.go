@@ -0,0 +1,55 @@
+package files
+
+import (
+	"context"
+	"fmt"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
+	"github.com/GCFactory/dbo-system/service/users/internal/users"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	fileApiDeleteFile = "/deletefile/"
+	maxErrorBodySize  = 1 << 10
+)
+
+// Client of file-api HTTP gateway
+type fileApiClient struct {
+	client  *http.Client
+	address string
+}
+
+func (c fileApiClient) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	ctxWithTrace, span := tracing.StartSpan(ctx, "fileApiClient.DeleteFile")
+	defer span.End()
+
+	request, err := http.NewRequestWithContext(ctxWithTrace, http.MethodDelete, c.address+fileApiDeleteFile+fileId.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// File is already gone
+	if response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+		return fmt.Errorf("%w %s: %s %s", ErrorDeleteFile, fileId, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func NewFileApiClient(address string, timeout time.Duration) users.Files {
+	return fileApiClient{client: &http.Client{Timeout: timeout}, address: strings.TrimRight(address, "/")}
+}
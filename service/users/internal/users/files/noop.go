@@ -0,0 +1,18 @@
+package files
+
+import (
+	"context"
+	"github.com/GCFactory/dbo-system/service/users/internal/users"
+	"github.com/google/uuid"
+)
+
+// Used when file-api isn't configured, files are left in place
+type noopFiles struct{}
+
+func (noopFiles) DeleteFile(ctx context.Context, fileId uuid.UUID) error {
+	return nil
+}
+
+func NewNoopFiles() users.Files {
+	return noopFiles{}
+}
@@ -1,8 +1,8 @@
 package users
 
 import (
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	api "github.com/GCFactory/dbo-system/service/users/gen_proto/proto/user_api"
-	"github.com/GCFactory/dbo-system/service/users/pkg/kafka"
 	"golang.org/x/net/context"
 )
 
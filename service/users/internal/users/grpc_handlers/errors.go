@@ -19,6 +19,8 @@ var (
 		repository.ErrorAddUser:          60,
 		repository.ErrorAddPassport:      70,
 		repository.ErrorUpdatePassword:   80,
+		repository.ErrorAddPassportFile:  90,
+		repository.ErrorGetPassportFiles: 100,
 
 		usecase.ErrorAccountAlreadyExists: 200,
 		usecase.ErrorUserExists:           210,
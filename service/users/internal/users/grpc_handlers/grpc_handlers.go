@@ -3,17 +3,17 @@ package grpc_handlers
 import (
 	"context"
 	"github.com/GCFactory/dbo-system/platform/config"
+	"github.com/GCFactory/dbo-system/platform/pkg/kafka"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/metric"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/users/gen_proto/proto/platform"
 	api "github.com/GCFactory/dbo-system/service/users/gen_proto/proto/user_api"
 	"github.com/GCFactory/dbo-system/service/users/internal/models"
 	"github.com/GCFactory/dbo-system/service/users/internal/users"
-	"github.com/GCFactory/dbo-system/service/users/pkg/kafka"
-	"github.com/IBM/sarama"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"time"
 )
 
@@ -22,12 +22,13 @@ type UsersGrpcHandlers struct {
 	kProducer *kafka.ProducerProvider
 	usersUC   users.UseCase
 	accLog    logger.Logger
+	metrics   metric.SagaMetrics
 }
 
 func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string, event_uuid string, users_data *api.UserInfo, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.AddUser")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.AddUser")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -52,9 +53,37 @@ func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string
 
 	passport_uuid := uuid.New()
 
+	var passport_files []uuid.UUID
+	for _, file := range users_data.GetPassportFiles() {
+		file_uuid, err := uuid.Parse(file)
+		if err != nil {
+			passport_files = nil
+			flag_error = true
+			answer_topic = TopicError
+
+			error_answer.Info = ErrorInvalidInputData.Error()
+			error_answer.Status = GetErrorCode(ErrorInvalidInputData)
+			break
+		}
+		passport_files = append(passport_files, file_uuid)
+	}
+
+	// Caller sets user UUID when passport scans are uploaded with new user as owner
+	user_uuid := uuid.New()
+	if users_data.GetUserUuid() != "" {
+		user_uuid, err = uuid.Parse(users_data.GetUserUuid())
+		if err != nil && !flag_error {
+			flag_error = true
+			answer_topic = TopicError
+
+			error_answer.Info = ErrorInvalidInputData.Error()
+			error_answer.Status = GetErrorCode(ErrorInvalidInputData)
+		}
+	}
+
 	user := &models.User_full_data{
 		User: &models.User{
-			User_uuid:     uuid.New(),
+			User_uuid:     user_uuid,
 			User_inn:      users_data.GetUserInn(),
 			User_accounts: models.ListOfAccounts{},
 			Passport_uuid: passport_uuid,
@@ -74,10 +103,13 @@ func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string
 			Authority_date:      passport.GetAuthorityDate().AsTime().Format("2006-01-02 15:04:05 -07:00:00"),
 			Authority:           passport.GetAuthority(),
 			Registration_adress: passport.GetRegistrationAdress(),
+			Files:               passport_files,
 		},
 	}
 
-	if err = usersGRPC.usersUC.AddUser(ctxWithTrace, user); err != nil {
+	if flag_error {
+		usersGRPC.accLog.Error(ErrorInvalidInputData)
+	} else if err = usersGRPC.usersUC.AddUser(ctxWithTrace, user); err != nil {
 		usersGRPC.accLog.Error(err)
 		flag_error = true
 		answer_topic = TopicError
@@ -89,8 +121,10 @@ func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -99,7 +133,7 @@ func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -111,8 +145,8 @@ func (usersGRPC UsersGrpcHandlers) AddUser(ctx context.Context, saga_uuid string
 
 func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.GetUserData")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.GetUserData")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -158,6 +192,12 @@ func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid st
 			accounts = append(accounts, user.User_accounts.Data[i].String())
 		}
 
+		var passport_files []string
+
+		for _, file_uuid := range passport.Files {
+			passport_files = append(passport_files, file_uuid.String())
+		}
+
 		birth_date, err := time.Parse(time.RFC3339, passport.Birth_date)
 		if err != nil {
 			return err
@@ -176,6 +216,7 @@ func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid st
 				Accounts: &api.ListOfAccounts{
 					Accounts: accounts,
 				},
+				PassportFiles: passport_files,
 				Passport: &platform.Passport{
 					Authority: passport.Authority,
 					Fcs: &platform.FCs{
@@ -202,8 +243,10 @@ func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid st
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -212,7 +255,7 @@ func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid st
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -224,8 +267,8 @@ func (usersGRPC UsersGrpcHandlers) GetUserData(ctx context.Context, saga_uuid st
 
 func (usersGRPC UsersGrpcHandlers) UpdateUsersPassport(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.UpdateUsersPassport")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.UpdateUsersPassport")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -285,8 +328,10 @@ func (usersGRPC UsersGrpcHandlers) UpdateUsersPassport(ctx context.Context, saga
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -295,7 +340,7 @@ func (usersGRPC UsersGrpcHandlers) UpdateUsersPassport(ctx context.Context, saga
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -306,8 +351,8 @@ func (usersGRPC UsersGrpcHandlers) UpdateUsersPassport(ctx context.Context, saga
 }
 
 func (usersGRPC UsersGrpcHandlers) DeleteUser(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.DeleteUser")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.DeleteUser")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -348,9 +393,11 @@ func (usersGRPC UsersGrpcHandlers) DeleteUser(ctx context.Context, saga_uuid str
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 		answer_topic = TopicError
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -359,7 +406,7 @@ func (usersGRPC UsersGrpcHandlers) DeleteUser(ctx context.Context, saga_uuid str
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -371,8 +418,8 @@ func (usersGRPC UsersGrpcHandlers) DeleteUser(ctx context.Context, saga_uuid str
 
 func (usersGRPC UsersGrpcHandlers) AddUserAccount(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.AddUserAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.AddUserAccount")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -419,8 +466,10 @@ func (usersGRPC UsersGrpcHandlers) AddUserAccount(ctx context.Context, saga_uuid
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -429,7 +478,7 @@ func (usersGRPC UsersGrpcHandlers) AddUserAccount(ctx context.Context, saga_uuid
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -441,8 +490,8 @@ func (usersGRPC UsersGrpcHandlers) AddUserAccount(ctx context.Context, saga_uuid
 
 func (usersGRPC UsersGrpcHandlers) RemoveUserAccount(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.RemoveUserAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.RemoveUserAccount")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -489,8 +538,10 @@ func (usersGRPC UsersGrpcHandlers) RemoveUserAccount(ctx context.Context, saga_u
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -499,7 +550,7 @@ func (usersGRPC UsersGrpcHandlers) RemoveUserAccount(ctx context.Context, saga_u
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -511,8 +562,8 @@ func (usersGRPC UsersGrpcHandlers) RemoveUserAccount(ctx context.Context, saga_u
 
 func (usersGRPC UsersGrpcHandlers) GetUsersAccounts(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.GetUsersAccounts")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.GetUsersAccounts")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -564,8 +615,10 @@ func (usersGRPC UsersGrpcHandlers) GetUsersAccounts(ctx context.Context, saga_uu
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -574,7 +627,7 @@ func (usersGRPC UsersGrpcHandlers) GetUsersAccounts(ctx context.Context, saga_uu
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -586,8 +639,8 @@ func (usersGRPC UsersGrpcHandlers) GetUsersAccounts(ctx context.Context, saga_uu
 
 func (usersGRPC UsersGrpcHandlers) UpdateUserPassword(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.UpdateUserPassword")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.UpdateUserPassword")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -631,8 +684,10 @@ func (usersGRPC UsersGrpcHandlers) UpdateUserPassword(ctx context.Context, saga_
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -641,7 +696,7 @@ func (usersGRPC UsersGrpcHandlers) UpdateUserPassword(ctx context.Context, saga_
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -654,8 +709,8 @@ func (usersGRPC UsersGrpcHandlers) UpdateUserPassword(ctx context.Context, saga_
 
 func (usersGRPC UsersGrpcHandlers) GetUserDataByLogin(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.GetUserDataByLogin")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.GetUserDataByLogin")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -705,8 +760,10 @@ func (usersGRPC UsersGrpcHandlers) GetUserDataByLogin(ctx context.Context, saga_
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -715,7 +772,7 @@ func (usersGRPC UsersGrpcHandlers) GetUserDataByLogin(ctx context.Context, saga_
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -728,8 +785,8 @@ func (usersGRPC UsersGrpcHandlers) GetUserDataByLogin(ctx context.Context, saga_
 
 func (usersGRPC UsersGrpcHandlers) CheckUserPassword(ctx context.Context, saga_uuid string, event_uuid string, operation_details *api.OperationDetails, kProducer *kafka.ProducerProvider) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "usersGRPC.CheckUserPassword")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "usersGRPC.CheckUserPassword")
+	defer span.End()
 
 	var answer_data []byte
 	flag_error := false
@@ -772,8 +829,10 @@ func (usersGRPC UsersGrpcHandlers) CheckUserPassword(ctx context.Context, saga_u
 	}
 
 	if flag_error {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusError)
 		answer_data, err = proto.Marshal(error_answer)
 	} else {
+		usersGRPC.metrics.IncEvent(answer.GetOperationName(), metric.StatusSuccess)
 		answer_data, err = proto.Marshal(answer)
 	}
 
@@ -782,7 +841,7 @@ func (usersGRPC UsersGrpcHandlers) CheckUserPassword(ctx context.Context, saga_u
 		return err
 	}
 
-	err = kProducer.ProduceRecord(answer_topic, sarama.ByteEncoder(answer_data))
+	err = kProducer.ProduceRecord(ctxWithTrace, answer_topic, answer_data)
 	if err != nil {
 		usersGRPC.accLog.Error(err)
 		return err
@@ -792,6 +851,6 @@ func (usersGRPC UsersGrpcHandlers) CheckUserPassword(ctx context.Context, saga_u
 	return nil
 }
 
-func NewUsersGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, usersUC users.UseCase, accLog logger.Logger) users.GRPCHandlers {
-	return &UsersGrpcHandlers{cfg: cfg, kProducer: kProducer, usersUC: usersUC, accLog: accLog}
+func NewUsersGRPCHandlers(cfg *config.Config, kProducer *kafka.ProducerProvider, usersUC users.UseCase, accLog logger.Logger, metrics metric.SagaMetrics) users.GRPCHandlers {
+	return &UsersGrpcHandlers{cfg: cfg, kProducer: kProducer, usersUC: usersUC, accLog: accLog, metrics: metrics}
 }
@@ -50,6 +50,35 @@ func (mr *MockRepositoryMockRecorder) AddUser(ctx, user_data interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockRepository)(nil).AddUser), ctx, user_data)
 }
 
+// DeleteUser mocks base method.
+func (m *MockRepository) DeleteUser(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryMockRecorder) DeleteUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), ctx, userId)
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", ctx, login)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockRepositoryMockRecorder) GetUserByLogin(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockRepository)(nil).GetUserByLogin), ctx, login)
+}
+
 // GetUserData mocks base method.
 func (m *MockRepository) GetUserData(ctx context.Context, user_uuid uuid.UUID) (*models.User_full_data, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +109,21 @@ func (mr *MockRepositoryMockRecorder) GetUsersAccounts(ctx, user_uuid interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersAccounts", reflect.TypeOf((*MockRepository)(nil).GetUsersAccounts), ctx, user_uuid)
 }
 
+// IsPassportFile mocks base method.
+func (m *MockRepository) IsPassportFile(ctx context.Context, file_uuid uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPassportFile", ctx, file_uuid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPassportFile indicates an expected call of IsPassportFile.
+func (mr *MockRepositoryMockRecorder) IsPassportFile(ctx, file_uuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPassportFile", reflect.TypeOf((*MockRepository)(nil).IsPassportFile), ctx, file_uuid)
+}
+
 // UpdatePassport mocks base method.
 func (m *MockRepository) UpdatePassport(ctx context.Context, passport *models.Passport) error {
 	m.ctrl.T.Helper()
@@ -107,3 +151,31 @@ func (mr *MockRepositoryMockRecorder) UpdateUserAccount(ctx, user_uuid, accounts
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAccount", reflect.TypeOf((*MockRepository)(nil).UpdateUserAccount), ctx, user_uuid, accounts)
 }
+
+// UpdateUserPassw mocks base method.
+func (m *MockRepository) UpdateUserPassw(ctx context.Context, user_uuid uuid.UUID, new_passw string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassw", ctx, user_uuid, new_passw)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassw indicates an expected call of UpdateUserPassw.
+func (mr *MockRepositoryMockRecorder) UpdateUserPassw(ctx, user_uuid, new_passw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassw", reflect.TypeOf((*MockRepository)(nil).UpdateUserPassw), ctx, user_uuid, new_passw)
+}
+
+// UpdateUserTotp mocks base method.
+func (m *MockRepository) UpdateUserTotp(ctx context.Context, userUuid, totpId uuid.UUID, totpUsage bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserTotp", ctx, userUuid, totpId, totpUsage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserTotp indicates an expected call of UpdateUserTotp.
+func (mr *MockRepositoryMockRecorder) UpdateUserTotp(ctx, userUuid, totpId, totpUsage interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserTotp", reflect.TypeOf((*MockRepository)(nil).UpdateUserTotp), ctx, userUuid, totpId, totpUsage)
+}
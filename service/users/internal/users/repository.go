@@ -11,6 +11,7 @@ import (
 type Repository interface {
 	AddUser(ctx context.Context, user_data *models.User_full_data) error
 	GetUserData(ctx context.Context, user_uuid uuid.UUID) (*models.User_full_data, error)
+	IsPassportFile(ctx context.Context, file_uuid uuid.UUID) (bool, error)
 	UpdatePassport(ctx context.Context, passport *models.Passport) error
 	DeleteUser(ctx context.Context, userId uuid.UUID) error
 	UpdateUserAccount(ctx context.Context, user_uuid uuid.UUID, accounts string) error
@@ -3,14 +3,17 @@ package repository
 import "errors"
 
 var (
-	ErrorAddPassport      = errors.New("Error adding passport data")
-	ErrorAddUser          = errors.New("Error adding user data")
-	ErrorGetPassport      = errors.New("Error getting passport")
-	ErrorGetUser          = errors.New("Error getting user")
-	ErrorUpdatePassport   = errors.New("Error updating passport")
-	ErrorUpdateAccounts   = errors.New("Error updating accounts")
-	ErrorGetUsersAccounts = errors.New("Error getting users accounts")
-	ErrorUpdatePassword   = errors.New("Error update password")
-	ErrorNoUserFound      = errors.New("No user found")
-	ErrorUpdateTotpInfo   = errors.New("Error update totp info")
+	ErrorAddPassport       = errors.New("Error adding passport data")
+	ErrorAddPassportFile   = errors.New("Error adding passport file")
+	ErrorAddUser           = errors.New("Error adding user data")
+	ErrorGetPassport       = errors.New("Error getting passport")
+	ErrorGetPassportFiles  = errors.New("Error getting passport files")
+	ErrorCheckPassportFile = errors.New("Error checking passport file")
+	ErrorGetUser           = errors.New("Error getting user")
+	ErrorUpdatePassport    = errors.New("Error updating passport")
+	ErrorUpdateAccounts    = errors.New("Error updating accounts")
+	ErrorGetUsersAccounts  = errors.New("Error getting users accounts")
+	ErrorUpdatePassword    = errors.New("Error update password")
+	ErrorNoUserFound       = errors.New("No user found")
+	ErrorUpdateTotpInfo    = errors.New("Error update totp info")
 )
@@ -18,6 +18,24 @@ const (
 		) 
 		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`
 
+	AddPassportFile = `insert into passport_file
+		(
+			passport_uuid,
+			file_uuid
+		)
+		values ($1, $2);`
+
+	GetPassportFiles = `select file_uuid
+		from passport_file
+		where passport_uuid = $1;`
+
+	IsPassportFile = `select exists
+		(
+			select 1
+			from passport_file
+			where file_uuid = $1
+		);`
+
 	AddUser = `insert into users 
     	(
 			user_uuid,
@@ -3,11 +3,11 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/users/internal/models"
 	"github.com/GCFactory/dbo-system/service/users/internal/users"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
-	"github.com/opentracing/opentracing-go"
 )
 
 type UserRepository struct {
@@ -15,10 +15,10 @@ type UserRepository struct {
 }
 
 func (repo UserRepository) AddUser(ctx context.Context, user_data *models.User_full_data) error {
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.AddUser")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.AddUser")
+	defer span.End()
 
-	tx, err := repo.db.Begin()
+	tx, err := repo.db.BeginTxx(local_ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -26,7 +26,7 @@ func (repo UserRepository) AddUser(ctx context.Context, user_data *models.User_f
 
 	passport := user_data.Passport
 
-	if _, err = repo.db.ExecContext(local_ctx,
+	if _, err = tx.ExecContext(local_ctx,
 		AddPassport,
 		passport.Passport_uuid,
 		passport.Passport_series,
@@ -44,11 +44,24 @@ func (repo UserRepository) AddUser(ctx context.Context, user_data *models.User_f
 		return ErrorAddPassport
 	}
 
+	for _, file_uuid := range passport.Files {
+		if _, err = tx.ExecContext(local_ctx,
+			AddPassportFile,
+			passport.Passport_uuid,
+			file_uuid,
+		); err != nil {
+			return ErrorAddPassportFile
+		}
+	}
+
 	user := user_data.User
 
 	accounts, err := json.Marshal(user.User_accounts)
+	if err != nil {
+		return ErrorAddUser
+	}
 
-	if _, err = repo.db.ExecContext(local_ctx,
+	if _, err = tx.ExecContext(local_ctx,
 		AddUser,
 		user.User_uuid,
 		user.Passport_uuid,
@@ -69,8 +82,8 @@ func (repo UserRepository) AddUser(ctx context.Context, user_data *models.User_f
 
 func (repo UserRepository) GetUserData(ctx context.Context, user_uuid uuid.UUID) (*models.User_full_data, error) {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.GetUserData")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.GetUserData")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -102,6 +115,14 @@ func (repo UserRepository) GetUserData(ctx context.Context, user_uuid uuid.UUID)
 		return nil, ErrorGetPassport
 	}
 
+	if err = repo.db.SelectContext(local_ctx,
+		&passport.Files,
+		GetPassportFiles,
+		&user.Passport_uuid,
+	); err != nil {
+		return nil, ErrorGetPassportFiles
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, err
 	}
@@ -112,10 +133,27 @@ func (repo UserRepository) GetUserData(ctx context.Context, user_uuid uuid.UUID)
 	}, nil
 }
 
+func (repo UserRepository) IsPassportFile(ctx context.Context, file_uuid uuid.UUID) (bool, error) {
+
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.IsPassportFile")
+	defer span.End()
+
+	var result bool
+
+	if err := repo.db.QueryRowxContext(local_ctx,
+		IsPassportFile,
+		&file_uuid,
+	).Scan(&result); err != nil {
+		return false, ErrorCheckPassportFile
+	}
+
+	return result, nil
+}
+
 func (repo UserRepository) UpdatePassport(ctx context.Context, passport *models.Passport) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.UpdatePassport")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.UpdatePassport")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -154,8 +192,8 @@ func (repo UserRepository) UpdatePassport(ctx context.Context, passport *models.
 
 func (repo UserRepository) UpdateUserAccount(ctx context.Context, user_uuid uuid.UUID, accounts string) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.UpdateUserAccount")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.UpdateUserAccount")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -184,8 +222,8 @@ func (repo UserRepository) UpdateUserAccount(ctx context.Context, user_uuid uuid
 
 func (repo UserRepository) GetUsersAccounts(ctx context.Context, user_uuid uuid.UUID) (string, error) {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.GetUsersAccounts")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.GetUsersAccounts")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -211,8 +249,8 @@ func (repo UserRepository) GetUsersAccounts(ctx context.Context, user_uuid uuid.
 
 func (repo UserRepository) UpdateUserPassw(ctx context.Context, user_uuid uuid.UUID, new_passw string) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.UpdateUserPassw")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.UpdateUserPassw")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -241,8 +279,8 @@ func (repo UserRepository) UpdateUserPassw(ctx context.Context, user_uuid uuid.U
 
 func (repo UserRepository) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.GetUserByLogin")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.GetUserByLogin")
+	defer span.End()
 
 	var result = &models.User{}
 
@@ -274,8 +312,8 @@ func (repo UserRepository) GetUserByLogin(ctx context.Context, login string) (*m
 
 func (repo UserRepository) DeleteUser(ctx context.Context, userId uuid.UUID) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.DeleteUser")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.DeleteUser")
+	defer span.End()
 
 	res, err := repo.db.ExecContext(local_ctx,
 		DeleteUser,
@@ -293,8 +331,8 @@ func (repo UserRepository) DeleteUser(ctx context.Context, userId uuid.UUID) err
 
 func (repo UserRepository) UpdateUserTotp(ctx context.Context, userUuid uuid.UUID, totpId uuid.UUID, totpUsage bool) error {
 
-	span, local_ctx := opentracing.StartSpanFromContext(ctx, "UserRepository.UpdateUserTotp")
-	defer span.Finish()
+	local_ctx, span := tracing.StartSpan(ctx, "UserRepository.UpdateUserTotp")
+	defer span.End()
 
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/GCFactory/dbo-system/platform/config"
@@ -91,6 +92,8 @@ func TestRepository_AddUser(t *testing.T) {
 			&user.Passport_uuid,
 			&user.User_inn,
 			accounts_marshal,
+			&user.User_login,
+			&user.User_passw,
 		).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		mock.ExpectCommit()
@@ -137,6 +140,45 @@ func TestRepository_AddUser(t *testing.T) {
 
 	})
 
+	t.Run("Error in passport file", func(t *testing.T) {
+
+		passport.Files = []uuid.UUID{uuid.New()}
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec(repository.AddPassport).WithArgs(
+			&passport.Passport_uuid,
+			&passport.Passport_series,
+			&passport.Passport_number,
+			&passport.Name,
+			&passport.Surname,
+			&passport.Patronimic,
+			&passport.Birth_date,
+			&passport.Birth_location,
+			&passport.Pick_up_point,
+			&passport.Authority,
+			&passport.Authority_date,
+			&passport.Registration_adress,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		mock.ExpectExec(repository.AddPassportFile).WithArgs(
+			&passport.Passport_uuid,
+			passport.Files[0],
+		).WillReturnError(sql.ErrConnDone)
+
+		// Passport is rolled back together with file links
+		mock.ExpectRollback()
+
+		err = userRepo.AddUser(context.Background(), user_full)
+		require.Equal(t, err, repository.ErrorAddPassportFile)
+
+		err = mock.ExpectationsWereMet()
+		require.Nil(t, err)
+
+		passport.Files = nil
+
+	})
+
 	t.Run("Error in user data", func(t *testing.T) {
 
 		tmp := user.Passport_uuid
@@ -164,6 +206,8 @@ func TestRepository_AddUser(t *testing.T) {
 			&user.Passport_uuid,
 			&user.User_inn,
 			accounts_marshal,
+			&user.User_login,
+			&user.User_passw,
 		).WillReturnError(err)
 
 		mock.ExpectRollback()
@@ -205,6 +249,8 @@ func TestRepository_AddUser(t *testing.T) {
 			&user.Passport_uuid,
 			&user.User_inn,
 			accounts_marshal,
+			&user.User_login,
+			&user.User_passw,
 		).WillReturnError(err)
 
 		mock.ExpectRollback().WillReturnError(err)
@@ -261,11 +307,17 @@ func TestRepository_GetUSerData(t *testing.T) {
 		Passport_uuid: passport.Passport_uuid,
 		User_inn:      "01234567890123456789",
 		User_accounts: models.ListOfAccounts{},
+		User_login:    "login",
+		User_passw:    "password",
+		UsingTotp:     false,
+		TotpId:        uuid.New(),
 	}
 
 	user.User_accounts.Data = append(user.User_accounts.Data, uuid.New())
 	user.User_accounts.Data = append(user.User_accounts.Data, uuid.New())
 
+	passport.Files = []uuid.UUID{uuid.New(), uuid.New()}
+
 	accounts_marshal, err := json.Marshal(user.User_accounts)
 	require.Nil(t, err)
 
@@ -308,12 +360,20 @@ func TestRepository_GetUSerData(t *testing.T) {
 			"user_uuid",
 			"passport_uuid",
 			"user_inn",
-			"user_accounts"},
+			"user_accounts",
+			"user_login",
+			"user_password",
+			"using_totp",
+			"totp_id"},
 		).AddRow(
 			user.User_uuid,
 			user.Passport_uuid,
 			user.User_inn,
 			accounts_marshal,
+			user.User_login,
+			user.User_passw,
+			user.UsingTotp,
+			user.TotpId,
 		)
 
 		mock.ExpectBegin()
@@ -324,6 +384,9 @@ func TestRepository_GetUSerData(t *testing.T) {
 		mock.ExpectQuery(repository.GetPassportData).WithArgs(
 			passport.Passport_uuid,
 		).WillReturnRows(passport_row)
+		mock.ExpectQuery(repository.GetPassportFiles).WithArgs(
+			passport.Passport_uuid,
+		).WillReturnRows(mock.NewRows([]string{"file_uuid"}).AddRow(passport.Files[0]).AddRow(passport.Files[1]))
 
 		mock.ExpectCommit()
 
@@ -359,12 +422,20 @@ func TestRepository_GetUSerData(t *testing.T) {
 			"user_uuid",
 			"passport_uuid",
 			"user_inn",
-			"user_accounts"},
+			"user_accounts",
+			"user_login",
+			"user_password",
+			"using_totp",
+			"totp_id"},
 		).AddRow(
 			user.User_uuid,
 			user.Passport_uuid,
 			user.User_inn,
 			accounts_marshal,
+			user.User_login,
+			user.User_passw,
+			user.UsingTotp,
+			user.TotpId,
 		)
 
 		mock.ExpectBegin()
@@ -386,6 +457,78 @@ func TestRepository_GetUSerData(t *testing.T) {
 		require.Nil(t, err)
 	})
 
+	t.Run("Error getting passport files", func(t *testing.T) {
+
+		passport_row := mock.NewRows([]string{
+			"passport_uuid",
+			"passport_series",
+			"passport_number",
+			"name",
+			"surname",
+			"patronimic",
+			"birth_date",
+			"birth_location",
+			"pick_up_point",
+			"authority",
+			"authority_date",
+			"registration_adress"},
+		).AddRow(
+			passport.Passport_uuid,
+			passport.Passport_series,
+			passport.Passport_number,
+			passport.Name,
+			passport.Surname,
+			passport.Patronimic,
+			passport.Birth_date,
+			passport.Birth_location,
+			passport.Pick_up_point,
+			passport.Authority,
+			passport.Authority_date,
+			passport.Registration_adress,
+		)
+
+		user_row := mock.NewRows([]string{
+			"user_uuid",
+			"passport_uuid",
+			"user_inn",
+			"user_accounts",
+			"user_login",
+			"user_password",
+			"using_totp",
+			"totp_id"},
+		).AddRow(
+			user.User_uuid,
+			user.Passport_uuid,
+			user.User_inn,
+			accounts_marshal,
+			user.User_login,
+			user.User_passw,
+			user.UsingTotp,
+			user.TotpId,
+		)
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(repository.GetUserData).WithArgs(
+			user.User_uuid,
+		).WillReturnRows(user_row)
+		mock.ExpectQuery(repository.GetPassportData).WithArgs(
+			passport.Passport_uuid,
+		).WillReturnRows(passport_row)
+		mock.ExpectQuery(repository.GetPassportFiles).WithArgs(
+			passport.Passport_uuid,
+		).WillReturnError(err)
+
+		mock.ExpectRollback()
+
+		result, err := userRepo.GetUserData(context.Background(), user.User_uuid)
+		require.Nil(t, result)
+		require.Equal(t, err, repository.ErrorGetPassportFiles)
+
+		err = mock.ExpectationsWereMet()
+		require.Nil(t, err)
+	})
+
 	t.Run("Error rollback", func(t *testing.T) {
 
 		mock.ExpectBegin()
@@ -766,3 +909,41 @@ func TestRepository_GetUsersAccounts(t *testing.T) {
 	})
 
 }
+
+func TestRepository_IsPassportFile(t *testing.T) {
+	t.Parallel()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+
+	sqlxDB := sqlx.NewDb(db, "slqmock")
+	defer sqlxDB.Close()
+
+	userRepo := repository.NewUserRepository(sqlxDB)
+
+	file_uuid := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+
+		mock.ExpectQuery(repository.IsPassportFile).WithArgs(
+			file_uuid,
+		).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		result, err := userRepo.IsPassportFile(context.Background(), file_uuid)
+		require.Nil(t, err)
+		require.True(t, result)
+
+	})
+
+	t.Run("Error", func(t *testing.T) {
+
+		mock.ExpectQuery(repository.IsPassportFile).WithArgs(
+			file_uuid,
+		).WillReturnError(sql.ErrConnDone)
+
+		_, err := userRepo.IsPassportFile(context.Background(), file_uuid)
+		require.Equal(t, repository.ErrorCheckPassportFile, err)
+
+	})
+
+	require.Nil(t, mock.ExpectationsWereMet())
+}
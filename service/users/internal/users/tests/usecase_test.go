@@ -6,13 +6,14 @@ import (
 	"errors"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/users/internal/models"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/mock"
 	"github.com/GCFactory/dbo-system/service/users/internal/users/usecase"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
+	"slices"
 	"testing"
 	"time"
 )
@@ -37,7 +38,7 @@ func TestUsersUC_AddUser(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, apiLogger)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	passport := &models.Passport{
 		Passport_uuid:       uuid.New(),
@@ -71,8 +72,8 @@ func TestUsersUC_AddUser(t *testing.T) {
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.AddUser")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.AddUser")
+	defer span.End()
 
 	var err error
 
@@ -118,7 +119,7 @@ func TestUsersUC_GetUserData(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, apiLogger)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	passport := &models.Passport{
 		Passport_uuid:       uuid.New(),
@@ -152,8 +153,8 @@ func TestUsersUC_GetUserData(t *testing.T) {
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.GetUserData")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.GetUserData")
+	defer span.End()
 
 	t.Run("Success", func(t *testing.T) {
 
@@ -186,7 +187,7 @@ func TestUsersUC_UpdateUsersPassport(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, apiLogger)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	passport := &models.Passport{
 		Passport_uuid:       uuid.New(),
@@ -235,8 +236,8 @@ func TestUsersUC_UpdateUsersPassport(t *testing.T) {
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.UpdateUsersPassport")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.UpdateUsersPassport")
+	defer span.End()
 
 	t.Run("Success", func(t *testing.T) {
 
@@ -280,7 +281,7 @@ func TestUsersUC_AddUserAccount(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, apiLogger)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	passport := &models.Passport{
 		Passport_uuid:       uuid.New(),
@@ -316,16 +317,18 @@ func TestUsersUC_AddUserAccount(t *testing.T) {
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.AddUserAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.AddUserAccount")
+	defer span.End()
 
 	t.Run("Success", func(t *testing.T) {
 
-		tmp := user.User_accounts
+		// Use case appends account to returned user data, so it's restored after call
+		save := user.User_accounts
+		defer func() { user.User_accounts = save }()
 
-		tmp.Data = append(tmp.Data, new_account)
+		tmp := models.ListOfAccounts{Data: append(slices.Clone(save.Data), new_account)}
 
-		bytes, err := json.Marshal(tmp.Data)
+		bytes, err := json.Marshal(tmp)
 		require.Nil(t, err)
 
 		mockRepo.EXPECT().GetUserData(gomock.Eq(ctxWithTrace), gomock.Eq(user.User_uuid)).Return(user_full, nil)
@@ -366,11 +369,13 @@ func TestUsersUC_AddUserAccount(t *testing.T) {
 
 	t.Run("Error update accounts", func(t *testing.T) {
 
-		tmp := user.User_accounts
+		// Use case appends account to returned user data, so it's restored after call
+		save := user.User_accounts
+		defer func() { user.User_accounts = save }()
 
-		tmp.Data = append(tmp.Data, new_account)
+		tmp := models.ListOfAccounts{Data: append(slices.Clone(save.Data), new_account)}
 
-		bytes, err := json.Marshal(tmp.Data)
+		bytes, err := json.Marshal(tmp)
 		require.Nil(t, err)
 
 		local_error := errors.New("Some error")
@@ -394,7 +399,7 @@ func TestUsersUC_GetUserAccounts(t *testing.T) {
 	apiLogger.InitLogger()
 
 	mockRepo := mock.NewMockRepository(ctrl)
-	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, apiLogger)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mock.NewMockFiles(ctrl), apiLogger)
 
 	passport := &models.Passport{
 		Passport_uuid:       uuid.New(),
@@ -423,8 +428,8 @@ func TestUsersUC_GetUserAccounts(t *testing.T) {
 
 	ctx := context.Background()
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.GetUserAccounts")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.GetUserAccounts")
+	defer span.End()
 
 	result_marshal, err := json.Marshal(user.User_accounts)
 	require.Nil(t, err)
@@ -449,3 +454,72 @@ func TestUsersUC_GetUserAccounts(t *testing.T) {
 
 	})
 }
+
+func TestUsersUC_PassportFiles(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiLogger := logger.NewServerLogger(usecaseTestCfg)
+	apiLogger.InitLogger()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	mockFiles := mock.NewMockFiles(ctrl)
+	usersUC := usecase.NewUsersUseCase(usecaseTestCfg, mockRepo, mockFiles, apiLogger)
+
+	passport := &models.Passport{
+		Passport_uuid: uuid.New(),
+		Files:         []uuid.UUID{uuid.New(), uuid.New()},
+	}
+
+	user_full := &models.User_full_data{
+		User: &models.User{
+			User_uuid:     uuid.New(),
+			Passport_uuid: passport.Passport_uuid,
+		},
+		Passport: passport,
+	}
+
+	ctx := context.Background()
+
+	t.Run("Error create user", func(t *testing.T) {
+
+		create_err := errors.New("duplicate passport")
+
+		mockRepo.EXPECT().GetUserData(gomock.Any(), gomock.Eq(user_full.User.User_uuid)).Return(nil, errors.New("no user"))
+		mockRepo.EXPECT().AddUser(gomock.Any(), gomock.Eq(user_full)).Return(create_err)
+		// Second file is already attached to other passport
+		mockRepo.EXPECT().IsPassportFile(gomock.Any(), gomock.Eq(passport.Files[0])).Return(false, nil)
+		mockRepo.EXPECT().IsPassportFile(gomock.Any(), gomock.Eq(passport.Files[1])).Return(true, nil)
+		mockFiles.EXPECT().DeleteFile(gomock.Any(), gomock.Eq(passport.Files[0])).Return(nil)
+
+		err := usersUC.AddUser(ctx, user_full)
+		require.Equal(t, create_err, err)
+
+	})
+
+	t.Run("Remove user", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetUserData(gomock.Any(), gomock.Eq(user_full.User.User_uuid)).Return(user_full, nil)
+		mockRepo.EXPECT().DeleteUser(gomock.Any(), gomock.Eq(user_full.User.User_uuid)).Return(nil)
+		mockRepo.EXPECT().IsPassportFile(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+		mockFiles.EXPECT().DeleteFile(gomock.Any(), gomock.Eq(passport.Files[0])).Return(nil)
+		// Remove doesn't fail when file-api is unavailable
+		mockFiles.EXPECT().DeleteFile(gomock.Any(), gomock.Eq(passport.Files[1])).Return(errors.New("file-api is unavailable"))
+
+		err := usersUC.RemoveUser(ctx, user_full.User.User_uuid)
+		require.Nil(t, err)
+
+	})
+
+	t.Run("Error remove user", func(t *testing.T) {
+
+		mockRepo.EXPECT().GetUserData(gomock.Any(), gomock.Eq(user_full.User.User_uuid)).Return(user_full, nil)
+		mockRepo.EXPECT().DeleteUser(gomock.Any(), gomock.Eq(user_full.User.User_uuid)).Return(errors.New("no user found"))
+
+		err := usersUC.RemoveUser(ctx, user_full.User.User_uuid)
+		require.NotNil(t, err)
+
+	})
+}
@@ -7,32 +7,35 @@ import (
 	"encoding/json"
 	"github.com/GCFactory/dbo-system/platform/config"
 	"github.com/GCFactory/dbo-system/platform/pkg/logger"
+	"github.com/GCFactory/dbo-system/platform/pkg/tracing"
 	"github.com/GCFactory/dbo-system/service/users/internal/models"
 	"github.com/GCFactory/dbo-system/service/users/internal/users"
 	"github.com/google/uuid"
-	"github.com/opentracing/opentracing-go"
 )
 
 type userUsecase struct {
 	cfg       *config.Config
 	usersRepo users.Repository
+	files     users.Files
 	logger    logger.Logger
 }
 
 func (uc userUsecase) AddUser(ctx context.Context, user *models.User_full_data) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.AddUser")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.AddUser")
+	defer span.End()
 
 	sha := sha512.Sum512([]byte(user.User.User_passw))
 	user.User.User_passw = hex.EncodeToString(sha[:])
 
 	bd_user, _ := uc.usersRepo.GetUserData(ctxWithTrace, user.User.User_uuid)
 	if bd_user != nil {
+		uc.deleteOrphanedFiles(ctxWithTrace, user.Passport.Files)
 		return ErrorUserExists
 	}
 
 	if err := uc.usersRepo.AddUser(ctxWithTrace, user); err != nil {
+		uc.deleteOrphanedFiles(ctxWithTrace, user.Passport.Files)
 		return err
 	}
 
@@ -41,8 +44,8 @@ func (uc userUsecase) AddUser(ctx context.Context, user *models.User_full_data)
 
 func (uc userUsecase) GetUserData(ctx context.Context, user_uuid uuid.UUID) (*models.User_full_data, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.GetUserData")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.GetUserData")
+	defer span.End()
 
 	result, err := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if err != nil {
@@ -54,8 +57,8 @@ func (uc userUsecase) GetUserData(ctx context.Context, user_uuid uuid.UUID) (*mo
 
 func (uc userUsecase) UpdateUsersPassport(ctx context.Context, user_uuid uuid.UUID, passport *models.Passport) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.UpdateUsersPassport")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.UpdateUsersPassport")
+	defer span.End()
 
 	user, _ := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if user == nil {
@@ -74,21 +77,50 @@ func (uc userUsecase) UpdateUsersPassport(ctx context.Context, user_uuid uuid.UU
 
 func (uc userUsecase) RemoveUser(ctx context.Context, userId uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.RemoveUser")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.RemoveUser")
+	defer span.End()
+
+	user, _ := uc.usersRepo.GetUserData(ctxWithTrace, userId)
 
 	err := uc.usersRepo.DeleteUser(ctxWithTrace, userId)
 	if err != nil {
 		return err
 	}
 
+	if user != nil {
+		uc.deleteOrphanedFiles(ctxWithTrace, user.Passport.Files)
+	}
+
 	return nil
 }
 
+// deleteOrphanedFiles removes passport scans of not created or removed user from file-api.
+// Files of other passports are kept, errors are only logged as user data is already consistent.
+func (uc userUsecase) deleteOrphanedFiles(ctx context.Context, files []uuid.UUID) {
+
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.deleteOrphanedFiles")
+	defer span.End()
+
+	for _, file_uuid := range files {
+		is_used, err := uc.usersRepo.IsPassportFile(ctxWithTrace, file_uuid)
+		if err != nil {
+			uc.logger.Error(err)
+			continue
+		}
+		if is_used {
+			continue
+		}
+
+		if err = uc.files.DeleteFile(ctxWithTrace, file_uuid); err != nil {
+			uc.logger.Error(err)
+		}
+	}
+}
+
 func (uc userUsecase) AddUserAccount(ctx context.Context, user_uuid uuid.UUID, account uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.AddUserAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.AddUserAccount")
+	defer span.End()
 
 	user, _ := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if user == nil {
@@ -121,8 +153,8 @@ func (uc userUsecase) AddUserAccount(ctx context.Context, user_uuid uuid.UUID, a
 
 func (uc userUsecase) RemoveUserAccount(ctx context.Context, user_uuid uuid.UUID, account uuid.UUID) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.RemoveUserAccount")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.RemoveUserAccount")
+	defer span.End()
 
 	user, _ := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if user == nil {
@@ -163,8 +195,8 @@ func (uc userUsecase) RemoveUserAccount(ctx context.Context, user_uuid uuid.UUID
 
 func (uc userUsecase) GetUserAccounts(ctx context.Context, user_uuid uuid.UUID) (*models.ListOfAccounts, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.GetUserAccounts")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.GetUserAccounts")
+	defer span.End()
 
 	accounts, _ := uc.usersRepo.GetUsersAccounts(ctxWithTrace, user_uuid)
 	if accounts == "" {
@@ -183,8 +215,8 @@ func (uc userUsecase) GetUserAccounts(ctx context.Context, user_uuid uuid.UUID)
 
 func (uc userUsecase) UpdateUserPassword(ctx context.Context, user_uuid uuid.UUID, passw string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.UpdateUserPassword")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.UpdateUserPassword")
+	defer span.End()
 
 	user, err := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if err != nil {
@@ -206,8 +238,8 @@ func (uc userUsecase) UpdateUserPassword(ctx context.Context, user_uuid uuid.UUI
 
 func (uc userUsecase) GetUserDataByLogin(ctx context.Context, login string) (*models.User, error) {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.GetUserDataByLogin")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.GetUserDataByLogin")
+	defer span.End()
 
 	user, err := uc.usersRepo.GetUserByLogin(ctxWithTrace, login)
 	if err != nil {
@@ -219,8 +251,8 @@ func (uc userUsecase) GetUserDataByLogin(ctx context.Context, login string) (*mo
 
 func (uc userUsecase) CheckUserPassword(ctx context.Context, user_uuid uuid.UUID, passw string) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.CheckUserPassword")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.CheckUserPassword")
+	defer span.End()
 
 	user, err := uc.usersRepo.GetUserData(ctxWithTrace, user_uuid)
 	if err != nil {
@@ -239,8 +271,8 @@ func (uc userUsecase) CheckUserPassword(ctx context.Context, user_uuid uuid.UUID
 
 func (uc userUsecase) UpdateTotpInfo(ctx context.Context, userId uuid.UUID, totpId uuid.UUID, totpUsage bool) error {
 
-	span, ctxWithTrace := opentracing.StartSpanFromContext(ctx, "userUsecase.UpdateTotpInfo")
-	defer span.Finish()
+	ctxWithTrace, span := tracing.StartSpan(ctx, "userUsecase.UpdateTotpInfo")
+	defer span.End()
 
 	err := uc.usersRepo.UpdateUserTotp(ctxWithTrace, userId, totpId, totpUsage)
 	if err != nil {
@@ -250,6 +282,6 @@ func (uc userUsecase) UpdateTotpInfo(ctx context.Context, userId uuid.UUID, totp
 	return nil
 }
 
-func NewUsersUseCase(cfg *config.Config, users_repo users.Repository, log logger.Logger) users.UseCase {
-	return &userUsecase{cfg: cfg, usersRepo: users_repo, logger: log}
+func NewUsersUseCase(cfg *config.Config, users_repo users.Repository, files users.Files, log logger.Logger) users.UseCase {
+	return &userUsecase{cfg: cfg, usersRepo: users_repo, files: files, logger: log}
 }
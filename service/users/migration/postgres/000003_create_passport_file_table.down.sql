@@ -0,0 +1 @@
+DROP TABLE IF EXISTS passport_file CASCADE;
\ No newline at end of file
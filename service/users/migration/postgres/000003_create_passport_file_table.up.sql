@@ -0,0 +1,7 @@
+CREATE TABLE passport_file
+(
+    file_uuid               UUID                PRIMARY KEY,
+    passport_uuid           UUID                REFERENCES passport(passport_uuid) ON DELETE CASCADE NOT NULL
+);
+
+CREATE INDEX passport_file_passport_idx ON passport_file (passport_uuid);